@@ -3,13 +3,20 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
+	"Aicon-assignment/internal/infrastructure/admin"
 	"Aicon-assignment/internal/infrastructure/server"
 )
 
 func main() {
 	ctx := context.Background()
 
+	// サブコマンドを指定した場合は管理コマンドとして実行する（引数なし・serveはサーバーを起動）
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		os.Exit(admin.Run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	server := server.NewServer()
 
 	if err := server.Run(ctx); err != nil {
@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// カスタム属性の型
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+// 文字列属性の最大長
+const maxAttributeStringLength = 255
+
+// AttributeDefinition はカテゴリーごとに定義されるカスタム属性のスキーマ
+type AttributeDefinition struct {
+	Key   string        `json:"key"`
+	Label string        `json:"label"`
+	Type  AttributeType `json:"type"`
+	Unit  string        `json:"unit,omitempty"`
+	Enum  []string      `json:"enum,omitempty"`
+}
+
+// すべてのカテゴリーで使用できる属性
+var CommonAttributes = []AttributeDefinition{
+	{Key: "color", Label: "色", Type: AttributeTypeString},
+	{Key: "note", Label: "メモ", Type: AttributeTypeString},
+}
+
+// カテゴリー別の属性スキーマ。同じキーはカテゴリーをまたいで同じ型で定義すること
+var CategoryAttributeSchemas = map[string][]AttributeDefinition{
+	"時計": {
+		{Key: "movement", Label: "ムーブメント", Type: AttributeTypeEnum, Enum: []string{"自動巻き", "手巻き", "クオーツ", "ソーラー"}},
+		{Key: "case_size_mm", Label: "ケースサイズ", Type: AttributeTypeNumber, Unit: "mm"},
+		{Key: "material", Label: "素材", Type: AttributeTypeString},
+	},
+	"バッグ": {
+		{Key: "material", Label: "素材", Type: AttributeTypeString},
+		{Key: "dimensions", Label: "サイズ（W×H×D）", Type: AttributeTypeString},
+	},
+	"ジュエリー": {
+		{Key: "material", Label: "素材", Type: AttributeTypeString},
+		{Key: "carat", Label: "カラット", Type: AttributeTypeNumber, Unit: "ct"},
+	},
+	"靴": {
+		{Key: "size", Label: "サイズ", Type: AttributeTypeNumber, Unit: "cm"},
+		{Key: "material", Label: "素材", Type: AttributeTypeString},
+	},
+	"その他": {},
+}
+
+// カテゴリーで使用できる属性定義（共通属性を含む）を返す
+func GetAttributeSchema(category string) []AttributeDefinition {
+	schema := append([]AttributeDefinition{}, CategoryAttributeSchemas[category]...)
+	return append(schema, CommonAttributes...)
+}
+
+// カテゴリーにおける属性定義を返す
+func LookupAttribute(category, key string) (AttributeDefinition, bool) {
+	for _, def := range GetAttributeSchema(category) {
+		if def.Key == key {
+			return def, true
+		}
+	}
+	return AttributeDefinition{}, false
+}
+
+// カテゴリーを問わず属性定義を返す（一覧の絞り込み用）
+func LookupAttributeAnyCategory(key string) (AttributeDefinition, bool) {
+	for _, category := range ValidCategories {
+		if def, ok := LookupAttribute(category, key); ok {
+			return def, true
+		}
+	}
+	return AttributeDefinition{}, false
+}
+
+// 値を定義の型に合わせて正規化する。数値はfloat64、文字列は前後の空白を除去する
+func (d AttributeDefinition) Normalize(value interface{}) (interface{}, error) {
+	switch d.Type {
+	case AttributeTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+		return nil, fmt.Errorf("attributes.%s must be a number", d.Key)
+	case AttributeTypeBoolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("attributes.%s must be a boolean", d.Key)
+	case AttributeTypeEnum:
+		v, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("attributes.%s must be a string", d.Key)
+		}
+		v = strings.TrimSpace(v)
+		for _, allowed := range d.Enum {
+			if v == allowed {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("attributes.%s must be one of: %s", d.Key, strings.Join(d.Enum, ", "))
+	default:
+		v, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("attributes.%s must be a string", d.Key)
+		}
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("attributes.%s cannot be empty", d.Key)
+		}
+		if len(v) > maxAttributeStringLength {
+			return nil, fmt.Errorf("attributes.%s must be %d characters or less", d.Key, maxAttributeStringLength)
+		}
+		return v, nil
+	}
+}
+
+// 正規化済みの値を保存用の文字列に変換する
+func (d AttributeDefinition) Encode(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// 保存用の文字列を定義の型の値に戻す
+func (d AttributeDefinition) Decode(raw string) (interface{}, error) {
+	switch d.Type {
+	case AttributeTypeNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("attributes.%s must be a number", d.Key)
+		}
+		return v, nil
+	case AttributeTypeBoolean:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("attributes.%s must be a boolean", d.Key)
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
+
+// 属性をカテゴリーのスキーマで検証する
+func validateAttributes(category string, attributes map[string]interface{}) []string {
+	var errs []string
+
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		def, ok := LookupAttribute(category, key)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("attributes.%s is not defined for category %s", key, category))
+			continue
+		}
+		if _, err := def.Normalize(attributes[key]); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	return errs
+}
+
+// 属性を正規化したコピーを返す。未定義のキーや型の誤りはValidateで検出するためそのまま残す
+func normalizeAttributes(category string, attributes map[string]interface{}) map[string]interface{} {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		normalized[key] = value
+		if def, ok := LookupAttribute(category, key); ok {
+			if v, err := def.Normalize(value); err == nil {
+				normalized[key] = v
+			}
+		}
+	}
+	return normalized
+}
+
+func attributesEqual(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_SetAttributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		category    string
+		attributes  map[string]interface{}
+		expected    map[string]interface{}
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:       "正常系: カテゴリーの属性と共通属性を設定できる",
+			category:   "時計",
+			attributes: map[string]interface{}{"movement": "自動巻き", "case_size_mm": 40, "color": " ブラック "},
+			expected:   map[string]interface{}{"movement": "自動巻き", "case_size_mm": float64(40), "color": "ブラック"},
+		},
+		{
+			name:        "異常系: カテゴリーに定義されていない属性",
+			category:    "バッグ",
+			attributes:  map[string]interface{}{"movement": "自動巻き"},
+			wantErr:     true,
+			expectedErr: "attributes.movement is not defined for category バッグ",
+		},
+		{
+			name:        "異常系: 列挙値以外の値",
+			category:    "時計",
+			attributes:  map[string]interface{}{"movement": "電池"},
+			wantErr:     true,
+			expectedErr: "attributes.movement must be one of: 自動巻き, 手巻き, クオーツ, ソーラー",
+		},
+		{
+			name:        "異常系: 数値属性に文字列",
+			category:    "靴",
+			attributes:  map[string]interface{}{"size": "25.5cm"},
+			wantErr:     true,
+			expectedErr: "attributes.size must be a number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("テストアイテム", tt.category, "BRAND", 1000, "2023-01-01")
+			require.NoError(t, err)
+
+			err = item.SetAttributes(tt.attributes)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Empty(t, item.Attributes)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, item.Attributes)
+			}
+		})
+	}
+}
+
+func TestItem_Apply_Attributes(t *testing.T) {
+	item, err := NewItem("ロレックス", "時計", "ROLEX", 1500000, "2023-01-01")
+	require.NoError(t, err)
+	require.NoError(t, item.SetAttributes(map[string]interface{}{"movement": "自動巻き", "color": "シルバー"}))
+	item.ClearChanges()
+
+	// nilを指定したキーは削除され、それ以外はキー単位でマージされる
+	err = item.Apply(ItemPatch{Attributes: map[string]interface{}{"color": nil, "case_size_mm": 41.5}})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"movement": "自動巻き", "case_size_mm": 41.5}, item.Attributes)
+	assert.Equal(t, []ItemField{FieldAttributes}, item.ChangedFields())
+}
+
+func TestAttributeDefinition_EncodeDecode(t *testing.T) {
+	def, ok := LookupAttribute("ジュエリー", "carat")
+	require.True(t, ok)
+
+	encoded := def.Encode(1.25)
+	assert.Equal(t, "1.25", encoded)
+
+	decoded, err := def.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, decoded)
+}
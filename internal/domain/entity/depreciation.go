@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DepreciationMethod は帳簿価額の計算方法
+type DepreciationMethod string
+
+const (
+	// 耐用年数で購入価格を均等に償却する（耐用年数を過ぎると0円）
+	DepreciationStraightLine DepreciationMethod = "straight_line"
+	// 毎年、前年の帳簿価額に一定の率を掛けて償却する
+	DepreciationDecliningBalance DepreciationMethod = "declining_balance"
+	// 毎年、前年の帳簿価額に一定の率で値上がりする（時計など）
+	DepreciationAppreciation DepreciationMethod = "appreciation"
+)
+
+// DepreciationModel はカテゴリーごとの帳簿価額の計算方法。
+// 定額法ではYears、定率法・値上がりではRateを使う
+type DepreciationModel struct {
+	Method DepreciationMethod `json:"method"`
+	Years  int                `json:"years,omitempty"` // 耐用年数
+	Rate   float64            `json:"rate,omitempty"`  // 年率（0.2 = 20%）
+}
+
+// DepreciationModels はカテゴリーごとの計算方法。含まれないカテゴリーは購入価格を帳簿価額とする
+type DepreciationModels map[string]DepreciationModel
+
+// DefaultDepreciationModels は既定の計算方法（時計は年3%の値上がりを見込む）
+var DefaultDepreciationModels = DepreciationModels{
+	"時計":    {Method: DepreciationAppreciation, Rate: 0.03},
+	"バッグ":   {Method: DepreciationDecliningBalance, Rate: 0.2},
+	"ジュエリー": {Method: DepreciationStraightLine, Years: 10},
+	"靴":     {Method: DepreciationStraightLine, Years: 3},
+	"その他":   {Method: DepreciationStraightLine, Years: 5},
+}
+
+// 経過年数の計算に使う1年の日数
+const daysPerYear = 365.0
+
+// ParseDepreciationModels は "時計=appreciation:0.05,靴=straight_line:5" 形式の設定を既定の計算方法に上書きする。
+// 定額法は耐用年数、定率法・値上がりは年率を指定する。none を指定したカテゴリーは購入価格を帳簿価額とする
+func ParseDepreciationModels(value string) (DepreciationModels, error) {
+	models := make(DepreciationModels, len(DefaultDepreciationModels))
+	for category, model := range DefaultDepreciationModels {
+		models[category] = model
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		category, spec, ok := strings.Cut(entry, "=")
+		category = strings.TrimSpace(category)
+		if !ok || !isValidCategory(category) {
+			return nil, fmt.Errorf("invalid depreciation model %q: must be <category>=<method>:<value>", entry)
+		}
+
+		spec = strings.TrimSpace(spec)
+		if spec == "none" {
+			delete(models, category)
+			continue
+		}
+
+		model, err := parseDepreciationModel(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid depreciation model %q: %s", entry, err.Error())
+		}
+		models[category] = model
+	}
+
+	return models, nil
+}
+
+func parseDepreciationModel(spec string) (DepreciationModel, error) {
+	method, param, ok := strings.Cut(spec, ":")
+	if !ok {
+		return DepreciationModel{}, fmt.Errorf("must be <method>:<value>")
+	}
+	param = strings.TrimSpace(param)
+
+	model := DepreciationModel{Method: DepreciationMethod(strings.TrimSpace(method))}
+	switch model.Method {
+	case DepreciationStraightLine:
+		years, err := strconv.Atoi(param)
+		if err != nil || years <= 0 {
+			return DepreciationModel{}, fmt.Errorf("years must be a positive integer")
+		}
+		model.Years = years
+	case DepreciationDecliningBalance:
+		rate, err := strconv.ParseFloat(param, 64)
+		if err != nil || rate <= 0 || rate >= 1 {
+			return DepreciationModel{}, fmt.Errorf("rate must be greater than 0 and less than 1")
+		}
+		model.Rate = rate
+	case DepreciationAppreciation:
+		rate, err := strconv.ParseFloat(param, 64)
+		if err != nil || rate <= 0 {
+			return DepreciationModel{}, fmt.Errorf("rate must be greater than 0")
+		}
+		model.Rate = rate
+	default:
+		return DepreciationModel{}, fmt.Errorf("method must be one of: straight_line, declining_balance, appreciation")
+	}
+
+	return model, nil
+}
+
+// BookValue は購入日と購入価格から指定日時点の帳簿価額を計算する（1円未満は四捨五入）。
+// 指定日時点で所有していないアイテム（購入前・売却済み・紛失）は false を返す
+func (m DepreciationModels) BookValue(item *Item, asOf time.Time) (int64, bool) {
+	if !item.isHeldOn(asOf) {
+		return 0, false
+	}
+
+	purchaseDate, err := time.Parse("2006-01-02", item.PurchaseDate)
+	if err != nil {
+		return 0, false
+	}
+	asOfDate, _ := time.Parse("2006-01-02", asOf.Format("2006-01-02"))
+	years := asOfDate.Sub(purchaseDate).Hours() / 24 / daysPerYear
+
+	price := float64(item.PurchasePrice)
+	model, ok := m[item.Category]
+	if !ok {
+		return int64(item.PurchasePrice), true
+	}
+
+	var value float64
+	switch model.Method {
+	case DepreciationStraightLine:
+		value = price * math.Max(0, 1-years/float64(model.Years))
+	case DepreciationDecliningBalance:
+		value = price * math.Pow(1-model.Rate, years)
+	case DepreciationAppreciation:
+		value = price * math.Pow(1+model.Rate, years)
+	default:
+		value = price
+	}
+
+	return int64(math.Round(value)), true
+}
+
+// 指定日時点で所有しているか。紛失したアイテムは紛失日が記録されないため所有していないものとする
+func (i *Item) isHeldOn(asOf time.Time) bool {
+	date := asOf.Format("2006-01-02")
+	if i.PurchaseDate > date {
+		return false
+	}
+
+	switch i.Status {
+	case ItemStatusLost:
+		return false
+	case ItemStatusSold:
+		return i.SaleDate > date
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDepreciationModels(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    DepreciationModels
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "正常系: 未指定は既定値", value: "", expected: DefaultDepreciationModels},
+		{
+			name:  "正常系: 指定したカテゴリーだけを上書き",
+			value: "時計=appreciation:0.05, 靴=declining_balance:0.3",
+			expected: DepreciationModels{
+				"時計":    {Method: DepreciationAppreciation, Rate: 0.05},
+				"バッグ":   {Method: DepreciationDecliningBalance, Rate: 0.2},
+				"ジュエリー": {Method: DepreciationStraightLine, Years: 10},
+				"靴":     {Method: DepreciationDecliningBalance, Rate: 0.3},
+				"その他":   {Method: DepreciationStraightLine, Years: 5},
+			},
+		},
+		{
+			name:  "正常系: noneで購入価格のまま",
+			value: "その他=none,バッグ=straight_line:8",
+			expected: DepreciationModels{
+				"時計":    {Method: DepreciationAppreciation, Rate: 0.03},
+				"バッグ":   {Method: DepreciationStraightLine, Years: 8},
+				"ジュエリー": {Method: DepreciationStraightLine, Years: 10},
+				"靴":     {Method: DepreciationStraightLine, Years: 3},
+			},
+		},
+		{name: "異常系: 不正なカテゴリー", value: "家具=straight_line:5", wantErr: true, expectedErr: "must be <category>=<method>:<value>"},
+		{name: "異常系: 不正な計算方法", value: "靴=sum_of_years:5", wantErr: true, expectedErr: "method must be one of"},
+		{name: "異常系: 耐用年数が0", value: "靴=straight_line:0", wantErr: true, expectedErr: "years must be a positive integer"},
+		{name: "異常系: 定率法の年率が1以上", value: "靴=declining_balance:1", wantErr: true, expectedErr: "rate must be greater than 0 and less than 1"},
+		{name: "異常系: 値を指定していない", value: "時計=appreciation", wantErr: true, expectedErr: "must be <method>:<value>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			models, err := ParseDepreciationModels(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, models)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, models)
+			}
+		})
+	}
+}
+
+func TestDepreciationModels_BookValue(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	models := DepreciationModels{
+		"時計":    {Method: DepreciationAppreciation, Rate: 0.03},
+		"バッグ":   {Method: DepreciationDecliningBalance, Rate: 0.2},
+		"ジュエリー": {Method: DepreciationStraightLine, Years: 10},
+		"靴":     {Method: DepreciationStraightLine, Years: 3},
+	}
+
+	tests := []struct {
+		name         string
+		item         *Item
+		expected     int64
+		expectedHeld bool
+	}{
+		{
+			name:         "正常系: 値上がり（2年で年3%）",
+			item:         &Item{Category: "時計", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusOwned},
+			expected:     1060900,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 定率法（2年で年20%）",
+			item:         &Item{Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusOwned},
+			expected:     640000,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 定額法（耐用年数10年のうち2年）",
+			item:         &Item{Category: "ジュエリー", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusLent},
+			expected:     800000,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 定額法で耐用年数を過ぎると0円",
+			item:         &Item{Category: "靴", PurchasePrice: 100000, PurchaseDate: "2020-01-01", Status: ItemStatusOwned},
+			expected:     0,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 計算方法のないカテゴリーは購入価格",
+			item:         &Item{Category: "その他", PurchasePrice: 50000, PurchaseDate: "2020-01-01", Status: ItemStatusOwned},
+			expected:     50000,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 購入日当日は購入価格",
+			item:         &Item{Category: "バッグ", PurchasePrice: 200000, PurchaseDate: "2024-01-01", Status: ItemStatusOwned},
+			expected:     200000,
+			expectedHeld: true,
+		},
+		{
+			name:         "正常系: 指定日より後に売却したアイテム",
+			item:         &Item{Category: "ジュエリー", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusSold, SaleDate: "2024-01-02"},
+			expected:     800000,
+			expectedHeld: true,
+		},
+		{
+			name: "異常系: 指定日より後に購入したアイテム",
+			item: &Item{Category: "時計", PurchasePrice: 1000000, PurchaseDate: "2024-01-02", Status: ItemStatusOwned},
+		},
+		{
+			name: "異常系: 指定日までに売却したアイテム",
+			item: &Item{Category: "時計", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusSold, SaleDate: "2024-01-01"},
+		},
+		{
+			name: "異常系: 紛失したアイテム",
+			item: &Item{Category: "時計", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusLost},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, held := models.BookValue(tt.item, asOf)
+
+			assert.Equal(t, tt.expectedHeld, held)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestItem_SetBookValue(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	owned := &Item{Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusOwned}
+	lost := &Item{Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: ItemStatusLost}
+
+	owned.SetBookValue(DefaultDepreciationModels, asOf)
+	lost.SetBookValue(DefaultDepreciationModels, asOf)
+
+	require.NotNil(t, owned.BookValue)
+	assert.Equal(t, int64(640000), *owned.BookValue)
+	assert.Nil(t, lost.BookValue)
+}
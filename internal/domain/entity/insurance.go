@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// InsurancePolicy はアイテムを補償する保険契約
+type InsurancePolicy struct {
+	ID            int64     `json:"id"`
+	Insurer       string    `json:"insurer"`
+	PolicyNumber  string    `json:"policy_number"`
+	CoverageLimit int64     `json:"coverage_limit"` // 補償限度額
+	StartsOn      string    `json:"starts_on"`      // 保険期間の開始日（YYYY-MM-DD 形式）
+	EndsOn        string    `json:"ends_on"`        // 保険期間の終了日（YYYY-MM-DD 形式、当日まで有効）
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func NewInsurancePolicy(insurer, policyNumber string, coverageLimit int64, startsOn, endsOn, note string) (*InsurancePolicy, error) {
+	policy := &InsurancePolicy{
+		CreatedAt: time.Now(),
+	}
+
+	if err := policy.Update(insurer, policyNumber, coverageLimit, startsOn, endsOn, note); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Update は保険契約の内容を置き換えてバリデーションする。失敗した場合は変更しない
+func (p *InsurancePolicy) Update(insurer, policyNumber string, coverageLimit int64, startsOn, endsOn, note string) error {
+	next := *p
+	next.Insurer = strings.TrimSpace(insurer)
+	next.PolicyNumber = strings.TrimSpace(policyNumber)
+	next.CoverageLimit = coverageLimit
+	next.StartsOn = strings.TrimSpace(startsOn)
+	next.EndsOn = strings.TrimSpace(endsOn)
+	next.Note = strings.TrimSpace(note)
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	next.UpdatedAt = time.Now()
+	*p = next
+
+	return nil
+}
+
+// 保険契約のバリデーション
+func (p *InsurancePolicy) Validate() error {
+	var errs []string
+
+	if p.Insurer == "" {
+		errs = append(errs, "insurer is required")
+	} else if len(p.Insurer) > 100 {
+		errs = append(errs, "insurer must be 100 characters or less")
+	}
+
+	if p.PolicyNumber == "" {
+		errs = append(errs, "policy_number is required")
+	} else if len(p.PolicyNumber) > 100 {
+		errs = append(errs, "policy_number must be 100 characters or less")
+	}
+
+	if p.CoverageLimit < 0 {
+		errs = append(errs, "coverage_limit must be 0 or greater")
+	}
+
+	validStartsOn := false
+	if p.StartsOn == "" {
+		errs = append(errs, "starts_on is required")
+	} else if !isValidDateFormat(p.StartsOn) {
+		errs = append(errs, "starts_on must be in YYYY-MM-DD format")
+	} else {
+		validStartsOn = true
+	}
+
+	if p.EndsOn == "" {
+		errs = append(errs, "ends_on is required")
+	} else if !isValidDateFormat(p.EndsOn) {
+		errs = append(errs, "ends_on must be in YYYY-MM-DD format")
+	} else if validStartsOn && p.EndsOn < p.StartsOn {
+		errs = append(errs, "ends_on must be on or after starts_on")
+	}
+
+	if len(p.Note) > 255 {
+		errs = append(errs, "note must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// IsActive は指定日時点で保険期間内かを返す
+func (p *InsurancePolicy) IsActive(now time.Time) bool {
+	today := now.Format("2006-01-02")
+	return p.StartsOn <= today && today <= p.EndsOn
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInsurancePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		insurer       string
+		policyNumber  string
+		coverageLimit int64
+		startsOn      string
+		endsOn        string
+		wantErr       bool
+		expectedErr   string
+	}{
+		{name: "正常系: 1年間の保険契約", insurer: "東京海上", policyNumber: "P-001", coverageLimit: 3000000, startsOn: "2024-01-01", endsOn: "2024-12-31"},
+		{name: "異常系: 保険会社がない", policyNumber: "P-001", startsOn: "2024-01-01", endsOn: "2024-12-31", wantErr: true, expectedErr: "insurer is required"},
+		{name: "異常系: 証券番号がない", insurer: "東京海上", startsOn: "2024-01-01", endsOn: "2024-12-31", wantErr: true, expectedErr: "policy_number is required"},
+		{name: "異常系: 補償限度額が負の値", insurer: "東京海上", policyNumber: "P-001", coverageLimit: -1, startsOn: "2024-01-01", endsOn: "2024-12-31", wantErr: true, expectedErr: "coverage_limit must be 0 or greater"},
+		{name: "異常系: 終了日が開始日より前", insurer: "東京海上", policyNumber: "P-001", startsOn: "2024-01-01", endsOn: "2023-12-31", wantErr: true, expectedErr: "ends_on must be on or after starts_on"},
+		{name: "異常系: 開始日の形式が不正", insurer: "東京海上", policyNumber: "P-001", startsOn: "2024/01/01", endsOn: "2024-12-31", wantErr: true, expectedErr: "starts_on must be in YYYY-MM-DD format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewInsurancePolicy(tt.insurer, tt.policyNumber, tt.coverageLimit, tt.startsOn, tt.endsOn, "")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, policy)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.coverageLimit, policy.CoverageLimit)
+			}
+		})
+	}
+}
+
+func TestInsurancePolicy_Update_KeepsPolicyOnError(t *testing.T) {
+	policy, err := NewInsurancePolicy("東京海上", "P-001", 3000000, "2024-01-01", "2024-12-31", "")
+	require.NoError(t, err)
+
+	err = policy.Update("", "P-002", 5000000, "2024-01-01", "2024-12-31", "")
+
+	assert.Error(t, err)
+	assert.Equal(t, "東京海上", policy.Insurer)
+	assert.Equal(t, int64(3000000), policy.CoverageLimit)
+}
+
+func TestInsurancePolicy_IsActive(t *testing.T) {
+	policy := &InsurancePolicy{StartsOn: "2024-01-01", EndsOn: "2024-12-31"}
+
+	assert.False(t, policy.IsActive(time.Date(2023, 12, 31, 23, 0, 0, 0, time.Local)))
+	assert.True(t, policy.IsActive(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)))
+	assert.True(t, policy.IsActive(time.Date(2024, 12, 31, 23, 0, 0, 0, time.Local)))
+	assert.False(t, policy.IsActive(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)))
+}
+
+func TestCurrentValue(t *testing.T) {
+	item := &Item{PurchasePrice: 1500000}
+
+	assert.Equal(t, int64(1500000), CurrentValue(item, nil))
+	assert.Equal(t, int64(2200000), CurrentValue(item, &ItemValuation{Amount: 2200000}))
+}
@@ -7,19 +7,90 @@ import (
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                int64                  `json:"id"`
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category"`
+	Brand             string                 `json:"brand"`
+	PurchasePrice     int                    `json:"purchase_price"`
+	PurchaseDate      string                 `json:"purchase_date"`       // YYYY-MM-DD 形式
+	SerialNumber      string                 `json:"serial_number"`       // シリアル番号。ブランド内で一意
+	ModelNumber       string                 `json:"model_number"`        // 型番・リファレンス番号
+	Condition         string                 `json:"condition"`           // コンディションランク（S/A/B/C/D）
+	Authenticity      string                 `json:"authenticity"`        // 真贋の確認状況
+	LocationID        *int64                 `json:"location_id"`         // 現在の保管場所（移動はMoveで記録する）
+	Status            ItemStatus             `json:"status"`              // 状態（変更はChangeStatus/Sellで行う）
+	SalePrice         *int                   `json:"sale_price"`          // 売却価格（売却済みの場合のみ）
+	SaleDate          string                 `json:"sale_date"`           // 売却日（YYYY-MM-DD 形式、売却済みの場合のみ）
+	WarrantyExpiresAt string                 `json:"warranty_expires_at"` // メーカー保証の期限（YYYY-MM-DD 形式）
+	InsurancePolicyID *int64                 `json:"insurance_policy_id"` // 補償する保険契約（変更は保険契約への割り当てで行う）
+	Tags              []string               `json:"tags"`
+	Attributes        map[string]interface{} `json:"attributes"` // カテゴリーごとのスキーマで定義されたカスタム属性
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+
+	// 購入価格と整備費用の合計（SetMaintenanceCostで設定した場合のみ）
+	TotalCostOfOwnership *int64 `json:"total_cost_of_ownership,omitempty"`
+	// カテゴリーごとの計算方法による帳簿価額（SetBookValueで設定した場合のみ）
+	BookValue *int64 `json:"book_value,omitempty"`
+
+	// 前回の永続化以降に変更されたフィールド（Apply/Updateで記録される）
+	changes []ItemField
+}
+
+// ItemField は変更追跡の対象となるフィールド名（JSONのキーと同じ）
+type ItemField string
+
+const (
+	FieldName              ItemField = "name"
+	FieldCategory          ItemField = "category"
+	FieldBrand             ItemField = "brand"
+	FieldPurchasePrice     ItemField = "purchase_price"
+	FieldPurchaseDate      ItemField = "purchase_date"
+	FieldSerialNumber      ItemField = "serial_number"
+	FieldModelNumber       ItemField = "model_number"
+	FieldCondition         ItemField = "condition"
+	FieldAuthenticity      ItemField = "authenticity"
+	FieldAttributes        ItemField = "attributes"
+	FieldStatus            ItemField = "status"
+	FieldSalePrice         ItemField = "sale_price"
+	FieldSaleDate          ItemField = "sale_date"
+	FieldWarrantyExpiresAt ItemField = "warranty_expires_at"
+)
+
+// ItemPatch はアイテムの部分更新の内容。nilのフィールドは変更しない
+type ItemPatch struct {
+	Name              *string
+	Category          *string
+	Brand             *string
+	PurchasePrice     *int
+	PurchaseDate      *string
+	SerialNumber      *string
+	ModelNumber       *string
+	Condition         *string
+	Authenticity      *string
+	WarrantyExpiresAt *string
+	// 属性はキー単位でマージする。値がnilのキーは削除する
+	Attributes map[string]interface{}
 }
 
 // カテゴリー定義
 var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
+// コンディションランク定義（S: 新品・未使用 〜 D: ジャンク）
+var ValidConditions = []string{"S", "A", "B", "C", "D"}
+
+// 真贋の確認状況
+const (
+	AuthenticityUnverified  = "unverified"
+	AuthenticityAuthentic   = "authentic"
+	AuthenticityCounterfeit = "counterfeit"
+)
+
+var ValidAuthenticityStatuses = []string{AuthenticityUnverified, AuthenticityAuthentic, AuthenticityCounterfeit}
+
+// シリアル番号・型番の最大長
+const maxIdentifierLength = 100
+
 func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
 	item := &Item{
 		Name:          strings.TrimSpace(name),
@@ -27,6 +98,8 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 		Brand:         strings.TrimSpace(brand),
 		PurchasePrice: purchasePrice,
 		PurchaseDate:  strings.TrimSpace(purchaseDate),
+		Authenticity:  AuthenticityUnverified,
+		Status:        ItemStatusOwned,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -70,6 +143,33 @@ func (i *Item) Validate() error {
 		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
 	}
 
+	if len(i.SerialNumber) > maxIdentifierLength {
+		errs = append(errs, "serial_number must be 100 characters or less")
+	}
+
+	if len(i.ModelNumber) > maxIdentifierLength {
+		errs = append(errs, "model_number must be 100 characters or less")
+	}
+
+	if i.Condition != "" && !contains(ValidConditions, i.Condition) {
+		errs = append(errs, "condition must be one of: "+strings.Join(ValidConditions, ", "))
+	}
+
+	// 未設定は未確認として扱う
+	if i.Authenticity != "" && !contains(ValidAuthenticityStatuses, i.Authenticity) {
+		errs = append(errs, "authenticity must be one of: "+strings.Join(ValidAuthenticityStatuses, ", "))
+	}
+
+	if i.WarrantyExpiresAt != "" && !isValidDateFormat(i.WarrantyExpiresAt) {
+		errs = append(errs, "warranty_expires_at must be in YYYY-MM-DD format")
+	}
+
+	errs = append(errs, i.validateStatus()...)
+
+	if isValidCategory(i.Category) {
+		errs = append(errs, validateAttributes(i.Category, i.Attributes)...)
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, ", "))
 	}
@@ -79,20 +179,167 @@ func (i *Item) Validate() error {
 
 // アイテムフィールドのアップデート
 func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
-	i.Name = strings.TrimSpace(name)
-	i.Category = strings.TrimSpace(category)
-	i.Brand = strings.TrimSpace(brand)
-	i.PurchasePrice = purchasePrice
-	i.PurchaseDate = strings.TrimSpace(purchaseDate)
-	i.UpdatedAt = time.Now()
+	return i.Apply(ItemPatch{
+		Name:          &name,
+		Category:      &category,
+		Brand:         &brand,
+		PurchasePrice: &purchasePrice,
+		PurchaseDate:  &purchaseDate,
+	})
+}
+
+// パッチを適用してバリデーションする。
+// バリデーションに失敗した場合はアイテムを変更しない。値が実際に変わったフィールドだけが変更として記録される
+func (i *Item) Apply(patch ItemPatch) error {
+	next := *i
+	next.changes = append([]ItemField(nil), i.changes...)
+
+	if patch.Name != nil {
+		next.setString(FieldName, &next.Name, strings.TrimSpace(*patch.Name))
+	}
+	if patch.Category != nil {
+		next.setString(FieldCategory, &next.Category, strings.TrimSpace(*patch.Category))
+	}
+	if patch.Brand != nil {
+		next.setString(FieldBrand, &next.Brand, strings.TrimSpace(*patch.Brand))
+	}
+	if patch.PurchasePrice != nil && next.PurchasePrice != *patch.PurchasePrice {
+		next.PurchasePrice = *patch.PurchasePrice
+		next.markChanged(FieldPurchasePrice)
+	}
+	if patch.PurchaseDate != nil {
+		next.setString(FieldPurchaseDate, &next.PurchaseDate, strings.TrimSpace(*patch.PurchaseDate))
+	}
+	if patch.SerialNumber != nil {
+		next.setString(FieldSerialNumber, &next.SerialNumber, strings.TrimSpace(*patch.SerialNumber))
+	}
+	if patch.ModelNumber != nil {
+		next.setString(FieldModelNumber, &next.ModelNumber, strings.TrimSpace(*patch.ModelNumber))
+	}
+	if patch.Condition != nil {
+		next.setString(FieldCondition, &next.Condition, strings.ToUpper(strings.TrimSpace(*patch.Condition)))
+	}
+	if patch.Authenticity != nil {
+		authenticity := strings.ToLower(strings.TrimSpace(*patch.Authenticity))
+		if authenticity == "" {
+			authenticity = AuthenticityUnverified
+		}
+		next.setString(FieldAuthenticity, &next.Authenticity, authenticity)
+	}
+	if patch.WarrantyExpiresAt != nil {
+		next.setString(FieldWarrantyExpiresAt, &next.WarrantyExpiresAt, strings.TrimSpace(*patch.WarrantyExpiresAt))
+	}
+	if patch.Attributes != nil {
+		merged := make(map[string]interface{}, len(next.Attributes)+len(patch.Attributes))
+		for key, value := range next.Attributes {
+			merged[key] = value
+		}
+		for key, value := range patch.Attributes {
+			if value == nil {
+				delete(merged, key)
+			} else {
+				merged[key] = value
+			}
+		}
+		next.setAttributes(merged)
+	}
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	if len(next.changes) > len(i.changes) {
+		next.UpdatedAt = time.Now()
+	}
+	*i = next
+
+	return nil
+}
+
+// カスタム属性をすべて置き換えてバリデーションする。失敗した場合はアイテムを変更しない
+func (i *Item) SetAttributes(attributes map[string]interface{}) error {
+	next := *i
+	next.changes = append([]ItemField(nil), i.changes...)
+	next.setAttributes(attributes)
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	if len(next.changes) > len(i.changes) {
+		next.UpdatedAt = time.Now()
+	}
+	*i = next
+
+	return nil
+}
+
+func (i *Item) setAttributes(attributes map[string]interface{}) {
+	normalized := normalizeAttributes(i.Category, attributes)
+	if attributesEqual(i.Attributes, normalized) {
+		return
+	}
+	i.Attributes = normalized
+	i.markChanged(FieldAttributes)
+}
 
-	return i.Validate()
+// SetMaintenanceCost は整備費用の合計から総保有コスト（購入価格 + 整備費用）を設定する
+func (i *Item) SetMaintenanceCost(cost int64) {
+	total := int64(i.PurchasePrice) + cost
+	i.TotalCostOfOwnership = &total
+}
+
+// SetBookValue は指定日時点の帳簿価額を設定する。所有していないアイテムは設定しない
+func (i *Item) SetBookValue(models DepreciationModels, asOf time.Time) {
+	if value, ok := models.BookValue(i, asOf); ok {
+		i.BookValue = &value
+	}
+}
+
+// IsWarrantyActive は指定日時点でメーカー保証の期間内かを返す（期限日の当日は期間内）
+func (i *Item) IsWarrantyActive(now time.Time) bool {
+	return i.WarrantyExpiresAt != "" && i.WarrantyExpiresAt >= now.Format("2006-01-02")
+}
+
+// 前回の永続化以降に変更されたフィールドを変更順で返す
+func (i *Item) ChangedFields() []ItemField {
+	return append([]ItemField(nil), i.changes...)
+}
+
+func (i *Item) HasChanges() bool {
+	return len(i.changes) > 0
+}
+
+// 永続化後に変更履歴をクリアする
+func (i *Item) ClearChanges() {
+	i.changes = nil
+}
+
+func (i *Item) setString(field ItemField, target *string, value string) {
+	if *target == value {
+		return
+	}
+	*target = value
+	i.markChanged(field)
+}
+
+func (i *Item) markChanged(field ItemField) {
+	for _, f := range i.changes {
+		if f == field {
+			return
+		}
+	}
+	i.changes = append(i.changes, field)
 }
 
 // カテゴリーのバリデーション
 func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
+	return contains(ValidCategories, category)
+}
+
+func contains(values []string, value string) bool {
+	for _, valid := range values {
+		if value == valid {
 			return true
 		}
 	}
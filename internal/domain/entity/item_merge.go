@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// ItemMerge は重複アイテムを統合した履歴。統合されたアイテムは削除されるため統合時点の内容を保持する
+type ItemMerge struct {
+	ID           int64     `json:"id"`
+	SurvivorID   int64     `json:"survivor_id"`
+	MergedItemID int64     `json:"merged_item_id"`
+	MergedItem   *Item     `json:"merged_item"`
+	MergedAt     time.Time `json:"merged_at"`
+}
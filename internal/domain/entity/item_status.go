@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// アイテムの状態（所有中・貸出中・修理中・委託中・売却済み・紛失）
+type ItemStatus string
+
+const (
+	ItemStatusOwned     ItemStatus = "owned"
+	ItemStatusLent      ItemStatus = "lent"
+	ItemStatusInRepair  ItemStatus = "in_repair"
+	ItemStatusConsigned ItemStatus = "consigned"
+	ItemStatusSold      ItemStatus = "sold"
+	ItemStatusLost      ItemStatus = "lost"
+)
+
+var ValidItemStatuses = []ItemStatus{
+	ItemStatusOwned, ItemStatusLent, ItemStatusInRepair, ItemStatusConsigned, ItemStatusSold, ItemStatusLost,
+}
+
+// 各状態から遷移できる状態。売却済みは最終状態
+var itemStatusTransitions = map[ItemStatus][]ItemStatus{
+	ItemStatusOwned:     {ItemStatusLent, ItemStatusInRepair, ItemStatusConsigned, ItemStatusSold, ItemStatusLost},
+	ItemStatusLent:      {ItemStatusOwned, ItemStatusLost},
+	ItemStatusInRepair:  {ItemStatusOwned, ItemStatusLost},
+	ItemStatusConsigned: {ItemStatusOwned, ItemStatusSold, ItemStatusLost},
+	ItemStatusLost:      {ItemStatusOwned},
+}
+
+// ErrInvalidStatusTransition は現在の状態から遷移できない状態への変更を表す
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// CanTransitionTo は次の状態に遷移できるかを返す
+func (s ItemStatus) CanTransitionTo(next ItemStatus) bool {
+	for _, allowed := range itemStatusTransitions[s.orDefault()] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// 状態が未設定の既存データは所有中として扱う
+func (s ItemStatus) orDefault() ItemStatus {
+	if s == "" {
+		return ItemStatusOwned
+	}
+	return s
+}
+
+func isValidItemStatus(status ItemStatus) bool {
+	for _, valid := range ValidItemStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus は売却以外の状態に遷移する。売却はSellで行う
+func (i *Item) ChangeStatus(next ItemStatus) error {
+	if next == ItemStatusSold {
+		return errors.New("sale_price and sale_date are required to mark an item as sold")
+	}
+	return i.transition(next, nil, "")
+}
+
+// Sell は売却価格と売却日を記録して売却済みにする
+func (i *Item) Sell(salePrice int, saleDate string) error {
+	return i.transition(ItemStatusSold, &salePrice, strings.TrimSpace(saleDate))
+}
+
+// RealizedGain は売却済みアイテムの実現損益（売却価格 - 購入価格）を返す。売却前はnil
+func (i *Item) RealizedGain() *int {
+	if i.Status != ItemStatusSold || i.SalePrice == nil {
+		return nil
+	}
+	gain := *i.SalePrice - i.PurchasePrice
+	return &gain
+}
+
+// 状態を遷移してバリデーションする。失敗した場合はアイテムを変更しない
+func (i *Item) transition(next ItemStatus, salePrice *int, saleDate string) error {
+	if !isValidItemStatus(next) {
+		return fmt.Errorf("status must be one of: %s", joinItemStatuses())
+	}
+	if !i.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: cannot change status from %s to %s", ErrInvalidStatusTransition, i.Status.orDefault(), next)
+	}
+
+	updated := *i
+	updated.changes = append([]ItemField(nil), i.changes...)
+
+	updated.Status = next
+	updated.markChanged(FieldStatus)
+	if !intPtrEqual(updated.SalePrice, salePrice) {
+		updated.SalePrice = salePrice
+		updated.markChanged(FieldSalePrice)
+	}
+	updated.setString(FieldSaleDate, &updated.SaleDate, saleDate)
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	*i = updated
+
+	return nil
+}
+
+// 状態と売却情報の整合性を検証する
+func (i *Item) validateStatus() []string {
+	var errs []string
+
+	if i.Status != "" && !isValidItemStatus(i.Status) {
+		errs = append(errs, "status must be one of: "+joinItemStatuses())
+	}
+
+	if i.Status != ItemStatusSold {
+		if i.SalePrice != nil || i.SaleDate != "" {
+			errs = append(errs, "sale_price and sale_date can only be set on sold items")
+		}
+		return errs
+	}
+
+	if i.SalePrice == nil {
+		errs = append(errs, "sale_price is required")
+	} else if *i.SalePrice < 0 {
+		errs = append(errs, "sale_price must be 0 or greater")
+	}
+
+	if i.SaleDate == "" {
+		errs = append(errs, "sale_date is required")
+	} else if !isValidDateFormat(i.SaleDate) {
+		errs = append(errs, "sale_date must be in YYYY-MM-DD format")
+	} else if isValidDateFormat(i.PurchaseDate) && i.SaleDate < i.PurchaseDate {
+		// YYYY-MM-DD形式は文字列の比較で日付の前後を判定できる
+		errs = append(errs, "sale_date must be on or after purchase_date")
+	}
+
+	return errs
+}
+
+func joinItemStatuses() string {
+	statuses := make([]string, len(ValidItemStatuses))
+	for i, status := range ValidItemStatuses {
+		statuses[i] = string(status)
+	}
+	return strings.Join(statuses, ", ")
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       ItemStatus
+		next          ItemStatus
+		expectedError string
+	}{
+		{name: "正常系: 所有中から貸出中", current: ItemStatusOwned, next: ItemStatusLent},
+		{name: "正常系: 修理中から所有中に戻す", current: ItemStatusInRepair, next: ItemStatusOwned},
+		{name: "正常系: 紛失から所有中に戻す", current: ItemStatusLost, next: ItemStatusOwned},
+		{name: "正常系: 状態未設定は所有中として扱う", current: "", next: ItemStatusConsigned},
+		{
+			name:          "異常系: 貸出中から修理中には遷移できない",
+			current:       ItemStatusLent,
+			next:          ItemStatusInRepair,
+			expectedError: "invalid status transition: cannot change status from lent to in_repair",
+		},
+		{
+			name:          "異常系: 売却済みからは遷移できない",
+			current:       ItemStatusSold,
+			next:          ItemStatusOwned,
+			expectedError: "invalid status transition: cannot change status from sold to owned",
+		},
+		{
+			name:          "異常系: 売却はSellで行う",
+			current:       ItemStatusOwned,
+			next:          ItemStatusSold,
+			expectedError: "sale_price and sale_date are required to mark an item as sold",
+		},
+		{
+			name:          "異常系: 不正な状態",
+			current:       ItemStatusOwned,
+			next:          "stolen",
+			expectedError: "status must be one of: owned, lent, in_repair, consigned, sold, lost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: tt.current}
+			if tt.current == ItemStatusSold {
+				item.SalePrice, item.SaleDate = intPtr(100), "2023-02-01"
+			}
+
+			err := item.ChangeStatus(tt.next)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, tt.current, item.Status)
+				assert.False(t, item.HasChanges())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, item.Status)
+			assert.Equal(t, []ItemField{FieldStatus}, item.ChangedFields())
+		})
+	}
+}
+
+func TestItem_Sell(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       ItemStatus
+		salePrice     int
+		saleDate      string
+		expectedGain  int
+		expectedError string
+	}{
+		{name: "正常系: 所有中のアイテムを利益が出る価格で売却", current: ItemStatusOwned, salePrice: 1800000, saleDate: "2024-01-10", expectedGain: 300000},
+		{name: "正常系: 委託中のアイテムを損失が出る価格で売却", current: ItemStatusConsigned, salePrice: 1200000, saleDate: "2024-01-10", expectedGain: -300000},
+		{
+			name:          "異常系: 貸出中は売却できない",
+			current:       ItemStatusLent,
+			salePrice:     1800000,
+			saleDate:      "2024-01-10",
+			expectedError: "invalid status transition: cannot change status from lent to sold",
+		},
+		{
+			name:          "異常系: 売却日が購入日より前",
+			current:       ItemStatusOwned,
+			salePrice:     1800000,
+			saleDate:      "2022-12-31",
+			expectedError: "sale_date must be on or after purchase_date",
+		},
+		{
+			name:          "異常系: 売却価格が負数で売却日が不正",
+			current:       ItemStatusOwned,
+			salePrice:     -1,
+			saleDate:      "2024/01/10",
+			expectedError: "sale_price must be 0 or greater, sale_date must be in YYYY-MM-DD format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-01", Status: tt.current}
+
+			err := item.Sell(tt.salePrice, tt.saleDate)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, tt.current, item.Status)
+				assert.Nil(t, item.SalePrice)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, ItemStatusSold, item.Status)
+			assert.Equal(t, tt.saleDate, item.SaleDate)
+			assert.Equal(t, tt.expectedGain, *item.RealizedGain())
+			assert.ElementsMatch(t, []ItemField{FieldStatus, FieldSalePrice, FieldSaleDate}, item.ChangedFields())
+		})
+	}
+}
+
+func TestItem_Apply_SoldItemPurchaseDate(t *testing.T) {
+	item := &Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"}
+	require.NoError(t, item.Sell(100, "2023-06-01"))
+
+	// 売却日より後の購入日には変更できない
+	assert.EqualError(t, item.Apply(ItemPatch{PurchaseDate: strPtr("2023-07-01")}), "sale_date must be on or after purchase_date")
+	assert.Equal(t, "2023-01-01", item.PurchaseDate)
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -231,6 +232,88 @@ func TestItem_Update(t *testing.T) {
 	}
 }
 
+func TestItem_Apply(t *testing.T) {
+	tests := []struct {
+		name            string
+		patch           ItemPatch
+		wantErr         bool
+		expectedErr     string
+		expectedChanges []ItemField
+		check           func(t *testing.T, item *Item)
+	}{
+		{
+			name:            "正常系: 購入価格を0円に変更",
+			patch:           ItemPatch{PurchasePrice: intPtr(0)},
+			expectedChanges: []ItemField{FieldPurchasePrice},
+			check: func(t *testing.T, item *Item) {
+				assert.Equal(t, 0, item.PurchasePrice)
+			},
+		},
+		{
+			name:            "正常系: 複数フィールドを変更（前後の空白は除去される）",
+			patch:           ItemPatch{Name: strPtr("  新しい名前  "), Category: strPtr("ジュエリー"), PurchaseDate: strPtr("2022-10-10")},
+			expectedChanges: []ItemField{FieldName, FieldCategory, FieldPurchaseDate},
+			check: func(t *testing.T, item *Item) {
+				assert.Equal(t, "新しい名前", item.Name)
+				assert.Equal(t, "ジュエリー", item.Category)
+				assert.Equal(t, "2022-10-10", item.PurchaseDate)
+			},
+		},
+		{
+			name:            "正常系: 現在と同じ値は変更として記録しない",
+			patch:           ItemPatch{Name: strPtr("初期アイテム"), PurchasePrice: intPtr(100000)},
+			expectedChanges: nil,
+		},
+		{
+			name:        "異常系: 名前が100文字超過",
+			patch:       ItemPatch{Name: strPtr(strings.Repeat("あ", 300))},
+			wantErr:     true,
+			expectedErr: "name must be 100 characters or less",
+		},
+		{
+			name:        "異常系: 無効なカテゴリー",
+			patch:       ItemPatch{Category: strPtr("無効なカテゴリー")},
+			wantErr:     true,
+			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+		},
+		{
+			name:        "異常系: 負の価格",
+			patch:       ItemPatch{Name: strPtr("新しい名前"), PurchasePrice: intPtr(-1)},
+			wantErr:     true,
+			expectedErr: "purchase_price must be 0 or greater",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+			require.NoError(t, err)
+			before := *item
+
+			err = item.Apply(tt.patch)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				// バリデーションに失敗した場合はアイテムが変更されない
+				assert.Equal(t, before, *item)
+				assert.False(t, item.HasChanges())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedChanges, item.ChangedFields())
+			assert.Equal(t, len(tt.expectedChanges) > 0, item.HasChanges())
+			if tt.check != nil {
+				tt.check(t, item)
+			}
+
+			item.ClearChanges()
+			assert.False(t, item.HasChanges())
+		})
+	}
+}
+
 func TestItem_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -335,3 +418,90 @@ func TestGetValidCategories(t *testing.T) {
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 5)
 }
+
+func TestItem_Apply_Identifiers(t *testing.T) {
+	tests := []struct {
+		name        string
+		patch       ItemPatch
+		wantErr     bool
+		expectedErr string
+		check       func(t *testing.T, item *Item)
+	}{
+		{
+			name:  "正常系: シリアル番号・型番・コンディション・真贋を設定",
+			patch: ItemPatch{SerialNumber: strPtr(" Z123456 "), ModelNumber: strPtr("116520"), Condition: strPtr("a"), Authenticity: strPtr("Authentic")},
+			check: func(t *testing.T, item *Item) {
+				assert.Equal(t, "Z123456", item.SerialNumber)
+				assert.Equal(t, "116520", item.ModelNumber)
+				assert.Equal(t, "A", item.Condition)
+				assert.Equal(t, AuthenticityAuthentic, item.Authenticity)
+			},
+		},
+		{
+			name:  "正常系: 真贋を空にすると未確認に戻る",
+			patch: ItemPatch{Authenticity: strPtr("")},
+			check: func(t *testing.T, item *Item) {
+				assert.Equal(t, AuthenticityUnverified, item.Authenticity)
+				assert.False(t, item.HasChanges())
+			},
+		},
+		{
+			name:        "異常系: 無効なコンディションランク",
+			patch:       ItemPatch{Condition: strPtr("E")},
+			wantErr:     true,
+			expectedErr: "condition must be one of: S, A, B, C, D",
+		},
+		{
+			name:        "異常系: 無効な真贋ステータス",
+			patch:       ItemPatch{Authenticity: strPtr("fake")},
+			wantErr:     true,
+			expectedErr: "authenticity must be one of: unverified, authentic, counterfeit",
+		},
+		{
+			name:        "異常系: シリアル番号が100文字超過",
+			patch:       ItemPatch{SerialNumber: strPtr(strings.Repeat("1", 101))},
+			wantErr:     true,
+			expectedErr: "serial_number must be 100 characters or less",
+		},
+		{
+			name:  "正常系: 保証期限を設定",
+			patch: ItemPatch{WarrantyExpiresAt: strPtr(" 2028-01-15 ")},
+			check: func(t *testing.T, item *Item) {
+				assert.Equal(t, "2028-01-15", item.WarrantyExpiresAt)
+				assert.Equal(t, []ItemField{FieldWarrantyExpiresAt}, item.ChangedFields())
+			},
+		},
+		{
+			name:        "異常系: 保証期限の形式が不正",
+			patch:       ItemPatch{WarrantyExpiresAt: strPtr("2028/01/15")},
+			wantErr:     true,
+			expectedErr: "warranty_expires_at must be in YYYY-MM-DD format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+			require.NoError(t, err)
+
+			err = item.Apply(tt.patch)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.False(t, item.HasChanges())
+			} else {
+				require.NoError(t, err)
+				tt.check(t, item)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
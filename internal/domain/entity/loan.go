@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Borrower はアイテムの貸出先（家族・撮影スタジオなど）
+type Borrower struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Contact   string    `json:"contact"` // 連絡先（メールアドレス・電話番号など）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewBorrower(name, contact string) (*Borrower, error) {
+	borrower := &Borrower{
+		Name:      strings.TrimSpace(name),
+		Contact:   strings.TrimSpace(contact),
+		CreatedAt: time.Now(),
+	}
+
+	if err := borrower.Validate(); err != nil {
+		return nil, err
+	}
+
+	return borrower, nil
+}
+
+// 貸出先のバリデーション
+func (b *Borrower) Validate() error {
+	var errs []string
+
+	if b.Name == "" {
+		errs = append(errs, "name is required")
+	} else if len(b.Name) > 100 {
+		errs = append(errs, "name must be 100 characters or less")
+	}
+
+	if len(b.Contact) > 255 {
+		errs = append(errs, "contact must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// Loan はアイテムの貸出記録。ReturnedAtがnilの間は貸出中
+type Loan struct {
+	ID             int64      `json:"id"`
+	ItemID         int64      `json:"item_id"`
+	BorrowerID     int64      `json:"borrower_id"`
+	Borrower       *Borrower  `json:"borrower,omitempty"`
+	LentAt         time.Time  `json:"lent_at"`
+	DueDate        string     `json:"due_date"` // 返却期限（YYYY-MM-DD 形式）
+	ReturnedAt     *time.Time `json:"returned_at"`
+	Note           string     `json:"note"`
+	LastNotifiedAt *time.Time `json:"last_notified_at"` // 最後に期限超過を通知した日時
+}
+
+// ErrLoanAlreadyReturned は返却済みの貸出を返却しようとしたことを表す
+var ErrLoanAlreadyReturned = errors.New("loan has already been returned")
+
+func NewLoan(itemID, borrowerID int64, dueDate, note string, lentAt time.Time) (*Loan, error) {
+	loan := &Loan{
+		ItemID:     itemID,
+		BorrowerID: borrowerID,
+		LentAt:     lentAt,
+		DueDate:    strings.TrimSpace(dueDate),
+		Note:       strings.TrimSpace(note),
+	}
+
+	if err := loan.Validate(); err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// 貸出記録のバリデーション
+func (l *Loan) Validate() error {
+	var errs []string
+
+	if l.BorrowerID <= 0 {
+		errs = append(errs, "borrower_id is required")
+	}
+
+	if l.DueDate == "" {
+		errs = append(errs, "due_date is required")
+	} else if !isValidDateFormat(l.DueDate) {
+		errs = append(errs, "due_date must be in YYYY-MM-DD format")
+	} else if l.DueDate < l.LentAt.Format("2006-01-02") {
+		errs = append(errs, "due_date must be on or after the lending date")
+	}
+
+	if len(l.Note) > 255 {
+		errs = append(errs, "note must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func (l *Loan) IsActive() bool {
+	return l.ReturnedAt == nil
+}
+
+// IsOverdue は返却期限を過ぎても返却されていないかを返す（期限日の当日は超過としない）
+func (l *Loan) IsOverdue(now time.Time) bool {
+	return l.IsActive() && l.DueDate < now.Format("2006-01-02")
+}
+
+// Return は貸出を返却済みにする
+func (l *Loan) Return(at time.Time) error {
+	if !l.IsActive() {
+		return ErrLoanAlreadyReturned
+	}
+	l.ReturnedAt = &at
+	return nil
+}
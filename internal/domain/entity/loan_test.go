@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBorrower(t *testing.T) {
+	tests := []struct {
+		name         string
+		borrowerName string
+		contact      string
+		wantErr      bool
+		expectedErr  string
+	}{
+		{name: "正常系: 名前と連絡先", borrowerName: " 山田太郎 ", contact: "taro@example.com"},
+		{name: "正常系: 連絡先なし", borrowerName: "撮影スタジオ"},
+		{name: "異常系: 名前が空", borrowerName: "  ", wantErr: true, expectedErr: "name is required"},
+		{name: "異常系: 連絡先が長すぎる", borrowerName: "山田太郎", contact: string(make([]byte, 256)), wantErr: true, expectedErr: "contact must be 255 characters or less"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrower, err := NewBorrower(tt.borrowerName, tt.contact)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, borrower)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, borrower.Name)
+				assert.NotContains(t, borrower.Name, " ")
+			}
+		})
+	}
+}
+
+func TestNewLoan(t *testing.T) {
+	lentAt := time.Date(2024, 1, 10, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		borrowerID  int64
+		dueDate     string
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "正常系: 返却期限が貸出日より後", borrowerID: 1, dueDate: "2024-01-20"},
+		{name: "正常系: 返却期限が貸出日と同じ", borrowerID: 1, dueDate: "2024-01-10"},
+		{name: "異常系: 貸出先がない", dueDate: "2024-01-20", wantErr: true, expectedErr: "borrower_id is required"},
+		{name: "異常系: 返却期限がない", borrowerID: 1, wantErr: true, expectedErr: "due_date is required"},
+		{name: "異常系: 返却期限の形式が不正", borrowerID: 1, dueDate: "2024/01/20", wantErr: true, expectedErr: "due_date must be in YYYY-MM-DD format"},
+		{name: "異常系: 返却期限が貸出日より前", borrowerID: 1, dueDate: "2024-01-09", wantErr: true, expectedErr: "due_date must be on or after the lending date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan, err := NewLoan(1, tt.borrowerID, tt.dueDate, "", lentAt)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, loan)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, loan.IsActive())
+			}
+		})
+	}
+}
+
+func TestLoan_IsOverdue(t *testing.T) {
+	now := time.Date(2024, 1, 20, 9, 0, 0, 0, time.Local)
+	returnedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		loan     Loan
+		expected bool
+	}{
+		{name: "正常系: 期限を過ぎている", loan: Loan{DueDate: "2024-01-19"}, expected: true},
+		{name: "正常系: 期限の当日は超過としない", loan: Loan{DueDate: "2024-01-20"}, expected: false},
+		{name: "正常系: 返却済みは超過としない", loan: Loan{DueDate: "2024-01-19", ReturnedAt: &returnedAt}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.loan.IsOverdue(now))
+		})
+	}
+}
+
+func TestLoan_Return(t *testing.T) {
+	loan := &Loan{ID: 1, DueDate: "2024-01-20"}
+	at := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+
+	require.NoError(t, loan.Return(at))
+	assert.False(t, loan.IsActive())
+	assert.Equal(t, at, *loan.ReturnedAt)
+
+	// 返却済みの貸出は再度返却できない
+	assert.ErrorIs(t, loan.Return(at.Add(time.Hour)), ErrLoanAlreadyReturned)
+	assert.Equal(t, at, *loan.ReturnedAt)
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 保管場所の種類（拠点 > 部屋 > 収納）
+type LocationType string
+
+const (
+	LocationTypeSite      LocationType = "site"
+	LocationTypeRoom      LocationType = "room"
+	LocationTypeContainer LocationType = "container"
+)
+
+var ValidLocationTypes = []LocationType{LocationTypeSite, LocationTypeRoom, LocationTypeContainer}
+
+// 各種類の親になれる種類。拠点は最上位のため親を持たない
+var locationParentTypes = map[LocationType]LocationType{
+	LocationTypeRoom:      LocationTypeSite,
+	LocationTypeContainer: LocationTypeRoom,
+}
+
+type Location struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Type      LocationType `json:"type"`
+	ParentID  *int64       `json:"parent_id"`
+	Path      string       `json:"path"` // 「自宅 > 書斎 > 金庫」のような最上位からの名前
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+func NewLocation(name string, locationType LocationType, parent *Location) (*Location, error) {
+	location := &Location{
+		Name:      strings.TrimSpace(name),
+		Type:      LocationType(strings.TrimSpace(string(locationType))),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+	if err := location.setParent(parent); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// 保管場所のバリデーション
+func (l *Location) Validate() error {
+	var errs []string
+
+	if l.Name == "" {
+		errs = append(errs, "name is required")
+	} else if len(l.Name) > 100 {
+		errs = append(errs, "name must be 100 characters or less")
+	}
+
+	if !isValidLocationType(l.Type) {
+		errs = append(errs, "type must be one of: site, room, container")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// 名前と親を変更する。種類は変更できない
+func (l *Location) Update(name string, parent *Location) error {
+	next := *l
+	next.Name = strings.TrimSpace(name)
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	if err := next.setParent(parent); err != nil {
+		return err
+	}
+
+	next.UpdatedAt = time.Now()
+	*l = next
+
+	return nil
+}
+
+// 親の種類が階層（拠点 > 部屋 > 収納）に合っているか確認して設定する
+func (l *Location) setParent(parent *Location) error {
+	expected, needsParent := locationParentTypes[l.Type]
+
+	if parent == nil {
+		if needsParent {
+			return fmt.Errorf("parent_id is required for %s (parent must be a %s)", l.Type, expected)
+		}
+		l.ParentID = nil
+		return nil
+	}
+
+	if !needsParent {
+		return fmt.Errorf("%s cannot have a parent", l.Type)
+	}
+	if parent.Type != expected {
+		return fmt.Errorf("parent of %s must be a %s", l.Type, expected)
+	}
+
+	parentID := parent.ID
+	l.ParentID = &parentID
+	return nil
+}
+
+func isValidLocationType(locationType LocationType) bool {
+	for _, valid := range ValidLocationTypes {
+		if locationType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemMovement はアイテムの保管場所の移動履歴。場所がnilの場合は保管場所未設定を表す
+type ItemMovement struct {
+	ID             int64     `json:"id"`
+	ItemID         int64     `json:"item_id"`
+	FromLocationID *int64    `json:"from_location_id"`
+	ToLocationID   *int64    `json:"to_location_id"`
+	Note           string    `json:"note"`
+	MovedAt        time.Time `json:"moved_at"`
+}
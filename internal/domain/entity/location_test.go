@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocation(t *testing.T) {
+	site := &Location{ID: 1, Name: "自宅", Type: LocationTypeSite}
+	room := &Location{ID: 2, Name: "書斎", Type: LocationTypeRoom, ParentID: &site.ID}
+
+	tests := []struct {
+		name          string
+		locationName  string
+		locationType  LocationType
+		parent        *Location
+		expectedError string
+	}{
+		{
+			name:         "正常系: 親のない拠点",
+			locationName: "自宅",
+			locationType: LocationTypeSite,
+		},
+		{
+			name:         "正常系: 拠点の下の部屋",
+			locationName: "書斎",
+			locationType: LocationTypeRoom,
+			parent:       site,
+		},
+		{
+			name:         "正常系: 部屋の下の収納",
+			locationName: "金庫",
+			locationType: LocationTypeContainer,
+			parent:       room,
+		},
+		{
+			name:          "異常系: 拠点は親を持てない",
+			locationName:  "別荘",
+			locationType:  LocationTypeSite,
+			parent:        site,
+			expectedError: "site cannot have a parent",
+		},
+		{
+			name:          "異常系: 部屋には親が必要",
+			locationName:  "書斎",
+			locationType:  LocationTypeRoom,
+			expectedError: "parent_id is required for room (parent must be a site)",
+		},
+		{
+			name:          "異常系: 収納の親が拠点",
+			locationName:  "金庫",
+			locationType:  LocationTypeContainer,
+			parent:        site,
+			expectedError: "parent of container must be a room",
+		},
+		{
+			name:          "異常系: 不正な種類",
+			locationName:  "倉庫",
+			locationType:  "warehouse",
+			expectedError: "type must be one of: site, room, container",
+		},
+		{
+			name:          "異常系: 名前が空",
+			locationName:  "  ",
+			locationType:  LocationTypeSite,
+			expectedError: "name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := NewLocation(tt.locationName, tt.locationType, tt.parent)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, location)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.locationName, location.Name)
+			if tt.parent != nil {
+				require.NotNil(t, location.ParentID)
+				assert.Equal(t, tt.parent.ID, *location.ParentID)
+			} else {
+				assert.Nil(t, location.ParentID)
+			}
+		})
+	}
+}
+
+func TestLocation_Update(t *testing.T) {
+	siteA := &Location{ID: 1, Name: "自宅", Type: LocationTypeSite}
+	siteB := &Location{ID: 2, Name: "実家", Type: LocationTypeSite}
+
+	t.Run("正常系: 名前と親を変更", func(t *testing.T) {
+		room := &Location{ID: 3, Name: "書斎", Type: LocationTypeRoom, ParentID: &siteA.ID}
+
+		require.NoError(t, room.Update(" 寝室 ", siteB))
+		assert.Equal(t, "寝室", room.Name)
+		assert.Equal(t, siteB.ID, *room.ParentID)
+	})
+
+	t.Run("異常系: 不正な親の場合は変更されない", func(t *testing.T) {
+		room := &Location{ID: 3, Name: "書斎", Type: LocationTypeRoom, ParentID: &siteA.ID}
+		other := &Location{ID: 4, Name: "寝室", Type: LocationTypeRoom, ParentID: &siteA.ID}
+
+		assert.EqualError(t, room.Update("客間", other), "parent of room must be a site")
+		assert.Equal(t, "書斎", room.Name)
+		assert.Equal(t, siteA.ID, *room.ParentID)
+	})
+}
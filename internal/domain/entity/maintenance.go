@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceRecord はアイテムの整備記録（オーバーホール・クリーニング・修理など）
+type MaintenanceRecord struct {
+	ID         int64     `json:"id"`
+	ItemID     int64     `json:"item_id"`
+	ServicedAt string    `json:"serviced_at"` // 整備日（YYYY-MM-DD 形式）
+	Vendor     string    `json:"vendor"`      // 整備を依頼した業者
+	Cost       int       `json:"cost"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewMaintenanceRecord(itemID int64, servicedAt, vendor string, cost int, note string) (*MaintenanceRecord, error) {
+	record := &MaintenanceRecord{
+		ItemID:     itemID,
+		ServicedAt: strings.TrimSpace(servicedAt),
+		Vendor:     strings.TrimSpace(vendor),
+		Cost:       cost,
+		Note:       strings.TrimSpace(note),
+		CreatedAt:  time.Now(),
+	}
+
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// 整備記録のバリデーション
+func (r *MaintenanceRecord) Validate() error {
+	var errs []string
+
+	if r.ServicedAt == "" {
+		errs = append(errs, "serviced_at is required")
+	} else if !isValidDateFormat(r.ServicedAt) {
+		errs = append(errs, "serviced_at must be in YYYY-MM-DD format")
+	}
+
+	if len(r.Vendor) > 100 {
+		errs = append(errs, "vendor must be 100 characters or less")
+	}
+
+	if r.Cost < 0 {
+		errs = append(errs, "cost must be 0 or greater")
+	}
+
+	if len(r.Note) > 255 {
+		errs = append(errs, "note must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// MaintenanceIntervals はカテゴリーごとの整備間隔（月数）。含まれないカテゴリーは定期整備の対象外
+type MaintenanceIntervals map[string]int
+
+// DefaultMaintenanceIntervals は既定の整備間隔（機械式時計のオーバーホールは3年ごと）
+var DefaultMaintenanceIntervals = MaintenanceIntervals{
+	"時計":    36,
+	"バッグ":   24,
+	"ジュエリー": 12,
+	"靴":     12,
+}
+
+// ParseMaintenanceIntervals は "時計=36,バッグ=24" 形式の設定を既定の整備間隔に上書きする。
+// 0を指定したカテゴリーは定期整備の対象外になる
+func ParseMaintenanceIntervals(value string) (MaintenanceIntervals, error) {
+	intervals := make(MaintenanceIntervals, len(DefaultMaintenanceIntervals))
+	for category, months := range DefaultMaintenanceIntervals {
+		intervals[category] = months
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		category, monthsStr, ok := strings.Cut(entry, "=")
+		category = strings.TrimSpace(category)
+		if !ok || !isValidCategory(category) {
+			return nil, fmt.Errorf("invalid maintenance interval %q: must be <category>=<months>", entry)
+		}
+		months, err := strconv.Atoi(strings.TrimSpace(monthsStr))
+		if err != nil || months < 0 {
+			return nil, fmt.Errorf("invalid maintenance interval %q: months must be 0 or greater", entry)
+		}
+
+		if months == 0 {
+			delete(intervals, category)
+		} else {
+			intervals[category] = months
+		}
+	}
+
+	return intervals, nil
+}
+
+// NextServiceDue は次回の整備予定日を返す。最後の整備日（未整備の場合は購入日）に整備間隔を加えた日付。
+// 定期整備の対象外のカテゴリー、または売却済み・紛失のアイテムは false を返す
+func (m MaintenanceIntervals) NextServiceDue(item *Item, lastServicedAt string) (string, bool) {
+	months, ok := m[item.Category]
+	if !ok || months <= 0 {
+		return "", false
+	}
+	if item.Status == ItemStatusSold || item.Status == ItemStatusLost {
+		return "", false
+	}
+
+	base := lastServicedAt
+	if base == "" {
+		base = item.PurchaseDate
+	}
+	baseDate, err := time.Parse("2006-01-02", base)
+	if err != nil {
+		return "", false
+	}
+
+	return baseDate.AddDate(0, months, 0).Format("2006-01-02"), true
+}
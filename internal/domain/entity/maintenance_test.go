@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaintenanceRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		servicedAt  string
+		cost        int
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "正常系: 整備日と費用", servicedAt: "2024-01-10", cost: 80000},
+		{name: "正常系: 無償の整備", servicedAt: "2024-01-10"},
+		{name: "異常系: 整備日がない", wantErr: true, expectedErr: "serviced_at is required"},
+		{name: "異常系: 整備日の形式が不正", servicedAt: "2024/01/10", wantErr: true, expectedErr: "serviced_at must be in YYYY-MM-DD format"},
+		{name: "異常系: 費用が負の値", servicedAt: "2024-01-10", cost: -1, wantErr: true, expectedErr: "cost must be 0 or greater"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := NewMaintenanceRecord(1, tt.servicedAt, " 日本ロレックス ", tt.cost, "")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, record)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "日本ロレックス", record.Vendor)
+			}
+		})
+	}
+}
+
+func TestParseMaintenanceIntervals(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    MaintenanceIntervals
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "正常系: 未指定は既定値", value: "", expected: DefaultMaintenanceIntervals},
+		{
+			name:     "正常系: 指定したカテゴリーだけを上書き",
+			value:    "時計=48, その他=6",
+			expected: MaintenanceIntervals{"時計": 48, "バッグ": 24, "ジュエリー": 12, "靴": 12, "その他": 6},
+		},
+		{
+			name:     "正常系: 0で定期整備の対象外",
+			value:    "靴=0",
+			expected: MaintenanceIntervals{"時計": 36, "バッグ": 24, "ジュエリー": 12},
+		},
+		{name: "異常系: 不正なカテゴリー", value: "家具=12", wantErr: true, expectedErr: "must be <category>=<months>"},
+		{name: "異常系: 月数が数値でない", value: "時計=three", wantErr: true, expectedErr: "months must be 0 or greater"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intervals, err := ParseMaintenanceIntervals(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, intervals)
+			}
+		})
+	}
+}
+
+func TestMaintenanceIntervals_NextServiceDue(t *testing.T) {
+	intervals := MaintenanceIntervals{"時計": 36}
+
+	tests := []struct {
+		name           string
+		item           Item
+		lastServicedAt string
+		expectedDue    string
+		expectedOK     bool
+	}{
+		{
+			name:        "正常系: 未整備は購入日から計算",
+			item:        Item{Category: "時計", PurchaseDate: "2023-01-15", Status: ItemStatusOwned},
+			expectedDue: "2026-01-15",
+			expectedOK:  true,
+		},
+		{
+			name:           "正常系: 最後の整備日から計算",
+			item:           Item{Category: "時計", PurchaseDate: "2020-01-15", Status: ItemStatusOwned},
+			lastServicedAt: "2024-06-01",
+			expectedDue:    "2027-06-01",
+			expectedOK:     true,
+		},
+		{
+			name: "正常系: 整備間隔のないカテゴリー",
+			item: Item{Category: "バッグ", PurchaseDate: "2023-01-15", Status: ItemStatusOwned},
+		},
+		{
+			name: "正常系: 売却済みのアイテム",
+			item: Item{Category: "時計", PurchaseDate: "2023-01-15", Status: ItemStatusSold},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, ok := intervals.NextServiceDue(&tt.item, tt.lastServicedAt)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedDue, due)
+		})
+	}
+}
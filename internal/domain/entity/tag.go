@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ItemCount int       `json:"item_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTag(name string) (*Tag, error) {
+	tag := &Tag{
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// タグのバリデーション
+func (t *Tag) Validate() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(t.Name) > 50 {
+		return errors.New("name must be 50 characters or less")
+	}
+	return nil
+}
+
+// タグ名の変更
+func (t *Tag) Rename(name string) error {
+	t.Name = strings.TrimSpace(name)
+	return t.Validate()
+}
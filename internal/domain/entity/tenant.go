@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultTenantSlug は既存のデータとサンプルデータが属するテナント（sql/init.sqlで作成する）
+const DefaultTenantSlug = "default"
+
+// Tenant はアイテムを管理する組織。アイテムをはじめとするすべてのデータはいずれかのテナントに属する
+type Tenant struct {
+	ID int64 `json:"id"`
+	// サブドメインやX-Tenantヘッダーでテナントを指定するための識別子
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// サブドメインとして使えるよう、英小文字・数字・ハイフンに限る
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+func NewTenant(slug, name string) (*Tenant, error) {
+	tenant := &Tenant{
+		Slug:      strings.ToLower(strings.TrimSpace(slug)),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+
+	if err := tenant.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+// テナントのバリデーション
+func (t *Tenant) Validate() error {
+	var errs []string
+
+	if t.Slug == "" {
+		errs = append(errs, "slug is required")
+	} else if len(t.Slug) > 63 {
+		errs = append(errs, "slug must be 63 characters or less")
+	} else if !tenantSlugPattern.MatchString(t.Slug) {
+		errs = append(errs, "slug must consist of lowercase letters, digits and hyphens and must not start or end with a hyphen")
+	}
+
+	if t.Name == "" {
+		errs = append(errs, "name is required")
+	} else if len(t.Name) > 100 {
+		errs = append(errs, "name must be 100 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		slug          string
+		tenantName    string
+		expectedSlug  string
+		expectedError string
+	}{
+		{
+			name:         "正常系: 英小文字・数字・ハイフン",
+			slug:         "acme-2024",
+			tenantName:   "Acme株式会社",
+			expectedSlug: "acme-2024",
+		},
+		{
+			name:         "正常系: 大文字と前後の空白は正規化する",
+			slug:         " Acme ",
+			tenantName:   "Acme株式会社",
+			expectedSlug: "acme",
+		},
+		{
+			name:          "異常系: サブドメインに使えない文字",
+			slug:          "acme.example",
+			tenantName:    "Acme株式会社",
+			expectedError: "slug must consist of lowercase letters, digits and hyphens and must not start or end with a hyphen",
+		},
+		{
+			name:          "異常系: ハイフンで始まる",
+			slug:          "-acme",
+			tenantName:    "Acme株式会社",
+			expectedError: "slug must consist of lowercase letters, digits and hyphens and must not start or end with a hyphen",
+		},
+		{
+			name:          "異常系: 長すぎる",
+			slug:          strings.Repeat("a", 64),
+			tenantName:    "Acme株式会社",
+			expectedError: "slug must be 63 characters or less",
+		},
+		{
+			name:          "異常系: 識別子と名前が空",
+			slug:          "",
+			tenantName:    " ",
+			expectedError: "slug is required, name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant, err := NewTenant(tt.slug, tt.tenantName)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, tenant)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSlug, tenant.Slug)
+			assert.Equal(t, tt.tenantName, tenant.Name)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ItemValuation はアイテムの評価額の記録（査定・相場の確認など）
+type ItemValuation struct {
+	ID        int64     `json:"id"`
+	ItemID    int64     `json:"item_id"`
+	ValuedAt  string    `json:"valued_at"` // 評価日（YYYY-MM-DD 形式）
+	Amount    int       `json:"amount"`
+	Source    string    `json:"source"` // 評価の根拠（査定業者・相場サイトなど）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewItemValuation(itemID int64, valuedAt string, amount int, source string) (*ItemValuation, error) {
+	valuation := &ItemValuation{
+		ItemID:    itemID,
+		ValuedAt:  strings.TrimSpace(valuedAt),
+		Amount:    amount,
+		Source:    strings.TrimSpace(source),
+		CreatedAt: time.Now(),
+	}
+
+	if err := valuation.Validate(); err != nil {
+		return nil, err
+	}
+
+	return valuation, nil
+}
+
+// 評価額のバリデーション
+func (v *ItemValuation) Validate() error {
+	var errs []string
+
+	if v.ValuedAt == "" {
+		errs = append(errs, "valued_at is required")
+	} else if !isValidDateFormat(v.ValuedAt) {
+		errs = append(errs, "valued_at must be in YYYY-MM-DD format")
+	}
+
+	if v.Amount < 0 {
+		errs = append(errs, "amount must be 0 or greater")
+	}
+
+	if len(v.Source) > 100 {
+		errs = append(errs, "source must be 100 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// CurrentValue はアイテムの現在の評価額を返す。評価額の記録がない場合は購入価格
+func CurrentValue(item *Item, latest *ItemValuation) int64 {
+	if latest != nil {
+		return int64(latest.Amount)
+	}
+	return int64(item.PurchasePrice)
+}
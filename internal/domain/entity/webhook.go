@@ -0,0 +1,334 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EventType はWebhookで通知するイベントの種類
+type EventType string
+
+const (
+	EventItemCreated EventType = "item.created"
+	EventItemUpdated EventType = "item.updated"
+	EventItemDeleted EventType = "item.deleted"
+)
+
+var ValidEventTypes = []EventType{EventItemCreated, EventItemUpdated, EventItemDeleted}
+
+// OutboxEvent はアイテムの変更と同じトランザクションで記録されるイベント。
+// 配信ワーカーが購読ごとの配信に振り分けるとDispatchedAtが設定される
+type OutboxEvent struct {
+	ID           int64           `json:"id"`
+	EventType    EventType       `json:"event_type"`
+	ItemID       int64           `json:"item_id"`
+	Payload      json.RawMessage `json:"payload"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	DispatchedAt *time.Time      `json:"dispatched_at"`
+	// イベントが発生したテナント（SSEでは同じテナントの購読者にだけ配る）
+	TenantID int64 `json:"-"`
+}
+
+// NewItemEvent はアイテムの作成・更新イベントを作成する。ペイロードは変更後のアイテム
+func NewItemEvent(eventType EventType, item *Item, at time.Time) (*OutboxEvent, error) {
+	payload, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{EventType: eventType, ItemID: item.ID, Payload: payload, OccurredAt: at}, nil
+}
+
+// NewItemDeletedEvent はアイテムの削除イベントを作成する。ペイロードは削除したアイテムのIDのみ
+func NewItemDeletedEvent(itemID int64, at time.Time) *OutboxEvent {
+	payload, _ := json.Marshal(map[string]int64{"id": itemID})
+	return &OutboxEvent{EventType: EventItemDeleted, ItemID: itemID, Payload: payload, OccurredAt: at}
+}
+
+// Body はWebhookとイベントストリームで送るイベントのJSON
+func (e *OutboxEvent) Body() ([]byte, error) {
+	return eventBody(e.ID, e.EventType, e.OccurredAt, e.Payload)
+}
+
+func eventBody(id int64, eventType EventType, occurredAt time.Time, payload json.RawMessage) ([]byte, error) {
+	return json.Marshal(struct {
+		ID         int64           `json:"id"`
+		Type       EventType       `json:"type"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Data       json.RawMessage `json:"data"`
+	}{
+		ID:         id,
+		Type:       eventType,
+		OccurredAt: occurredAt,
+		Data:       payload,
+	})
+}
+
+// WebhookSubscription はイベントの配信先。Secretは署名にのみ使い、レスポンスには含めない
+type WebhookSubscription struct {
+	ID        int64       `json:"id"`
+	URL       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	Secret    string      `json:"-"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// 署名用シークレットの最小文字数
+const minWebhookSecretLength = 16
+
+func NewWebhookSubscription(rawURL string, events []EventType, secret string) (*WebhookSubscription, error) {
+	now := time.Now()
+	subscription := &WebhookSubscription{
+		URL:       strings.TrimSpace(rawURL),
+		Events:    events,
+		Secret:    secret,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Update は配信先・イベント・有効状態を置き換える。secretが空の場合は既存のシークレットを維持する。
+// バリデーションに失敗した場合は変更しない
+func (s *WebhookSubscription) Update(rawURL string, events []EventType, secret string, active bool) error {
+	updated := *s
+	updated.URL = strings.TrimSpace(rawURL)
+	updated.Events = events
+	updated.Active = active
+	if secret != "" {
+		updated.Secret = secret
+	}
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	*s = updated
+	return nil
+}
+
+// Webhook購読のバリデーション
+func (s *WebhookSubscription) Validate() error {
+	var errs []string
+
+	if s.URL == "" {
+		errs = append(errs, "url is required")
+	} else if len(s.URL) > 500 {
+		errs = append(errs, "url must be 500 characters or less")
+	} else if parsed, err := url.Parse(s.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs = append(errs, "url must be an absolute http or https URL")
+	}
+
+	if len(s.Events) == 0 {
+		errs = append(errs, "events is required")
+	}
+	for _, event := range s.Events {
+		if !isValidEventType(event) {
+			errs = append(errs, fmt.Sprintf("events must be one of: %s", joinEventTypes(ValidEventTypes)))
+			break
+		}
+	}
+
+	if len(s.Secret) < minWebhookSecretLength {
+		errs = append(errs, fmt.Sprintf("secret must be at least %d characters", minWebhookSecretLength))
+	} else if len(s.Secret) > 255 {
+		errs = append(errs, "secret must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// Subscribes は有効な購読で、指定したイベントを購読しているかを返す
+func (s *WebhookSubscription) Subscribes(eventType EventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, event := range s.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidEventType(eventType EventType) bool {
+	for _, valid := range ValidEventTypes {
+		if eventType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func joinEventTypes(eventTypes []EventType) string {
+	values := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = string(eventType)
+	}
+	return strings.Join(values, ", ")
+}
+
+// DeliveryStatus はWebhook配信の状態
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // 配信待ち・再試行待ち
+	DeliverySucceeded DeliveryStatus = "succeeded" // 2xxの応答を受け取った
+	DeliveryDead      DeliveryStatus = "dead"      // 再試行の上限に達した（デッドレター）
+)
+
+var ValidDeliveryStatuses = []DeliveryStatus{DeliveryPending, DeliverySucceeded, DeliveryDead}
+
+// MaxWebhookAttempts は配信を諦めてデッドレターにするまでの試行回数
+const MaxWebhookAttempts = 8
+
+// 再試行間隔の初期値と上限
+const (
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 6 * time.Hour
+)
+
+// 保存する失敗理由の最大文字数
+const maxDeliveryErrorLength = 500
+
+// ErrDeliveryNotDead はデッドレター以外の配信を再送しようとしたことを表す
+var ErrDeliveryNotDead = errors.New("only dead deliveries can be retried")
+
+// WebhookDelivery は1つのイベントを1つの購読に配信する単位
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"` // 配信待ちの場合のみ
+	LastError      string          `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// NewWebhookDelivery はイベントを購読に配信するための配信待ちの配信を作成する
+func NewWebhookDelivery(subscription *WebhookSubscription, event *OutboxEvent, at time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventID:        event.ID,
+		EventType:      event.EventType,
+		Payload:        event.Payload,
+		OccurredAt:     event.OccurredAt,
+		Status:         DeliveryPending,
+		NextAttemptAt:  &at,
+		CreatedAt:      at,
+	}
+}
+
+// Body は配信するリクエストボディ。署名はこのバイト列に対して計算する
+func (d *WebhookDelivery) Body() ([]byte, error) {
+	return eventBody(d.EventID, d.EventType, d.OccurredAt, d.Payload)
+}
+
+// RecordSuccess は配信の成功を記録する
+func (d *WebhookDelivery) RecordSuccess(at time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.NextAttemptAt = nil
+	d.LastError = ""
+	d.DeliveredAt = &at
+}
+
+// RecordFailure は配信の失敗を記録する。試行回数が上限に達した場合はデッドレターにし、
+// それ以外は試行回数に応じて間隔を倍にしながら再試行を予定する
+func (d *WebhookDelivery) RecordFailure(at time.Time, reason string) {
+	d.Attempts++
+	d.LastError = truncateRunes(reason, maxDeliveryErrorLength)
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = DeliveryDead
+		d.NextAttemptAt = nil
+		return
+	}
+
+	next := at.Add(WebhookRetryDelay(d.Attempts))
+	d.Status = DeliveryPending
+	d.NextAttemptAt = &next
+}
+
+// Abandon は再試行せずにデッドレターにする（購読が無効になった場合など）
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = DeliveryDead
+	d.NextAttemptAt = nil
+	d.LastError = truncateRunes(reason, maxDeliveryErrorLength)
+}
+
+// Requeue はデッドレターの配信を試行回数を0に戻して配信待ちにする
+func (d *WebhookDelivery) Requeue(at time.Time) error {
+	if d.Status != DeliveryDead {
+		return ErrDeliveryNotDead
+	}
+
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &at
+	return nil
+}
+
+// WebhookRetryDelay は失敗した回数に応じた再試行までの間隔（30秒から倍々にし、6時間で打ち止め）
+func WebhookRetryDelay(failures int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// WebhookAttempt は配信の試行1回分のログ
+type WebhookAttempt struct {
+	ID             int64     `json:"id"`
+	DeliveryID     int64     `json:"delivery_id"`
+	AttemptedAt    time.Time `json:"attempted_at"`
+	ResponseStatus *int      `json:"response_status"` // 応答がなかった場合はnull
+	Error          string    `json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+}
+
+// SignWebhookPayload はタイムスタンプとボディを "<timestamp>.<body>" で連結してHMAC-SHA256で署名し、16進数で返す。
+// 受信側は同じ計算結果と X-Webhook-Signature ヘッダーの "sha256=" 以降を比較して検証する
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 文字列を最大文字数までに切り詰める
+func truncateRunes(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func TestNewWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		events      []EventType
+		secret      string
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "正常系: httpsの配信先", url: "https://example.com/hooks", events: []EventType{EventItemCreated, EventItemDeleted}, secret: testWebhookSecret},
+		{name: "正常系: httpの配信先", url: " http://localhost:9000/hooks ", events: []EventType{EventItemUpdated}, secret: testWebhookSecret},
+		{name: "異常系: URLがない", events: []EventType{EventItemCreated}, secret: testWebhookSecret, wantErr: true, expectedErr: "url is required"},
+		{name: "異常系: 相対URL", url: "/hooks", events: []EventType{EventItemCreated}, secret: testWebhookSecret, wantErr: true, expectedErr: "url must be an absolute http or https URL"},
+		{name: "異常系: http以外のスキーム", url: "ftp://example.com/hooks", events: []EventType{EventItemCreated}, secret: testWebhookSecret, wantErr: true, expectedErr: "url must be an absolute http or https URL"},
+		{name: "異常系: イベントがない", url: "https://example.com/hooks", secret: testWebhookSecret, wantErr: true, expectedErr: "events is required"},
+		{name: "異常系: 未定義のイベント", url: "https://example.com/hooks", events: []EventType{"item.sold"}, secret: testWebhookSecret, wantErr: true, expectedErr: "events must be one of: item.created, item.updated, item.deleted"},
+		{name: "異常系: シークレットが短い", url: "https://example.com/hooks", events: []EventType{EventItemCreated}, secret: "short", wantErr: true, expectedErr: "secret must be at least 16 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewWebhookSubscription(tt.url, tt.events, tt.secret)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, subscription)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, subscription.Active)
+				assert.NotContains(t, subscription.URL, " ")
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_Update(t *testing.T) {
+	t.Run("正常系: シークレットが空の場合は既存のシークレットを維持する", func(t *testing.T) {
+		subscription, err := NewWebhookSubscription("https://example.com/hooks", []EventType{EventItemCreated}, testWebhookSecret)
+		require.NoError(t, err)
+
+		err = subscription.Update("https://example.com/v2/hooks", []EventType{EventItemDeleted}, "", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/v2/hooks", subscription.URL)
+		assert.Equal(t, testWebhookSecret, subscription.Secret)
+		assert.False(t, subscription.Active)
+	})
+
+	t.Run("異常系: バリデーションに失敗した場合は変更しない", func(t *testing.T) {
+		subscription, err := NewWebhookSubscription("https://example.com/hooks", []EventType{EventItemCreated}, testWebhookSecret)
+		require.NoError(t, err)
+
+		err = subscription.Update("https://example.com/v2/hooks", nil, "", true)
+
+		assert.Error(t, err)
+		assert.Equal(t, "https://example.com/hooks", subscription.URL)
+		assert.Equal(t, []EventType{EventItemCreated}, subscription.Events)
+	})
+}
+
+func TestWebhookSubscription_Subscribes(t *testing.T) {
+	subscription := &WebhookSubscription{Events: []EventType{EventItemCreated}, Active: true}
+
+	assert.True(t, subscription.Subscribes(EventItemCreated))
+	assert.False(t, subscription.Subscribes(EventItemDeleted))
+
+	subscription.Active = false
+	assert.False(t, subscription.Subscribes(EventItemCreated))
+}
+
+func TestWebhookDelivery_RecordFailure(t *testing.T) {
+	at := time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+	delivery := &WebhookDelivery{Status: DeliveryPending}
+
+	delivery.RecordFailure(at, "connection refused")
+
+	assert.Equal(t, DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "connection refused", delivery.LastError)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.Equal(t, at.Add(30*time.Second), *delivery.NextAttemptAt)
+
+	for delivery.Attempts < MaxWebhookAttempts-1 {
+		delivery.RecordFailure(at, "connection refused")
+		assert.Equal(t, DeliveryPending, delivery.Status)
+	}
+
+	// 上限に達するとデッドレターになる
+	delivery.RecordFailure(at, "connection refused")
+	assert.Equal(t, DeliveryDead, delivery.Status)
+	assert.Equal(t, MaxWebhookAttempts, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookDelivery_RecordSuccess(t *testing.T) {
+	at := time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+	delivery := &WebhookDelivery{Status: DeliveryPending, Attempts: 2, LastError: "timeout", NextAttemptAt: &at}
+
+	delivery.RecordSuccess(at)
+
+	assert.Equal(t, DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Equal(t, &at, delivery.DeliveredAt)
+}
+
+func TestWebhookDelivery_Requeue(t *testing.T) {
+	at := time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+
+	t.Run("正常系: デッドレターを配信待ちに戻す", func(t *testing.T) {
+		delivery := &WebhookDelivery{Status: DeliveryDead, Attempts: MaxWebhookAttempts, LastError: "timeout"}
+
+		require.NoError(t, delivery.Requeue(at))
+		assert.Equal(t, DeliveryPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.Equal(t, &at, delivery.NextAttemptAt)
+	})
+
+	t.Run("異常系: 配信済みの配信は再送できない", func(t *testing.T) {
+		delivery := &WebhookDelivery{Status: DeliverySucceeded, Attempts: 1}
+
+		assert.ErrorIs(t, delivery.Requeue(at), ErrDeliveryNotDead)
+		assert.Equal(t, DeliverySucceeded, delivery.Status)
+	})
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 1, expected: 30 * time.Second},
+		{failures: 2, expected: time.Minute},
+		{failures: 3, expected: 2 * time.Minute},
+		{failures: 7, expected: 32 * time.Minute},
+		{failures: 20, expected: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, WebhookRetryDelay(tt.failures), "failures=%d", tt.failures)
+	}
+}
+
+func TestWebhookDelivery_Body(t *testing.T) {
+	delivery := &WebhookDelivery{
+		EventID:    10,
+		EventType:  EventItemDeleted,
+		OccurredAt: time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC),
+		Payload:    json.RawMessage(`{"id":1}`),
+	}
+
+	body, err := delivery.Body()
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":10,"type":"item.deleted","occurred_at":"2024-06-15T09:00:00Z","data":{"id":1}}`, string(body))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := SignWebhookPayload(testWebhookSecret, 1700000000, []byte(`{"id":1}`))
+
+	assert.Equal(t, "4bcaced68dfea90a68df035b89cb7fb26692d899d32a1ccb1b0616cf48e4d1ed", signature)
+	assert.NotEqual(t, signature, SignWebhookPayload(testWebhookSecret, 1700000001, []byte(`{"id":1}`)))
+}
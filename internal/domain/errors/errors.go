@@ -1,16 +1,51 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrItemNotFound   = errors.New("item not found")
+	ErrNotFound       = errors.New("not found")
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabaseError  = errors.New("database error")
 	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrBatchAborted   = errors.New("batch aborted")
+	ErrConflict       = errors.New("conflict")
+	// テナントが指定されていないctxでリポジトリを呼び出した（テナントをまたいだ読み書きを防ぐ）
+	ErrTenantRequired = errors.New("tenant is required")
+)
+
+// アイテム以外のリソースの NotFound エラーは ErrNotFound をラップする
+var (
+	ErrTagNotFound               = fmt.Errorf("tag %w", ErrNotFound)
+	ErrLocationNotFound          = fmt.Errorf("location %w", ErrNotFound)
+	ErrBorrowerNotFound          = fmt.Errorf("borrower %w", ErrNotFound)
+	ErrLoanNotFound              = fmt.Errorf("loan %w", ErrNotFound)
+	ErrMaintenanceRecordNotFound = fmt.Errorf("maintenance record %w", ErrNotFound)
+	ErrInsurancePolicyNotFound   = fmt.Errorf("insurance policy %w", ErrNotFound)
+	ErrWebhookNotFound           = fmt.Errorf("webhook %w", ErrNotFound)
+	ErrWebhookDeliveryNotFound   = fmt.Errorf("webhook delivery %w", ErrNotFound)
+	ErrTenantNotFound            = fmt.Errorf("tenant %w", ErrNotFound)
 )
 
+// BatchItemError はまとめて実行した書き込みのうち、Index番目の要素が失敗したことを示す
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item at index %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrItemNotFound) || errors.Is(err, ErrNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -20,3 +55,16 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsDuplicateError(err error) bool {
+	return errors.Is(err, ErrDuplicateEntry)
+}
+
+func IsBatchAbortedError(err error) bool {
+	return errors.Is(err, ErrBatchAborted)
+}
+
+// 現在の状態では実行できない操作（使用中のリソースの削除など）
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
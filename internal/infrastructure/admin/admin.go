@@ -0,0 +1,109 @@
+// Package admin は管理コマンド（main <command>）の依存性注入を行う
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/interfaces/cli"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 終了コード
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// Run は管理コマンドを実行し、終了コードを返す
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := run(ctx, args, stdin, stdout, stderr)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, cli.ErrUsage):
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "tui" {
+		return runTUI(ctx, args[1:], stdin, stdout, stderr)
+	}
+
+	// 使い方の表示などデータベースを使わないコマンドは接続せずに実行する
+	if _, command, err := cli.CutTenantFlag(args); err != nil || len(command) == 0 || !slices.Contains(cli.Commands, command[0]) {
+		return cli.NewApp(nil, nil, nil, stdin, stdout, stderr).Run(ctx, args)
+	}
+
+	// 依存性注入（サーバーと異なり、init.sqlは実行しない）
+	dbHandler, err := databaseInfra.Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbHandler.Close()
+
+	itemUsecase, err := newItemUsecase(dbHandler)
+	if err != nil {
+		return err
+	}
+
+	tenantRepo := &itemDatabase.TenantRepository{
+		SqlHandler: dbHandler,
+	}
+
+	schemaRepo := &itemDatabase.SchemaRepository{
+		SqlHandler: dbHandler,
+	}
+
+	return cli.NewApp(itemUsecase, usecase.NewTenantUsecase(tenantRepo), schemaRepo, stdin, stdout, stderr).Run(ctx, args)
+}
+
+func newItemUsecase(dbHandler itemDatabase.SqlHandler) (usecase.ItemUsecase, error) {
+	depreciationModels, err := entity.ParseDepreciationModels(config.DepreciationModels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEPRECIATION_MODELS: %w", err)
+	}
+
+	itemRepo := &itemDatabase.ItemRepository{
+		SqlHandler: dbHandler,
+	}
+
+	loanRepo := &itemDatabase.LoanRepository{
+		SqlHandler: dbHandler,
+	}
+
+	maintenanceRepo := &itemDatabase.MaintenanceRepository{
+		SqlHandler: dbHandler,
+	}
+
+	outboxRepo := &itemDatabase.OutboxRepository{
+		SqlHandler: dbHandler,
+	}
+
+	// 変更イベントはアウトボックスに記録し、サーバーのWebhook配信ワーカーが送信する
+	return usecase.NewItemUsecase(itemRepo,
+		usecase.WithLoanRepository(loanRepo),
+		usecase.WithMaintenanceRepository(maintenanceRepo),
+		usecase.WithDepreciationModels(depreciationModels),
+		usecase.WithOutbox(outboxRepo),
+	), nil
+}
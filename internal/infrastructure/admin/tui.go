@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Aicon-assignment/internal/domain/entity"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/interfaces/cli"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/tui"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/pkg/client"
+)
+
+// runTUI はターミナルUIを起動する。--serverを指定した場合はREST APIでサーバーを操作する
+func runTUI(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: main tui [--server <url>] [--api-key <key>] [--tenant <slug>]")
+		fs.PrintDefaults()
+	}
+	serverURL := fs.String("server", "", "REST APIで操作するサーバーのURL（例: http://localhost:8080）。省略時はデータベースに直接接続する")
+	apiKey := fs.String("api-key", "", "サーバーに送るAPIキー（X-API-Key）")
+	tenant := fs.String("tenant", "", "操作するテナントのスラッグ。省略時はデータベースに直接接続する場合はdefault、サーバーの場合はサーバーが決める")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", cli.ErrUsage, err.Error())
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments: %v", cli.ErrUsage, fs.Args())
+	}
+
+	var backend tui.Backend
+	if *serverURL != "" {
+		var opts []client.Option
+		if *apiKey != "" {
+			opts = append(opts, client.WithAPIKey(*apiKey))
+		}
+		if *tenant != "" {
+			opts = append(opts, client.WithTenant(*tenant))
+		}
+		c, err := client.New(*serverURL, opts...)
+		if err != nil {
+			return fmt.Errorf("%w: %s", cli.ErrUsage, err.Error())
+		}
+		backend = tui.NewClientBackend(c)
+	} else {
+		dbHandler, err := databaseInfra.Open(ctx)
+		if err != nil {
+			return err
+		}
+		defer dbHandler.Close()
+
+		itemUsecase, err := newItemUsecase(dbHandler)
+		if err != nil {
+			return err
+		}
+
+		// データベースに直接接続する場合はテナントをctxに設定する
+		slug := *tenant
+		if slug == "" {
+			slug = entity.DefaultTenantSlug
+		}
+		tenantUsecase := usecase.NewTenantUsecase(&itemDatabase.TenantRepository{SqlHandler: dbHandler})
+		resolved, err := tenantUsecase.GetTenantBySlug(ctx, slug)
+		if err != nil {
+			return fmt.Errorf("failed to resolve tenant %s: %w", slug, err)
+		}
+		ctx = usecase.WithTenant(ctx, resolved.ID)
+		backend = tui.NewUsecaseBackend(itemUsecase)
+	}
+
+	program := tea.NewProgram(tui.New(ctx, backend),
+		tea.WithAltScreen(),
+		tea.WithContext(ctx),
+		tea.WithInput(stdin),
+		tea.WithOutput(stdout),
+	)
+	if _, err := program.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return err
+	}
+	return nil
+}
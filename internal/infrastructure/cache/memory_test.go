@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore は現在時刻を*nowに固定したMemoryStoreを返す
+func newTestStore(capacity int, now *time.Time) *MemoryStore {
+	store := NewMemoryStore(capacity)
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+// keys は最近使われた順のキー（Getと異なり順序を変えない）
+func keys(s *MemoryStore) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []string
+	for element := s.order.Front(); element != nil; element = element.Next() {
+		result = append(result, element.Value.(*entry).key)
+	}
+	return result
+}
+
+func TestMemoryStore_Eviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(3, &now)
+	ctx := context.Background()
+	set := func(key string) {
+		require.NoError(t, store.Set(ctx, key, []byte(key), time.Minute))
+	}
+
+	set("a")
+	set("b")
+	set("c")
+	require.Equal(t, []string{"c", "b", "a"}, keys(store))
+
+	t.Run("正常系: 取得したエントリーは最近使われたものとして残す", func(t *testing.T) {
+		_, ok, err := store.Get(ctx, "a")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		set("d")
+
+		assert.Equal(t, []string{"d", "a", "c"}, keys(store))
+		_, ok, err = store.Get(ctx, "b")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 既存のキーの更新では削除せず、最近使われたものにする", func(t *testing.T) {
+		require.NoError(t, store.Set(ctx, "c", []byte("c2"), time.Minute))
+
+		assert.Equal(t, []string{"c", "d", "a"}, keys(store))
+		value, ok, err := store.Get(ctx, "c")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("c2"), value)
+	})
+
+	t.Run("正常系: 容量を超えると最も長く使われていないものから削除する", func(t *testing.T) {
+		set("e")
+		set("f")
+
+		assert.Equal(t, []string{"f", "e", "c"}, keys(store))
+		assert.Equal(t, 3, store.Len())
+	})
+}
+
+func TestMemoryStore_TTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(10, &now)
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "short", []byte("1"), time.Minute))
+	require.NoError(t, store.Set(ctx, "long", []byte("2"), time.Hour))
+
+	t.Run("正常系: 有効期間内は取得できる", func(t *testing.T) {
+		now = now.Add(59 * time.Second)
+
+		value, ok, err := store.Get(ctx, "short")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("正常系: 有効期間を過ぎたエントリーは取得せず削除する", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		_, ok, err := store.Get(ctx, "short")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, []string{"long"}, keys(store))
+	})
+
+	t.Run("正常系: 再設定すると有効期間を延長する", func(t *testing.T) {
+		now = now.Add(59 * time.Minute)
+		require.NoError(t, store.Set(ctx, "long", []byte("3"), time.Hour))
+		now = now.Add(30 * time.Minute)
+
+		value, ok, err := store.Get(ctx, "long")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("3"), value)
+	})
+}
+
+func TestMemoryStore_Delete(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(10, &now)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Set(ctx, key, []byte(key), time.Minute))
+	}
+
+	require.NoError(t, store.Delete(ctx, "a", "c", "missing"))
+
+	assert.Equal(t, []string{"b"}, keys(store))
+}
+
+func TestMemoryStore_Disabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+	}{
+		{name: "正常系: 容量が0（CACHE_SIZE=0）の場合は何も保持しない", capacity: 0},
+		{name: "正常系: 容量が負の場合も何も保持しない", capacity: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			store := newTestStore(tt.capacity, &now)
+			ctx := context.Background()
+
+			require.NoError(t, store.Set(ctx, "a", []byte("a"), time.Minute))
+			_, ok, err := store.Get(ctx, "a")
+
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, 0, store.Len())
+			assert.NoError(t, store.Delete(ctx, "a"))
+		})
+	}
+}
@@ -3,17 +3,87 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// APP_ENVの値
+const AppEnvTest = "test"
+
 var (
+	// 実行環境（development / test / staging / production）
+	AppEnv string
+
+	// gRPCサーバーのアドレス（例: :9090）
+	GRPCPort string
+
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBName     string
 	DBPort     string
+	// 読み込みを分散するレプリカ（host:port のカンマ区切り、ポートの省略時はDB_PORT）。未設定の場合はプライマリだけを使う
+	DBReplicaHosts string
+	// レプリカのヘルスチェックの間隔
+	DBReplicaHealthCheckInterval time.Duration
+
+	// 通知の送信先（log / webhook）
+	Notifier           string
+	NotifierWebhookURL string
+	// 返却期限超過をチェックする間隔
+	OverdueCheckInterval time.Duration
+
+	// カテゴリーごとの整備間隔（例: 時計=36,バッグ=24）。未指定のカテゴリーは既定値を使う
+	MaintenanceIntervals string
+	// カテゴリーごとの帳簿価額の計算方法（例: 時計=appreciation:0.03,靴=straight_line:3）。未指定のカテゴリーは既定値を使う
+	DepreciationModels string
+
+	// Webhookの配信ワーカーの実行間隔と、1回の送信のタイムアウト
+	WebhookDispatchInterval time.Duration
+	WebhookTimeout          time.Duration
+
+	// SSEのアイドル接続を維持するためのハートビートの間隔
+	SSEHeartbeatInterval time.Duration
+
+	// GraphQLのクエリの深さと計算量（フィールド数の見積もり）の上限
+	GraphQLMaxDepth      int64
+	GraphQLMaxComplexity int64
+
+	// アイテムの取得とカテゴリーごとの集計をキャッシュする最大件数（0でキャッシュしない）と有効期間
+	CacheSize int64
+	CacheTTL  time.Duration
+
+	// すべてのクエリをログに出すか、遅いクエリとして記録する実行時間と、遅いSELECTの実行計画をログに出すか
+	QueryLog           bool
+	SlowQueryThreshold time.Duration
+	SlowQueryExplain   bool
+
+	// 読み取り・書き込みのリクエスト数の制限（例: 300/1m）。0で制限しない
+	RateLimitRead  string
+	RateLimitWrite string
+	// IPアドレスの代わりに識別に使う登録済みのAPIキー（カンマ区切り）
+	RateLimitAPIKeys string
+	// X-Forwarded-Forのクライアントアドレスを信頼するか（リバースプロキシの背後で動かす場合）
+	TrustProxy bool
+	// リクエストボディの最大バイト数
+	MaxRequestBodyBytes int64
+
+	// APIキーを送らないクライアントと、テナントを割り当てていないAPIキーのテナントのスラッグ
+	TenantDefault string
+	// APIキーを送らないクライアントを拒否するか
+	TenantRequireAPIKey bool
+	// サブドメインでテナントを指定するドメイン（例: example.com）
+	TenantBaseDomain string
+	// APIキーとそのテナントのスラッグ（例: key1=acme,key2=globex）。キーを送ったクライアントはそのテナントだけにアクセスできる
+	TenantAPIKeys string
+
+	// 運用のエンドポイント（/admin）にアクセスするためのキー（空の場合は運用のエンドポイントを使えない）
+	AdminAPIKey string
 )
 
 func init() {
@@ -22,17 +92,136 @@ func init() {
 		log.Println("⚠️  .envファイルが見つかりませんでした。")
 	}
 
+	AppEnv = getString("APP_ENV", "development")
+
+	GRPCPort = getString("GRPC_PORT", ":9090")
+
 	DBUser = os.Getenv("DB_USER")
 	DBPassword = os.Getenv("DB_PASSWORD")
 	DBHost = os.Getenv("DB_HOST")
 	DBPort = os.Getenv("DB_PORT")
 	DBName = os.Getenv("DB_NAME")
+	DBReplicaHosts = os.Getenv("DB_REPLICA_HOSTS")
+	DBReplicaHealthCheckInterval = getDuration("DB_REPLICA_HEALTH_CHECK_INTERVAL", 5*time.Second)
+
+	Notifier = os.Getenv("NOTIFIER")
+	NotifierWebhookURL = os.Getenv("NOTIFIER_WEBHOOK_URL")
+	OverdueCheckInterval = getDuration("OVERDUE_CHECK_INTERVAL", time.Hour)
+
+	MaintenanceIntervals = os.Getenv("MAINTENANCE_INTERVALS")
+	DepreciationModels = os.Getenv("DEPRECIATION_MODELS")
+
+	WebhookDispatchInterval = getDuration("WEBHOOK_DISPATCH_INTERVAL", 10*time.Second)
+	WebhookTimeout = getDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+
+	SSEHeartbeatInterval = getDuration("SSE_HEARTBEAT_INTERVAL", 15*time.Second)
+
+	GraphQLMaxDepth = getInt64("GRAPHQL_MAX_DEPTH", 8)
+	GraphQLMaxComplexity = getInt64("GRAPHQL_MAX_COMPLEXITY", 1000)
+
+	CacheSize = getNonNegativeInt64("CACHE_SIZE", 10000)
+	CacheTTL = getDuration("CACHE_TTL", time.Minute)
+
+	QueryLog = os.Getenv("QUERY_LOG") == "true"
+	SlowQueryThreshold = getDuration("SLOW_QUERY_THRESHOLD", 200*time.Millisecond)
+	SlowQueryExplain = os.Getenv("SLOW_QUERY_EXPLAIN") == "true"
+
+	RateLimitRead = getString("RATE_LIMIT_READ", "300/1m")
+	RateLimitWrite = getString("RATE_LIMIT_WRITE", "60/1m")
+	RateLimitAPIKeys = os.Getenv("RATE_LIMIT_API_KEYS")
+	TrustProxy = os.Getenv("TRUST_PROXY") == "true"
+	MaxRequestBodyBytes = getInt64("MAX_REQUEST_BODY_BYTES", 1<<20)
+
+	TenantDefault = getString("TENANT_DEFAULT", "default")
+	TenantRequireAPIKey = os.Getenv("TENANT_REQUIRE_API_KEY") == "true"
+	TenantBaseDomain = os.Getenv("TENANT_BASE_DOMAIN")
+	TenantAPIKeys = os.Getenv("TENANT_API_KEYS")
+
+	AdminAPIKey = os.Getenv("ADMIN_API_KEY")
+}
+
+// 環境変数を読み込む。未設定の場合はデフォルト値を返す
+func getString(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// 環境変数を正の整数として読み込む。未設定・不正な値の場合はデフォルト値を返す
+func getInt64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number <= 0 {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値 %d を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+// 環境変数を0以上の整数として読み込む（0で無効にできる設定に使う）。未設定・不正な値の場合はデフォルト値を返す
+func getNonNegativeInt64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値 %d を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+// 環境変数を期間として読み込む。未設定・不正な値の場合はデフォルト値を返す
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("⚠️  %sの値が不正です（%s）。デフォルト値 %s を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
 }
 
 // DB接続文字列を返す
 func GetDSN() string {
+	return dsn(net.JoinHostPort(DBHost, DBPort))
+}
+
+// GetReplicaAddresses はDB_REPLICA_HOSTSのレプリカのアドレス（host:port）を返す
+func GetReplicaAddresses() []string {
+	var addresses []string
+	for _, host := range strings.Split(DBReplicaHosts, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, DBPort)
+		}
+		addresses = append(addresses, host)
+	}
+	return addresses
+}
+
+// GetReplicaDSN はレプリカのアドレスへの接続文字列を返す（ユーザー・パスワード・データベース名はプライマリと同じ）
+func GetReplicaDSN(address string) string {
+	return dsn(address)
+}
+
+func dsn(address string) string {
 	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+		"%s:%s@tcp(%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
+		DBUser, DBPassword, address, DBName,
 	)
 }
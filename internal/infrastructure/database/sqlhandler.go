@@ -3,29 +3,47 @@ package databaseInfra
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"log"
+	"net"
 	"os"
+	"sync/atomic"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 
 	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/interfaces/database"
 )
 
+// MySqlHandler はプライマリとレプリカのコネクションプールを持つSqlHandler。
+// Query/QueryRowは正常なレプリカにラウンドロビンで送り、Execute・トランザクション・
+// database.ReadFromPrimaryのctxはプライマリに送る。レプリカがなければすべてプライマリで処理する
 type MySqlHandler struct {
+	// プライマリのコネクションプール
 	Conn *sql.DB
+
+	replicas []*replica
+	// 次に使うレプリカ
+	next atomic.Uint64
+	// ヘルスチェックの停止
+	stopHealthCheck context.CancelFunc
+	healthCheckDone chan struct{}
 }
 
-func NewSqlHandler() database.SqlHandler {
-	dsn := config.GetDSN()
-	conn, err := sql.Open("mysql", dsn)
-	if err != nil {
-		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
-	}
+// replica はレプリカのコネクションプールと、ヘルスチェックの結果
+type replica struct {
+	address string
+	conn    *sql.DB
+	healthy atomic.Bool
+}
 
-	// DB接続が確立できているかを確認
-	if err := conn.Ping(); err != nil {
-		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
+func NewSqlHandler() *MySqlHandler {
+	handler, err := Open(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("❌ %v", err))
 	}
 
 	fmt.Println("✅ Successfully connected to the database!")
@@ -34,42 +52,248 @@ func NewSqlHandler() database.SqlHandler {
 	if err != nil {
 		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
 	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
+		if _, err := handler.Conn.Exec(string(sqlBytes)); err != nil {
 			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
 		} else {
 			fmt.Println("✅ Successfully initialized database from init.sql")
 		}
 	}
 
-	return &MySqlHandler{Conn: conn}
+	return handler
+}
+
+// Open は設定のデータベースに接続する。NewSqlHandlerと異なり、init.sqlの実行やログの出力は行わない
+func Open(ctx context.Context) (*MySqlHandler, error) {
+	conn, err := sql.Open("mysql", config.GetDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// DB接続が確立できているかを確認
+	if err := conn.PingContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	handler := &MySqlHandler{Conn: conn}
+	for _, address := range config.GetReplicaAddresses() {
+		replicaConn, err := sql.Open("mysql", config.GetReplicaDSN(address))
+		if err != nil {
+			_ = handler.Close()
+			return nil, fmt.Errorf("failed to connect to replica %s: %w", address, err)
+		}
+		handler.replicas = append(handler.replicas, &replica{address: address, conn: replicaConn})
+	}
+
+	// レプリカに接続できなくてもプライマリで処理を続けられるため、起動は止めずにヘルスチェックで復旧を待つ
+	if len(handler.replicas) > 0 {
+		handler.checkReplicas(ctx)
+		healthCtx, cancel := context.WithCancel(context.Background())
+		handler.stopHealthCheck = cancel
+		handler.healthCheckDone = make(chan struct{})
+		go handler.runHealthCheck(healthCtx, config.DBReplicaHealthCheckInterval)
+	}
+
+	return handler, nil
+}
+
+// runHealthCheck はintervalごとにレプリカの状態を確認する
+func (h *MySqlHandler) runHealthCheck(ctx context.Context, interval time.Duration) {
+	defer close(h.healthCheckDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.checkReplicas(ctx)
+		}
+	}
+}
+
+// レプリカのヘルスチェックのタイムアウト
+const replicaPingTimeout = 2 * time.Second
+
+func (h *MySqlHandler) checkReplicas(ctx context.Context) {
+	for _, r := range h.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := r.conn.PingContext(pingCtx)
+		cancel()
+		if err != nil {
+			r.markUnhealthy(err)
+			continue
+		}
+		if !r.healthy.Swap(true) {
+			log.Printf("✅ replica %s is available", r.address)
+		}
+	}
+}
+
+func (r *replica) markUnhealthy(err error) {
+	if r.healthy.Swap(false) {
+		log.Printf("⚠️  replica %s is unavailable, reading from the primary: %v", r.address, err)
+	}
+}
+
+// reader は読み込みに使う接続を返す。トランザクション中とプライマリを指定したctxではプライマリ（またはトランザクション）、
+// それ以外は正常なレプリカをラウンドロビンで選ぶ。正常なレプリカがなければプライマリを返す
+func (h *MySqlHandler) reader(ctx context.Context) (executor, *replica) {
+	if h.InTransaction(ctx) || len(h.replicas) == 0 || database.ReadFromPrimary(ctx) {
+		return h.executor(ctx), nil
+	}
+
+	start := h.next.Add(1)
+	for i := range h.replicas {
+		r := h.replicas[(start+uint64(i))%uint64(len(h.replicas))]
+		if r.healthy.Load() {
+			return r.conn, r
+		}
+	}
+	return h.Conn, nil
+}
+
+// isConnectionError はレプリカに接続できなかったエラーか（クエリ自体のエラーはプライマリでも失敗するため再試行しない）
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) || errors.As(err, &netErr)
+}
+
+// トランザクションをctxに保持するためのキー
+type txKey struct{}
+
+// txState はctxに保持するトランザクションと、コミット後に実行する処理
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+// ExecContext/QueryContext/QueryRowContextを持つ*sql.DBと*sql.Txの共通インターフェース
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ctxにトランザクションがあればそれを、なければコネクションプールを返す
+func (h *MySqlHandler) executor(ctx context.Context) executor {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return h.Conn
+}
+
+func (h *MySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	// 既にトランザクション中であれば外側のトランザクションに参加する
+	if h.InTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	state := &txState{tx: tx}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			return
+		}
+		for _, hook := range state.afterCommit {
+			hook()
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, state))
+}
+
+// InTransaction はctxがトランザクション中か
+func (h *MySqlHandler) InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// AfterCommit はctxのトランザクションがコミットされた後にfnを実行する（ロールバックした場合は実行しない）。
+// トランザクション外ではすぐに実行する
+func (h *MySqlHandler) AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+	state.afterCommit = append(state.afterCommit, fn)
 }
 
 func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+	result, err := h.executor(ctx).ExecContext(ctx, statement, args...)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
+	// 同じリクエストのこの後の読み込みをプライマリに送る
+	database.MarkWritten(ctx)
 	return &mysqlResult{result: result}, nil
 }
 
 func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+	reader, r := h.reader(ctx)
+	rows, err := reader.QueryContext(ctx, statement, args...)
+	if err != nil && r != nil && isConnectionError(err) {
+		r.markUnhealthy(err)
+		rows, err = h.Conn.QueryContext(ctx, statement, args...)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &mysqlRows{rows: rows}, nil
 }
 
+// QueryRow はQueryと同じく、レプリカの接続エラーではレプリカを除外してプライマリで再試行する。
+// クエリのエラーはScanを待たずにRow.Errで分かる
 func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
+	reader, r := h.reader(ctx)
+	row := reader.QueryRowContext(ctx, statement, args...)
+	if err := row.Err(); err != nil && r != nil && isConnectionError(err) {
+		r.markUnhealthy(err)
+		row = h.Conn.QueryRowContext(ctx, statement, args...)
+	}
 	return &mysqlRow{row: row}
 }
 
 func (h *MySqlHandler) Close() error {
+	if h.stopHealthCheck != nil {
+		h.stopHealthCheck()
+		<-h.healthCheckDone
+	}
+
+	var errs []error
+	for _, r := range h.replicas {
+		errs = append(errs, r.conn.Close())
+	}
 	if h.Conn != nil {
-		return h.Conn.Close()
+		errs = append(errs, h.Conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// MySQLのエラー番号
+const mysqlErrDuplicateEntry = 1062
+
+// ドライバー固有のエラーをリポジトリ層で判定できるエラーに変換する
+func translateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return fmt.Errorf("%w: %s", database.ErrDuplicateKey, err.Error())
 	}
-	return nil
+	return err
 }
 
 type mysqlResult struct {
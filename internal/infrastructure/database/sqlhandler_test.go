@@ -0,0 +1,351 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// fakeDriverName はテスト用のドライバー。DSNごとのfakeServerに接続し、クエリには処理したサーバーの名前を返す
+const fakeDriverName = "fakemysql"
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+// fakeServers はDSN → *fakeServer
+var fakeServers sync.Map
+
+type fakeServer struct {
+	name string
+	// trueの間は接続・Ping・クエリが接続エラーになる
+	down    atomic.Bool
+	queries atomic.Int64
+}
+
+func (s *fakeServer) connError() error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused: " + s.name)}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	value, ok := fakeServers.Load(dsn)
+	if !ok {
+		return nil, errors.New("unknown server: " + dsn)
+	}
+	server := value.(*fakeServer)
+	if server.down.Load() {
+		return nil, server.connError()
+	}
+	return &fakeConn{server: server}, nil
+}
+
+type fakeConn struct {
+	server *fakeServer
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	if c.server.down.Load() {
+		return nil, c.server.connError()
+	}
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	if c.server.down.Load() {
+		return c.server.connError()
+	}
+	return nil
+}
+
+// QueryContext は"FAIL"ではクエリのエラーを、それ以外では処理したサーバーの名前を1行返す
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.server.down.Load() {
+		return nil, c.server.connError()
+	}
+	if query == "FAIL" {
+		return nil, errors.New("You have an error in your SQL syntax")
+	}
+	c.server.queries.Add(1)
+	return &fakeRows{values: []string{c.server.name}}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.server.down.Load() {
+		return nil, c.server.connError()
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	values []string
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"server"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+// newTestHandler はプライマリとreplicaCount台のレプリカに接続し、ヘルスチェックを1回実行したMySqlHandlerを返す
+func newTestHandler(t *testing.T, replicaCount int) (*MySqlHandler, *fakeServer, []*fakeServer) {
+	t.Helper()
+
+	open := func(name string) (*sql.DB, *fakeServer) {
+		server := &fakeServer{name: name}
+		dsn := t.Name() + "/" + name
+		fakeServers.Store(dsn, server)
+		t.Cleanup(func() { fakeServers.Delete(dsn) })
+		conn, err := sql.Open(fakeDriverName, dsn)
+		require.NoError(t, err)
+		return conn, server
+	}
+
+	conn, primary := open("primary")
+	handler := &MySqlHandler{Conn: conn}
+	var replicas []*fakeServer
+	for i := 0; i < replicaCount; i++ {
+		name := "replica" + string(rune('1'+i))
+		replicaConn, server := open(name)
+		handler.replicas = append(handler.replicas, &replica{address: name, conn: replicaConn})
+		replicas = append(replicas, server)
+	}
+	handler.checkReplicas(context.Background())
+	t.Cleanup(func() { _ = handler.Close() })
+
+	return handler, primary, replicas
+}
+
+// 読み込みを処理したサーバーの名前を返す関数。QueryとQueryRowの両方で同じテストを実行する
+type readFunc func(t *testing.T, h *MySqlHandler, ctx context.Context) string
+
+var readFuncs = map[string]readFunc{
+	"Query": func(t *testing.T, h *MySqlHandler, ctx context.Context) string {
+		rows, err := h.Query(ctx, "SELECT server")
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		return name
+	},
+	"QueryRow": func(t *testing.T, h *MySqlHandler, ctx context.Context) string {
+		var name string
+		require.NoError(t, h.QueryRow(ctx, "SELECT server").Scan(&name))
+		return name
+	},
+}
+
+func TestMySqlHandler_RoundRobin(t *testing.T) {
+	for method, read := range readFuncs {
+		t.Run("正常系: "+method+"は正常なレプリカに順に送る", func(t *testing.T) {
+			h, primary, replicas := newTestHandler(t, 2)
+
+			var served []string
+			for i := 0; i < 4; i++ {
+				served = append(served, read(t, h, context.Background()))
+			}
+
+			assert.NotEqual(t, served[0], served[1])
+			assert.Equal(t, served[0], served[2])
+			assert.Equal(t, served[1], served[3])
+			assert.NotContains(t, served, "primary")
+			assert.Equal(t, int64(0), primary.queries.Load())
+			assert.Equal(t, int64(2), replicas[0].queries.Load())
+			assert.Equal(t, int64(2), replicas[1].queries.Load())
+		})
+	}
+
+	t.Run("正常系: レプリカがなければプライマリに送る", func(t *testing.T) {
+		h, _, _ := newTestHandler(t, 0)
+
+		assert.Equal(t, "primary", readFuncs["QueryRow"](t, h, context.Background()))
+	})
+}
+
+func TestMySqlHandler_CheckReplicas(t *testing.T) {
+	h, primary, replicas := newTestHandler(t, 2)
+	read := readFuncs["QueryRow"]
+	ctx := context.Background()
+
+	t.Run("正常系: ヘルスチェックに失敗したレプリカには送らない", func(t *testing.T) {
+		replicas[0].down.Store(true)
+		h.checkReplicas(ctx)
+
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, "replica2", read(t, h, ctx))
+		}
+		assert.False(t, h.replicas[0].healthy.Load())
+	})
+
+	t.Run("正常系: すべてのレプリカが停止していればプライマリに送る", func(t *testing.T) {
+		replicas[1].down.Store(true)
+		h.checkReplicas(ctx)
+
+		assert.Equal(t, "primary", read(t, h, ctx))
+		assert.Equal(t, "primary", read(t, h, ctx))
+		assert.Equal(t, int64(2), primary.queries.Load())
+	})
+
+	t.Run("正常系: 復旧したレプリカは次のヘルスチェックで戻す", func(t *testing.T) {
+		replicas[0].down.Store(false)
+		h.checkReplicas(ctx)
+
+		assert.Equal(t, "replica1", read(t, h, ctx))
+		assert.True(t, h.replicas[0].healthy.Load())
+		assert.False(t, h.replicas[1].healthy.Load())
+	})
+}
+
+func TestMySqlHandler_ReplicaFallback(t *testing.T) {
+	for method, read := range readFuncs {
+		t.Run("正常系: "+method+"はレプリカの接続エラーでプライマリに再試行する", func(t *testing.T) {
+			h, primary, replicas := newTestHandler(t, 1)
+			// 次のヘルスチェックより前にレプリカが停止した
+			replicas[0].down.Store(true)
+
+			assert.Equal(t, "primary", read(t, h, context.Background()))
+			assert.False(t, h.replicas[0].healthy.Load())
+
+			// 停止したレプリカはヘルスチェックで復旧するまで使わない
+			assert.Equal(t, "primary", read(t, h, context.Background()))
+			assert.Equal(t, int64(2), primary.queries.Load())
+		})
+	}
+
+	t.Run("異常系: Queryのクエリのエラーはプライマリで再試行しない", func(t *testing.T) {
+		h, primary, _ := newTestHandler(t, 1)
+
+		_, err := h.Query(context.Background(), "FAIL")
+
+		assert.Error(t, err)
+		assert.True(t, h.replicas[0].healthy.Load())
+		assert.Equal(t, int64(0), primary.queries.Load())
+	})
+
+	t.Run("異常系: QueryRowのクエリのエラーはプライマリで再試行しない", func(t *testing.T) {
+		h, primary, _ := newTestHandler(t, 1)
+
+		var name string
+		err := h.QueryRow(context.Background(), "FAIL").Scan(&name)
+
+		assert.Error(t, err)
+		assert.True(t, h.replicas[0].healthy.Load())
+		assert.Equal(t, int64(0), primary.queries.Load())
+	})
+}
+
+func TestMySqlHandler_ReadFromPrimary(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error
+		expected string
+	}{
+		{
+			name: "正常系: トランザクション中はプライマリに送る",
+			run: func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error {
+				return h.Transaction(ctx, func(ctx context.Context) error {
+					read(ctx)
+					return nil
+				})
+			},
+			expected: "primary",
+		},
+		{
+			name: "正常系: WithPrimaryのctxはプライマリに送る",
+			run: func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error {
+				read(database.WithPrimary(ctx))
+				return nil
+			},
+			expected: "primary",
+		},
+		{
+			name: "正常系: WithReadYourWritesのctxで書き込んだ後はプライマリに送る",
+			run: func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error {
+				ctx = database.WithReadYourWrites(ctx)
+				if _, err := h.Execute(ctx, "UPDATE items SET name = ?", "x"); err != nil {
+					return err
+				}
+				read(ctx)
+				return nil
+			},
+			expected: "primary",
+		},
+		{
+			name: "正常系: トランザクション内で書き込んだ後もコミット後の読み込みをプライマリに送る",
+			run: func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error {
+				ctx = database.WithReadYourWrites(ctx)
+				err := h.Transaction(ctx, func(ctx context.Context) error {
+					_, err := h.Execute(ctx, "UPDATE items SET name = ?", "x")
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				read(ctx)
+				return nil
+			},
+			expected: "primary",
+		},
+		{
+			name: "正常系: WithReadYourWritesのctxでも書き込む前はレプリカに送る",
+			run: func(h *MySqlHandler, ctx context.Context, read func(ctx context.Context)) error {
+				read(database.WithReadYourWrites(ctx))
+				return nil
+			},
+			expected: "replica1",
+		},
+	}
+
+	for _, tt := range tests {
+		for method, read := range readFuncs {
+			t.Run(tt.name+"("+method+")", func(t *testing.T) {
+				h, _, _ := newTestHandler(t, 1)
+
+				var served string
+				err := tt.run(h, context.Background(), func(ctx context.Context) {
+					served = read(t, h, ctx)
+				})
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, served)
+			})
+		}
+	}
+}
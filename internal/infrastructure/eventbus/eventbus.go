@@ -0,0 +1,91 @@
+package eventbus
+
+import (
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 購読者ごとに溜められるイベントの数の既定値
+const defaultBufferSize = 64
+
+// Bus はプロセス内でアイテムのイベントを同じテナントの購読者に配る。
+// 受信が追いつかない購読者は切断し、クライアントにLast-Event-IDで再接続させる
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[int]*subscriber
+	nextID      int
+	bufferSize  int
+	closed      bool
+}
+
+func New(bufferSize int) *Bus {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Bus{
+		subscribers: make(map[int]*subscriber),
+		bufferSize:  bufferSize,
+	}
+}
+
+type subscriber struct {
+	ch       chan *entity.OutboxEvent
+	tenantID int64
+}
+
+func (b *Bus) Publish(event *entity.OutboxEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, s := range b.subscribers {
+		if s.tenantID != event.TenantID {
+			continue
+		}
+		select {
+		case s.ch <- event:
+		default:
+			// 発行側を待たせないよう、溢れた購読者は切断する
+			close(s.ch)
+			delete(b.subscribers, id)
+		}
+	}
+}
+
+func (b *Bus) Subscribe(tenantID int64) (<-chan *entity.OutboxEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *entity.OutboxEvent, b.bufferSize)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = &subscriber{ch: ch, tenantID: tenantID}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		// 切断済みの場合はすでに閉じている
+		if _, ok := b.subscribers[id]; ok {
+			close(ch)
+			delete(b.subscribers, id)
+		}
+	}
+}
+
+// Close はすべての購読者を切断し、以降の購読を受け付けない（サーバーの停止時に呼ぶ）
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for id, s := range b.subscribers {
+		close(s.ch)
+		delete(b.subscribers, id)
+	}
+}
@@ -0,0 +1,167 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func event(id, tenantID int64) *entity.OutboxEvent {
+	return &entity.OutboxEvent{ID: id, EventType: entity.EventItemUpdated, ItemID: id, TenantID: tenantID}
+}
+
+// receive はchから受信済みのイベントのIDと、chが閉じているかを返す
+func receive(ch <-chan *entity.OutboxEvent) ([]int64, bool) {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return ids, true
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids, false
+		}
+	}
+}
+
+func TestBus_Publish(t *testing.T) {
+	t.Run("正常系: 同じテナントのすべての購読者に配る", func(t *testing.T) {
+		bus := New(0)
+		first, unsubscribeFirst := bus.Subscribe(1)
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe(1)
+		defer unsubscribeSecond()
+
+		bus.Publish(event(1, 1))
+		bus.Publish(event(2, 1))
+
+		ids, closed := receive(first)
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.False(t, closed)
+		ids, closed = receive(second)
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.False(t, closed)
+	})
+
+	t.Run("正常系: 他のテナントのイベントは配らない", func(t *testing.T) {
+		bus := New(0)
+		tenantA, unsubscribeA := bus.Subscribe(1)
+		defer unsubscribeA()
+		tenantB, unsubscribeB := bus.Subscribe(2)
+		defer unsubscribeB()
+
+		bus.Publish(event(1, 1))
+		bus.Publish(event(2, 2))
+		bus.Publish(event(3, 1))
+
+		ids, _ := receive(tenantA)
+		assert.Equal(t, []int64{1, 3}, ids)
+		ids, _ = receive(tenantB)
+		assert.Equal(t, []int64{2}, ids)
+	})
+
+	t.Run("正常系: 他のテナントのイベントがどれだけ発行されても受信せず、切断もされない", func(t *testing.T) {
+		bus := New(2)
+		tenantA, unsubscribeA := bus.Subscribe(1)
+		defer unsubscribeA()
+
+		for id := int64(1); id <= 10; id++ {
+			bus.Publish(event(id, 2))
+		}
+		bus.Publish(event(11, 1))
+
+		ids, closed := receive(tenantA)
+		assert.Equal(t, []int64{11}, ids)
+		assert.False(t, closed)
+	})
+
+	t.Run("正常系: 購読者がいなくても発行できる", func(t *testing.T) {
+		bus := New(0)
+
+		assert.NotPanics(t, func() { bus.Publish(event(1, 1)) })
+	})
+}
+
+func TestBus_SlowSubscriber(t *testing.T) {
+	bus := New(2)
+	slow, unsubscribeSlow := bus.Subscribe(1)
+	fast, unsubscribeFast := bus.Subscribe(1)
+	defer unsubscribeFast()
+
+	var fastIDs []int64
+	for id := int64(1); id <= 4; id++ {
+		// 受信が追いつかない購読者がいても発行側は待たない
+		bus.Publish(event(id, 1))
+		ids, closed := receive(fast)
+		require.False(t, closed)
+		fastIDs = append(fastIDs, ids...)
+	}
+
+	t.Run("正常系: 受信が追いつかない購読者はバッファの分を受信した後に切断する", func(t *testing.T) {
+		ids, closed := receive(slow)
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.True(t, closed)
+	})
+
+	t.Run("正常系: 他の購読者には配り続ける", func(t *testing.T) {
+		assert.Equal(t, []int64{1, 2, 3, 4}, fastIDs)
+	})
+
+	t.Run("正常系: 切断された購読者の購読解除は何もしない", func(t *testing.T) {
+		assert.NotPanics(t, unsubscribeSlow)
+	})
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := New(0)
+	ch, unsubscribe := bus.Subscribe(1)
+	other, unsubscribeOther := bus.Subscribe(1)
+	defer unsubscribeOther()
+
+	unsubscribe()
+	bus.Publish(event(1, 1))
+
+	t.Run("正常系: 購読を解除するとチャネルを閉じ、以降のイベントを配らない", func(t *testing.T) {
+		ids, closed := receive(ch)
+		assert.Empty(t, ids)
+		assert.True(t, closed)
+	})
+
+	t.Run("正常系: 他の購読者には影響しない", func(t *testing.T) {
+		ids, closed := receive(other)
+		assert.Equal(t, []int64{1}, ids)
+		assert.False(t, closed)
+	})
+
+	t.Run("正常系: 2回解除してもよい", func(t *testing.T) {
+		assert.NotPanics(t, unsubscribe)
+	})
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := New(0)
+	ch, unsubscribe := bus.Subscribe(1)
+
+	bus.Close()
+
+	t.Run("正常系: すべての購読者を切断する", func(t *testing.T) {
+		_, closed := receive(ch)
+		assert.True(t, closed)
+		assert.NotPanics(t, unsubscribe)
+	})
+
+	t.Run("正常系: 停止後の購読は閉じたチャネルを返す", func(t *testing.T) {
+		after, unsubscribeAfter := bus.Subscribe(1)
+		bus.Publish(event(1, 1))
+
+		ids, closed := receive(after)
+		assert.Empty(t, ids)
+		assert.True(t, closed)
+		assert.NotPanics(t, unsubscribeAfter)
+	})
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAdminKey は運用のエンドポイントにアクセスするためのキーのヘッダー
+const HeaderAdminKey = "X-Admin-Key"
+
+// Admin は運用のエンドポイント（キャッシュの統計・遅いクエリ）をキーを送ったクライアントだけに公開する。
+// テナントのAPIキーとは別のキーを使い、keyが空の場合はすべて拒否する
+func Admin(key string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if key == "" {
+				return c.JSON(http.StatusForbidden, ErrorResponse{Error: "admin endpoints are disabled"})
+			}
+			sent := c.Request().Header.Get(HeaderAdminKey)
+			if subtle.ConstantTimeCompare([]byte(sent), []byte(key)) != 1 {
+				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "invalid admin key"})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		header         map[string]string
+		expectedStatus int
+	}{
+		{
+			name:           "正常系: 運用のキーを送ったクライアント",
+			key:            "admin-secret",
+			header:         map[string]string{HeaderAdminKey: "admin-secret"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: キーを送らない",
+			key:            "admin-secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: 誤ったキー",
+			key:            "admin-secret",
+			header:         map[string]string{HeaderAdminKey: "admin-secre"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: テナントのAPIキーでは運用のエンドポイントにアクセスできない",
+			key:            "admin-secret",
+			header:         map[string]string{HeaderAPIKey: "admin-secret"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: キーを設定していない場合はすべて拒否する",
+			key:            "",
+			header:         map[string]string{HeaderAdminKey: ""},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			admin := e.Group("/admin", Admin(tt.key))
+			admin.GET("/slow-queries", func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			})
+			req := httptest.NewRequest(http.MethodGet, "/admin/slow-queries", nil)
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// BodyLimit はリクエストボディがmaxBytesを超える場合に、ハンドラーがc.Bindする前に413を返す。
+// Content-Lengthがない（chunked）リクエストは上限まで読み込んで確認する
+func BodyLimit(maxBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if maxBytes <= 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			if req.ContentLength > maxBytes {
+				return bodyTooLarge(c, maxBytes)
+			}
+
+			if req.ContentLength < 0 {
+				body, err := io.ReadAll(io.LimitReader(req.Body, maxBytes+1))
+				if err != nil {
+					return c.JSON(http.StatusBadRequest, ErrorResponse{
+						Error: "failed to read request body",
+					})
+				}
+				if int64(len(body)) > maxBytes {
+					return bodyTooLarge(c, maxBytes)
+				}
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				return next(c)
+			}
+
+			// Content-Lengthより長く送られた場合に備えて読み込める量も制限する
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBytes)
+			return next(c)
+		}
+	}
+}
+
+func bodyTooLarge(c echo.Context, maxBytes int64) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Error:   "request body too large",
+		Details: []string{fmt.Sprintf("request body must be %d bytes or less", maxBytes)},
+	})
+}
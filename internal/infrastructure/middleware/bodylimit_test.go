@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	const maxBytes = 16
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64 // -1はchunked（Content-Lengthなし）
+		maxBytes      int64
+		// ハンドラーは本文を読み込めた場合に200、読み込めなかった場合に400を返す
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: Content-Lengthが上限以下",
+			body:           strings.Repeat("a", maxBytes),
+			contentLength:  maxBytes,
+			maxBytes:       maxBytes,
+			expectedStatus: http.StatusOK,
+			expectedBody:   strings.Repeat("a", maxBytes),
+		},
+		{
+			name:           "正常系: chunkedで上限以下",
+			body:           strings.Repeat("a", maxBytes),
+			contentLength:  -1,
+			maxBytes:       maxBytes,
+			expectedStatus: http.StatusOK,
+			expectedBody:   strings.Repeat("a", maxBytes),
+		},
+		{
+			name:           "正常系: 上限が0の場合は制限しない",
+			body:           strings.Repeat("a", 100),
+			contentLength:  100,
+			maxBytes:       0,
+			expectedStatus: http.StatusOK,
+			expectedBody:   strings.Repeat("a", 100),
+		},
+		{
+			name:           "異常系: Content-Lengthが上限を超える",
+			body:           strings.Repeat("a", maxBytes+1),
+			contentLength:  maxBytes + 1,
+			maxBytes:       maxBytes,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "異常系: chunkedで上限を超える",
+			body:           strings.Repeat("a", maxBytes+1),
+			contentLength:  -1,
+			maxBytes:       maxBytes,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "異常系: Content-Lengthより長い本文は上限までしか読み込めない",
+			body:           strings.Repeat("a", maxBytes*2),
+			contentLength:  maxBytes,
+			maxBytes:       maxBytes,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			e := echo.New()
+			e.Use(BodyLimit(tt.maxBytes))
+			e.POST("/items", func(c echo.Context) error {
+				called = true
+				body, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return c.NoContent(http.StatusBadRequest)
+				}
+				return c.String(http.StatusOK, string(body))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			if tt.contentLength < 0 {
+				req.TransferEncoding = []string{"chunked"}
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusRequestEntityTooLarge {
+				// ハンドラーがc.Bindする前に拒否する
+				assert.False(t, called)
+				assert.JSONEq(t, `{"error": "request body too large", "details": ["request body must be 16 bytes or less"]}`, rec.Body.String())
+				return
+			}
+			assert.True(t, called)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
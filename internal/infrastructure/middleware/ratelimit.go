@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+)
+
+// HeaderAPIKey はクライアントを識別するAPIキーのヘッダー
+const HeaderAPIKey = "X-API-Key"
+
+// RateLimitConfig は読み取り（GET・HEAD・OPTIONS）と書き込みのそれぞれの制限。
+// 登録済みのAPIキーを送ったクライアントはキーごとに、それ以外はIPアドレスごとに制限する
+type RateLimitConfig struct {
+	Store ratelimit.Store
+	Read  ratelimit.Limit
+	Write ratelimit.Limit
+	// 登録済みのAPIキー。未登録のキーで制限を回避できないよう、登録済みのキーだけを識別に使う
+	APIKeys map[string]bool
+	// 制限しないリクエスト（ヘルスチェックなど）
+	Skipper func(c echo.Context) bool
+}
+
+// RateLimit はトークンバケットでリクエストを制限し、RateLimit-* ヘッダーを付与する。
+// 制限を超えた場合は429とRetry-Afterを返す
+func RateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper != nil && config.Skipper(c) {
+				return next(c)
+			}
+
+			class, limit := "write", config.Write
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				class, limit = "read", config.Read
+			}
+			if !limit.Enabled() {
+				return next(c)
+			}
+
+			result, err := config.Store.Take(c.Request().Context(), class+":"+clientKey(c, config.APIKeys), limit)
+			if err != nil {
+				// ストアの障害でAPI全体を止めないよう、制限せずに通す
+				log.Printf("⚠️  rate limit store error: %v", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				header.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+				return c.JSON(http.StatusTooManyRequests, ErrorResponse{
+					Error: "rate limit exceeded",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func clientKey(c echo.Context, apiKeys map[string]bool) string {
+	if key := c.Request().Header.Get(HeaderAPIKey); key != "" && apiKeys[key] {
+		return "key:" + key
+	}
+	return "ip:" + c.RealIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+)
+
+// failingStore は常に失敗するratelimit.Store
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func newRateLimitServer(config RateLimitConfig) *echo.Echo {
+	if config.Store == nil {
+		config.Store = ratelimit.NewMemoryStore()
+	}
+	e := echo.New()
+	e.Use(RateLimit(config))
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/items", ok)
+	e.POST("/items", ok)
+	e.GET("/health", ok)
+	return e
+}
+
+type rateLimitRequest struct {
+	method     string
+	path       string
+	remoteAddr string
+	apiKey     string
+}
+
+func (r rateLimitRequest) serve(e *echo.Echo) *httptest.ResponseRecorder {
+	method, path := r.method, r.path
+	if method == "" {
+		method = http.MethodGet
+	}
+	if path == "" {
+		path = "/items"
+	}
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	if r.remoteAddr != "" {
+		req.RemoteAddr = r.remoteAddr
+	}
+	if r.apiKey != "" {
+		req.Header.Set(HeaderAPIKey, r.apiKey)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	e := newRateLimitServer(RateLimitConfig{
+		Read: ratelimit.Limit{Burst: 2, Period: time.Minute},
+	})
+
+	rec := rateLimitRequest{}.serve(e)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", rec.Header().Get("RateLimit-Reset"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+
+	rec = rateLimitRequest{}.serve(e)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = rateLimitRequest{}.serve(e)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	// 1トークンの補充（60秒 / 2）までの秒数
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error": "rate limit exceeded"}`, rec.Body.String())
+}
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Burst: 1, Period: time.Minute}
+
+	tests := []struct {
+		name     string
+		config   RateLimitConfig
+		requests []rateLimitRequest
+		// リクエストごとの期待するステータス
+		expected []int
+	}{
+		{
+			name:   "正常系: 読み取りと書き込みは別のバケットで制限する",
+			config: RateLimitConfig{Read: limit, Write: limit},
+			requests: []rateLimitRequest{
+				{method: http.MethodGet},
+				{method: http.MethodPost},
+				{method: http.MethodGet},
+				{method: http.MethodPost},
+			},
+			expected: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests},
+		},
+		{
+			name:   "正常系: 制限のない種類のリクエストは制限しない",
+			config: RateLimitConfig{Write: limit},
+			requests: []rateLimitRequest{
+				{method: http.MethodGet},
+				{method: http.MethodGet},
+				{method: http.MethodPost},
+				{method: http.MethodPost},
+			},
+			expected: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:   "正常系: APIキーがない場合はIPアドレスごとに制限する",
+			config: RateLimitConfig{Read: limit},
+			requests: []rateLimitRequest{
+				{remoteAddr: "192.0.2.1:1234"},
+				{remoteAddr: "192.0.2.2:1234"},
+				{remoteAddr: "192.0.2.1:5678"},
+			},
+			expected: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:   "正常系: 登録済みのAPIキーはIPアドレスによらずキーごとに制限する",
+			config: RateLimitConfig{Read: limit, APIKeys: map[string]bool{"key1": true, "key2": true}},
+			requests: []rateLimitRequest{
+				{remoteAddr: "192.0.2.1:1234"},
+				{remoteAddr: "192.0.2.1:1234", apiKey: "key1"},
+				{remoteAddr: "192.0.2.1:1234", apiKey: "key2"},
+				{remoteAddr: "192.0.2.2:1234", apiKey: "key1"},
+			},
+			expected: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:   "異常系: 未登録のAPIキーではIPアドレスの制限を回避できない",
+			config: RateLimitConfig{Read: limit, APIKeys: map[string]bool{"key1": true}},
+			requests: []rateLimitRequest{
+				{apiKey: "unknown1"},
+				{apiKey: "unknown2"},
+			},
+			expected: []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name: "正常系: Skipperに該当するリクエストは制限しない",
+			config: RateLimitConfig{Read: limit, Skipper: func(c echo.Context) bool {
+				return c.Path() == "/health"
+			}},
+			requests: []rateLimitRequest{
+				{path: "/health"},
+				{path: "/health"},
+				{path: "/items"},
+			},
+			expected: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			name:   "正常系: ストアの障害時は制限せずに通す",
+			config: RateLimitConfig{Store: failingStore{}, Read: limit},
+			requests: []rateLimitRequest{
+				{},
+				{},
+			},
+			expected: []int{http.StatusOK, http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newRateLimitServer(tt.config)
+
+			for i, req := range tt.requests {
+				rec := req.serve(e)
+				assert.Equal(t, tt.expected[i], rec.Code, "request %d", i)
+			}
+		})
+	}
+}
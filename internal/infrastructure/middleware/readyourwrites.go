@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// ReadYourWrites はリクエストの中で書き込んだ後の読み込みをプライマリに送る。
+// POST /items のように作成した直後に取得し直す場合でも、レプリカの遅延で古いデータを返さない
+func ReadYourWrites() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(database.WithReadYourWrites(req.Context())))
+			return next(c)
+		}
+	}
+}
+
+// ReadYourWritesUnaryInterceptor はgRPCの呼び出しごとにReadYourWritesと同じことを行う
+func ReadYourWritesUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(database.WithReadYourWrites(ctx), req)
+	}
+}
+
+// ReadYourWritesStreamInterceptor はストリーミングのgRPCの呼び出しごとにReadYourWritesと同じことを行う
+func ReadYourWritesStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withStreamContext(stream, database.WithReadYourWrites(stream.Context())))
+	}
+}
+
+// contextStream はContextだけを差し替えたServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func withStreamContext(stream grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &contextStream{ServerStream: stream, ctx: ctx}
+}
@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// HeaderTenant はテナントのスラッグを指定するヘッダー
+const HeaderTenant = "X-Tenant"
+
+var (
+	// 登録されていないAPIキー
+	errUnknownAPIKey = errors.New("unknown API key")
+	// 既定のテナント以外にアクセスするにはそのテナントのAPIキーが必要
+	errAPIKeyRequired = errors.New("an API key of the tenant is required")
+	// APIキーのテナントと異なるテナントを指定した
+	errTenantForbidden = errors.New("tenant does not match the API key")
+)
+
+// TenantConfig はテナントの解決方法
+type TenantConfig struct {
+	Tenants usecase.TenantUsecase
+	// APIキーごとのテナントのスラッグ。キーを送ったクライアントはそのテナントだけにアクセスでき、登録されていないキーは拒否する
+	APIKeys map[string]string
+	// サブドメインでテナントを指定するドメイン（例: example.com の場合は acme.example.com が acme）
+	BaseDomain string
+	// APIキーを送らないクライアントがアクセスできるテナント（空の場合はAPIキーを必須にする）
+	DefaultTenant string
+	// テナントを解決しないリクエスト（ヘルスチェックなど）
+	Skipper func(c echo.Context) bool
+}
+
+// TenantResolver はリクエストのテナントをスラッグから解決する。解決したIDはプロセス内にキャッシュする
+type TenantResolver struct {
+	config TenantConfig
+	// スラッグ → テナントID
+	ids sync.Map
+}
+
+func NewTenantResolver(config TenantConfig) *TenantResolver {
+	return &TenantResolver{config: config}
+}
+
+// Resolve はリクエストの主体（APIキー）がアクセスできるテナントを決める。APIキーを送ったクライアントはそのキーのテナント、
+// 送らないクライアントは既定のテナントだけにアクセスできる。サブドメインやX-Tenantヘッダーはテナントの確認に使い、
+// 主体がアクセスできないテナントを指定した場合はエラーにする
+func (r *TenantResolver) Resolve(ctx context.Context, apiKey, host, header string) (int64, error) {
+	requested := strings.ToLower(strings.TrimSpace(header))
+	if subdomain := r.subdomain(host); subdomain != "" {
+		requested = subdomain
+	}
+
+	var slug string
+	switch owner, ok := r.config.APIKeys[apiKey]; {
+	case apiKey != "" && !ok:
+		return 0, errUnknownAPIKey
+	case apiKey != "":
+		if requested != "" && requested != owner {
+			return 0, errTenantForbidden
+		}
+		slug = owner
+	default:
+		if r.config.DefaultTenant == "" || (requested != "" && requested != r.config.DefaultTenant) {
+			return 0, errAPIKeyRequired
+		}
+		slug = r.config.DefaultTenant
+	}
+
+	if id, ok := r.ids.Load(slug); ok {
+		return id.(int64), nil
+	}
+	tenant, err := r.config.Tenants.GetTenantBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidInput) {
+			return 0, domainErrors.ErrTenantRequired
+		}
+		return 0, err
+	}
+	r.ids.Store(slug, tenant.ID)
+	return tenant.ID, nil
+}
+
+// subdomain はBaseDomainの1つ下のサブドメインを返す（www.acme.example.com のような多段のサブドメインは使わない）
+func (r *TenantResolver) subdomain(host string) string {
+	if r.config.BaseDomain == "" || host == "" {
+		return ""
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	label, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(r.config.BaseDomain))
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
+
+// Tenant はリクエストのテナントをctxに設定する。以降のリポジトリはそのテナントのデータだけを読み書きする
+func Tenant(resolver *TenantResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if resolver.config.Skipper != nil && resolver.config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			tenantID, err := resolver.Resolve(req.Context(), req.Header.Get(HeaderAPIKey), req.Host, req.Header.Get(HeaderTenant))
+			if err != nil {
+				return c.JSON(tenantErrorStatus(err), ErrorResponse{
+					Error: tenantErrorMessage(err),
+				})
+			}
+
+			c.SetRequest(req.WithContext(usecase.WithTenant(req.Context(), tenantID)))
+			return next(c)
+		}
+	}
+}
+
+// TenantUnaryInterceptor はgRPCのメタデータ（x-api-key・:authority・x-tenant）からTenantと同じようにテナントを設定する
+func TenantUnaryInterceptor(resolver *TenantResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := resolver.resolveRPC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantStreamInterceptor はストリーミングのgRPCの呼び出しにTenantUnaryInterceptorと同じようにテナントを設定する
+func TenantStreamInterceptor(resolver *TenantResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolver.resolveRPC(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, withStreamContext(stream, ctx))
+	}
+}
+
+// resolveRPC はgRPCのメタデータからテナントを解決してctxに設定する。失敗した場合はgRPCのステータスのエラーを返す
+func (r *TenantResolver) resolveRPC(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	tenantID, err := r.Resolve(ctx, first(strings.ToLower(HeaderAPIKey)), first(":authority"), first(strings.ToLower(HeaderTenant)))
+	if err != nil {
+		code := codes.Internal
+		switch tenantErrorStatus(err) {
+		case http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case http.StatusUnauthorized:
+			code = codes.Unauthenticated
+		case http.StatusForbidden:
+			code = codes.PermissionDenied
+		case http.StatusNotFound:
+			code = codes.NotFound
+		}
+		return nil, status.Error(code, tenantErrorMessage(err))
+	}
+	return usecase.WithTenant(ctx, tenantID), nil
+}
+
+func tenantErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainErrors.ErrTenantRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, errUnknownAPIKey), errors.Is(err, errAPIKeyRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, errTenantForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domainErrors.ErrTenantNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func tenantErrorMessage(err error) string {
+	if tenantErrorStatus(err) == http.StatusInternalServerError {
+		log.Printf("⚠️  failed to resolve tenant: %v", err)
+		return "failed to resolve tenant"
+	}
+	return err.Error()
+}
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeTenantUsecase はdefault（ID: 1）とacme（ID: 2）のテナントを持つTenantUsecase
+type fakeTenantUsecase struct {
+	usecase.TenantUsecase
+}
+
+func (fakeTenantUsecase) GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	switch slug {
+	case "default":
+		return &entity.Tenant{ID: 1, Slug: slug}, nil
+	case "acme":
+		return &entity.Tenant{ID: 2, Slug: slug}, nil
+	}
+	return nil, domainErrors.ErrTenantNotFound
+}
+
+// tenantItems はリポジトリと同じく、ctxのテナントのアイテムだけを読み書きできるアイテムの保存先
+type tenantItems struct {
+	mu    sync.Mutex
+	names map[int64]string
+	// アイテムID → テナントID
+	owners map[int64]int64
+}
+
+func newTenantItems() *tenantItems {
+	return &tenantItems{
+		// 1: defaultのアイテム、2: acmeのアイテム
+		names:  map[int64]string{1: "ロレックス デイトナ", 2: "カルティエ タンク"},
+		owners: map[int64]int64{1: 1, 2: 2},
+	}
+}
+
+func (s *tenantItems) lookup(c echo.Context) (int64, bool) {
+	tenantID, ok := usecase.TenantIDFromContext(c.Request().Context())
+	if !ok {
+		return 0, false
+	}
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	return id, s.owners[id] == tenantID
+}
+
+func (s *tenantItems) get(c echo.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.lookup(c)
+	if !ok {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "item not found"})
+	}
+	return c.String(http.StatusOK, s.names[id])
+}
+
+func (s *tenantItems) update(c echo.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.lookup(c)
+	if !ok {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "item not found"})
+	}
+	s.names[id] = c.QueryParam("name")
+	return c.NoContent(http.StatusNoContent)
+}
+
+func newTenantServer(config TenantConfig) (*echo.Echo, *tenantItems) {
+	config.Tenants = fakeTenantUsecase{}
+	if config.APIKeys == nil {
+		config.APIKeys = map[string]string{"acme-key": "acme", "default-key": "default"}
+	}
+	config.Skipper = func(c echo.Context) bool {
+		return c.Path() == "/health"
+	}
+
+	items := newTenantItems()
+	e := echo.New()
+	e.Use(Tenant(NewTenantResolver(config)))
+	e.GET("/items/:id", items.get)
+	e.PUT("/items/:id", items.update)
+	e.GET("/health", func(c echo.Context) error {
+		_, ok := usecase.TenantIDFromContext(c.Request().Context())
+		return c.String(http.StatusOK, strconv.FormatBool(ok))
+	})
+	return e, items
+}
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         TenantConfig
+		method         string
+		path           string
+		host           string
+		header         map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: APIキーがない場合は既定のテナントのアイテムを取得できる",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ロレックス デイトナ",
+		},
+		{
+			name:           "正常系: APIキーのテナントのアイテムを取得できる",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/2",
+			header:         map[string]string{HeaderAPIKey: "acme-key"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "カルティエ タンク",
+		},
+		{
+			name:           "正常系: APIキーのテナントと同じX-Tenant",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/2",
+			header:         map[string]string{HeaderAPIKey: "acme-key", HeaderTenant: "ACME"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: APIキーのテナントと同じサブドメイン",
+			config:         TenantConfig{DefaultTenant: "default", BaseDomain: "example.com"},
+			path:           "/items/2",
+			host:           "acme.example.com:8080",
+			header:         map[string]string{HeaderAPIKey: "acme-key"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: テナントに属さないエンドポイントはテナントを解決しない",
+			config:         TenantConfig{},
+			path:           "/health",
+			header:         map[string]string{HeaderAPIKey: "unknown"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "false",
+		},
+		{
+			name:           "異常系: 他のテナントのアイテムは存在しないアイテムとして扱う",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/1",
+			header:         map[string]string{HeaderAPIKey: "acme-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "異常系: APIキーがない場合は他のテナントのアイテムを取得できない",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/2",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "異常系: APIキーなしでX-Tenantに他のテナントを指定する",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/2",
+			header:         map[string]string{HeaderTenant: "acme"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: APIキーなしでサブドメインに他のテナントを指定する",
+			config:         TenantConfig{DefaultTenant: "default", BaseDomain: "example.com"},
+			path:           "/items/2",
+			host:           "acme.example.com",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: APIキーと異なるテナントをX-Tenantで指定する",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/1",
+			header:         map[string]string{HeaderAPIKey: "acme-key", HeaderTenant: "default"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "異常系: APIキーと異なるテナントをサブドメインで指定する",
+			config:         TenantConfig{DefaultTenant: "default", BaseDomain: "example.com"},
+			path:           "/items/2",
+			host:           "acme.example.com",
+			header:         map[string]string{HeaderAPIKey: "default-key"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "異常系: 登録されていないAPIキー",
+			config:         TenantConfig{DefaultTenant: "default"},
+			path:           "/items/1",
+			header:         map[string]string{HeaderAPIKey: "unknown", HeaderTenant: "default"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: 既定のテナントがない場合はAPIキーが必須",
+			config:         TenantConfig{},
+			path:           "/items/1",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: APIキーに存在しないテナントを割り当てている",
+			config:         TenantConfig{APIKeys: map[string]string{"globex-key": "globex"}},
+			path:           "/items/1",
+			header:         map[string]string{HeaderAPIKey: "globex-key"},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newTenantServer(tt.config)
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.path, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+// 他のテナントのアイテムはどの方法でテナントを指定しても変更できない
+func TestTenant_CrossTenantWrite(t *testing.T) {
+	e, items := newTenantServer(TenantConfig{DefaultTenant: "default", BaseDomain: "example.com"})
+
+	attempts := []struct {
+		name   string
+		host   string
+		header map[string]string
+	}{
+		{name: "APIキーなし"},
+		{name: "APIキーなしのX-Tenant", header: map[string]string{HeaderTenant: "acme"}},
+		{name: "APIキーなしのサブドメイン", host: "acme.example.com"},
+		{name: "他のテナントのAPIキー", header: map[string]string{HeaderAPIKey: "default-key"}},
+		{name: "他のテナントのAPIキーとX-Tenant", header: map[string]string{HeaderAPIKey: "default-key", HeaderTenant: "acme"}},
+		{name: "登録されていないAPIキー", header: map[string]string{HeaderAPIKey: "acme"}},
+	}
+	for _, attempt := range attempts {
+		t.Run("異常系: "+attempt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/items/2?name="+url.QueryEscape("乗っ取り"), nil)
+			if attempt.host != "" {
+				req.Host = attempt.host
+			}
+			for key, value := range attempt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.GreaterOrEqual(t, rec.Code, http.StatusBadRequest)
+		})
+	}
+
+	assert.Equal(t, "カルティエ タンク", items.names[2])
+
+	t.Run("正常系: 所有するテナントのAPIキーでは変更できる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/items/2?name="+url.QueryEscape("カルティエ サントス"), nil)
+		req.Header.Set(HeaderAPIKey, "acme-key")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "カルティエ サントス", items.names[2])
+	})
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// LogNotifier は通知を標準ログに出力する
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification usecase.Notification) error {
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Printf("🔔 [%s] %s %s", notification.Type, notification.Message, data)
+	return nil
+}
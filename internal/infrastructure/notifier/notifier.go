@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"fmt"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 通知先の種類
+const (
+	TypeLog     = "log"
+	TypeWebhook = "webhook"
+)
+
+// New は設定に応じた Notifier を返す。種類が空の場合はログに出力する
+func New(notifierType, webhookURL string) (usecase.Notifier, error) {
+	switch notifierType {
+	case "", TypeLog:
+		return NewLogNotifier(nil), nil
+	case TypeWebhook:
+		if webhookURL == "" {
+			return nil, fmt.Errorf("NOTIFIER_WEBHOOK_URL is required for the webhook notifier")
+		}
+		return NewWebhookNotifier(webhookURL, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %s (must be %s or %s)", notifierType, TypeLog, TypeWebhook)
+	}
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit はトークンバケットの設定。Burst個のトークンがPeriodかけて均等に補充される
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// Enabled は制限が有効か（Burstが0の場合は制限しない）
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Period > 0
+}
+
+// 1秒あたりに補充されるトークン数
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+// ParseLimit は "60/1m" 形式（1分あたり60リクエストまで、最大60リクエストを連続で受け付ける）の設定を読み込む。
+// "0" または空文字の場合は制限しない
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return Limit{}, nil
+	}
+
+	burst, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: must be <requests>/<period>", value)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(burst))
+	if err != nil || requests < 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a non-negative integer", value)
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || duration <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration such as 1m", value)
+	}
+
+	return Limit{Burst: requests, Period: duration}, nil
+}
+
+// Result はトークンを1つ消費した結果
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // 拒否した場合に次のトークンが補充されるまでの時間
+	Reset      time.Duration // バケットが満杯に戻るまでの時間
+}
+
+// Store はキーごとのトークンバケットを保持する。
+// 複数のサーバーで制限を共有する場合はRedisなどを使った実装に差し替える
+type Store interface {
+	// Take はkeyのバケットからトークンを1つ消費し、リクエストを受け付けるかを返す
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	period    time.Duration
+}
+
+// 使われなくなったバケットを削除する間隔
+const sweepInterval = time.Minute
+
+// MemoryStore はプロセス内にバケットを保持するStore
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	// テストで時刻を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	// 前回から経過した時間の分だけ補充する
+	rate := limit.rate()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updatedAt).Seconds()*rate)
+	b.updatedAt = now
+	b.period = limit.Period
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = secondsToDuration((float64(limit.Burst) - b.tokens) / rate)
+
+	return result, nil
+}
+
+// 満杯まで補充されたバケットは新しく作り直しても同じなので削除する
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
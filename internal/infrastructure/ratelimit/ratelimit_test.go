@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore は時刻を進められるMemoryStoreを返す
+func newTestStore() (*MemoryStore, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, func(d time.Duration) { now = now.Add(d) }
+}
+
+func take(t *testing.T, store *MemoryStore, key string, limit Limit) Result {
+	t.Helper()
+	result, err := store.Take(context.Background(), key, limit)
+	require.NoError(t, err)
+	return result
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Limit
+		wantErr  bool
+	}{
+		{name: "正常系: 1分あたり60リクエスト", value: "60/1m", expected: Limit{Burst: 60, Period: time.Minute}},
+		{name: "正常系: 前後の空白", value: " 10 / 1s ", expected: Limit{Burst: 10, Period: time.Second}},
+		{name: "正常系: 0は制限しない", value: "0", expected: Limit{}},
+		{name: "正常系: 空文字は制限しない", value: "", expected: Limit{}},
+		{name: "異常系: 期間がない", value: "60", wantErr: true},
+		{name: "異常系: リクエスト数が負数", value: "-1/1m", wantErr: true},
+		{name: "異常系: 期間が0", value: "60/0s", wantErr: true},
+		{name: "異常系: 期間の形式が不正", value: "60/minute", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := ParseLimit(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+			assert.Equal(t, tt.expected.Burst > 0, limit.Enabled())
+		})
+	}
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := Limit{Burst: 3, Period: 3 * time.Second}
+
+	t.Run("正常系: Burst個まで連続で受け付け、超えると次の補充までの時間を返す", func(t *testing.T) {
+		store, _ := newTestStore()
+
+		for i := 0; i < limit.Burst; i++ {
+			result := take(t, store, "ip:192.0.2.1", limit)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, limit.Burst-1-i, result.Remaining)
+		}
+
+		result := take(t, store, "ip:192.0.2.1", limit)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.Reset)
+	})
+
+	t.Run("正常系: 経過時間に応じてトークンを補充する", func(t *testing.T) {
+		store, advance := newTestStore()
+		for i := 0; i < limit.Burst; i++ {
+			take(t, store, "ip:192.0.2.1", limit)
+		}
+
+		advance(500 * time.Millisecond)
+		result := take(t, store, "ip:192.0.2.1", limit)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+		advance(500 * time.Millisecond)
+		assert.True(t, take(t, store, "ip:192.0.2.1", limit).Allowed)
+		assert.False(t, take(t, store, "ip:192.0.2.1", limit).Allowed)
+	})
+
+	t.Run("正常系: 補充はBurstを超えない", func(t *testing.T) {
+		store, advance := newTestStore()
+		take(t, store, "ip:192.0.2.1", limit)
+
+		advance(time.Hour)
+		for i := 0; i < limit.Burst; i++ {
+			assert.True(t, take(t, store, "ip:192.0.2.1", limit).Allowed)
+		}
+		assert.False(t, take(t, store, "ip:192.0.2.1", limit).Allowed)
+	})
+
+	t.Run("正常系: キーごとに別のバケットを使う", func(t *testing.T) {
+		store, _ := newTestStore()
+		for i := 0; i < limit.Burst; i++ {
+			take(t, store, "ip:192.0.2.1", limit)
+		}
+
+		assert.False(t, take(t, store, "ip:192.0.2.1", limit).Allowed)
+		assert.True(t, take(t, store, "ip:192.0.2.2", limit).Allowed)
+	})
+
+	t.Run("正常系: 満杯まで補充されたバケットを削除する", func(t *testing.T) {
+		store, advance := newTestStore()
+		take(t, store, "ip:192.0.2.1", limit)
+		advance(sweepInterval)
+		take(t, store, "ip:192.0.2.2", limit)
+
+		assert.Len(t, store.buckets, 1)
+		assert.Contains(t, store.buckets, "ip:192.0.2.2")
+	})
+}
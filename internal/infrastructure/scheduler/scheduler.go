@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job は一定間隔で実行する処理
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler はサーバープロセス内でジョブを定期実行する
+type Scheduler struct {
+	jobs   []Job
+	logger *log.Logger
+	wg     sync.WaitGroup
+}
+
+func New(logger *log.Logger, jobs ...Job) *Scheduler {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Scheduler{jobs: jobs, logger: logger}
+}
+
+// Start はジョブごとにゴルーチンを起動する。起動直後に1回実行し、以降はIntervalごとに実行する。
+// ctxがキャンセルされると停止する（実行中のジョブの終了はWaitで待つ）
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait はすべてのジョブが停止するまで待つ
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	// ジョブのパニックでサーバーが停止しないようにする
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("❌ job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		s.logger.Printf("❌ job %s failed: %v", job.Name, err)
+	}
+}
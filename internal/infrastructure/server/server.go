@@ -2,18 +2,48 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/config"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/eventbus"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	"Aicon-assignment/internal/infrastructure/notifier"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/infrastructure/scheduler"
+	"Aicon-assignment/internal/infrastructure/webhook"
+	adminController "Aicon-assignment/internal/interfaces/controller/admin"
+	eventController "Aicon-assignment/internal/interfaces/controller/events"
+	insuranceController "Aicon-assignment/internal/interfaces/controller/insurance"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	loanController "Aicon-assignment/internal/interfaces/controller/loans"
+	locationController "Aicon-assignment/internal/interfaces/controller/locations"
+	maintenanceController "Aicon-assignment/internal/interfaces/controller/maintenance"
 	"Aicon-assignment/internal/interfaces/controller/system"
+	tagController "Aicon-assignment/internal/interfaces/controller/tags"
+	valuationController "Aicon-assignment/internal/interfaces/controller/valuations"
+	webhookController "Aicon-assignment/internal/interfaces/controller/webhooks"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/graph"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/interfaces/rpc"
+	"Aicon-assignment/internal/interfaces/rpc/itemsv1"
 	"Aicon-assignment/internal/usecase"
 )
 
@@ -30,16 +60,193 @@ func (s *Server) Run(ctx context.Context) error {
 
 	// 依存性注入
 	dbHandler := databaseInfra.NewSqlHandler()
-	defer dbHandler.Close()
 
-	itemRepo := &itemDatabase.ItemRepository{
-		SqlHandler: dbHandler,
+	// クエリの実行時間の記録と遅いクエリのログ。停止時は実行中のEXPLAINを待ってから接続を閉じる
+	sqlHandler := itemDatabase.NewLoggingSqlHandler(dbHandler, itemDatabase.QueryLogConfig{
+		LogQueries:    config.QueryLog,
+		SlowThreshold: config.SlowQueryThreshold,
+		Explain:       config.SlowQueryExplain,
+	})
+	defer sqlHandler.Close()
+
+	var itemRepo usecase.ItemRepository = &itemDatabase.ItemRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	var tagRepo usecase.TagRepository = &itemDatabase.TagRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	var locationRepo usecase.LocationRepository = &itemDatabase.LocationRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	loanRepo := &itemDatabase.LoanRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	maintenanceRepo := &itemDatabase.MaintenanceRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	var insuranceRepo usecase.InsuranceRepository = &itemDatabase.InsuranceRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	valuationRepo := &itemDatabase.ValuationRepository{
+		SqlHandler: sqlHandler,
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	outboxRepo := &itemDatabase.OutboxRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	webhookRepo := &itemDatabase.WebhookRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	tenantRepo := &itemDatabase.TenantRepository{
+		SqlHandler: sqlHandler,
+	}
+
+	// アイテムの取得とカテゴリーごとの集計のキャッシュ。アイテムを変更するタグ・保管場所・保険のリポジトリでも無効化する
+	var cacheStats adminController.CacheStatsProvider
+	if config.CacheSize > 0 {
+		cachedItemRepo := itemDatabase.NewCachedItemRepository(itemRepo, cache.NewMemoryStore(int(config.CacheSize)), dbHandler, config.CacheTTL)
+		itemRepo = cachedItemRepo
+		tagRepo = cachedItemRepo.TagRepository(tagRepo)
+		locationRepo = cachedItemRepo.LocationRepository(locationRepo)
+		insuranceRepo = cachedItemRepo.InsuranceRepository(insuranceRepo)
+		cacheStats = cachedItemRepo
+	}
+
+	maintenanceIntervals, err := entity.ParseMaintenanceIntervals(config.MaintenanceIntervals)
+	if err != nil {
+		return fmt.Errorf("invalid MAINTENANCE_INTERVALS: %w", err)
+	}
+
+	depreciationModels, err := entity.ParseDepreciationModels(config.DepreciationModels)
+	if err != nil {
+		return fmt.Errorf("invalid DEPRECIATION_MODELS: %w", err)
+	}
+
+	readLimit, err := ratelimit.ParseLimit(config.RateLimitRead)
+	if err != nil {
+		return fmt.Errorf("invalid RATE_LIMIT_READ: %w", err)
+	}
+
+	writeLimit, err := ratelimit.ParseLimit(config.RateLimitWrite)
+	if err != nil {
+		return fmt.Errorf("invalid RATE_LIMIT_WRITE: %w", err)
+	}
+
+	apiDocument, err := openapi.New()
+	if err != nil {
+		return fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	overdueNotifier, err := notifier.New(config.Notifier, config.NotifierWebhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to create notifier: %w", err)
+	}
+
+	// SSEの接続はサーバーの停止時に切断する
+	itemEventBus := eventbus.New(0)
+	e.Server.RegisterOnShutdown(itemEventBus.Close)
+
+	// 貸出・返却による状態の変更も、アイテムの更新と同じくアウトボックスとイベントバスに記録する
+	itemEventOptions := []usecase.ItemUsecaseOption{
+		usecase.WithOutbox(outboxRepo),
+		usecase.WithEventBus(itemEventBus),
+	}
+	itemUsecase := usecase.NewItemUsecase(itemRepo, append([]usecase.ItemUsecaseOption{
+		usecase.WithLoanRepository(loanRepo),
+		usecase.WithMaintenanceRepository(maintenanceRepo),
+		usecase.WithDepreciationModels(depreciationModels),
+	}, itemEventOptions...)...)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, itemRepo)
+	locationUsecase := usecase.NewLocationUsecase(locationRepo, itemRepo)
+	loanUsecase := usecase.NewLoanUsecase(loanRepo, itemRepo, overdueNotifier, itemEventOptions...)
+	maintenanceUsecase := usecase.NewMaintenanceUsecase(maintenanceRepo, itemRepo, maintenanceIntervals)
+	valuationUsecase := usecase.NewValuationUsecase(valuationRepo, itemRepo)
+	insuranceUsecase := usecase.NewInsuranceUsecase(insuranceRepo, valuationRepo, itemRepo)
+	eventStreamUsecase := usecase.NewEventStreamUsecase(itemEventBus, outboxRepo)
+	tenantUsecase := usecase.NewTenantUsecase(tenantRepo)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, outboxRepo, webhook.NewHTTPSender(&http.Client{Timeout: config.WebhookTimeout}))
 
 	systemHandler := system.NewSystemHandler()
+	adminHandler := adminController.NewAdminHandler(cacheStats, sqlHandler)
 	itemHandler := itemController.NewItemHandler(itemUsecase)
+	tagHandler := tagController.NewTagHandler(tagUsecase)
+	locationHandler := locationController.NewLocationHandler(locationUsecase)
+	loanHandler := loanController.NewLoanHandler(loanUsecase)
+	maintenanceHandler := maintenanceController.NewMaintenanceHandler(maintenanceUsecase)
+	valuationHandler := valuationController.NewValuationHandler(valuationUsecase)
+	insuranceHandler := insuranceController.NewInsuranceHandler(insuranceUsecase)
+	webhookHandler := webhookController.NewWebhookHandler(webhookUsecase)
+	eventHandler := eventController.NewEventHandler(eventStreamUsecase, config.SSEHeartbeatInterval)
+	graphHandler, err := graph.NewHandler(itemUsecase, valuationUsecase, graph.Limits{
+		MaxDepth:      int(config.GraphQLMaxDepth),
+		MaxComplexity: int(config.GraphQLMaxComplexity),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+
+	// クライアントのアドレスは直接の接続元を使い、プロキシの背後ではX-Forwarded-Forを信頼する
+	e.IPExtractor = echo.ExtractIPDirect()
+	if config.TrustProxy {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
+
+	// テナントのAPIキーはリクエスト数の制限の識別にも使い、制限のためだけに登録したキーは既定のテナントのキーとして扱う
+	rateLimitAPIKeys := parseAPIKeys(config.RateLimitAPIKeys)
+	tenantAPIKeys := parseTenantAPIKeys(config.TenantAPIKeys)
+	for key := range tenantAPIKeys {
+		rateLimitAPIKeys[key] = true
+	}
+	for key := range rateLimitAPIKeys {
+		if _, ok := tenantAPIKeys[key]; !ok && config.TenantDefault != "" {
+			tenantAPIKeys[key] = config.TenantDefault
+		}
+	}
+
+	// リクエスト数とボディサイズの制限（ヘルスチェックは除く）
+	e.Use(middleware.RateLimit(middleware.RateLimitConfig{
+		Store:   ratelimit.NewMemoryStore(),
+		Read:    readLimit,
+		Write:   writeLimit,
+		APIKeys: rateLimitAPIKeys,
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/health"
+		},
+	}))
+	e.Use(middleware.BodyLimit(config.MaxRequestBodyBytes))
+
+	// 書き込んだ後の読み込みはレプリカではなくプライマリに送る
+	e.Use(middleware.ReadYourWrites())
+
+	// リクエストのテナント（APIキーのテナント、キーがない場合は既定のテナント）。ドキュメントと運用のエンドポイントはテナントに属さない
+	anonymousTenant := config.TenantDefault
+	if config.TenantRequireAPIKey {
+		anonymousTenant = ""
+	}
+	tenantResolver := middleware.NewTenantResolver(middleware.TenantConfig{
+		Tenants:       tenantUsecase,
+		APIKeys:       tenantAPIKeys,
+		BaseDomain:    config.TenantBaseDomain,
+		DefaultTenant: anonymousTenant,
+		Skipper: func(c echo.Context) bool {
+			path := c.Path()
+			return path == "/health" || path == "/openapi.json" || path == "/docs" || strings.HasPrefix(path, "/admin/")
+		},
+	})
+	e.Use(middleware.Tenant(tenantResolver))
+
+	// OpenAPIドキュメントによるリクエストの検証（テスト環境ではレスポンスも検証する）
+	e.Use(apiDocument.Validator(openapi.ValidatorConfig{
+		ValidateResponses: config.AppEnv == config.AppEnvTest,
+	}))
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
@@ -47,21 +254,226 @@ func (s *Server) Run(ctx context.Context) error {
 		return nil
 	})
 
+	// APIドキュメント
+	e.GET("/openapi.json", apiDocument.ServeDocument)
+	e.GET("/docs", apiDocument.ServeSwaggerUI)
+
 	// アイテムに関するエンドポイント
 	itemsGroup := e.Group("/items")
 	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // 💡 新規追加: PATCH /items/{id}
+		itemsGroup.GET("", itemHandler.GetItems)                                     // GET /items
+		itemsGroup.POST("", itemHandler.CreateItem)                                  // POST /items
+		itemsGroup.POST("/batch", itemHandler.BatchItems)                            // POST /items/batch
+		itemsGroup.GET("/events", eventHandler.StreamItemEvents)                     // GET /items/events (SSE)
+		itemsGroup.GET("/:id", itemHandler.GetItem)                                  // GET /items/{id}
+		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)                            // DELETE /items/{id}
+		itemsGroup.GET("/summary", itemHandler.GetSummary)                           // GET /items/summary (bonus)
+		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)                             // 💡 新規追加: PATCH /items/{id}
+		itemsGroup.PUT("/:id", itemHandler.ReplaceItem)                              // PUT /items/{id}
+		itemsGroup.GET("/attributes", itemHandler.GetAttributeSchemas)               // GET /items/attributes
+		itemsGroup.GET("/lookup", itemHandler.LookupItems)                           // GET /items/lookup?serial=
+		itemsGroup.GET("/duplicates", itemHandler.GetDuplicates)                     // GET /items/duplicates
+		itemsGroup.POST("/:id/merge", itemHandler.MergeItem)                         // POST /items/{id}/merge
+		itemsGroup.GET("/:id/merges", itemHandler.GetMergeHistory)                   // GET /items/{id}/merges
+		itemsGroup.POST("/:id/sell", itemHandler.SellItem)                           // POST /items/{id}/sell
+		itemsGroup.POST("/:id/lend", itemHandler.LendItem)                           // POST /items/{id}/lend
+		itemsGroup.POST("/:id/repair", itemHandler.RepairItem)                       // POST /items/{id}/repair
+		itemsGroup.POST("/:id/consign", itemHandler.ConsignItem)                     // POST /items/{id}/consign
+		itemsGroup.POST("/:id/return", itemHandler.ReturnItem)                       // POST /items/{id}/return
+		itemsGroup.POST("/:id/lose", itemHandler.LoseItem)                           // POST /items/{id}/lose
+		itemsGroup.POST("/:id/tags", tagHandler.AddItemTags)                         // POST /items/{id}/tags
+		itemsGroup.DELETE("/:id/tags/:tagId", tagHandler.RemoveItemTag)              // DELETE /items/{id}/tags/{tagId}
+		itemsGroup.POST("/:id/move", locationHandler.MoveItem)                       // POST /items/{id}/move
+		itemsGroup.GET("/:id/movements", locationHandler.GetItemMovements)           // GET /items/{id}/movements
+		itemsGroup.POST("/:id/loans", loanHandler.LendItem)                          // POST /items/{id}/loans
+		itemsGroup.GET("/:id/loans", loanHandler.GetItemLoans)                       // GET /items/{id}/loans
+		itemsGroup.GET("/:id/maintenance", maintenanceHandler.GetItemMaintenance)    // GET /items/{id}/maintenance
+		itemsGroup.POST("/:id/maintenance", maintenanceHandler.AddMaintenanceRecord) // POST /items/{id}/maintenance
+		itemsGroup.GET("/:id/valuations", valuationHandler.GetItemValuations)        // GET /items/{id}/valuations
+		itemsGroup.POST("/:id/valuations", valuationHandler.AddValuation)            // POST /items/{id}/valuations
+		itemsGroup.PUT("/:id/insurance", insuranceHandler.AssignItem)                // PUT /items/{id}/insurance
+	}
+
+	// タグに関するエンドポイント
+	tagsGroup := e.Group("/tags")
+	{
+		tagsGroup.GET("", tagHandler.GetTags)          // GET /tags
+		tagsGroup.POST("", tagHandler.CreateTag)       // POST /tags
+		tagsGroup.GET("/:id", tagHandler.GetTag)       // GET /tags/{id}
+		tagsGroup.PUT("/:id", tagHandler.UpdateTag)    // PUT /tags/{id}
+		tagsGroup.DELETE("/:id", tagHandler.DeleteTag) // DELETE /tags/{id}
+	}
+
+	// 保管場所に関するエンドポイント
+	locationsGroup := e.Group("/locations")
+	{
+		locationsGroup.GET("", locationHandler.GetLocations)          // GET /locations
+		locationsGroup.POST("", locationHandler.CreateLocation)       // POST /locations
+		locationsGroup.GET("/:id", locationHandler.GetLocation)       // GET /locations/{id}
+		locationsGroup.PUT("/:id", locationHandler.UpdateLocation)    // PUT /locations/{id}
+		locationsGroup.DELETE("/:id", locationHandler.DeleteLocation) // DELETE /locations/{id}
+	}
+
+	// 貸出先に関するエンドポイント
+	borrowersGroup := e.Group("/borrowers")
+	{
+		borrowersGroup.GET("", loanHandler.GetBorrowers)    // GET /borrowers
+		borrowersGroup.POST("", loanHandler.CreateBorrower) // POST /borrowers
+		borrowersGroup.GET("/:id", loanHandler.GetBorrower) // GET /borrowers/{id}
 	}
 
-	return s.startWithGracefulShutdown(ctx, e)
+	// 貸出に関するエンドポイント
+	loansGroup := e.Group("/loans")
+	{
+		loansGroup.GET("", loanHandler.GetLoans)               // GET /loans?overdue=true
+		loansGroup.POST("/:id/return", loanHandler.ReturnLoan) // POST /loans/{id}/return
+	}
+
+	// 整備に関するエンドポイント
+	maintenanceGroup := e.Group("/maintenance")
+	{
+		maintenanceGroup.GET("/upcoming", maintenanceHandler.GetUpcomingMaintenance) // GET /maintenance/upcoming?days=30
+		maintenanceGroup.DELETE("/:id", maintenanceHandler.DeleteMaintenanceRecord)  // DELETE /maintenance/{id}
+	}
+
+	// 保険に関するエンドポイント
+	insuranceGroup := e.Group("/insurance/policies")
+	{
+		insuranceGroup.GET("", insuranceHandler.GetPolicies)         // GET /insurance/policies
+		insuranceGroup.POST("", insuranceHandler.CreatePolicy)       // POST /insurance/policies
+		insuranceGroup.GET("/:id", insuranceHandler.GetPolicy)       // GET /insurance/policies/{id}
+		insuranceGroup.PUT("/:id", insuranceHandler.UpdatePolicy)    // PUT /insurance/policies/{id}
+		insuranceGroup.DELETE("/:id", insuranceHandler.DeletePolicy) // DELETE /insurance/policies/{id}
+	}
+
+	// Webhookに関するエンドポイント
+	webhooksGroup := e.Group("/webhooks")
+	{
+		webhooksGroup.GET("", webhookHandler.GetWebhooks)                                 // GET /webhooks
+		webhooksGroup.POST("", webhookHandler.CreateWebhook)                              // POST /webhooks
+		webhooksGroup.GET("/dead-letters", webhookHandler.GetDeadLetters)                 // GET /webhooks/dead-letters
+		webhooksGroup.GET("/deliveries/:deliveryId", webhookHandler.GetDelivery)          // GET /webhooks/deliveries/{deliveryId}
+		webhooksGroup.POST("/deliveries/:deliveryId/retry", webhookHandler.RetryDelivery) // POST /webhooks/deliveries/{deliveryId}/retry
+		webhooksGroup.GET("/:id", webhookHandler.GetWebhook)                              // GET /webhooks/{id}
+		webhooksGroup.PUT("/:id", webhookHandler.UpdateWebhook)                           // PUT /webhooks/{id}
+		webhooksGroup.DELETE("/:id", webhookHandler.DeleteWebhook)                        // DELETE /webhooks/{id}
+		webhooksGroup.GET("/:id/deliveries", webhookHandler.GetDeliveries)                // GET /webhooks/{id}/deliveries?status=dead
+	}
+
+	// レポートに関するエンドポイント
+	reportsGroup := e.Group("/reports")
+	{
+		reportsGroup.GET("/insurance", insuranceHandler.GetCoverageReport) // GET /reports/insurance?threshold=100000&days=30
+		reportsGroup.GET("/book-value", itemHandler.GetBookValueReport)    // GET /reports/book-value?as_of=2024-12-31
+	}
+
+	// GraphQL（アイテム・サマリーの取得と更新）
+	e.POST("/graphql", graphHandler.Query) // POST /graphql
+
+	// 運用に関するエンドポイント（ADMIN_API_KEYを送ったクライアントのみ）
+	adminAuth := middleware.Admin(config.AdminAPIKey)
+	adminGroup := e.Group("/admin")
+	{
+		adminGroup.GET("/cache", adminHandler.GetCacheStats, adminAuth)         // GET /admin/cache
+		adminGroup.GET("/slow-queries", adminHandler.GetSlowQueries, adminAuth) // GET /admin/slow-queries
+	}
+
+	// 登録したルートとドキュメントがずれていないか確認する
+	if err := apiDocument.CheckRoutes(e.Routes()); err != nil {
+		return fmt.Errorf("OpenAPI document does not match the routes: %w", err)
+	}
+
+	// gRPCサーバー（RESTと同じユースケースを使う）
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			middleware.ReadYourWritesUnaryInterceptor(),
+			middleware.TenantUnaryInterceptor(tenantResolver),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.ReadYourWritesStreamInterceptor(),
+			middleware.TenantStreamInterceptor(tenantResolver),
+		),
+	)
+	itemsv1.RegisterItemServiceServer(grpcServer, rpc.NewItemService(itemUsecase))
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	// バックグラウンドジョブ（サーバー停止時にキャンセルする）。テナントごとにそのテナントのデータを処理する
+	jobCtx, cancelJobs := context.WithCancel(ctx)
+	jobs := scheduler.New(log.Default(), scheduler.Job{
+		Name:     "overdue-loan-notification",
+		Interval: config.OverdueCheckInterval,
+		Run: func(ctx context.Context) error {
+			return tenantUsecase.ForEachTenant(ctx, func(ctx context.Context, tenant *entity.Tenant) error {
+				count, err := loanUsecase.NotifyOverdueLoans(ctx)
+				if count > 0 {
+					log.Printf("🔔 notified %d overdue loans of tenant %s", count, tenant.Slug)
+				}
+				return err
+			})
+		},
+	}, scheduler.Job{
+		Name:     "webhook-delivery",
+		Interval: config.WebhookDispatchInterval,
+		Run: func(ctx context.Context) error {
+			return tenantUsecase.ForEachTenant(ctx, func(ctx context.Context, tenant *entity.Tenant) error {
+				result, err := webhookUsecase.ProcessWebhooks(ctx)
+				if result != nil && (result.Succeeded > 0 || result.Failed > 0) {
+					log.Printf("📨 delivered %d webhooks of tenant %s (%d failed)", result.Succeeded, tenant.Slug, result.Failed)
+				}
+				return err
+			})
+		},
+	})
+	jobs.Start(jobCtx)
+	defer func() {
+		cancelJobs()
+		jobs.Wait()
+	}()
+
+	return s.startWithGracefulShutdown(ctx, e, grpcServer, healthServer)
+}
+
+// カンマ区切りのAPIキーを読み込む
+func parseAPIKeys(value string) map[string]bool {
+	keys := make(map[string]bool)
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// APIキーとテナントのスラッグの組（key=slug のカンマ区切り）を読み込む
+func parseTenantAPIKeys(value string) map[string]string {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, slug, ok := strings.Cut(pair, "=")
+		key, slug = strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(slug))
+		if !ok || key == "" || slug == "" {
+			continue
+		}
+		keys[key] = slug
+	}
+	return keys
 }
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
+func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo, grpcServer *grpc.Server, healthServer *health.Server) error {
+	listener, err := net.Listen("tcp", config.GRPCPort)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC on %s: %w", config.GRPCPort, err)
+	}
+
+	go func() {
+		fmt.Printf("🚀 gRPC server starting on port %s\n", config.GRPCPort)
+
+		if err := grpcServer.Serve(listener); err != nil {
+			e.Logger.Fatal("gRPC server startup failed:", err)
+		}
+	}()
+
 	go func() {
 		port := ":8080"
 		fmt.Printf("🚀 Server starting on port %s\n", port)
@@ -84,10 +496,26 @@ func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) er
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// gRPCは処理中のRPCの完了を待ち、タイムアウトした場合は接続を切断する
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
 	if err := e.Shutdown(shutdownCtx); err != nil {
+		grpcServer.Stop()
 		return fmt.Errorf("server forced to shutdown: %w", err)
 	}
 
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+		return errors.New("gRPC server forced to shutdown")
+	}
+
 	fmt.Println("✅ Server exited gracefully")
 	return nil
 }
@@ -0,0 +1,237 @@
+// Package cli はHTTPサーバーを起動せずにアイテムとデータベースを操作する管理コマンドを実装する
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ErrUsage はコマンドや引数が不正な場合のエラー（終了コード2）
+var ErrUsage = errors.New("invalid usage")
+
+// 出力形式
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// sql/init.sqlの既定のパス（サーバーと同じく作業ディレクトリからの相対パス）
+const defaultScript = "sql/init.sql"
+
+// Schema はmigrate・seed・check-dbで使うデータベースの操作（database.SchemaRepository）
+type Schema interface {
+	Migrate(ctx context.Context, script string) (int, error)
+	Seed(ctx context.Context, script string) (int64, error)
+	CheckTables(ctx context.Context, script string) ([]*database.TableStatus, error)
+}
+
+// App はサブコマンドを解釈してユースケースを呼び出す
+type App struct {
+	itemUsecase   usecase.ItemUsecase
+	tenantUsecase usecase.TenantUsecase
+	schema        Schema
+	stdin         io.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+}
+
+func NewApp(itemUsecase usecase.ItemUsecase, tenantUsecase usecase.TenantUsecase, schema Schema, stdin io.Reader, stdout, stderr io.Writer) *App {
+	return &App{
+		itemUsecase:   itemUsecase,
+		tenantUsecase: tenantUsecase,
+		schema:        schema,
+		stdin:         stdin,
+		stdout:        stdout,
+		stderr:        stderr,
+	}
+}
+
+// Commands は管理コマンドのサブコマンド名
+var Commands = []string{"items", "import", "export", "summary", "tenants", "migrate", "seed", "check-db"}
+
+// tenantCommands はテナントのデータを操作するサブコマンド
+var tenantCommands = []string{"items", "import", "export", "summary", "seed"}
+
+const usage = `usage: main [--tenant <slug>] <command> [arguments]
+
+commands:
+  items list      アイテム一覧（--category --tag --status --location-id --attribute で絞り込み）
+  items get       アイテムの取得
+  items create    アイテムの登録
+  items delete    アイテムの削除（--yes で確認を省略）
+  import          JSON・CSVファイルからアイテムを登録する
+  export          アイテムをJSON・CSVで出力する
+  summary         カテゴリー・状態・保管場所ごとの集計
+  tenants list    テナント一覧
+  tenants create  テナントの登録
+  migrate         sql/init.sqlのスキーマを適用する
+  seed            sql/init.sqlのサンプルデータを登録する
+  check-db        データベースの接続とテーブルを確認する
+  tui             アイテムを閲覧・編集するターミナルUI（--server でREST APIのサーバーを操作）
+  serve           HTTP・gRPCサーバーを起動する（引数なしと同じ）
+
+--tenant でアイテムを操作するテナントを指定します（デフォルト: default）。
+各コマンドの引数は main <command> -h で確認できます。`
+
+// Run はargs（コマンド名以降の引数）のサブコマンドを実行する
+func (a *App) Run(ctx context.Context, args []string) error {
+	slug, args, err := CutTenantFlag(args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, usage)
+		return ErrUsage
+	}
+
+	if slices.Contains(tenantCommands, args[0]) {
+		if ctx, err = a.withTenant(ctx, args[0], slug); err != nil {
+			return err
+		}
+	}
+
+	switch args[0] {
+	case "items":
+		err = a.runItems(ctx, args[1:])
+	case "import":
+		err = a.runImport(ctx, args[1:])
+	case "export":
+		err = a.runExport(ctx, args[1:])
+	case "summary":
+		err = a.runSummary(ctx, args[1:])
+	case "tenants":
+		err = a.runTenants(ctx, args[1:])
+	case "migrate":
+		err = a.runMigrate(ctx, args[1:])
+	case "seed":
+		err = a.runSeed(ctx, args[1:])
+	case "check-db":
+		err = a.runCheckDB(ctx, args[1:])
+	case "help", "-h", "--help":
+		fmt.Fprintln(a.stdout, usage)
+		return nil
+	default:
+		fmt.Fprintln(a.stderr, usage)
+		return usageError("unknown command %q", args[0])
+	}
+
+	// -hで使い方を表示した場合は成功として扱う
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+func usageError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, args...))
+}
+
+// newFlagSet はエラーと使い方をstderrに出力するフラグセットを作る
+func (a *App) newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: main %s\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse はフラグと位置引数の順序を問わずに解釈し、位置引数を返す
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUsage, err.Error())
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// outputFlag は-o/--outputで出力形式を受け付ける
+func outputFlag(fs *flag.FlagSet) *string {
+	format := new(string)
+	fs.StringVar(format, "output", outputTable, "出力形式（table / json）")
+	fs.StringVar(format, "o", outputTable, "--output の短縮形")
+	return format
+}
+
+func checkOutput(format string) error {
+	if format != outputTable && format != outputJSON {
+		return usageError("output must be one of table, json: %s", format)
+	}
+	return nil
+}
+
+// write はjsonの場合はvalueを、tableの場合はtableで書き込んだ表を出力する
+func (a *App) write(format string, value interface{}, table func(w io.Writer)) error {
+	if format == outputJSON {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// 表の1行を書き込む
+func row(w io.Writer, columns ...interface{}) {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = fmt.Sprint(column)
+	}
+	fmt.Fprintln(w, strings.Join(values, "\t"))
+}
+
+// stringsFlag は繰り返し指定できる文字列のフラグ（--tag 限定 --tag 新品）
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// keyValueFlag は繰り返し指定できるkey=valueのフラグ（--attribute movement=自動巻き）
+type keyValueFlag map[string]string
+
+func (f keyValueFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for key, value := range f {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f keyValueFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("must be key=value: %s", value)
+	}
+	f[key] = val
+	return nil
+}
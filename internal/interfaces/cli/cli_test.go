@@ -0,0 +1,562 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// MockItemUsecase はテストで使うメソッドだけを実装したモック
+type MockItemUsecase struct {
+	usecase.ItemUsecase
+	mock.Mock
+}
+
+func (m *MockItemUsecase) ListItems(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemUsecase) IncludeBookValue(items []*entity.Item) {}
+
+func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
+}
+
+// fakeTenantUsecase はdefault（ID: 1）とacme（ID: 2）のテナントを持つTenantUsecase
+type fakeTenantUsecase struct {
+	tenants []*entity.Tenant
+}
+
+func newFakeTenantUsecase() *fakeTenantUsecase {
+	return &fakeTenantUsecase{tenants: []*entity.Tenant{
+		{ID: 1, Slug: "default", Name: "Default"},
+		{ID: 2, Slug: "acme", Name: "Acme株式会社"},
+	}}
+}
+
+func (u *fakeTenantUsecase) GetTenants(ctx context.Context) ([]*entity.Tenant, error) {
+	return u.tenants, nil
+}
+
+func (u *fakeTenantUsecase) GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	for _, tenant := range u.tenants {
+		if tenant.Slug == slug {
+			return tenant, nil
+		}
+	}
+	return nil, domainErrors.ErrTenantNotFound
+}
+
+func (u *fakeTenantUsecase) CreateTenant(ctx context.Context, input usecase.TenantInput) (*entity.Tenant, error) {
+	tenant, err := entity.NewTenant(input.Slug, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if _, err := u.GetTenantBySlug(ctx, tenant.Slug); err == nil {
+		return nil, domainErrors.ErrDuplicateEntry
+	}
+	tenant.ID = int64(len(u.tenants) + 1)
+	u.tenants = append(u.tenants, tenant)
+	return tenant, nil
+}
+
+func (u *fakeTenantUsecase) ForEachTenant(ctx context.Context, fn func(ctx context.Context, tenant *entity.Tenant) error) error {
+	return nil
+}
+
+// inTenant はctxがテナントtenantIDに絞り込まれていることを確認する
+func inTenant(tenantID int64) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		id, ok := usecase.TenantIDFromContext(ctx)
+		return ok && id == tenantID
+	})
+}
+
+// fakeSchema は適用したスクリプトを記録するSchema
+type fakeSchema struct {
+	seeded bool
+	tables []*database.TableStatus
+}
+
+func (s *fakeSchema) Migrate(ctx context.Context, script string) (int, error) {
+	return strings.Count(script, ";"), nil
+}
+
+func (s *fakeSchema) Seed(ctx context.Context, script string) (int64, error) {
+	s.seeded = true
+	return 4, nil
+}
+
+func (s *fakeSchema) CheckTables(ctx context.Context, script string) ([]*database.TableStatus, error) {
+	return s.tables, nil
+}
+
+type testApp struct {
+	*App
+	stdin  *bytes.Buffer
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+func newTestApp(itemUsecase usecase.ItemUsecase, schema Schema) *testApp {
+	stdin, stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	return &testApp{
+		App:    NewApp(itemUsecase, newFakeTenantUsecase(), schema, stdin, stdout, stderr),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+}
+
+func newTestItem(id int64, name string) *entity.Item {
+	item, _ := entity.NewItem(name, "時計", "ROLEX", 1500000, "2023-01-15")
+	item.ID = id
+	item.Tags = []string{"限定"}
+	item.Attributes = map[string]interface{}{"movement": "自動巻き"}
+	item.CreatedAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	item.UpdatedAt = item.CreatedAt
+	return item
+}
+
+func TestApp_ItemsList(t *testing.T) {
+	t.Run("正常系: 絞り込み条件を渡して表で出力する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{
+			Category:   "時計",
+			Tags:       []string{"限定", "新品"},
+			Status:     entity.ItemStatusOwned,
+			Attributes: map[string]string{"movement": "自動巻き"},
+		}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ")}, nil)
+		app := newTestApp(itemUsecase, nil)
+
+		err := app.Run(context.Background(), []string{"items", "list", "--category", "時計", "--tag", "限定", "--tag", "新品", "--status", "owned", "--attribute", "movement=自動巻き"})
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(app.stdout.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"ID", "NAME", "CATEGORY", "BRAND", "PURCHASE_PRICE", "PURCHASE_DATE", "STATUS", "TAGS"}, strings.Fields(lines[0]))
+		assert.Contains(t, lines[1], "ロレックス デイトナ")
+		assert.Contains(t, lines[1], "owned")
+		itemUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: JSONで出力する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ")}, nil)
+		app := newTestApp(itemUsecase, nil)
+
+		err := app.Run(context.Background(), []string{"items", "list", "-o", "json"})
+
+		require.NoError(t, err)
+		var items []*entity.Item
+		require.NoError(t, json.Unmarshal(app.stdout.Bytes(), &items))
+		require.Len(t, items, 1)
+		assert.Equal(t, "ロレックス デイトナ", items[0].Name)
+	})
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "異常系: 不正な出力形式", args: []string{"items", "list", "-o", "yaml"}},
+		{name: "異常系: 不正な状態", args: []string{"items", "list", "--status", "broken"}},
+		{name: "異常系: 不正なカスタム属性", args: []string{"items", "list", "--attribute", "movement"}},
+		{name: "異常系: 未知のサブコマンド", args: []string{"items", "rename"}},
+		{name: "異常系: 未知のコマンド", args: []string{"serve-forever"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := new(MockItemUsecase)
+			app := newTestApp(itemUsecase, nil)
+
+			err := app.Run(context.Background(), tt.args)
+
+			assert.ErrorIs(t, err, ErrUsage)
+			itemUsecase.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApp_ItemsGet(t *testing.T) {
+	t.Run("正常系: 項目ごとに出力する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+		app := newTestApp(itemUsecase, nil)
+
+		err := app.Run(context.Background(), []string{"items", "get", "1"})
+
+		require.NoError(t, err)
+		assert.Contains(t, app.stdout.String(), "NAME                 ロレックス デイトナ")
+		assert.Contains(t, app.stdout.String(), `ATTRIBUTE.movement   "自動巻き"`)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("GetItemByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+		app := newTestApp(itemUsecase, nil)
+
+		err := app.Run(context.Background(), []string{"items", "get", "999", "-o", "json"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, app.stdout.String())
+	})
+
+	t.Run("異常系: 不正なID", func(t *testing.T) {
+		app := newTestApp(new(MockItemUsecase), nil)
+
+		err := app.Run(context.Background(), []string{"items", "get", "abc"})
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
+
+func TestApp_ItemsCreate(t *testing.T) {
+	itemUsecase := new(MockItemUsecase)
+	itemUsecase.On("CreateItem", mock.Anything, usecase.CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		SerialNumber:  "ABC123",
+		Attributes:    map[string]interface{}{"movement": "自動巻き", "water_resistance": float64(100), "box": true},
+	}).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+	app := newTestApp(itemUsecase, nil)
+
+	err := app.Run(context.Background(), []string{
+		"items", "create", "--name", "ロレックス デイトナ", "--category", "時計", "--brand", "ROLEX",
+		"--purchase-price", "1500000", "--purchase-date", "2023-01-15", "--serial-number", "ABC123",
+		"--attribute", "movement=自動巻き", "--attribute", "water_resistance=100", "--attribute", "box=true",
+		"-o", "json",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, app.stdout.String(), `"id": 1`)
+	itemUsecase.AssertExpectations(t)
+}
+
+func TestApp_ItemsDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		answer         string
+		expectDeleted  bool
+		expectedOutput string
+	}{
+		{name: "正常系: 確認してから削除する", args: []string{"items", "delete", "1"}, answer: "y\n", expectDeleted: true, expectedOutput: "deleted item 1\n"},
+		{name: "正常系: --yesで確認を省略する", args: []string{"items", "delete", "--yes", "1"}, expectDeleted: true, expectedOutput: "deleted item 1\n"},
+		{name: "正常系: 確認で拒否した場合は削除しない", args: []string{"items", "delete", "1"}, answer: "n\n"},
+		{name: "正常系: 入力がない場合は削除しない", args: []string{"items", "delete", "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := new(MockItemUsecase)
+			itemUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+			itemUsecase.On("DeleteItem", mock.Anything, int64(1)).Return(nil)
+			app := newTestApp(itemUsecase, nil)
+			app.stdin.WriteString(tt.answer)
+
+			err := app.Run(context.Background(), tt.args)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, app.stdout.String())
+			if tt.expectDeleted {
+				itemUsecase.AssertCalled(t, "DeleteItem", mock.Anything, int64(1))
+			} else {
+				itemUsecase.AssertNotCalled(t, "DeleteItem", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestApp_Summary(t *testing.T) {
+	itemUsecase := new(MockItemUsecase)
+	itemUsecase.On("GetCategorySummary", mock.Anything).Return(&usecase.CategorySummary{
+		Categories: map[string]int{"時計": 2, "バッグ": 1},
+		Total:      3,
+		Statuses:   map[string]int{"owned": 3},
+	}, nil)
+	app := newTestApp(itemUsecase, nil)
+
+	err := app.Run(context.Background(), []string{"summary"})
+
+	require.NoError(t, err)
+	output := app.stdout.String()
+	assert.Contains(t, output, "TOTAL  3")
+	// カテゴリーは名前順に出力する
+	assert.Less(t, strings.Index(output, "バッグ"), strings.Index(output, "時計"))
+}
+
+func TestApp_Import(t *testing.T) {
+	t.Run("正常系: 出力したCSVをそのまま登録できる", func(t *testing.T) {
+		item := newTestItem(1, "ロレックス デイトナ")
+		item.SerialNumber = "ABC123"
+		var exported bytes.Buffer
+		require.NoError(t, writeCSV(&exported, []*entity.Item{item}))
+
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("CreateItem", mock.Anything, usecase.CreateItemInput{
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 1500000,
+			PurchaseDate:  "2023-01-15",
+			SerialNumber:  "ABC123",
+			Authenticity:  "unverified",
+			Attributes:    map[string]interface{}{"movement": "自動巻き"},
+		}).Return(newTestItem(10, "ロレックス デイトナ"), nil)
+		app := newTestApp(itemUsecase, nil)
+		app.stdin.Write(exported.Bytes())
+
+		err := app.Run(context.Background(), []string{"import", "--format", "csv", "-o", "json", "-"})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"row": 1, "name": "ロレックス デイトナ", "id": 10}]`, app.stdout.String())
+	})
+
+	t.Run("異常系: 失敗した行があっても残りを登録し、エラーを返す", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "items.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[
+			{"name": "", "category": "時計", "brand": "ROLEX", "purchase_price": 1, "purchase_date": "2023-01-15"},
+			{"name": "エルメス バーキン", "category": "バッグ", "brand": "HERMÈS", "purchase_price": 2000000, "purchase_date": "2023-02-20"}
+		]`), 0o600))
+
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("CreateItem", mock.Anything, mock.MatchedBy(func(input usecase.CreateItemInput) bool { return input.Name == "" })).
+			Return(nil, domainErrors.ErrInvalidInput)
+		itemUsecase.On("CreateItem", mock.Anything, mock.MatchedBy(func(input usecase.CreateItemInput) bool { return input.Name != "" })).
+			Return(newTestItem(2, "エルメス バーキン"), nil)
+		app := newTestApp(itemUsecase, nil)
+
+		err := app.Run(context.Background(), []string{"import", path, "-o", "json"})
+
+		require.EqualError(t, err, "1 of 2 items failed to import")
+		var results []*ImportResult
+		require.NoError(t, json.Unmarshal(app.stdout.Bytes(), &results))
+		require.Len(t, results, 2)
+		assert.NotEmpty(t, results[0].Error)
+		assert.Equal(t, int64(2), results[1].ID)
+	})
+
+	t.Run("異常系: CSVの価格が整数でない", func(t *testing.T) {
+		app := newTestApp(new(MockItemUsecase), nil)
+		app.stdin.WriteString("name,purchase_price\nロレックス,高い\n")
+
+		err := app.Run(context.Background(), []string{"import", "--format", "csv", "-"})
+
+		assert.ErrorContains(t, err, "line 2: purchase_price must be an integer")
+	})
+}
+
+func TestApp_Export(t *testing.T) {
+	itemUsecase := new(MockItemUsecase)
+	itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{Category: "時計"}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ")}, nil)
+	app := newTestApp(itemUsecase, nil)
+	path := filepath.Join(t.TempDir(), "items.csv")
+
+	err := app.Run(context.Background(), []string{"export", "--category", "時計", path})
+
+	require.NoError(t, err)
+	exported, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		strings.Join(exportColumns, ","),
+		`1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15,,,,unverified,,owned,,限定,"{""movement"":""自動巻き""}"`,
+		"",
+	}, "\n"), string(exported))
+	assert.Equal(t, "exported 1 items to "+path+"\n", app.stderr.String())
+}
+
+func TestApp_Seed(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "init.sql")
+	require.NoError(t, os.WriteFile(script, []byte("INSERT INTO items (name) VALUES ('ロレックス');\n"), 0o600))
+
+	t.Run("正常系: アイテムがない場合はサンプルデータを登録する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{}, nil)
+		schema := &fakeSchema{}
+		app := newTestApp(itemUsecase, schema)
+
+		err := app.Run(context.Background(), []string{"seed", "--script", script})
+
+		require.NoError(t, err)
+		assert.True(t, schema.seeded)
+		assert.Equal(t, "inserted 4 rows from "+script+"\n", app.stdout.String())
+	})
+
+	t.Run("異常系: アイテムがある場合は--forceがなければ登録しない", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ")}, nil)
+		schema := &fakeSchema{}
+		app := newTestApp(itemUsecase, schema)
+
+		err := app.Run(context.Background(), []string{"seed", "--script", script})
+
+		assert.ErrorContains(t, err, "1 items already exist")
+		assert.False(t, schema.seeded)
+	})
+}
+
+func TestApp_CheckDB(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "init.sql")
+	require.NoError(t, os.WriteFile(script, []byte("CREATE TABLE items (id BIGINT);\n"), 0o600))
+
+	t.Run("正常系: すべてのテーブルがある", func(t *testing.T) {
+		app := newTestApp(nil, &fakeSchema{tables: []*database.TableStatus{{Name: "items", Exists: true, Rows: 5}}})
+
+		err := app.Run(context.Background(), []string{"check-db", "--script", script, "-o", "json"})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"connected": true, "tables": [{"name": "items", "exists": true, "rows": 5}]}`, app.stdout.String())
+	})
+
+	t.Run("異常系: テーブルがない", func(t *testing.T) {
+		app := newTestApp(nil, &fakeSchema{tables: []*database.TableStatus{
+			{Name: "items", Exists: true, Rows: 5},
+			{Name: "tags", Exists: false},
+		}})
+
+		err := app.Run(context.Background(), []string{"check-db", "--script", script})
+
+		require.EqualError(t, err, "1 of 2 tables are missing; run migrate")
+		assert.Contains(t, app.stdout.String(), "tags   missing  -")
+	})
+}
+
+func TestApp_Tenant(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedTenantID int64
+	}{
+		{name: "正常系: 省略時は既定のテナント", args: []string{"items", "list"}, expectedTenantID: 1},
+		{name: "正常系: --tenantで指定したテナント", args: []string{"--tenant", "acme", "items", "list"}, expectedTenantID: 2},
+		{name: "正常系: --tenant=の形式", args: []string{"--tenant=acme", "items", "list"}, expectedTenantID: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := new(MockItemUsecase)
+			itemUsecase.On("ListItems", inTenant(tt.expectedTenantID), usecase.ItemFilter{}).Return([]*entity.Item{}, nil)
+			app := newTestApp(itemUsecase, nil)
+
+			err := app.Run(context.Background(), tt.args)
+
+			require.NoError(t, err)
+			itemUsecase.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 存在しないテナント", func(t *testing.T) {
+		app := newTestApp(new(MockItemUsecase), nil)
+
+		err := app.Run(context.Background(), []string{"--tenant", "unknown", "items", "list"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrTenantNotFound)
+	})
+
+	t.Run("異常系: --tenantの値がない", func(t *testing.T) {
+		app := newTestApp(nil, nil)
+
+		err := app.Run(context.Background(), []string{"--tenant"})
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("異常系: サンプルデータは既定のテナント以外に登録できない", func(t *testing.T) {
+		schema := &fakeSchema{}
+		app := newTestApp(new(MockItemUsecase), schema)
+
+		err := app.Run(context.Background(), []string{"--tenant", "acme", "seed"})
+
+		assert.ErrorIs(t, err, ErrUsage)
+		assert.False(t, schema.seeded)
+	})
+}
+
+func TestApp_Tenants(t *testing.T) {
+	t.Run("正常系: テナント一覧", func(t *testing.T) {
+		app := newTestApp(nil, nil)
+
+		err := app.Run(context.Background(), []string{"tenants", "list"})
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(app.stdout.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"ID", "SLUG", "NAME"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"2", "acme", "Acme株式会社"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("正常系: テナントの登録", func(t *testing.T) {
+		app := newTestApp(nil, nil)
+
+		err := app.Run(context.Background(), []string{"tenants", "create", "--slug", "globex", "--name", "Globex", "-o", "json"})
+
+		require.NoError(t, err)
+		var tenant entity.Tenant
+		require.NoError(t, json.Unmarshal(app.stdout.Bytes(), &tenant))
+		assert.Equal(t, int64(3), tenant.ID)
+		assert.Equal(t, "globex", tenant.Slug)
+	})
+
+	t.Run("異常系: スラッグが重複している", func(t *testing.T) {
+		app := newTestApp(nil, nil)
+
+		err := app.Run(context.Background(), []string{"tenants", "create", "--slug", "acme", "--name", "Acme"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+}
+
+func TestApp_Help(t *testing.T) {
+	app := newTestApp(nil, nil)
+
+	require.NoError(t, app.Run(context.Background(), []string{"items", "list", "-h"}))
+	assert.Contains(t, app.stderr.String(), "usage: main items list [flags]")
+}
@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func (a *App) runItems(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("items requires a subcommand: list, get, create, delete")
+	}
+
+	switch args[0] {
+	case "list":
+		return a.listItems(ctx, args[1:])
+	case "get":
+		return a.getItem(ctx, args[1:])
+	case "create":
+		return a.createItem(ctx, args[1:])
+	case "delete":
+		return a.deleteItem(ctx, args[1:])
+	}
+	return usageError("unknown items subcommand %q", args[0])
+}
+
+// filterFlags はitems listとexportの絞り込み条件
+type filterFlags struct {
+	category   string
+	tags       stringsFlag
+	status     string
+	locationID int64
+	attributes keyValueFlag
+}
+
+func addFilterFlags(fs *flag.FlagSet) *filterFlags {
+	f := &filterFlags{attributes: keyValueFlag{}}
+	fs.StringVar(&f.category, "category", "", "カテゴリーで絞り込む")
+	fs.Var(&f.tags, "tag", "指定したすべてのタグを持つアイテムに絞り込む（複数指定可）")
+	fs.StringVar(&f.status, "status", "", "状態で絞り込む（owned / sold / lent など）")
+	fs.Int64Var(&f.locationID, "location-id", 0, "保管場所（配下の保管場所を含む）で絞り込む")
+	fs.Var(f.attributes, "attribute", "カスタム属性で絞り込む（key=value、複数指定可）")
+	return f
+}
+
+func (f *filterFlags) toItemFilter() (usecase.ItemFilter, error) {
+	filter := usecase.ItemFilter{
+		Category:   f.category,
+		Tags:       f.tags,
+		LocationID: f.locationID,
+		Status:     entity.ItemStatus(f.status),
+	}
+	if f.status != "" && !slices.Contains(entity.ValidItemStatuses, filter.Status) {
+		return filter, usageError("invalid status: %s", f.status)
+	}
+	if len(f.attributes) > 0 {
+		filter.Attributes = f.attributes
+	}
+	return filter, nil
+}
+
+// items list
+func (a *App) listItems(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("items list", "items list [flags]")
+	filters := addFilterFlags(fs)
+	output := outputFlag(fs)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	filter, err := filters.toItemFilter()
+	if err != nil {
+		return err
+	}
+
+	items, err := a.itemUsecase.ListItems(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	a.itemUsecase.IncludeBookValue(items)
+
+	return a.write(*output, items, func(w io.Writer) {
+		row(w, "ID", "NAME", "CATEGORY", "BRAND", "PURCHASE_PRICE", "PURCHASE_DATE", "STATUS", "TAGS")
+		for _, item := range items {
+			row(w, item.ID, item.Name, item.Category, item.Brand, item.PurchasePrice, item.PurchaseDate, item.Status, strings.Join(item.Tags, ","))
+		}
+	})
+}
+
+// items get <id>
+func (a *App) getItem(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("items get", "items get <id> [flags]")
+	output := outputFlag(fs)
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+
+	item, err := a.itemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	a.itemUsecase.IncludeBookValue([]*entity.Item{item})
+
+	return a.writeItem(*output, item)
+}
+
+// items create
+func (a *App) createItem(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("items create", "items create --name <name> --category <category> --brand <brand> --purchase-date <YYYY-MM-DD> [flags]")
+	var input usecase.CreateItemInput
+	attributes := keyValueFlag{}
+	fs.StringVar(&input.Name, "name", "", "名前（必須）")
+	fs.StringVar(&input.Category, "category", "", "カテゴリー（必須）")
+	fs.StringVar(&input.Brand, "brand", "", "ブランド（必須）")
+	fs.IntVar(&input.PurchasePrice, "purchase-price", 0, "購入価格")
+	fs.StringVar(&input.PurchaseDate, "purchase-date", "", "購入日（YYYY-MM-DD、必須）")
+	fs.StringVar(&input.SerialNumber, "serial-number", "", "シリアル番号")
+	fs.StringVar(&input.ModelNumber, "model-number", "", "型番")
+	fs.StringVar(&input.Condition, "condition", "", "コンディションランク（S/A/B/C/D）")
+	fs.StringVar(&input.Authenticity, "authenticity", "", "真贋の確認状況")
+	fs.StringVar(&input.WarrantyExpiresAt, "warranty-expires-at", "", "保証期限（YYYY-MM-DD）")
+	fs.Var(attributes, "attribute", "カスタム属性（key=value、複数指定可。値はJSONとして解釈できれば数値・真偽値になる）")
+	output := outputFlag(fs)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		input.Attributes = attributeValues(attributes)
+	}
+
+	item, err := a.itemUsecase.CreateItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create item: %w", err)
+	}
+	a.itemUsecase.IncludeBookValue([]*entity.Item{item})
+
+	return a.writeItem(*output, item)
+}
+
+// items delete <id>
+func (a *App) deleteItem(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("items delete", "items delete <id> [--yes]")
+	yes := fs.Bool("yes", false, "確認せずに削除する")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+
+	if !*yes {
+		item, err := a.itemUsecase.GetItemByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if !a.confirm(fmt.Sprintf("delete item %d (%s)? [y/N]: ", item.ID, item.Name)) {
+			fmt.Fprintln(a.stderr, "canceled")
+			return nil
+		}
+	}
+
+	if err := a.itemUsecase.DeleteItem(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete item: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "deleted item %d\n", id)
+	return nil
+}
+
+// summary
+func (a *App) runSummary(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("summary", "summary [flags]")
+	output := outputFlag(fs)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	summary, err := a.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve summary: %w", err)
+	}
+
+	return a.write(*output, summary, func(w io.Writer) {
+		row(w, "TOTAL", summary.Total)
+		fmt.Fprintln(w)
+		row(w, "CATEGORY", "COUNT")
+		for _, category := range sortedKeys(summary.Categories) {
+			row(w, category, summary.Categories[category])
+		}
+		fmt.Fprintln(w)
+		row(w, "STATUS", "COUNT")
+		for _, status := range sortedKeys(summary.Statuses) {
+			row(w, status, summary.Statuses[status])
+		}
+		fmt.Fprintln(w)
+		row(w, "LOCATION", "ITEMS", "TOTAL_VALUE")
+		for _, location := range summary.Locations {
+			row(w, location.Name, location.ItemCount, location.TotalValue)
+		}
+		if summary.Sales != nil {
+			fmt.Fprintln(w)
+			row(w, "SOLD", "PURCHASE_TOTAL", "SALE_TOTAL", "REALIZED_GAIN")
+			row(w, summary.Sales.SoldCount, summary.Sales.PurchaseTotal, summary.Sales.SaleTotal, summary.Sales.RealizedGain)
+		}
+	})
+}
+
+// writeItem は1件のアイテムを項目ごとの表で出力する
+func (a *App) writeItem(format string, item *entity.Item) error {
+	return a.write(format, item, func(w io.Writer) {
+		row(w, "ID", item.ID)
+		row(w, "NAME", item.Name)
+		row(w, "CATEGORY", item.Category)
+		row(w, "BRAND", item.Brand)
+		row(w, "PURCHASE_PRICE", item.PurchasePrice)
+		row(w, "PURCHASE_DATE", item.PurchaseDate)
+		row(w, "STATUS", item.Status)
+		row(w, "SERIAL_NUMBER", item.SerialNumber)
+		row(w, "MODEL_NUMBER", item.ModelNumber)
+		row(w, "CONDITION", item.Condition)
+		row(w, "AUTHENTICITY", item.Authenticity)
+		row(w, "WARRANTY_EXPIRES_AT", item.WarrantyExpiresAt)
+		row(w, "LOCATION_ID", optionalInt64(item.LocationID))
+		row(w, "TAGS", strings.Join(item.Tags, ","))
+		for _, key := range sortedKeys(item.Attributes) {
+			value, _ := json.Marshal(item.Attributes[key])
+			row(w, "ATTRIBUTE."+key, string(value))
+		}
+		if item.BookValue != nil {
+			row(w, "BOOK_VALUE", *item.BookValue)
+		}
+		row(w, "CREATED_AT", item.CreatedAt.Format("2006-01-02 15:04:05"))
+		row(w, "UPDATED_AT", item.UpdatedAt.Format("2006-01-02 15:04:05"))
+	})
+}
+
+// confirm はstdinからyまたはyesが入力された場合にtrueを返す
+func (a *App) confirm(prompt string) bool {
+	fmt.Fprint(a.stderr, prompt)
+	answer, _ := bufio.NewReader(a.stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func parseID(positional []string) (int64, error) {
+	if len(positional) != 1 {
+		return 0, usageError("exactly one item ID is required")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usageError("ID must be a positive integer: %s", positional[0])
+	}
+	return id, nil
+}
+
+// attributeValues は値をJSONとして解釈できれば数値・真偽値にし、それ以外は文字列のままにする
+func attributeValues(attributes map[string]string) map[string]interface{} {
+	values := make(map[string]interface{}, len(attributes))
+	for key, raw := range attributes {
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		values[key] = value
+	}
+	return values
+}
+
+func optionalInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
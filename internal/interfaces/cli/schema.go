@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// migrate
+func (a *App) runMigrate(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("migrate", "migrate [--script sql/init.sql]")
+	scriptPath := fs.String("script", defaultScript, "適用するSQLファイル（INSERT文は実行しない）")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	script, err := os.ReadFile(*scriptPath)
+	if err != nil {
+		return err
+	}
+
+	count, err := a.schema.Migrate(ctx, string(script))
+	if err != nil {
+		return fmt.Errorf("failed to migrate after %d statements: %w", count, err)
+	}
+	fmt.Fprintf(a.stdout, "applied %d statements from %s\n", count, *scriptPath)
+	return nil
+}
+
+// seed
+func (a *App) runSeed(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("seed", "seed [--script sql/init.sql] [--force]")
+	scriptPath := fs.String("script", defaultScript, "サンプルデータ（INSERT文）を含むSQLファイル")
+	force := fs.Bool("force", false, "アイテムが登録済みでもサンプルデータを追加する")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	script, err := os.ReadFile(*scriptPath)
+	if err != nil {
+		return err
+	}
+
+	// 同じサンプルデータを重ねて登録しないよう、空のデータベースにだけ追加する
+	if !*force {
+		items, err := a.itemUsecase.ListItems(ctx, usecase.ItemFilter{})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		if len(items) > 0 {
+			return fmt.Errorf("%d items already exist; use --force to add the sample data anyway", len(items))
+		}
+	}
+
+	inserted, err := a.schema.Seed(ctx, string(script))
+	if err != nil {
+		return fmt.Errorf("failed to seed: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "inserted %d rows from %s\n", inserted, *scriptPath)
+	return nil
+}
+
+// CheckResult はcheck-dbの結果
+type CheckResult struct {
+	Connected bool                    `json:"connected"`
+	Tables    []*database.TableStatus `json:"tables"`
+}
+
+// check-db
+func (a *App) runCheckDB(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("check-db", "check-db [--script sql/init.sql] [flags]")
+	scriptPath := fs.String("script", defaultScript, "確認するテーブルを定義したSQLファイル")
+	output := outputFlag(fs)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	script, err := os.ReadFile(*scriptPath)
+	if err != nil {
+		return err
+	}
+
+	// 接続はコマンドの実行前に確認しているため、ここではテーブルを確認する
+	tables, err := a.schema.CheckTables(ctx, string(script))
+	if err != nil {
+		return fmt.Errorf("failed to check tables: %w", err)
+	}
+
+	missing := 0
+	for _, table := range tables {
+		if !table.Exists {
+			missing++
+		}
+	}
+
+	if err := a.write(*output, CheckResult{Connected: true, Tables: tables}, func(w io.Writer) {
+		row(w, "TABLE", "STATUS", "ROWS")
+		for _, table := range tables {
+			if table.Exists {
+				row(w, table.Name, "ok", table.Rows)
+			} else {
+				row(w, table.Name, "missing", "-")
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("%d of %d tables are missing; run migrate", missing, len(tables))
+	}
+	return nil
+}
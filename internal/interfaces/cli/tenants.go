@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// CutTenantFlag はコマンド名の前の--tenant（--tenant=<slug>）を取り除き、テナントのスラッグと残りの引数を返す
+func CutTenantFlag(args []string) (string, []string, error) {
+	slug := entity.DefaultTenantSlug
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		if name != "--tenant" && name != "-tenant" {
+			break
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return "", nil, usageError("flag needs an argument: --tenant")
+			}
+			value, args = args[1], args[1:]
+		}
+		slug, args = value, args[1:]
+	}
+	return slug, args, nil
+}
+
+// withTenant はコマンドが操作するテナントをctxに設定する
+func (a *App) withTenant(ctx context.Context, command, slug string) (context.Context, error) {
+	// サンプルデータは既定のテナントに登録される
+	if command == "seed" && slug != entity.DefaultTenantSlug {
+		return nil, usageError("seed inserts sample data into the %s tenant; omit --tenant", entity.DefaultTenantSlug)
+	}
+
+	tenant, err := a.tenantUsecase.GetTenantBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve tenant %s: %w", slug, err)
+	}
+	return usecase.WithTenant(ctx, tenant.ID), nil
+}
+
+func (a *App) runTenants(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("tenants requires a subcommand: list, create")
+	}
+
+	switch args[0] {
+	case "list":
+		return a.listTenants(ctx, args[1:])
+	case "create":
+		return a.createTenant(ctx, args[1:])
+	}
+	return usageError("unknown tenants subcommand %q", args[0])
+}
+
+// tenants list
+func (a *App) listTenants(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("tenants list", "tenants list [flags]")
+	output := outputFlag(fs)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	tenants, err := a.tenantUsecase.GetTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tenants: %w", err)
+	}
+
+	return a.write(*output, tenants, func(w io.Writer) {
+		row(w, "ID", "SLUG", "NAME")
+		for _, tenant := range tenants {
+			row(w, tenant.ID, tenant.Slug, tenant.Name)
+		}
+	})
+}
+
+// tenants create
+func (a *App) createTenant(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("tenants create", "tenants create --slug <slug> --name <name> [flags]")
+	var input usecase.TenantInput
+	fs.StringVar(&input.Slug, "slug", "", "サブドメイン・X-Tenantヘッダーで指定するスラッグ（必須）")
+	fs.StringVar(&input.Name, "name", "", "名前（必須）")
+	output := outputFlag(fs)
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError("unexpected arguments: %v", positional)
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	tenant, err := a.tenantUsecase.CreateTenant(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	return a.write(*output, tenant, func(w io.Writer) {
+		row(w, "ID", "SLUG", "NAME")
+		row(w, tenant.ID, tenant.Slug, tenant.Name)
+	})
+}
@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ファイル形式
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// exportColumns はexportのCSVの列。importはid・status・location_id・tagsを読み飛ばすため、出力したファイルをそのまま登録できる
+var exportColumns = []string{
+	"id", "name", "category", "brand", "purchase_price", "purchase_date",
+	"serial_number", "model_number", "condition", "authenticity", "warranty_expires_at",
+	"status", "location_id", "tags", "attributes",
+}
+
+// ImportResult はimportの1件ごとの結果
+type ImportResult struct {
+	// ファイルの何件目か（1始まり）
+	Row   int    `json:"row"`
+	Name  string `json:"name"`
+	ID    int64  `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// import <file>
+func (a *App) runImport(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("import", "import [flags] <file|->")
+	format := fs.String("format", "", "ファイル形式（json / csv）。省略時は拡張子から判定し、それ以外はjson")
+	output := outputFlag(fs)
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError("exactly one file is required (- for stdin)")
+	}
+	path := positional[0]
+	fileFormat, err := detectFormat(*format, path)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = a.stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var inputs []usecase.CreateItemInput
+	if fileFormat == formatCSV {
+		inputs, err = readCSV(r)
+	} else {
+		err = json.NewDecoder(r).Decode(&inputs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	// 1件ずつ登録し、失敗した行があっても残りの登録を続ける
+	results := make([]*ImportResult, len(inputs))
+	failed := 0
+	for i, input := range inputs {
+		result := &ImportResult{Row: i + 1, Name: input.Name}
+		item, err := a.itemUsecase.CreateItem(ctx, input)
+		if err != nil {
+			result.Error = err.Error()
+			failed++
+		} else {
+			result.ID = item.ID
+		}
+		results[i] = result
+	}
+
+	if err := a.write(*output, results, func(w io.Writer) {
+		row(w, "ROW", "NAME", "ID", "ERROR")
+		for _, result := range results {
+			id := ""
+			if result.ID != 0 {
+				id = strconv.FormatInt(result.ID, 10)
+			}
+			row(w, result.Row, result.Name, id, result.Error)
+		}
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d items failed to import", failed, len(inputs))
+	}
+	return nil
+}
+
+// export [file]
+func (a *App) runExport(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("export", "export [flags] [file|-]")
+	format := fs.String("format", "", "ファイル形式（json / csv）。省略時は拡張子から判定し、それ以外はjson")
+	filters := addFilterFlags(fs)
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usageError("at most one file can be given")
+	}
+	path := "-"
+	if len(positional) == 1 {
+		path = positional[0]
+	}
+	fileFormat, err := detectFormat(*format, path)
+	if err != nil {
+		return err
+	}
+	filter, err := filters.toItemFilter()
+	if err != nil {
+		return err
+	}
+
+	items, err := a.itemUsecase.ListItems(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	a.itemUsecase.IncludeBookValue(items)
+
+	w := a.stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if fileFormat == formatCSV {
+		err = writeCSV(w, items)
+	} else {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(items)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if path != "-" {
+		fmt.Fprintf(a.stderr, "exported %d items to %s\n", len(items), path)
+	}
+	return nil
+}
+
+// detectFormat は指定された形式、またはファイルの拡張子から形式を決める
+func detectFormat(format, path string) (string, error) {
+	switch format {
+	case formatJSON, formatCSV:
+		return format, nil
+	case "":
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			return formatCSV, nil
+		}
+		return formatJSON, nil
+	}
+	return "", usageError("format must be one of json, csv: %s", format)
+}
+
+// readCSV はヘッダー行の列名でCreateItemInputの各フィールドを読み込む。未知の列は読み飛ばす
+func readCSV(r io.Reader) ([]usecase.CreateItemInput, error) {
+	reader := csv.NewReader(r)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	inputs := make([]usecase.CreateItemInput, 0, len(records)-1)
+	for i, record := range records[1:] {
+		var input usecase.CreateItemInput
+		for j, column := range header {
+			value := record[j]
+			switch strings.TrimSpace(column) {
+			case "name":
+				input.Name = value
+			case "category":
+				input.Category = value
+			case "brand":
+				input.Brand = value
+			case "purchase_price":
+				if value == "" {
+					continue
+				}
+				price, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: purchase_price must be an integer: %s", i+2, value)
+				}
+				input.PurchasePrice = price
+			case "purchase_date":
+				input.PurchaseDate = value
+			case "serial_number":
+				input.SerialNumber = value
+			case "model_number":
+				input.ModelNumber = value
+			case "condition":
+				input.Condition = value
+			case "authenticity":
+				input.Authenticity = value
+			case "warranty_expires_at":
+				input.WarrantyExpiresAt = value
+			case "attributes":
+				if value == "" {
+					continue
+				}
+				if err := json.Unmarshal([]byte(value), &input.Attributes); err != nil {
+					return nil, fmt.Errorf("line %d: attributes must be a JSON object: %s", i+2, value)
+				}
+			}
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+func writeCSV(w io.Writer, items []*entity.Item) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		attributes := ""
+		if len(item.Attributes) > 0 {
+			encoded, err := json.Marshal(item.Attributes)
+			if err != nil {
+				return err
+			}
+			attributes = string(encoded)
+		}
+
+		record := []string{
+			strconv.FormatInt(item.ID, 10), item.Name, item.Category, item.Brand,
+			strconv.Itoa(item.PurchasePrice), item.PurchaseDate,
+			item.SerialNumber, item.ModelNumber, item.Condition, item.Authenticity, item.WarrantyExpiresAt,
+			string(item.Status), optionalInt64(item.LocationID), strings.Join(item.Tags, ","), attributes,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
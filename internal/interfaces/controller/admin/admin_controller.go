@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// CacheStatsProvider はキャッシュのヒット・ミスの回数を返す（database.CachedItemRepository）
+type CacheStatsProvider interface {
+	Stats() database.CacheStats
+}
+
+// SlowQueryProvider はクエリのフィンガープリントごとの統計を返す（database.LoggingSqlHandler）
+type SlowQueryProvider interface {
+	SlowQueries(limit int) []database.QueryStats
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// 遅いクエリとして返すフィンガープリントの既定の件数
+const defaultSlowQueryLimit = 10
+
+type AdminHandler struct {
+	cache   CacheStatsProvider
+	queries SlowQueryProvider
+}
+
+// NewAdminHandler はキャッシュを使わない場合はcacheにnilを渡す
+func NewAdminHandler(cache CacheStatsProvider, queries SlowQueryProvider) *AdminHandler {
+	return &AdminHandler{
+		cache:   cache,
+		queries: queries,
+	}
+}
+
+// GetCacheStats はアイテムのキャッシュの統計を返す。キャッシュを使わない場合はenabledがfalseになる
+func (h *AdminHandler) GetCacheStats(c echo.Context) error {
+	if h.cache == nil {
+		return c.JSON(http.StatusOK, database.CacheStats{})
+	}
+	return c.JSON(http.StatusOK, h.cache.Stats())
+}
+
+// GET /admin/slow-queries
+// 起動してからの最大の実行時間が長い順に、limit件（デフォルト: 10）のクエリのフィンガープリントを返す
+func (h *AdminHandler) GetSlowQueries(c echo.Context) error {
+	limit := defaultSlowQueryLimit
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid query",
+				Details: []string{"limit must be a positive integer"},
+			})
+		}
+		limit = n
+	}
+	return c.JSON(http.StatusOK, h.queries.SlowQueries(limit))
+}
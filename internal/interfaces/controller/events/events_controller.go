@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// クライアントが切断後に再接続するまでの待ち時間（ミリ秒）
+const reconnectDelayMs = 3000
+
+type EventHandler struct {
+	eventStreamUsecase usecase.EventStreamUsecase
+	// プロキシにアイドル接続を切断されないよう、コメント行を送る間隔
+	heartbeatInterval time.Duration
+}
+
+func NewEventHandler(eventStreamUsecase usecase.EventStreamUsecase, heartbeatInterval time.Duration) *EventHandler {
+	return &EventHandler{
+		eventStreamUsecase: eventStreamUsecase,
+		heartbeatInterval:  heartbeatInterval,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// GET /items/events
+// アイテムの作成・更新・削除をServer-Sent Eventsで配信する。
+// Last-Event-IDヘッダー（またはlast_event_idクエリ）を指定すると、そのイベント以降を再送してから配信を続ける
+func (h *EventHandler) StreamItemEvents(c echo.Context) error {
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid Last-Event-ID",
+			Details: []string{"Last-Event-ID must be a non-negative integer"},
+		})
+	}
+
+	ctx := c.Request().Context()
+	stream, err := h.eventStreamUsecase.Subscribe(ctx, lastEventID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to subscribe to item events",
+		})
+	}
+	defer stream.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// nginxなどのプロキシにバッファリングさせない
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", reconnectDelayMs); err != nil {
+		return nil
+	}
+	// 再送しきれないほど変更があった場合は、クライアントにアイテムを読み込み直させる。
+	// idを付けないため、次の配信までに再接続した場合も同じLast-Event-IDで再読み込みを促す
+	if stream.Truncated {
+		if _, err := fmt.Fprint(res, "event: reset\ndata: {\"reason\":\"too many events to replay\"}\n\n"); err != nil {
+			return nil
+		}
+	}
+
+	// 再送したイベントのID。IDはコミット順ではなく採番順のため、再送したものだけを配信から除く
+	replayed := make(map[int64]bool, len(stream.Replay))
+	for _, event := range stream.Replay {
+		if err := writeEvent(res, event); err != nil {
+			return nil
+		}
+		replayed[event.ID] = true
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		case event, ok := <-stream.Events:
+			if !ok {
+				// 受信が追いつかない・サーバーが停止する場合。クライアントはLast-Event-IDで再接続する
+				return nil
+			}
+			// 再送済みのイベントは送らない（同じイベントは一度しか発行されないため、確認したら忘れる）
+			if replayed[event.ID] {
+				delete(replayed, event.ID)
+				continue
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+func parseLastEventID(c echo.Context) (int64, error) {
+	value := c.Request().Header.Get("Last-Event-ID")
+	if value == "" {
+		value = c.QueryParam("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid Last-Event-ID: %q", value)
+	}
+	return id, nil
+}
+
+// イベントを1件書き込む。IDのないイベント（変更履歴を使わない構成）はid行を省略する
+func writeEvent(res *echo.Response, event *entity.OutboxEvent) error {
+	body, err := event.Body()
+	if err != nil {
+		return err
+	}
+
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(res, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.EventType, body)
+	return err
+}
@@ -0,0 +1,312 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeEventBus はテストから発行したイベントをそのまま購読者に渡すEventBus
+type fakeEventBus struct {
+	events chan *entity.OutboxEvent
+	// 購読したテナント
+	tenantID atomic.Int64
+	// 購読を解除したら閉じる
+	unsubscribed chan struct{}
+}
+
+func newFakeEventBus() *fakeEventBus {
+	return &fakeEventBus{
+		events:       make(chan *entity.OutboxEvent, 10),
+		unsubscribed: make(chan struct{}),
+	}
+}
+
+func (b *fakeEventBus) Publish(event *entity.OutboxEvent) {
+	b.events <- event
+}
+
+func (b *fakeEventBus) Subscribe(tenantID int64) (<-chan *entity.OutboxEvent, func()) {
+	b.tenantID.Store(tenantID)
+	return b.events, func() { close(b.unsubscribed) }
+}
+
+// fakeOutboxRepository は変更履歴の再送だけを実装したリポジトリ
+type fakeOutboxRepository struct {
+	usecase.OutboxRepository
+	events []*entity.OutboxEvent
+	// FindAfterに渡されたID（呼ばれていなければ-1）
+	afterID atomic.Int64
+}
+
+func (r *fakeOutboxRepository) FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.OutboxEvent, error) {
+	r.afterID.Store(afterID)
+	var events []*entity.OutboxEvent
+	for _, event := range r.events {
+		if event.ID > afterID && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func itemEvent(id int64) *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		ID:         id,
+		EventType:  entity.EventItemUpdated,
+		ItemID:     1,
+		Payload:    []byte(`{"id":1}`),
+		OccurredAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		TenantID:   2,
+	}
+}
+
+// newEventServer はテナント2のリクエストとしてGET /items/eventsを処理するサーバーを起動する
+func newEventServer(t *testing.T, heartbeatInterval time.Duration) (*httptest.Server, *fakeEventBus, *fakeOutboxRepository) {
+	t.Helper()
+
+	bus := newFakeEventBus()
+	outboxRepo := &fakeOutboxRepository{events: []*entity.OutboxEvent{itemEvent(1), itemEvent(2), itemEvent(3), itemEvent(4)}}
+	outboxRepo.afterID.Store(-1)
+	handler := NewEventHandler(usecase.NewEventStreamUsecase(bus, outboxRepo), heartbeatInterval)
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(usecase.WithTenant(c.Request().Context(), 2)))
+			return next(c)
+		}
+	})
+	e.GET("/items/events", handler.StreamItemEvents)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server, bus, outboxRepo
+}
+
+// connect はSSEに接続する。応答しない場合はタイムアウトでテストを失敗させる
+func connect(t *testing.T, url string, header map[string]string) (*http.Response, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cancel()
+		res.Body.Close()
+	})
+	return res, cancel
+}
+
+// readFrame は空行で区切られた1つのメッセージを読み込む
+func readFrame(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
+// eventFrame はイベントのメッセージの期待値
+func eventFrame(t *testing.T, id int64) string {
+	t.Helper()
+
+	event := itemEvent(id)
+	body, err := event.Body()
+	require.NoError(t, err)
+	return fmt.Sprintf("id: %d\nevent: %s\ndata: %s", id, event.EventType, body)
+}
+
+func TestEventHandler_StreamItemEvents_Heartbeat(t *testing.T) {
+	server, bus, _ := newEventServer(t, 10*time.Millisecond)
+
+	res, cancel := connect(t, server.URL+"/items/events", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+	assert.Equal(t, "no-cache", res.Header.Get(echo.HeaderCacheControl))
+	assert.Equal(t, "no", res.Header.Get("X-Accel-Buffering"))
+	body := bufio.NewReader(res.Body)
+
+	t.Run("正常系: 最初に再接続の待ち時間を送る", func(t *testing.T) {
+		assert.Equal(t, "retry: 3000", readFrame(t, body))
+	})
+
+	t.Run("正常系: イベントがない間はハートビートを送る", func(t *testing.T) {
+		assert.Equal(t, ": heartbeat", readFrame(t, body))
+		assert.Equal(t, ": heartbeat", readFrame(t, body))
+	})
+
+	t.Run("正常系: ハートビートの間に発行されたイベントを送る", func(t *testing.T) {
+		bus.Publish(itemEvent(5))
+
+		frame := readFrame(t, body)
+		for frame == ": heartbeat" {
+			frame = readFrame(t, body)
+		}
+		assert.Equal(t, eventFrame(t, 5), frame)
+		assert.Equal(t, int64(2), bus.tenantID.Load())
+	})
+
+	t.Run("正常系: クライアントが切断したら購読を解除する", func(t *testing.T) {
+		cancel()
+
+		select {
+		case <-bus.unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("購読が解除されなかった")
+		}
+	})
+}
+
+func TestEventHandler_StreamItemEvents_LastEventID(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		header map[string]string
+		// 再送されるイベントと、再送の後に4と5を発行したときに送られるイベント
+		expectedReplay  []int64
+		expectedLive    []int64
+		expectedAfterID int64
+	}{
+		{
+			name:            "正常系: Last-Event-IDより後のイベントを再送する",
+			header:          map[string]string{"Last-Event-ID": "2"},
+			expectedReplay:  []int64{3, 4},
+			expectedLive:    []int64{5},
+			expectedAfterID: 2,
+		},
+		{
+			name:            "正常系: last_event_idクエリでも再送する",
+			query:           "?last_event_id=3",
+			expectedReplay:  []int64{4},
+			expectedLive:    []int64{5},
+			expectedAfterID: 3,
+		},
+		{
+			name:            "正常系: Last-Event-IDヘッダーをクエリより優先する",
+			query:           "?last_event_id=1",
+			header:          map[string]string{"Last-Event-ID": "3"},
+			expectedReplay:  []int64{4},
+			expectedLive:    []int64{5},
+			expectedAfterID: 3,
+		},
+		{
+			name:            "正常系: Last-Event-IDがなければ再送しない",
+			expectedLive:    []int64{4, 5},
+			expectedAfterID: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, bus, outboxRepo := newEventServer(t, time.Hour)
+
+			res, _ := connect(t, server.URL+"/items/events"+tt.query, tt.header)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			body := bufio.NewReader(res.Body)
+			require.Equal(t, "retry: 3000", readFrame(t, body))
+			for _, id := range tt.expectedReplay {
+				assert.Equal(t, eventFrame(t, id), readFrame(t, body))
+			}
+			assert.Equal(t, tt.expectedAfterID, outboxRepo.afterID.Load())
+
+			// 再送の前に購読しているため、再送したイベントが配信されても重複して送らない
+			bus.Publish(itemEvent(4))
+			bus.Publish(itemEvent(5))
+			for _, id := range tt.expectedLive {
+				assert.Equal(t, eventFrame(t, id), readFrame(t, body))
+			}
+
+			// サーバーの停止などで購読が切断されたら、クライアントに再接続させるため応答を終える
+			close(bus.events)
+			_, err := body.ReadString('\n')
+			assert.Error(t, err)
+		})
+	}
+}
+
+// IDはコミット順に発行されないため、再送していないイベントはIDが前後しても送る
+func TestEventHandler_StreamItemEvents_OutOfOrderIDs(t *testing.T) {
+	server, bus, _ := newEventServer(t, time.Hour)
+
+	res, _ := connect(t, server.URL+"/items/events", map[string]string{"Last-Event-ID": "3"})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body := bufio.NewReader(res.Body)
+	require.Equal(t, "retry: 3000", readFrame(t, body))
+	require.Equal(t, eventFrame(t, 4), readFrame(t, body))
+
+	bus.Publish(itemEvent(11))
+	bus.Publish(itemEvent(10))
+	bus.Publish(itemEvent(4))
+	bus.Publish(itemEvent(12))
+
+	assert.Equal(t, eventFrame(t, 11), readFrame(t, body))
+	assert.Equal(t, eventFrame(t, 10), readFrame(t, body))
+	assert.Equal(t, eventFrame(t, 12), readFrame(t, body))
+}
+
+func TestEventHandler_StreamItemEvents_TooManyEventsToReplay(t *testing.T) {
+	server, bus, outboxRepo := newEventServer(t, time.Hour)
+	outboxRepo.events = nil
+	for id := int64(1); id <= 6000; id++ {
+		outboxRepo.events = append(outboxRepo.events, itemEvent(id))
+	}
+
+	res, _ := connect(t, server.URL+"/items/events", map[string]string{"Last-Event-ID": "1"})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body := bufio.NewReader(res.Body)
+	require.Equal(t, "retry: 3000", readFrame(t, body))
+
+	// 再送せずに読み込み直しを促し、その後の変更は配信を続ける
+	assert.Equal(t, "event: reset\ndata: {\"reason\":\"too many events to replay\"}", readFrame(t, body))
+	bus.Publish(itemEvent(6001))
+	assert.Equal(t, eventFrame(t, 6001), readFrame(t, body))
+}
+
+func TestEventHandler_StreamItemEvents_InvalidLastEventID(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		header map[string]string
+	}{
+		{name: "異常系: 負のLast-Event-ID", header: map[string]string{"Last-Event-ID": "-1"}},
+		{name: "異常系: 数値ではないLast-Event-ID", header: map[string]string{"Last-Event-ID": "abc"}},
+		{name: "異常系: 数値ではないlast_event_idクエリ", query: "?last_event_id=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, outboxRepo := newEventServer(t, time.Hour)
+
+			res, _ := connect(t, server.URL+"/items/events"+tt.query, tt.header)
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+			assert.Equal(t, int64(-1), outboxRepo.afterID.Load())
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InsuranceHandler struct {
+	insuranceUsecase usecase.InsuranceUsecase
+}
+
+func NewInsuranceHandler(insuranceUsecase usecase.InsuranceUsecase) *InsuranceHandler {
+	return &InsuranceHandler{
+		insuranceUsecase: insuranceUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// GET /insurance/policies
+// 保険契約の一覧を割り当てられたアイテムの評価額の合計とともに返す
+func (h *InsuranceHandler) GetPolicies(c echo.Context) error {
+	policies, err := h.insuranceUsecase.GetPolicies(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve insurance policies",
+		})
+	}
+
+	return c.JSON(http.StatusOK, policies)
+}
+
+// GET /insurance/policies/:id
+// 保険契約を割り当てられたアイテムとともに返す
+func (h *InsuranceHandler) GetPolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid insurance policy ID",
+		})
+	}
+
+	policy, err := h.insuranceUsecase.GetPolicy(c.Request().Context(), id)
+	if err != nil {
+		return insuranceError(c, err, "failed to retrieve insurance policy")
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *InsuranceHandler) CreatePolicy(c echo.Context) error {
+	var input usecase.PolicyInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	policy, err := h.insuranceUsecase.CreatePolicy(c.Request().Context(), input)
+	if err != nil {
+		return insuranceError(c, err, "failed to create insurance policy")
+	}
+
+	return c.JSON(http.StatusCreated, policy)
+}
+
+func (h *InsuranceHandler) UpdatePolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid insurance policy ID",
+		})
+	}
+
+	var input usecase.PolicyInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	policy, err := h.insuranceUsecase.UpdatePolicy(c.Request().Context(), id, input)
+	if err != nil {
+		return insuranceError(c, err, "failed to update insurance policy")
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *InsuranceHandler) DeletePolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid insurance policy ID",
+		})
+	}
+
+	if err := h.insuranceUsecase.DeletePolicy(c.Request().Context(), id); err != nil {
+		return insuranceError(c, err, "failed to delete insurance policy")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PUT /items/:id/insurance
+// リクエストボディの policy_id の保険契約にアイテムを割り当てる（nullで割り当てを解除）
+func (h *InsuranceHandler) AssignItem(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.AssignPolicyInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	item, err := h.insuranceUsecase.AssignItem(c.Request().Context(), itemID, input)
+	if err != nil {
+		return insuranceError(c, err, "failed to assign insurance policy")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// GET /reports/insurance?threshold=100000&days=30
+// 閾値以上の未加入アイテム、評価額が補償限度額を超える保険契約、days日以内に終了する保険契約を返す
+func (h *InsuranceHandler) GetCoverageReport(c echo.Context) error {
+	query := usecase.CoverageReportQuery{
+		Threshold:          usecase.DefaultUninsuredThreshold,
+		ExpiringWithinDays: usecase.DefaultPolicyExpiringDays,
+	}
+
+	if value := c.QueryParam("threshold"); value != "" {
+		threshold, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "threshold must be an integer",
+			})
+		}
+		query.Threshold = threshold
+	}
+	if value := c.QueryParam("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "days must be an integer",
+			})
+		}
+		query.ExpiringWithinDays = days
+	}
+
+	report, err := h.insuranceUsecase.GetCoverageReport(c.Request().Context(), query)
+	if err != nil {
+		return insuranceError(c, err, "failed to create insurance report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func insuranceError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	case domainErrors.IsConflictError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "insurance policy is in use",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
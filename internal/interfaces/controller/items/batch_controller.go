@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// バッチリクエストの形式
+type BatchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []BatchOperationRequest `json:"operations"`
+}
+
+type BatchOperationRequest struct {
+	Op   string          `json:"op"`
+	ID   int64           `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// バッチレスポンスの形式
+type BatchResponse struct {
+	Atomic    bool                     `json:"atomic"`
+	Committed bool                     `json:"committed"`
+	Results   []BatchOperationResponse `json:"results"`
+}
+
+type BatchOperationResponse struct {
+	Index  int          `json:"index"`
+	Op     string       `json:"op"`
+	ID     int64        `json:"id,omitempty"`
+	Status int          `json:"status"`
+	Item   *entity.Item `json:"item,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+func (h *ItemHandler) BatchItems(c echo.Context) error {
+	var req BatchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	input, validationErrors := toBatchInput(req)
+	if len(validationErrors) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: validationErrors,
+		})
+	}
+
+	result, err := h.itemUsecase.ExecuteBatch(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to execute batch",
+		})
+	}
+
+	res := BatchResponse{
+		Atomic:    result.Atomic,
+		Committed: result.Committed,
+		Results:   make([]BatchOperationResponse, len(result.Results)),
+	}
+
+	status := http.StatusOK
+	for i, r := range result.Results {
+		res.Results[i] = toBatchOperationResponse(r)
+		// アトミック実行が失敗した場合は、失敗した操作のステータスを全体のステータスとする
+		if result.Atomic && !result.Committed && r.Err != nil && !domainErrors.IsBatchAbortedError(r.Err) {
+			status = res.Results[i].Status
+		}
+	}
+
+	return c.JSON(status, res)
+}
+
+// リクエストをユースケースの入力に変換する。形式の誤りは操作のインデックス付きで返す。
+// 非アトミックの場合は誤りのある操作だけをその操作の400として返すため、操作のErrに設定する
+func toBatchInput(req BatchRequest) (usecase.BatchInput, []string) {
+	var errs []string
+	input := usecase.BatchInput{
+		Atomic:     req.Atomic,
+		Operations: make([]usecase.BatchOperation, 0, len(req.Operations)),
+	}
+
+	if len(req.Operations) == 0 {
+		errs = append(errs, "operations is required")
+	}
+
+	for i, opReq := range req.Operations {
+		op, opErrs := toBatchOperation(opReq)
+		if len(opErrs) > 0 {
+			if req.Atomic {
+				for _, e := range opErrs {
+					errs = append(errs, fmt.Sprintf("operations[%d]: %s", i, e))
+				}
+			} else {
+				op.Err = fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(opErrs, ", "))
+			}
+		}
+		input.Operations = append(input.Operations, op)
+	}
+
+	return input, errs
+}
+
+func toBatchOperation(req BatchOperationRequest) (usecase.BatchOperation, []string) {
+	var errs []string
+	op := usecase.BatchOperation{
+		Op: usecase.BatchOperationType(req.Op),
+		ID: req.ID,
+	}
+
+	switch op.Op {
+	case usecase.BatchOperationCreate:
+		var data usecase.CreateItemInput
+		if err := json.Unmarshal(req.Data, &data); err != nil {
+			return op, []string{"invalid data"}
+		}
+		errs = append(errs, validateCreateItemInput(data)...)
+		op.Create = &data
+	case usecase.BatchOperationUpdate:
+		if op.ID <= 0 {
+			errs = append(errs, "id is required")
+		}
+		var data usecase.UpdateItemInput
+		if err := json.Unmarshal(req.Data, &data); err != nil {
+			return op, append(errs, "invalid data")
+		}
+		errs = append(errs, validateUpdateItemInput(data)...)
+		op.Update = &data
+	case usecase.BatchOperationDelete:
+		if op.ID <= 0 {
+			errs = append(errs, "id is required")
+		}
+	default:
+		errs = append(errs, "op must be one of: create, update, delete")
+	}
+
+	return op, errs
+}
+
+func toBatchOperationResponse(r usecase.BatchOperationResult) BatchOperationResponse {
+	res := BatchOperationResponse{
+		Index: r.Index,
+		Op:    string(r.Op),
+		ID:    r.ID,
+		Item:  r.Item,
+	}
+
+	if r.Err == nil {
+		switch r.Op {
+		case usecase.BatchOperationCreate:
+			res.Status = http.StatusCreated
+		case usecase.BatchOperationDelete:
+			res.Status = http.StatusNoContent
+		default:
+			res.Status = http.StatusOK
+		}
+		return res
+	}
+
+	switch {
+	case domainErrors.IsBatchAbortedError(r.Err):
+		res.Status = http.StatusFailedDependency
+		res.Error = "batch aborted"
+	case domainErrors.IsNotFoundError(r.Err):
+		res.Status = http.StatusNotFound
+		res.Error = "item not found"
+	case domainErrors.IsValidationError(r.Err):
+		res.Status = http.StatusBadRequest
+		res.Error = r.Err.Error()
+	case domainErrors.IsDuplicateError(r.Err):
+		res.Status = http.StatusConflict
+		res.Error = r.Err.Error()
+	default:
+		res.Status = http.StatusInternalServerError
+		res.Error = fmt.Sprintf("failed to %s item", r.Op)
+	}
+
+	return res
+}
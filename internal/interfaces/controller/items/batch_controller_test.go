@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeItemRepository はバッチで使う操作だけを実装し、呼ばれた順に記録するリポジトリ
+type fakeItemRepository struct {
+	usecase.ItemRepository
+	calls []string
+}
+
+func (r *fakeItemRepository) CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	r.calls = append(r.calls, "CreateBatch")
+	for i, item := range items {
+		item.ID = int64(10 + i)
+	}
+	return items, nil
+}
+
+func (r *fakeItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.calls = append(r.calls, "Create")
+	item.ID = 10
+	return item, nil
+}
+
+func (r *fakeItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	items := make([]*entity.Item, len(ids))
+	for i, id := range ids {
+		items[i] = &entity.Item{ID: id}
+	}
+	return items, nil
+}
+
+func (r *fakeItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	r.calls = append(r.calls, "DeleteBatch")
+	return nil
+}
+
+func (r *fakeItemRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestItemHandler_BatchItems(t *testing.T) {
+	validCreate := `{"op": "create", "data": {"name": "カルティエ タンク", "category": "時計", "brand": "Cartier", "purchase_price": 400000, "purchase_date": "2023-06-01"}}`
+	invalidCreate := `{"op": "create", "data": {"category": "時計", "brand": "Cartier", "purchase_price": -1, "purchase_date": "2023-06-01"}}`
+
+	tests := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedStatuses []int
+		expectedCalls    []string
+		expectedError    string
+	}{
+		{
+			name:             "正常系: 非アトミックでは形式に誤りのある操作だけを400として残りを実行する",
+			body:             `{"atomic": false, "operations": [` + validCreate + `, ` + invalidCreate + `, {"op": "delete", "id": 3}, {"op": "move"}]}`,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []int{http.StatusCreated, http.StatusBadRequest, http.StatusNoContent, http.StatusBadRequest},
+			expectedCalls:    []string{"Create", "DeleteBatch"},
+		},
+		{
+			name:             "正常系: 操作はリクエストの順に実行する",
+			body:             `{"atomic": true, "operations": [{"op": "delete", "id": 3}, ` + validCreate + `, {"op": "delete", "id": 4}]}`,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []int{http.StatusNoContent, http.StatusCreated, http.StatusNoContent},
+			expectedCalls:    []string{"DeleteBatch", "CreateBatch", "DeleteBatch"},
+		},
+		{
+			name:           "異常系: アトミックでは形式に誤りのある操作があればリクエスト全体を400にする",
+			body:           `{"atomic": true, "operations": [` + validCreate + `, ` + invalidCreate + `]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+		{
+			name:           "異常系: 操作が空",
+			body:           `{"atomic": false, "operations": []}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeItemRepository{}
+			handler := NewItemHandler(usecase.NewItemUsecase(repo))
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/batch", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, handler.BatchItems(e.NewContext(req, rec)))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.expectedCalls, repo.calls)
+			if tt.expectedError != "" {
+				var res ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, tt.expectedError, res.Error)
+				return
+			}
+
+			var res BatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			require.Len(t, res.Results, len(tt.expectedStatuses))
+			for i, status := range tt.expectedStatuses {
+				assert.Equal(t, i, res.Results[i].Index)
+				assert.Equal(t, status, res.Results[i].Status, res.Results[i].Error)
+			}
+		})
+	}
+}
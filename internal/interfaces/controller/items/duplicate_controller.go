@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GET /items/duplicates
+// クエリパラメータ min_score（0〜1）, limit, category で重複候補を絞り込める
+func (h *ItemHandler) GetDuplicates(c echo.Context) error {
+	var query usecase.DuplicateQuery
+	var errs []string
+
+	if v := c.QueryParam("min_score"); v != "" {
+		score, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, "min_score must be a number")
+		}
+		query.MinScore = score
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "limit must be an integer")
+		}
+		query.Limit = limit
+	}
+	query.Category = c.QueryParam("category")
+
+	if len(errs) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid query",
+			Details: errs,
+		})
+	}
+
+	candidates, err := h.itemUsecase.FindDuplicateCandidates(c.Request().Context(), query)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid query",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to find duplicates",
+		})
+	}
+
+	return c.JSON(http.StatusOK, candidates)
+}
+
+// POST /items/:id/merge
+// リクエストボディの duplicate_id のアイテムを :id のアイテムに統合する
+func (h *ItemHandler) MergeItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.MergeItemsInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+	if input.DuplicateID <= 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{"duplicate_id is required"},
+		})
+	}
+
+	item, err := h.itemUsecase.MergeItems(c.Request().Context(), id, input)
+	if err != nil {
+		switch {
+		case domainErrors.IsNotFoundError(err):
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "item not found",
+			})
+		case domainErrors.IsValidationError(err):
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation failed",
+				Details: []string{err.Error()},
+			})
+		case domainErrors.IsDuplicateError(err):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "duplicate item",
+				Details: []string{err.Error()},
+			})
+		case domainErrors.IsConflictError(err):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "item cannot be merged",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to merge items",
+		})
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// GET /items/:id/merges
+// アイテムに統合されたアイテムの履歴を返す
+func (h *ItemHandler) GetMergeHistory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	merges, err := h.itemUsecase.GetMergeHistory(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "item not found",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve merge history",
+		})
+	}
+
+	return c.JSON(http.StatusOK, merges)
+}
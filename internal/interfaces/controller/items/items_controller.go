@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 	"Aicon-assignment/internal/usecase"
 
@@ -26,14 +30,108 @@ type ErrorResponse struct {
 	Details []string `json:"details,omitempty"`
 }
 
+// GET /items
+// クエリパラメータ category, tag（複数指定可、すべてを持つアイテム）, attr.<キー>, location_id（配下の保管場所を含む）, status で絞り込める。
+// include=tco で総保有コスト（購入価格 + 整備費用）を含める。帳簿価額は常に含める
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	filter, err := parseItemFilter(c)
 	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid filter",
+			Details: []string{err.Error()},
+		})
+	}
+
+	items, err := h.itemUsecase.ListItems(c.Request().Context(), filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid filter",
+				Details: []string{err.Error()},
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "failed to retrieve items",
 		})
 	}
 
+	if includesTotalCostOfOwnership(c) {
+		if err := h.itemUsecase.IncludeTotalCostOfOwnership(c.Request().Context(), items); err != nil {
+			return c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "failed to retrieve items",
+			})
+		}
+	}
+
+	h.itemUsecase.IncludeBookValue(items)
+
+	return c.JSON(http.StatusOK, items)
+}
+
+// include=tco が指定されているか
+func includesTotalCostOfOwnership(c echo.Context) bool {
+	return c.QueryParam("include") == "tco"
+}
+
+// 属性の絞り込みに使うクエリパラメータの接頭辞
+const attributeQueryPrefix = "attr."
+
+func parseItemFilter(c echo.Context) (usecase.ItemFilter, error) {
+	params := c.QueryParams()
+	filter := usecase.ItemFilter{
+		Category: params.Get("category"),
+		Tags:     params["tag"],
+		Status:   entity.ItemStatus(params.Get("status")),
+	}
+
+	if v := params.Get("location_id"); v != "" {
+		locationID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || locationID <= 0 {
+			return usecase.ItemFilter{}, errors.New("location_id must be a positive integer")
+		}
+		filter.LocationID = locationID
+	}
+
+	for key, values := range params {
+		if !strings.HasPrefix(key, attributeQueryPrefix) || len(values) == 0 {
+			continue
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[strings.TrimPrefix(key, attributeQueryPrefix)] = values[0]
+	}
+
+	return filter, nil
+}
+
+// GET /items/attributes
+// カテゴリーごとのカスタム属性のスキーマを返す
+func (h *ItemHandler) GetAttributeSchemas(c echo.Context) error {
+	schemas := make(map[string][]entity.AttributeDefinition)
+	for _, category := range entity.GetValidCategories() {
+		schemas[category] = entity.GetAttributeSchema(category)
+	}
+
+	return c.JSON(http.StatusOK, schemas)
+}
+
+// GET /items/lookup?serial=...&brand=...
+// 入荷時の確認用にシリアル番号でアイテムを検索する（brandは任意）
+func (h *ItemHandler) LookupItems(c echo.Context) error {
+	items, err := h.itemUsecase.LookupItemsBySerial(c.Request().Context(), c.QueryParam("serial"), c.QueryParam("brand"))
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid lookup",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to look up items",
+		})
+	}
+
 	return c.JSON(http.StatusOK, items)
 }
 
@@ -58,6 +156,16 @@ func (h *ItemHandler) GetItem(c echo.Context) error {
 		})
 	}
 
+	if includesTotalCostOfOwnership(c) {
+		if err := h.itemUsecase.IncludeTotalCostOfOwnership(c.Request().Context(), []*entity.Item{item}); err != nil {
+			return c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "failed to retrieve item",
+			})
+		}
+	}
+
+	h.itemUsecase.IncludeBookValue([]*entity.Item{item})
+
 	return c.JSON(http.StatusOK, item)
 }
 
@@ -85,6 +193,12 @@ func (h *ItemHandler) CreateItem(c echo.Context) error {
 				Details: []string{err.Error()},
 			})
 		}
+		if domainErrors.IsDuplicateError(err) {
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "duplicate item",
+				Details: []string{err.Error()},
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "failed to create item",
 		})
@@ -117,8 +231,9 @@ func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
-
-// 💡 新規追加: UpdateItemハンドラ
+// PATCH /items/:id
+// Content-Typeにより application/json（従来の部分更新）、application/merge-patch+json（RFC 7396）、
+// application/json-patch+json（RFC 6902）を受け付ける
 func (h *ItemHandler) UpdateItem(c echo.Context) error {
     idStr := c.Param("id")
     id, err := strconv.ParseInt(idStr, 10, 64)
@@ -129,9 +244,18 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
     }
 
     var input usecase.UpdateItemInput
-    if err := c.Bind(&input); err != nil {
-        return c.JSON(http.StatusBadRequest, ErrorResponse{
-            Error: "invalid request format",
+    switch mediaType(c.Request().Header.Get(echo.HeaderContentType)) {
+    case MIMEApplicationMergePatch, MIMEApplicationJSONPatch:
+        return h.patchItem(c, id)
+    case echo.MIMEApplicationJSON, "":
+        if err := c.Bind(&input); err != nil {
+            return c.JSON(http.StatusBadRequest, ErrorResponse{
+                Error: "invalid request format",
+            })
+        }
+    default:
+        return c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{
+            Error: "unsupported content type",
         })
     }
 
@@ -143,22 +267,131 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
         })
     }
 
-    item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
-    if err != nil {
-        if domainErrors.IsNotFoundError(err) {
-            return c.JSON(http.StatusNotFound, ErrorResponse{
-                Error: "item not found",
-            })
-        }
-        return c.JSON(http.StatusInternalServerError, ErrorResponse{
-            Error: "failed to update item",
-        })
-    }
+    return h.updateItem(c, id, input)
+}
+
+// Merge Patch / JSON Patchによる更新
+func (h *ItemHandler) patchItem(c echo.Context, id int64) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	var input usecase.UpdateItemInput
+	if mediaType(c.Request().Header.Get(echo.HeaderContentType)) == MIMEApplicationMergePatch {
+		var validationErrors []string
+		if input, validationErrors = decodeMergePatch(body); len(validationErrors) > 0 {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation failed",
+				Details: validationErrors,
+			})
+		}
+	} else {
+		// JSON Patchは現在のドキュメントに対して適用する
+		current, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
+		if err != nil {
+			return h.updateItemError(c, err)
+		}
+		if input, err = decodeJSONPatch(body, current); err != nil {
+			if errors.Is(err, errPatchTestFailed) {
+				return c.JSON(http.StatusConflict, ErrorResponse{
+					Error:   "patch test failed",
+					Details: []string{err.Error()},
+				})
+			}
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid patch",
+				Details: []string{err.Error()},
+			})
+		}
+	}
+
+	// 変更がないパッチは現在のアイテムをそのまま返す
+	if input.IsEmpty() {
+		item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
+		if err != nil {
+			return h.updateItemError(c, err)
+		}
+		return c.JSON(http.StatusOK, item)
+	}
+
+	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: validationErrors,
+		})
+	}
+
+	return h.updateItem(c, id, input)
+}
+
+func (h *ItemHandler) updateItem(c echo.Context, id int64, input usecase.UpdateItemInput) error {
+	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
+	if err != nil {
+		return h.updateItemError(c, err)
+	}
 
-    return c.JSON(http.StatusOK, item)
+	return c.JSON(http.StatusOK, item)
 }
 
+func (h *ItemHandler) updateItemError(c echo.Context, err error) error {
+	if domainErrors.IsNotFoundError(err) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "item not found",
+		})
+	}
+	if domainErrors.IsValidationError(err) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	}
+	if domainErrors.IsDuplicateError(err) {
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "duplicate item",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: "failed to update item",
+	})
+}
 
+// PUT /items/:id
+// 変更可能なフィールドをすべて置き換える
+func (h *ItemHandler) ReplaceItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.ReplaceItemInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	// PUTは全フィールド必須のため、作成時と同じバリデーションを行う
+	if validationErrors := validateCreateItemInput(usecase.CreateItemInput(input)); len(validationErrors) > 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: validationErrors,
+		})
+	}
+
+	item, err := h.itemUsecase.ReplaceItem(c.Request().Context(), id, input)
+	if err != nil {
+		return h.updateItemError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
 
 func (h *ItemHandler) GetSummary(c echo.Context) error {
 	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
@@ -171,6 +404,25 @@ func (h *ItemHandler) GetSummary(c echo.Context) error {
 	return c.JSON(http.StatusOK, summary)
 }
 
+// GET /reports/book-value
+// クエリパラメータ as_of（YYYY-MM-DD、既定は今日）時点の帳簿価額をカテゴリーごとに集計する
+func (h *ItemHandler) GetBookValueReport(c echo.Context) error {
+	report, err := h.itemUsecase.GetBookValueReport(c.Request().Context(), c.QueryParam("as_of"))
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid query",
+				Details: []string{err.Error()},
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve book value report",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
 func validateCreateItemInput(input usecase.CreateItemInput) []string {
 	var errs []string
 
@@ -197,7 +449,7 @@ func validateCreateItemInput(input usecase.CreateItemInput) []string {
 // 💡 新規追加: validateUpdateItemInput関数
 func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
     var errs []string
-    
+
     // PATCHは部分更新のため、すべてのフィールドが必須ではない
     // ただし、もし提供された場合はバリデーションする
     if input.PurchasePrice != nil && *input.PurchasePrice < 0 {
@@ -206,14 +458,29 @@ func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
     if input.Name != nil && *input.Name == "" {
         errs = append(errs, "name cannot be empty")
     }
+    if input.Category != nil && *input.Category == "" {
+        errs = append(errs, "category cannot be empty")
+    }
     if input.Brand != nil && *input.Brand == "" {
         errs = append(errs, "brand cannot be empty")
     }
-    
+    if input.PurchaseDate != nil && *input.PurchaseDate == "" {
+        errs = append(errs, "purchase_date cannot be empty")
+    }
+    // serial_number, model_number, condition, authenticity, warranty_expires_atは空文字でクリアできる
+
     // どのフィールドも提供されていない場合はエラーを返す
-    if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-        errs = append(errs, "at least one field (name, brand, or purchase_price) is required for update")
+    if input.IsEmpty() {
+        errs = append(errs, "at least one field (name, category, brand, purchase_price, purchase_date, serial_number, model_number, condition, authenticity, warranty_expires_at, or attributes) is required for update")
     }
 
     return errs
-}
\ No newline at end of file
+}
+
+// Content-Typeからパラメータ（charsetなど）を除いたメディアタイプを返す
+func mediaType(contentType string) string {
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// PATCHで受け付けるContent-Type
+const (
+	MIMEApplicationMergePatch = "application/merge-patch+json" // RFC 7396
+	MIMEApplicationJSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+// PATCHで更新可能なフィールド。値はnullを指定してクリアできるかどうか
+var patchableFields = map[string]bool{
+	"name":                false,
+	"category":            false,
+	"brand":               false,
+	"purchase_price":      false,
+	"purchase_date":       false,
+	"serial_number":       true,
+	"model_number":        true,
+	"condition":           true,
+	"authenticity":        true,
+	"warranty_expires_at": true,
+	"attributes":          false,
+}
+
+// JSON Patchの"test"操作が失敗したことを表す
+var errPatchTestFailed = errors.New("test operation failed")
+
+// JSON Patch (RFC 6902) の1操作
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSON Merge Patch (RFC 7396) のドキュメントをUpdateItemInputに変換する。
+// メンバーごとに置き換え（nullは削除）として扱い、attributesはキー単位でマージする
+func decodeMergePatch(body []byte) (usecase.UpdateItemInput, []string) {
+	var input usecase.UpdateItemInput
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return input, []string{"merge patch must be a JSON object"}
+	}
+
+	var errs []string
+	for field, raw := range doc {
+		nullable, ok := patchableFields[field]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s cannot be updated", field))
+			continue
+		}
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if !nullable {
+				errs = append(errs, fmt.Sprintf("%s cannot be null", field))
+				continue
+			}
+			// nullでクリアできるフィールドは空文字として更新する
+			doc[field] = json.RawMessage(`""`)
+		}
+	}
+	if len(errs) > 0 {
+		return input, errs
+	}
+
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return input, []string{"merge patch must be a JSON object"}
+	}
+	if err := json.Unmarshal(normalized, &input); err != nil {
+		return input, []string{"invalid field type in merge patch"}
+	}
+
+	return input, nil
+}
+
+// JSON Patch (RFC 6902) を現在のアイテムに適用し、変更されたフィールドをUpdateItemInputとして返す
+func decodeJSONPatch(body []byte, current *entity.Item) (usecase.UpdateItemInput, error) {
+	var input usecase.UpdateItemInput
+
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(body, &operations); err != nil {
+		return input, errors.New("json patch must be an array of operations")
+	}
+
+	original, err := toPatchDocument(current)
+	if err != nil {
+		return input, err
+	}
+	doc, err := toPatchDocument(current)
+	if err != nil {
+		return input, err
+	}
+
+	for i, op := range operations {
+		if err := applyJSONPatchOperation(doc, op); err != nil {
+			return input, fmt.Errorf("operations[%d]: %w", i, err)
+		}
+	}
+
+	merged, err := json.Marshal(diffMergePatch(original, doc))
+	if err != nil {
+		return input, err
+	}
+
+	input, errs := decodeMergePatch(merged)
+	if len(errs) > 0 {
+		return input, errors.New(strings.Join(errs, ", "))
+	}
+	return input, nil
+}
+
+// 変更前後のドキュメントの差分からMerge Patchを組み立てる。オブジェクトはメンバー単位で差分を取る
+func diffMergePatch(before, after map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for field, b := range before {
+		a, exists := after[field]
+		if !exists {
+			patch[field] = nil
+			continue
+		}
+		if reflect.DeepEqual(a, b) {
+			continue
+		}
+		bm, bIsObject := b.(map[string]interface{})
+		am, aIsObject := a.(map[string]interface{})
+		if bIsObject && aIsObject {
+			patch[field] = diffMergePatch(bm, am)
+			continue
+		}
+		patch[field] = a
+	}
+	for field, a := range after {
+		if _, exists := before[field]; !exists {
+			patch[field] = a
+		}
+	}
+	return patch
+}
+
+// アイテムをJSON Patchの適用対象となるドキュメントに変換する
+func toPatchDocument(item *entity.Item) (map[string]interface{}, error) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func applyJSONPatchOperation(doc map[string]interface{}, op jsonPatchOperation) error {
+	parent, name, err := resolvePatchPath(doc, op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case "add", "replace":
+		value, err := decodePatchValue(op.Value)
+		if err != nil {
+			return err
+		}
+		if _, exists := parent[name]; !exists && op.Op == "replace" {
+			return fmt.Errorf("path %s does not exist", op.Path)
+		}
+		parent[name] = value
+	case "remove":
+		if _, exists := parent[name]; !exists {
+			return fmt.Errorf("path %s does not exist", op.Path)
+		}
+		delete(parent, name)
+	case "move", "copy":
+		fromParent, fromName, err := resolvePatchPath(doc, op.From)
+		if err != nil {
+			return err
+		}
+		value, exists := fromParent[fromName]
+		if !exists {
+			return fmt.Errorf("path %s does not exist", op.From)
+		}
+		if op.Op == "move" {
+			delete(fromParent, fromName)
+		}
+		parent[name] = value
+	case "test":
+		value, err := decodePatchValue(op.Value)
+		if err != nil {
+			return err
+		}
+		if current, exists := parent[name]; !exists || !reflect.DeepEqual(current, value) {
+			return fmt.Errorf("%w: %s", errPatchTestFailed, op.Path)
+		}
+	default:
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+
+	return nil
+}
+
+func decodePatchValue(raw json.RawMessage) (interface{}, error) {
+	if raw == nil {
+		return nil, errors.New("value is required")
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, errors.New("invalid value")
+	}
+	return value, nil
+}
+
+// JSON Pointer (RFC 6901) を解決し、対象メンバーを持つオブジェクトとメンバー名を返す。
+// "/attributes/color" のようにオブジェクトをたどるパスのみ扱い、配列の要素は指定できない
+func resolvePatchPath(doc map[string]interface{}, pointer string) (map[string]interface{}, string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, "", fmt.Errorf("invalid path %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+
+	parent := doc
+	for _, token := range tokens[:len(tokens)-1] {
+		child, ok := parent[token].(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("path %s does not exist", pointer)
+		}
+		parent = child
+	}
+	return parent, tokens[len(tokens)-1], nil
+}
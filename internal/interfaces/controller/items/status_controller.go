@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// POST /items/:id/sell
+// リクエストボディの sale_price, sale_date を記録して売却済みにする
+func (h *ItemHandler) SellItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.SellItemInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	item, err := h.itemUsecase.SellItem(c.Request().Context(), id, input)
+	if err != nil {
+		return statusError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// POST /items/:id/lend
+func (h *ItemHandler) LendItem(c echo.Context) error {
+	return h.changeStatus(c, entity.ItemStatusLent)
+}
+
+// POST /items/:id/repair
+func (h *ItemHandler) RepairItem(c echo.Context) error {
+	return h.changeStatus(c, entity.ItemStatusInRepair)
+}
+
+// POST /items/:id/consign
+func (h *ItemHandler) ConsignItem(c echo.Context) error {
+	return h.changeStatus(c, entity.ItemStatusConsigned)
+}
+
+// POST /items/:id/return
+// 貸出・修理・委託・紛失から所有中に戻す
+func (h *ItemHandler) ReturnItem(c echo.Context) error {
+	return h.changeStatus(c, entity.ItemStatusOwned)
+}
+
+// POST /items/:id/lose
+func (h *ItemHandler) LoseItem(c echo.Context) error {
+	return h.changeStatus(c, entity.ItemStatusLost)
+}
+
+func (h *ItemHandler) changeStatus(c echo.Context, status entity.ItemStatus) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	item, err := h.itemUsecase.ChangeItemStatus(c.Request().Context(), id, status)
+	if err != nil {
+		return statusError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func statusError(c echo.Context, err error) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "item not found",
+		})
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsConflictError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "invalid status transition",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: "failed to change item status",
+	})
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LoanHandler struct {
+	loanUsecase usecase.LoanUsecase
+}
+
+func NewLoanHandler(loanUsecase usecase.LoanUsecase) *LoanHandler {
+	return &LoanHandler{
+		loanUsecase: loanUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+func (h *LoanHandler) GetBorrowers(c echo.Context) error {
+	borrowers, err := h.loanUsecase.GetBorrowers(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve borrowers",
+		})
+	}
+
+	return c.JSON(http.StatusOK, borrowers)
+}
+
+func (h *LoanHandler) GetBorrower(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid borrower ID",
+		})
+	}
+
+	borrower, err := h.loanUsecase.GetBorrowerByID(c.Request().Context(), id)
+	if err != nil {
+		return loanError(c, err, "failed to retrieve borrower")
+	}
+
+	return c.JSON(http.StatusOK, borrower)
+}
+
+func (h *LoanHandler) CreateBorrower(c echo.Context) error {
+	var input usecase.BorrowerInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	borrower, err := h.loanUsecase.CreateBorrower(c.Request().Context(), input)
+	if err != nil {
+		return loanError(c, err, "failed to create borrower")
+	}
+
+	return c.JSON(http.StatusCreated, borrower)
+}
+
+// GET /loans?overdue=true&active=true
+// overdue=true で返却期限を過ぎた未返却の貸出、active=true で未返却の貸出だけを返す
+func (h *LoanHandler) GetLoans(c echo.Context) error {
+	var query usecase.LoanQuery
+	var err error
+
+	if value := c.QueryParam("overdue"); value != "" {
+		if query.Overdue, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "overdue must be true or false",
+			})
+		}
+	}
+	if value := c.QueryParam("active"); value != "" {
+		if query.Active, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "active must be true or false",
+			})
+		}
+	}
+
+	loans, err := h.loanUsecase.ListLoans(c.Request().Context(), query)
+	if err != nil {
+		return loanError(c, err, "failed to retrieve loans")
+	}
+
+	return c.JSON(http.StatusOK, loans)
+}
+
+// POST /loans/:id/return
+// 貸出を返却済みにし、アイテムを所有中に戻す
+func (h *LoanHandler) ReturnLoan(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid loan ID",
+		})
+	}
+
+	loan, err := h.loanUsecase.ReturnLoan(c.Request().Context(), id)
+	if err != nil {
+		return loanError(c, err, "failed to return loan")
+	}
+
+	return c.JSON(http.StatusOK, loan)
+}
+
+// POST /items/:id/loans
+// 貸出先と返却期限を指定してアイテムを貸し出す
+func (h *LoanHandler) LendItem(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.CreateLoanInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	loan, err := h.loanUsecase.LendItem(c.Request().Context(), itemID, input)
+	if err != nil {
+		return loanError(c, err, "failed to lend item")
+	}
+
+	return c.JSON(http.StatusCreated, loan)
+}
+
+// GET /items/:id/loans
+// アイテムの貸出履歴を新しい順で返す
+func (h *LoanHandler) GetItemLoans(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	loans, err := h.loanUsecase.GetItemLoans(c.Request().Context(), itemID)
+	if err != nil {
+		return loanError(c, err, "failed to retrieve loans")
+	}
+
+	return c.JSON(http.StatusOK, loans)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func loanError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	case domainErrors.IsConflictError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "loan conflict",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LocationHandler struct {
+	locationUsecase usecase.LocationUsecase
+}
+
+func NewLocationHandler(locationUsecase usecase.LocationUsecase) *LocationHandler {
+	return &LocationHandler{
+		locationUsecase: locationUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+func (h *LocationHandler) GetLocations(c echo.Context) error {
+	locations, err := h.locationUsecase.GetAllLocations(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve locations",
+		})
+	}
+
+	return c.JSON(http.StatusOK, locations)
+}
+
+func (h *LocationHandler) GetLocation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid location ID",
+		})
+	}
+
+	location, err := h.locationUsecase.GetLocationByID(c.Request().Context(), id)
+	if err != nil {
+		return locationError(c, err, "failed to retrieve location")
+	}
+
+	return c.JSON(http.StatusOK, location)
+}
+
+func (h *LocationHandler) CreateLocation(c echo.Context) error {
+	var input usecase.CreateLocationInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	location, err := h.locationUsecase.CreateLocation(c.Request().Context(), input)
+	if err != nil {
+		return locationError(c, err, "failed to create location")
+	}
+
+	return c.JSON(http.StatusCreated, location)
+}
+
+func (h *LocationHandler) UpdateLocation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid location ID",
+		})
+	}
+
+	var input usecase.UpdateLocationInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	location, err := h.locationUsecase.UpdateLocation(c.Request().Context(), id, input)
+	if err != nil {
+		return locationError(c, err, "failed to update location")
+	}
+
+	return c.JSON(http.StatusOK, location)
+}
+
+func (h *LocationHandler) DeleteLocation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid location ID",
+		})
+	}
+
+	if err := h.locationUsecase.DeleteLocation(c.Request().Context(), id); err != nil {
+		return locationError(c, err, "failed to delete location")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /items/:id/move
+// リクエストボディの location_id の保管場所にアイテムを移動する（nullで保管場所を解除）
+func (h *LocationHandler) MoveItem(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.MoveItemInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	item, err := h.locationUsecase.MoveItem(c.Request().Context(), itemID, input)
+	if err != nil {
+		return locationError(c, err, "failed to move item")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// GET /items/:id/movements
+// アイテムの移動履歴を新しい順で返す
+func (h *LocationHandler) GetItemMovements(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	movements, err := h.locationUsecase.GetItemMovements(c.Request().Context(), itemID)
+	if err != nil {
+		return locationError(c, err, "failed to retrieve movements")
+	}
+
+	return c.JSON(http.StatusOK, movements)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func locationError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	case domainErrors.IsConflictError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "location is in use",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MaintenanceHandler struct {
+	maintenanceUsecase usecase.MaintenanceUsecase
+}
+
+func NewMaintenanceHandler(maintenanceUsecase usecase.MaintenanceUsecase) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceUsecase: maintenanceUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// GET /items/:id/maintenance
+// 保証期限・整備履歴・次回の整備予定日・総保有コストを返す
+func (h *MaintenanceHandler) GetItemMaintenance(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	maintenance, err := h.maintenanceUsecase.GetItemMaintenance(c.Request().Context(), itemID)
+	if err != nil {
+		return maintenanceError(c, err, "failed to retrieve maintenance")
+	}
+
+	return c.JSON(http.StatusOK, maintenance)
+}
+
+// POST /items/:id/maintenance
+// 整備記録（整備日・業者・費用・メモ）を追加する
+func (h *MaintenanceHandler) AddMaintenanceRecord(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.MaintenanceRecordInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	record, err := h.maintenanceUsecase.AddMaintenanceRecord(c.Request().Context(), itemID, input)
+	if err != nil {
+		return maintenanceError(c, err, "failed to create maintenance record")
+	}
+
+	return c.JSON(http.StatusCreated, record)
+}
+
+func (h *MaintenanceHandler) DeleteMaintenanceRecord(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid maintenance record ID",
+		})
+	}
+
+	if err := h.maintenanceUsecase.DeleteMaintenanceRecord(c.Request().Context(), id); err != nil {
+		return maintenanceError(c, err, "failed to delete maintenance record")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /maintenance/upcoming?days=30
+// days日以内（既定30日）に整備予定日を迎えるアイテムを返す。整備予定日を過ぎたアイテムも含む
+func (h *MaintenanceHandler) GetUpcomingMaintenance(c echo.Context) error {
+	days := usecase.DefaultUpcomingMaintenanceDays
+	if value := c.QueryParam("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "days must be an integer",
+			})
+		}
+		days = parsed
+	}
+
+	upcoming, err := h.maintenanceUsecase.GetUpcomingMaintenance(c.Request().Context(), days)
+	if err != nil {
+		return maintenanceError(c, err, "failed to retrieve upcoming maintenance")
+	}
+
+	return c.JSON(http.StatusOK, upcoming)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func maintenanceError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
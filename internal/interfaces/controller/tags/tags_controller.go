@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+func (h *TagHandler) GetTags(c echo.Context) error {
+	tags, err := h.tagUsecase.GetAllTags(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to retrieve tags",
+		})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (h *TagHandler) GetTag(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid tag ID",
+		})
+	}
+
+	tag, err := h.tagUsecase.GetTagByID(c.Request().Context(), id)
+	if err != nil {
+		return tagError(c, err, "failed to retrieve tag")
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) CreateTag(c echo.Context) error {
+	var input usecase.TagInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	tag, err := h.tagUsecase.CreateTag(c.Request().Context(), input)
+	if err != nil {
+		return tagError(c, err, "failed to create tag")
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+func (h *TagHandler) UpdateTag(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid tag ID",
+		})
+	}
+
+	var input usecase.TagInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	tag, err := h.tagUsecase.UpdateTag(c.Request().Context(), id, input)
+	if err != nil {
+		return tagError(c, err, "failed to update tag")
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid tag ID",
+		})
+	}
+
+	if err := h.tagUsecase.DeleteTag(c.Request().Context(), id); err != nil {
+		return tagError(c, err, "failed to delete tag")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /items/:id/tags
+func (h *TagHandler) AddItemTags(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.AddItemTagsInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	item, err := h.tagUsecase.AddItemTags(c.Request().Context(), itemID, input)
+	if err != nil {
+		return tagError(c, err, "failed to add tags")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// DELETE /items/:id/tags/:tagId
+func (h *TagHandler) RemoveItemTag(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+	tagID, err := strconv.ParseInt(c.Param("tagId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid tag ID",
+		})
+	}
+
+	item, err := h.tagUsecase.RemoveItemTag(c.Request().Context(), itemID, tagID)
+	if err != nil {
+		return tagError(c, err, "failed to remove tag")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func tagError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	case domainErrors.IsDuplicateError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "tag already exists",
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ValuationHandler struct {
+	valuationUsecase usecase.ValuationUsecase
+}
+
+func NewValuationHandler(valuationUsecase usecase.ValuationUsecase) *ValuationHandler {
+	return &ValuationHandler{
+		valuationUsecase: valuationUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// GET /items/:id/valuations
+// アイテムの評価額の履歴を新しい順で返す
+func (h *ValuationHandler) GetItemValuations(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	valuations, err := h.valuationUsecase.GetItemValuations(c.Request().Context(), itemID)
+	if err != nil {
+		return valuationError(c, err, "failed to retrieve valuations")
+	}
+
+	return c.JSON(http.StatusOK, valuations)
+}
+
+// POST /items/:id/valuations
+// 評価額（評価日・金額・根拠）を記録する
+func (h *ValuationHandler) AddValuation(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid item ID",
+		})
+	}
+
+	var input usecase.ValuationInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	valuation, err := h.valuationUsecase.AddValuation(c.Request().Context(), itemID, input)
+	if err != nil {
+		return valuationError(c, err, "failed to create valuation")
+	}
+
+	return c.JSON(http.StatusCreated, valuation)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func valuationError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	subscriptions, err := h.webhookUsecase.GetSubscriptions(c.Request().Context())
+	if err != nil {
+		return webhookError(c, err, "failed to retrieve webhooks")
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid webhook ID",
+		})
+	}
+
+	subscription, err := h.webhookUsecase.GetSubscription(c.Request().Context(), id)
+	if err != nil {
+		return webhookError(c, err, "failed to retrieve webhook")
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+// POST /webhooks
+// 配信先URL・購読するイベント・署名用シークレットを登録する
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var input usecase.WebhookInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	subscription, err := h.webhookUsecase.CreateSubscription(c.Request().Context(), input)
+	if err != nil {
+		return webhookError(c, err, "failed to create webhook")
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+// PUT /webhooks/:id
+// secretを省略した場合は既存のシークレットを、activeを省略した場合は現在の状態を維持する
+func (h *WebhookHandler) UpdateWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid webhook ID",
+		})
+	}
+
+	var input usecase.WebhookInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+
+	subscription, err := h.webhookUsecase.UpdateSubscription(c.Request().Context(), id, input)
+	if err != nil {
+		return webhookError(c, err, "failed to update webhook")
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid webhook ID",
+		})
+	}
+
+	if err := h.webhookUsecase.DeleteSubscription(c.Request().Context(), id); err != nil {
+		return webhookError(c, err, "failed to delete webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /webhooks/:id/deliveries?status=dead
+// 購読の配信を新しい順で返す（最大100件）
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid webhook ID",
+		})
+	}
+
+	deliveries, err := h.webhookUsecase.GetDeliveries(c.Request().Context(), id, c.QueryParam("status"))
+	if err != nil {
+		return webhookError(c, err, "failed to retrieve webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// GET /webhooks/dead-letters
+// 再試行の上限に達した配信を返す
+func (h *WebhookHandler) GetDeadLetters(c echo.Context) error {
+	deliveries, err := h.webhookUsecase.GetDeadLetters(c.Request().Context())
+	if err != nil {
+		return webhookError(c, err, "failed to retrieve dead letters")
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// GET /webhooks/deliveries/:deliveryId
+// 配信と試行ごとのログ（応答ステータス・エラー・所要時間）を返す
+func (h *WebhookHandler) GetDelivery(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid delivery ID",
+		})
+	}
+
+	delivery, err := h.webhookUsecase.GetDelivery(c.Request().Context(), id)
+	if err != nil {
+		return webhookError(c, err, "failed to retrieve webhook delivery")
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// POST /webhooks/deliveries/:deliveryId/retry
+// デッドレターの配信を配信待ちに戻す
+func (h *WebhookHandler) RetryDelivery(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid delivery ID",
+		})
+	}
+
+	delivery, err := h.webhookUsecase.RetryDelivery(c.Request().Context(), id)
+	if err != nil {
+		return webhookError(c, err, "failed to retry webhook delivery")
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// ユースケースのエラーをレスポンスに変換する
+func webhookError(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{err.Error()},
+		})
+	case domainErrors.IsNotFoundError(err):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+	case domainErrors.IsConflictError(err):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "webhook delivery cannot be retried",
+			Details: []string{err.Error()},
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,440 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// CacheStore はキャッシュの保存先。値はJSONにエンコードしたバイト列で保存する。
+// プロセス内のLRU（infrastructure/cacheのMemoryStore）のほか、複数のサーバーで共有する場合はRedisなどを使った実装に差し替える
+type CacheStore interface {
+	// Get はkeyの値を返す。存在しないか期限切れの場合はfalseを返す
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set はkeyにvalueをttlの間保存する
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete はkeysを削除する。存在しないキーは無視する
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// TxObserver はctxのトランザクションの状態とコミット後の処理（infrastructure/databaseのMySqlHandlerが実装する）
+type TxObserver interface {
+	InTransaction(ctx context.Context) bool
+	AfterCommit(ctx context.Context, fn func())
+}
+
+// 集計（GET /items/summary）のキャッシュのキー
+const (
+	summaryByCategoryCacheKey = "items:summary:category"
+	summaryByLocationCacheKey = "items:summary:location"
+	summaryByStatusCacheKey   = "items:summary:status"
+	salesByCategoryCacheKey   = "items:summary:sales"
+)
+
+// 集計のキャッシュはまとめて無効化する
+var summaryCacheKeys = []string{summaryByCategoryCacheKey, summaryByLocationCacheKey, summaryByStatusCacheKey, salesByCategoryCacheKey}
+
+// 変更すると集計が変わるフィールド
+var summaryFields = []entity.ItemField{entity.FieldCategory, entity.FieldPurchasePrice, entity.FieldStatus, entity.FieldSalePrice}
+
+func itemCacheKey(id int64) string {
+	return "items:id:" + strconv.FormatInt(id, 10)
+}
+
+// tenantCacheKey はctxのテナントのキーを返す。同じIDのアイテムや集計でもテナントが異なれば別のキーになる
+func tenantCacheKey(ctx context.Context, key string) (string, bool) {
+	tenantID, ok := usecase.TenantIDFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return "tenants:" + strconv.FormatInt(tenantID, 10) + ":" + key, true
+}
+
+// CacheStats はキャッシュのヒット・ミスの回数
+type CacheStats struct {
+	Enabled  bool              `json:"enabled"`
+	FindByID CacheCounterStats `json:"find_by_id"`
+	// カテゴリー別・保管場所別・状態別の集計と売却損益の合計
+	Summary CacheCounterStats `json:"summary"`
+	// 作成・更新・削除で無効化したキーの数
+	Invalidations int64 `json:"invalidations"`
+}
+
+type CacheCounterStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	// キャッシュの読み書きに失敗した回数（失敗してもデータベースの値を返す）
+	Errors   int64   `json:"errors"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+type cacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+func (c *cacheCounter) stats() CacheCounterStats {
+	stats := CacheCounterStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// CachedItemRepository はFindByIDと集計（GetSummaryByCategory・GetSummaryByLocation・GetSummaryByStatus・GetSalesByCategory）の
+// 結果をキャッシュするItemRepositoryのデコレーター。
+// 作成・更新・削除では影響を受けるアイテムと集計のキーだけを無効化する。それ以外のメソッドはそのまま呼び出す
+type CachedItemRepository struct {
+	usecase.ItemRepository
+	store CacheStore
+	tx    TxObserver
+	ttl   time.Duration
+
+	// 同じキーの同時のミスをデータベースへの1回の問い合わせにまとめる
+	group singleflight.Group
+	// 無効化のたびに増える。読み込み中に無効化された古い値を保存しないために使う
+	epoch atomic.Uint64
+
+	findByID      cacheCounter
+	summary       cacheCounter
+	invalidations atomic.Int64
+}
+
+func NewCachedItemRepository(repo usecase.ItemRepository, store CacheStore, tx TxObserver, ttl time.Duration) *CachedItemRepository {
+	return &CachedItemRepository{
+		ItemRepository: repo,
+		store:          store,
+		tx:             tx,
+		ttl:            ttl,
+	}
+}
+
+func (r *CachedItemRepository) Stats() CacheStats {
+	return CacheStats{
+		Enabled:       true,
+		FindByID:      r.findByID.stats(),
+		Summary:       r.summary.stats(),
+		Invalidations: r.invalidations.Load(),
+	}
+}
+
+func (r *CachedItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	return cached(ctx, r, itemCacheKey(id), &r.findByID, func(ctx context.Context) (*entity.Item, error) {
+		return r.ItemRepository.FindByID(ctx, id)
+	})
+}
+
+func (r *CachedItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	return cached(ctx, r, summaryByCategoryCacheKey, &r.summary, r.ItemRepository.GetSummaryByCategory)
+}
+
+func (r *CachedItemRepository) GetSummaryByLocation(ctx context.Context) ([]*usecase.LocationSummary, error) {
+	return cached(ctx, r, summaryByLocationCacheKey, &r.summary, r.ItemRepository.GetSummaryByLocation)
+}
+
+func (r *CachedItemRepository) GetSummaryByStatus(ctx context.Context) (map[string]int, error) {
+	return cached(ctx, r, summaryByStatusCacheKey, &r.summary, r.ItemRepository.GetSummaryByStatus)
+}
+
+func (r *CachedItemRepository) GetSalesByCategory(ctx context.Context) (map[string]*usecase.SalesTotals, error) {
+	return cached(ctx, r, salesByCategoryCacheKey, &r.summary, r.ItemRepository.GetSalesByCategory)
+}
+
+func (r *CachedItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	created, err := r.ItemRepository.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, summaryCacheKeys...)
+	return created, nil
+}
+
+func (r *CachedItemRepository) CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	created, err := r.ItemRepository.CreateBatch(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, summaryCacheKeys...)
+	return created, nil
+}
+
+func (r *CachedItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	// 永続化すると変更履歴がクリアされるため、先に変更されたフィールドを確認する
+	changes := item.ChangedFields()
+
+	updated, err := r.ItemRepository.Update(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) > 0 {
+		keys := []string{itemCacheKey(item.ID)}
+		if slices.ContainsFunc(changes, func(field entity.ItemField) bool { return slices.Contains(summaryFields, field) }) {
+			keys = append(keys, summaryCacheKeys...)
+		}
+		r.invalidate(ctx, keys...)
+	}
+	return updated, nil
+}
+
+func (r *CachedItemRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.ItemRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, append([]string{itemCacheKey(id)}, summaryCacheKeys...)...)
+	return nil
+}
+
+func (r *CachedItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	if err := r.ItemRepository.DeleteBatch(ctx, ids); err != nil {
+		return err
+	}
+	r.invalidate(ctx, append(itemCacheKeys(ids), summaryCacheKeys...)...)
+	return nil
+}
+
+func (r *CachedItemRepository) MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error {
+	if err := r.ItemRepository.MergeInto(ctx, survivorID, duplicate); err != nil {
+		return err
+	}
+	r.invalidate(ctx, append([]string{itemCacheKey(survivorID), itemCacheKey(duplicate.ID)}, summaryCacheKeys...)...)
+	return nil
+}
+
+// cached はctxのテナントのkeyのキャッシュを返し、なければloadで取得して保存する。
+// 呼び出し元が結果を変更してもほかの呼び出し元に影響しないよう、呼び出しごとにJSONから復元した値を返す
+func cached[T any](ctx context.Context, r *CachedItemRepository, key string, counter *cacheCounter, load func(ctx context.Context) (T, error)) (T, error) {
+	// トランザクション中は自身の未コミットの変更を読み、それをキャッシュに保存しないよう、キャッシュを使わない
+	if r.tx.InTransaction(ctx) {
+		return load(ctx)
+	}
+	// テナントのないctxではキャッシュを使わない（リポジトリがErrTenantRequiredを返す）
+	key, ok := tenantCacheKey(ctx, key)
+	if !ok {
+		return load(ctx)
+	}
+
+	data, ok, err := r.store.Get(ctx, key)
+	if err != nil {
+		counter.errors.Add(1)
+		log.Printf("⚠️  cache get %s: %v", key, err)
+	}
+	if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			counter.hits.Add(1)
+			return value, nil
+		}
+		counter.errors.Add(1)
+	}
+	counter.misses.Add(1)
+
+	epoch := r.epoch.Load()
+	result, err, _ := r.group.Do(key, func() (interface{}, error) {
+		// 最初の呼び出し元がキャンセルしても、相乗りしたほかの呼び出し元には結果を返す。
+		// レプリカの遅延で無効化の直後に古い値を保存しないよう、プライマリから読み込む
+		ctx := context.WithoutCancel(ctx)
+		loaded, err := load(WithPrimary(ctx))
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, err
+		}
+		// 読み込み中に無効化された場合は古い値の可能性があるため保存しない
+		if r.epoch.Load() == epoch {
+			if err := r.store.Set(ctx, key, data, r.ttl); err != nil {
+				counter.errors.Add(1)
+				log.Printf("⚠️  cache set %s: %v", key, err)
+			}
+		}
+		return data, nil
+	})
+	var value T
+	if err != nil {
+		return value, err
+	}
+	err = json.Unmarshal(result.([]byte), &value)
+	return value, err
+}
+
+// invalidate はctxのテナントのキーをキャッシュから削除する。トランザクション中の場合は、コミットまでの間に
+// ほかのリクエストが変更前の値を保存する可能性があるため、コミット後にもう一度削除する
+func (r *CachedItemRepository) invalidate(ctx context.Context, keys ...string) {
+	tenantKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key, ok := tenantCacheKey(ctx, key); ok {
+			tenantKeys = append(tenantKeys, key)
+		}
+	}
+	if len(tenantKeys) == 0 {
+		return
+	}
+	keys = tenantKeys
+
+	r.invalidations.Add(int64(len(keys)))
+	r.delete(ctx, keys)
+	if r.tx.InTransaction(ctx) {
+		ctx := context.WithoutCancel(ctx)
+		r.tx.AfterCommit(ctx, func() {
+			r.delete(ctx, keys)
+		})
+	}
+}
+
+func (r *CachedItemRepository) delete(ctx context.Context, keys []string) {
+	r.epoch.Add(1)
+	// 無効化より前に始まった読み込みに、これから読み込む呼び出し元を相乗りさせない
+	for _, key := range keys {
+		r.group.Forget(key)
+	}
+	if err := r.store.Delete(ctx, keys...); err != nil {
+		log.Printf("⚠️  cache delete %v: %v", keys, err)
+	}
+}
+
+func itemCacheKeys(ids []int64) []string {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = itemCacheKey(id)
+	}
+	return keys
+}
+
+// TagRepository はタグの付け外し・名前の変更・削除で、タグが付いたアイテムのキャッシュを無効化するTagRepositoryを返す
+func (r *CachedItemRepository) TagRepository(repo usecase.TagRepository) usecase.TagRepository {
+	return &cachedTagRepository{TagRepository: repo, items: r}
+}
+
+// LocationRepository はアイテムの移動で、そのアイテムと集計のキャッシュを無効化するLocationRepositoryを返す。
+// 保管場所の作成・変更・削除でも保管場所別の集計が変わるため、集計のキャッシュを無効化する
+func (r *CachedItemRepository) LocationRepository(repo usecase.LocationRepository) usecase.LocationRepository {
+	return &cachedLocationRepository{LocationRepository: repo, items: r}
+}
+
+// InsuranceRepository は保険契約への割り当てで、そのアイテムのキャッシュを無効化するInsuranceRepositoryを返す
+func (r *CachedItemRepository) InsuranceRepository(repo usecase.InsuranceRepository) usecase.InsuranceRepository {
+	return &cachedInsuranceRepository{InsuranceRepository: repo, items: r}
+}
+
+type cachedTagRepository struct {
+	usecase.TagRepository
+	items *CachedItemRepository
+}
+
+func (r *cachedTagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	keys := r.taggedItemKeys(ctx, tag.ID)
+	updated, err := r.TagRepository.Update(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	r.items.invalidate(ctx, keys...)
+	return updated, nil
+}
+
+func (r *cachedTagRepository) Delete(ctx context.Context, id int64) error {
+	keys := r.taggedItemKeys(ctx, id)
+	if err := r.TagRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.items.invalidate(ctx, keys...)
+	return nil
+}
+
+func (r *cachedTagRepository) AttachToItem(ctx context.Context, itemID int64, tagIDs []int64) error {
+	if err := r.TagRepository.AttachToItem(ctx, itemID, tagIDs); err != nil {
+		return err
+	}
+	r.items.invalidate(ctx, itemCacheKey(itemID))
+	return nil
+}
+
+func (r *cachedTagRepository) DetachFromItem(ctx context.Context, itemID, tagID int64) error {
+	if err := r.TagRepository.DetachFromItem(ctx, itemID, tagID); err != nil {
+		return err
+	}
+	r.items.invalidate(ctx, itemCacheKey(itemID))
+	return nil
+}
+
+// taggedItemKeys は名前の変更・削除の前に、タグが付いたアイテムのキーを取得する。
+// 取得できない場合（タグが存在しないなど）は無効化するキーはない
+func (r *cachedTagRepository) taggedItemKeys(ctx context.Context, tagID int64) []string {
+	tag, err := r.TagRepository.FindByID(ctx, tagID)
+	if err != nil {
+		return nil
+	}
+	items, err := r.items.ItemRepository.FindByFilter(ctx, usecase.ItemFilter{Tags: []string{tag.Name}})
+	if err != nil {
+		return nil
+	}
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return itemCacheKeys(ids)
+}
+
+type cachedLocationRepository struct {
+	usecase.LocationRepository
+	items *CachedItemRepository
+}
+
+func (r *cachedLocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	created, err := r.LocationRepository.Create(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	r.items.invalidate(ctx, summaryCacheKeys...)
+	return created, nil
+}
+
+func (r *cachedLocationRepository) Update(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	updated, err := r.LocationRepository.Update(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	r.items.invalidate(ctx, summaryCacheKeys...)
+	return updated, nil
+}
+
+func (r *cachedLocationRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.LocationRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.items.invalidate(ctx, summaryCacheKeys...)
+	return nil
+}
+
+func (r *cachedLocationRepository) MoveItem(ctx context.Context, movement *entity.ItemMovement) (*entity.ItemMovement, error) {
+	moved, err := r.LocationRepository.MoveItem(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+	r.items.invalidate(ctx, append([]string{itemCacheKey(movement.ItemID)}, summaryCacheKeys...)...)
+	return moved, nil
+}
+
+type cachedInsuranceRepository struct {
+	usecase.InsuranceRepository
+	items *CachedItemRepository
+}
+
+func (r *cachedInsuranceRepository) AssignItem(ctx context.Context, itemID int64, policyID *int64) error {
+	if err := r.InsuranceRepository.AssignItem(ctx, itemID, policyID); err != nil {
+		return err
+	}
+	r.items.invalidate(ctx, itemCacheKey(itemID))
+	return nil
+}
@@ -0,0 +1,609 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeCacheStore はRedisなどの共有キャッシュの代わりに使うテスト用のCacheStore
+type fakeCacheStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	// Get・Setで返すエラー
+	err error
+}
+
+func newFakeCacheStore() *fakeCacheStore {
+	return &fakeCacheStore{values: make(map[string][]byte)}
+}
+
+func (s *fakeCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *fakeCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *fakeCacheStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func (s *fakeCacheStore) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.values[key]
+	return ok
+}
+
+type fakeTxKey struct{}
+
+// fakeTx はbeginで始めたトランザクションのコミット後の処理をcommitで実行する
+type fakeTx struct {
+	hooks []func()
+}
+
+func (t *fakeTx) begin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fakeTxKey{}, true)
+}
+
+func (t *fakeTx) commit() {
+	for _, hook := range t.hooks {
+		hook()
+	}
+	t.hooks = nil
+}
+
+func (t *fakeTx) InTransaction(ctx context.Context) bool {
+	return ctx.Value(fakeTxKey{}) != nil
+}
+
+func (t *fakeTx) AfterCommit(ctx context.Context, fn func()) {
+	if !t.InTransaction(ctx) {
+		fn()
+		return
+	}
+	t.hooks = append(t.hooks, fn)
+}
+
+// fakeItemRepository はFindByIDと集計の呼び出し回数を数えるItemRepository
+type fakeItemRepository struct {
+	usecase.ItemRepository
+	mu            sync.Mutex
+	items         map[int64]*entity.Item
+	findByIDCalls int
+	// 4つの集計の呼び出し回数の合計
+	summaryCalls int
+	// FindByIDの開始を通知し、releaseが閉じられるまで結果を返さない（nilの場合は待たない）
+	started chan struct{}
+	release chan struct{}
+}
+
+func newFakeItemRepository() *fakeItemRepository {
+	return &fakeItemRepository{items: map[int64]*entity.Item{
+		1: {ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15", Tags: []string{"限定"}, Attributes: map[string]interface{}{"case_size_mm": 40.0}},
+		2: {ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchaseDate: "2022-06-01", Tags: []string{"限定"}},
+		3: {ID: 3, Name: "オメガ スピードマスター", Category: "時計", Brand: "OMEGA", PurchaseDate: "2021-03-10"},
+	}}
+}
+
+func (r *fakeItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	r.mu.Lock()
+	r.findByIDCalls++
+	started, release := r.started, r.release
+	item, ok := r.items[id]
+	r.mu.Unlock()
+
+	if started != nil {
+		started <- struct{}{}
+		<-release
+	}
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (r *fakeItemRepository) FindByFilter(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []*entity.Item
+	for _, item := range r.items {
+		if len(filter.Tags) == 0 || slices.Contains(item.Tags, filter.Tags[0]) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.summaryCalls++
+	summary := make(map[string]int)
+	for _, item := range r.items {
+		summary[item.Category]++
+	}
+	return summary, nil
+}
+
+func (r *fakeItemRepository) GetSummaryByLocation(ctx context.Context) ([]*usecase.LocationSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.summaryCalls++
+	return []*usecase.LocationSummary{{Name: "unassigned", ItemCount: len(r.items)}}, nil
+}
+
+func (r *fakeItemRepository) GetSummaryByStatus(ctx context.Context) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.summaryCalls++
+	return map[string]int{string(entity.ItemStatusOwned): len(r.items)}, nil
+}
+
+func (r *fakeItemRepository) GetSalesByCategory(ctx context.Context) (map[string]*usecase.SalesTotals, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.summaryCalls++
+	return map[string]*usecase.SalesTotals{}, nil
+}
+
+func (r *fakeItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	return item, nil
+}
+
+func (r *fakeItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	item.ClearChanges()
+	return item, nil
+}
+
+func (r *fakeItemRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *fakeItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	return nil
+}
+
+func (r *fakeItemRepository) MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error {
+	return nil
+}
+
+func (r *fakeItemRepository) calls() (findByID, summary int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.findByIDCalls, r.summaryCalls
+}
+
+type fakeCacheTagRepository struct {
+	usecase.TagRepository
+}
+
+func (r *fakeCacheTagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	return &entity.Tag{ID: id, Name: "限定"}, nil
+}
+
+func (r *fakeCacheTagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	return tag, nil
+}
+
+func (r *fakeCacheTagRepository) AttachToItem(ctx context.Context, itemID int64, tagIDs []int64) error {
+	return nil
+}
+
+type fakeCacheLocationRepository struct {
+	usecase.LocationRepository
+}
+
+func (r *fakeCacheLocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	return location, nil
+}
+
+func (r *fakeCacheLocationRepository) MoveItem(ctx context.Context, movement *entity.ItemMovement) (*entity.ItemMovement, error) {
+	return movement, nil
+}
+
+type fakeCacheInsuranceRepository struct {
+	usecase.InsuranceRepository
+}
+
+func (r *fakeCacheInsuranceRepository) AssignItem(ctx context.Context, itemID int64, policyID *int64) error {
+	return nil
+}
+
+// tenantContext はテナントのctxを返す
+func tenantContext(tenantID int64) context.Context {
+	return usecase.WithTenant(context.Background(), tenantID)
+}
+
+func newCachedItemRepository() (*CachedItemRepository, *fakeItemRepository, *fakeCacheStore, *fakeTx) {
+	inner, store, tx := newFakeItemRepository(), newFakeCacheStore(), &fakeTx{}
+	return NewCachedItemRepository(inner, store, tx, time.Minute), inner, store, tx
+}
+
+func TestCachedItemRepository_FindByID(t *testing.T) {
+	t.Run("正常系: 2回目はキャッシュから返す", func(t *testing.T) {
+		repo, inner, _, _ := newCachedItemRepository()
+		ctx := tenantContext(1)
+
+		first, err := repo.FindByID(ctx, 1)
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, 40.0, second.Attributes["case_size_mm"])
+		findByID, _ := inner.calls()
+		assert.Equal(t, 1, findByID)
+
+		stats := repo.Stats()
+		assert.Equal(t, CacheCounterStats{Hits: 1, Misses: 1, HitRatio: 0.5}, stats.FindByID)
+
+		// 呼び出し元が結果を変更してもキャッシュには影響しない
+		second.Name = "変更"
+		third, err := repo.FindByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", third.Name)
+	})
+
+	t.Run("異常系: 存在しないアイテムはキャッシュしない", func(t *testing.T) {
+		repo, inner, _, _ := newCachedItemRepository()
+		ctx := tenantContext(1)
+
+		_, err := repo.FindByID(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		_, err = repo.FindByID(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		findByID, _ := inner.calls()
+		assert.Equal(t, 2, findByID)
+	})
+
+	t.Run("異常系: キャッシュの障害時はデータベースの値を返す", func(t *testing.T) {
+		repo, inner, store, _ := newCachedItemRepository()
+		store.err = errors.New("connection refused")
+
+		item, err := repo.FindByID(tenantContext(1), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+		findByID, _ := inner.calls()
+		assert.Equal(t, 1, findByID)
+		assert.Equal(t, int64(2), repo.Stats().FindByID.Errors)
+	})
+}
+
+// GET /items/summaryの4つの集計をすべてキャッシュする
+func TestCachedItemRepository_Summary(t *testing.T) {
+	repo, inner, _, _ := newCachedItemRepository()
+	itemUsecase := usecase.NewItemUsecase(repo)
+	ctx := tenantContext(1)
+
+	first, err := itemUsecase.GetCategorySummary(ctx)
+	require.NoError(t, err)
+	second, err := itemUsecase.GetCategorySummary(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	_, summary := inner.calls()
+	assert.Equal(t, 4, summary)
+	assert.Equal(t, CacheCounterStats{Hits: 4, Misses: 4, HitRatio: 0.5}, repo.Stats().Summary)
+}
+
+func TestCachedItemRepository_Tenants(t *testing.T) {
+	t.Run("正常系: 同じIDでもテナントごとに別にキャッシュする", func(t *testing.T) {
+		repo, inner, store, _ := newCachedItemRepository()
+
+		_, err := repo.FindByID(tenantContext(1), 1)
+		require.NoError(t, err)
+		_, err = repo.FindByID(tenantContext(2), 1)
+		require.NoError(t, err)
+
+		findByID, _ := inner.calls()
+		assert.Equal(t, 2, findByID)
+		assert.True(t, store.has("tenants:1:"+itemCacheKey(1)))
+		assert.True(t, store.has("tenants:2:"+itemCacheKey(1)))
+
+		// 他のテナントの変更では無効化しない
+		require.NoError(t, repo.Delete(tenantContext(2), 1))
+		assert.True(t, store.has("tenants:1:"+itemCacheKey(1)))
+		assert.False(t, store.has("tenants:2:"+itemCacheKey(1)))
+	})
+
+	t.Run("異常系: テナントのないctxではキャッシュを使わない", func(t *testing.T) {
+		repo, inner, store, _ := newCachedItemRepository()
+		_, err := repo.FindByID(tenantContext(1), 1)
+		require.NoError(t, err)
+
+		_, err = repo.FindByID(context.Background(), 1)
+		require.NoError(t, err)
+
+		findByID, _ := inner.calls()
+		assert.Equal(t, 2, findByID)
+		assert.Len(t, store.values, 1)
+	})
+}
+
+// 変更したアイテムと集計のキャッシュだけが無効化される
+func TestCachedItemRepository_Invalidation(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error
+		// 無効化される（再度データベースから取得する）キャッシュ
+		item1, item2, summary bool
+	}{
+		{
+			name: "正常系: 作成は集計だけを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				_, err := repo.Create(ctx, &entity.Item{Name: "カルティエ タンク", Category: "時計"})
+				return err
+			},
+			summary: true,
+		},
+		{
+			name: "正常系: 名前の更新はそのアイテムだけを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				if err := item.Apply(entity.ItemPatch{Name: strPtr("新しい名前")}); err != nil {
+					return err
+				}
+				_, err := repo.Update(ctx, item)
+				return err
+			},
+			item1: true,
+		},
+		{
+			name: "正常系: 購入価格の更新は集計も無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				if err := item.Apply(entity.ItemPatch{PurchasePrice: intPtr(2000000)}); err != nil {
+					return err
+				}
+				_, err := repo.Update(ctx, item)
+				return err
+			},
+			item1: true, summary: true,
+		},
+		{
+			name: "正常系: カテゴリーの更新は集計も無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				bag, err := repo.FindByID(ctx, 2)
+				if err != nil {
+					return err
+				}
+				if err := bag.Apply(entity.ItemPatch{Category: strPtr("ジュエリー")}); err != nil {
+					return err
+				}
+				_, err = repo.Update(ctx, bag)
+				return err
+			},
+			item2: true, summary: true,
+		},
+		{
+			name: "正常系: 変更のない更新は無効化しない",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				_, err := repo.Update(ctx, item)
+				return err
+			},
+		},
+		{
+			name: "正常系: 削除はそのアイテムと集計を無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				return repo.Delete(ctx, 1)
+			},
+			item1: true, summary: true,
+		},
+		{
+			name: "正常系: 一括削除は指定したアイテムと集計を無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				return repo.DeleteBatch(ctx, []int64{2})
+			},
+			item2: true, summary: true,
+		},
+		{
+			name: "正常系: 統合は両方のアイテムと集計を無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				return repo.MergeInto(ctx, 1, &entity.Item{ID: 2})
+			},
+			item1: true, item2: true, summary: true,
+		},
+		{
+			name: "正常系: タグの付与はそのアイテムを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				return repo.TagRepository(&fakeCacheTagRepository{}).AttachToItem(ctx, 2, []int64{1})
+			},
+			item2: true,
+		},
+		{
+			name: "正常系: タグの名前の変更はタグが付いたアイテムを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				_, err := repo.TagRepository(&fakeCacheTagRepository{}).Update(ctx, &entity.Tag{ID: 1, Name: "限定品"})
+				return err
+			},
+			item1: true, item2: true,
+		},
+		{
+			name: "正常系: 保管場所の移動はそのアイテムと集計を無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				_, err := repo.LocationRepository(&fakeCacheLocationRepository{}).MoveItem(ctx, &entity.ItemMovement{ItemID: 1})
+				return err
+			},
+			item1: true, summary: true,
+		},
+		{
+			name: "正常系: 保管場所の作成は集計だけを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				_, err := repo.LocationRepository(&fakeCacheLocationRepository{}).Create(ctx, &entity.Location{Name: "金庫"})
+				return err
+			},
+			summary: true,
+		},
+		{
+			name: "正常系: 保険契約への割り当てはそのアイテムを無効化する",
+			op: func(ctx context.Context, repo *CachedItemRepository, item *entity.Item) error {
+				policyID := int64(1)
+				return repo.InsuranceRepository(&fakeCacheInsuranceRepository{}).AssignItem(ctx, 2, &policyID)
+			},
+			item2: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, inner, _, _ := newCachedItemRepository()
+			ctx := tenantContext(1)
+
+			// キャッシュに載せる
+			item, err := repo.FindByID(ctx, 1)
+			require.NoError(t, err)
+			_, err = repo.FindByID(ctx, 2)
+			require.NoError(t, err)
+			_, err = repo.GetSummaryByCategory(ctx)
+			require.NoError(t, err)
+			_, err = repo.GetSummaryByStatus(ctx)
+			require.NoError(t, err)
+
+			require.NoError(t, tt.op(ctx, repo, item))
+
+			_, err = repo.FindByID(ctx, 1)
+			require.NoError(t, err)
+			_, err = repo.FindByID(ctx, 2)
+			require.NoError(t, err)
+			_, err = repo.GetSummaryByCategory(ctx)
+			require.NoError(t, err)
+			_, err = repo.GetSummaryByStatus(ctx)
+			require.NoError(t, err)
+
+			// 集計はまとめて無効化される
+			findByID, summary := inner.calls()
+			assert.Equal(t, 2+countTrue(tt.item1, tt.item2), findByID)
+			assert.Equal(t, 2*(1+countTrue(tt.summary)), summary)
+		})
+	}
+}
+
+func countTrue(values ...bool) int {
+	count := 0
+	for _, v := range values {
+		if v {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCachedItemRepository_Transaction(t *testing.T) {
+	t.Run("正常系: トランザクション中の読み込みはキャッシュを使わない", func(t *testing.T) {
+		repo, inner, store, tx := newCachedItemRepository()
+		ctx := tx.begin(tenantContext(1))
+
+		_, err := repo.FindByID(ctx, 1)
+		require.NoError(t, err)
+
+		assert.False(t, store.has("tenants:1:"+itemCacheKey(1)))
+		assert.Equal(t, CacheCounterStats{}, repo.Stats().FindByID)
+		findByID, _ := inner.calls()
+		assert.Equal(t, 1, findByID)
+	})
+
+	t.Run("正常系: コミット前にほかのリクエストが保存した変更前の値をコミット後に無効化する", func(t *testing.T) {
+		repo, _, store, tx := newCachedItemRepository()
+		txCtx := tx.begin(tenantContext(1))
+
+		item, err := repo.FindByID(txCtx, 1)
+		require.NoError(t, err)
+		require.NoError(t, item.Apply(entity.ItemPatch{Name: strPtr("新しい名前")}))
+		_, err = repo.Update(txCtx, item)
+		require.NoError(t, err)
+
+		// コミット前のほかのリクエストは変更前の値を読み込んで保存する
+		_, err = repo.FindByID(tenantContext(1), 1)
+		require.NoError(t, err)
+		require.True(t, store.has("tenants:1:"+itemCacheKey(1)))
+
+		tx.commit()
+
+		assert.False(t, store.has("tenants:1:"+itemCacheKey(1)))
+	})
+}
+
+func TestCachedItemRepository_Singleflight(t *testing.T) {
+	t.Run("正常系: 同時のミスはデータベースへの1回の問い合わせにまとめる", func(t *testing.T) {
+		repo, inner, _, _ := newCachedItemRepository()
+		inner.started, inner.release = make(chan struct{}, 10), make(chan struct{})
+
+		const callers = 5
+		var wg sync.WaitGroup
+		results := make([]*entity.Item, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				item, err := repo.FindByID(tenantContext(1), 1)
+				assert.NoError(t, err)
+				results[i] = item
+			}(i)
+		}
+
+		<-inner.started
+		require.Eventually(t, func() bool { return repo.Stats().FindByID.Misses == callers }, time.Second, time.Millisecond)
+		// ミスを数えてから相乗りするまでの間を待つ
+		time.Sleep(20 * time.Millisecond)
+		close(inner.release)
+		wg.Wait()
+
+		findByID, _ := inner.calls()
+		assert.Equal(t, 1, findByID)
+		for _, item := range results {
+			assert.Equal(t, "ロレックス デイトナ", item.Name)
+		}
+		// 呼び出し元ごとに別の値を返す
+		assert.NotSame(t, results[0], results[1])
+	})
+
+	t.Run("正常系: 読み込み中に無効化された値は保存しない", func(t *testing.T) {
+		repo, inner, store, _ := newCachedItemRepository()
+		inner.started, inner.release = make(chan struct{}, 10), make(chan struct{})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := repo.FindByID(tenantContext(1), 1)
+			assert.NoError(t, err)
+		}()
+
+		<-inner.started
+		require.NoError(t, repo.Delete(tenantContext(1), 1))
+		close(inner.release)
+		<-done
+
+		assert.False(t, store.has("tenants:1:"+itemCacheKey(1)))
+	})
+}
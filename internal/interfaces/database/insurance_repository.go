@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type InsuranceRepository struct {
+	SqlHandler
+}
+
+// scanInsurancePolicyで読み込むカラム
+const insurancePolicySelectColumns = `id, insurer, policy_number, coverage_limit, starts_on, ends_on, note, created_at, updated_at`
+
+func (r *InsuranceRepository) FindAll(ctx context.Context) ([]*entity.InsurancePolicy, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM insurance_policies WHERE tenant_id = ? ORDER BY ends_on, id`, insurancePolicySelectColumns)
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	policies := []*entity.InsurancePolicy{}
+	for rows.Next() {
+		policy, err := scanInsurancePolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return policies, nil
+}
+
+func (r *InsuranceRepository) FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM insurance_policies WHERE id = ? AND tenant_id = ?`, insurancePolicySelectColumns)
+
+	policy, err := scanInsurancePolicy(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrInsurancePolicyNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return policy, nil
+}
+
+func (r *InsuranceRepository) Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO insurance_policies (tenant_id, insurer, policy_number, coverage_limit, starts_on, ends_on, note)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, tenantID,
+		policy.Insurer, policy.PolicyNumber, policy.CoverageLimit, policy.StartsOn, policy.EndsOn, policy.Note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(WithPrimary(ctx), id)
+}
+
+func (r *InsuranceRepository) Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE insurance_policies
+        SET insurer = ?, policy_number = ?, coverage_limit = ?, starts_on = ?, ends_on = ?, note = ?, updated_at = ?
+        WHERE id = ? AND tenant_id = ?
+    `
+
+	result, err := r.Execute(ctx, query,
+		policy.Insurer, policy.PolicyNumber, policy.CoverageLimit, policy.StartsOn, policy.EndsOn, policy.Note,
+		time.Now(), policy.ID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return nil, domainErrors.ErrInsurancePolicyNotFound
+	}
+
+	return r.FindByID(WithPrimary(ctx), policy.ID)
+}
+
+func (r *InsuranceRepository) Delete(ctx context.Context, id int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM insurance_policies WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrInsurancePolicyNotFound
+	}
+
+	return nil
+}
+
+func (r *InsuranceRepository) CountItems(ctx context.Context, id int64) (int, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items WHERE insurance_policy_id = ? AND tenant_id = ?`, id, tenantID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return count, nil
+}
+
+func (r *InsuranceRepository) AssignItem(ctx context.Context, itemID int64, policyID *int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	// updated_atも更新するため、割り当てが変わらない場合も1行が更新される
+	result, err := r.Execute(ctx, `UPDATE items SET insurance_policy_id = ?, updated_at = ? WHERE id = ? AND tenant_id = ?`,
+		nullableInt64(policyID), time.Now(), itemID, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func scanInsurancePolicy(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.InsurancePolicy, error) {
+	var policy entity.InsurancePolicy
+	var startsOn, endsOn string
+
+	err := scanner.Scan(
+		&policy.ID,
+		&policy.Insurer,
+		&policy.PolicyNumber,
+		&policy.CoverageLimit,
+		&startsOn,
+		&endsOn,
+		&policy.Note,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.StartsOn = formatDate(startsOn)
+	policy.EndsOn = formatDate(endsOn)
+
+	return &policy, nil
+}
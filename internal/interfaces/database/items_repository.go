@@ -3,32 +3,224 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"sort"
 	"strings"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
 )
 
 type ItemRepository struct {
 	SqlHandler
 }
 
+// scanItemで読み込むカラム
+const itemSelectColumns = `id, name, category, brand, purchase_price, purchase_date,
+            serial_number, model_number, condition_grade, authenticity, location_id,
+            status, sale_price, sale_date, warranty_expires_at, insurance_policy_id,
+            created_at, updated_at`
+
 func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+	return r.FindByFilter(ctx, usecase.ItemFilter{})
+}
+
+// FindByFilterは絞り込み条件をすべて満たすアイテムを作成日時の新しい順で取得する
+func (r *ItemRepository) FindByFilter(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"tenant_id = ?"}
+	params := []interface{}{tenantID}
+
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		params = append(params, filter.Category)
+	}
+
+	// 指定されたタグをすべて持つアイテム
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, `EXISTS (
+            SELECT 1 FROM item_tags it JOIN tags t ON t.id = it.tag_id
+            WHERE it.item_id = items.id AND t.name = ?
+        )`)
+		params = append(params, tag)
+	}
+
+	attributeKeys := make([]string, 0, len(filter.Attributes))
+	for key := range filter.Attributes {
+		attributeKeys = append(attributeKeys, key)
+	}
+	sort.Strings(attributeKeys)
+	for _, key := range attributeKeys {
+		conditions = append(conditions, `EXISTS (
+            SELECT 1 FROM item_attributes ia
+            WHERE ia.item_id = items.id AND ia.attr_key = ? AND ia.attr_value = ?
+        )`)
+		params = append(params, key, filter.Attributes[key])
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		params = append(params, filter.Status)
+	}
+
+	// 指定された保管場所とその配下の保管場所にあるアイテム
+	if filter.LocationID != 0 {
+		conditions = append(conditions, `location_id IN (
+            WITH RECURSIVE sub AS (
+                SELECT id FROM locations WHERE id = ? AND tenant_id = ?
+                UNION ALL
+                SELECT l.id FROM locations l JOIN sub ON l.parent_id = sub.id
+            )
+            SELECT id FROM sub
+        )`)
+		params = append(params, filter.LocationID, tenantID)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
         FROM items
+        WHERE %s
         ORDER BY created_at DESC
+    `, itemSelectColumns, strings.Join(conditions, " AND "))
+
+	return r.queryItems(ctx, query, params...)
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	return r.findByID(ctx, id, "")
+}
+
+// FindByIDForUpdateはアイテムの行をトランザクションの終了までロックして取得する。
+// 同じアイテムの状態を同時に変更するリクエストは、先に取得したトランザクションがコミットするまで待つ
+func (r *ItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	return r.findByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *ItemRepository) findByID(ctx context.Context, id int64, lock string) (*entity.Item, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE id = ? AND tenant_id = ?
+        %s
+    `, itemSelectColumns, lock)
+
+	row := r.QueryRow(ctx, query, id, tenantID)
+
+	item, err := scanItem(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if err := r.loadRelations(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO items (tenant_id, name, category, brand, purchase_price, purchase_date,
+            serial_number, model_number, condition_grade, authenticity, warranty_expires_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
-	rows, err := r.Query(ctx, query)
+	var id int64
+	err = r.Transaction(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query, itemInsertValues(tenantID, item)...)
+		if err != nil {
+			return translateItemWriteError(err, item)
+		}
+
+		id, err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		return r.saveAttributes(ctx, tenantID, id, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 書き込んだ直後の読み込みはレプリカの遅延の影響を受けないようプライマリに送る
+	return r.FindByID(WithPrimary(ctx), id)
+}
+
+// FindByIDsは指定したIDのアイテムをID順で取得する。存在しないIDは結果に含まれない
+func (r *ItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	if len(ids) == 0 {
+		return []*entity.Item{}, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE id IN (%s) AND tenant_id = ?
+        ORDER BY id
+    `, itemSelectColumns, placeholders(len(ids)))
+
+	return r.queryItems(ctx, query, append(int64sToArgs(ids), tenantID)...)
+}
+
+// FindBySerialNumberはシリアル番号が一致するアイテムを取得する。brandが空の場合はすべてのブランドが対象
+func (r *ItemRepository) FindBySerialNumber(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"tenant_id = ?", "serial_number = ?"}
+	params := []interface{}{tenantID, serialNumber}
+	if brand != "" {
+		conditions = append(conditions, "brand = ?")
+		params = append(params, brand)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE %s
+        ORDER BY id
+    `, itemSelectColumns, strings.Join(conditions, " AND "))
+
+	return r.queryItems(ctx, query, params...)
+}
+
+// アイテムを取得してタグと属性を読み込む
+func (r *ItemRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*entity.Item, error) {
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 	defer rows.Close()
 
-	var items []*entity.Item
+	items := []*entity.Item{}
 	for rows.Next() {
 		item, err := scanItem(rows)
 		if err != nil {
@@ -41,58 +233,140 @@ func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
+	if err := r.loadRelations(ctx, items); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 }
 
-func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+// CreateBatchは複数のアイテムを1つのトランザクション内で1件ずつ登録し、採番されたIDを含めて入力順で返す。
+// 複数行INSERTではLastInsertIdが先頭行のIDしか返さず、auto_increment_incrementやロックモードによっては
+// 残りの行のIDを推測できないため、行ごとにLastInsertIdを取得する
+func (r *ItemRepository) CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	if len(items) == 0 {
+		return []*entity.Item{}, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
-        FROM items
-        WHERE id = ?
+        INSERT INTO items (tenant_id, name, category, brand, purchase_price, purchase_date,
+            serial_number, model_number, condition_grade, authenticity, warranty_expires_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
-	row := r.QueryRow(ctx, query, id)
+	ids := make([]int64, len(items))
+	err = r.Transaction(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			result, err := r.Execute(ctx, query, itemInsertValues(tenantID, item)...)
+			if err != nil {
+				return &domainErrors.BatchItemError{Index: i, Err: translateItemWriteError(err, item)}
+			}
+
+			ids[i], err = result.LastInsertId()
+			if err != nil {
+				return &domainErrors.BatchItemError{Index: i, Err: fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())}
+			}
+
+			if err := r.saveAttributes(ctx, tenantID, ids[i], item); err != nil {
+				return &domainErrors.BatchItemError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	item, err := scanItem(row)
+	created, err := r.FindByIDs(WithPrimary(ctx), ids)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, domainErrors.ErrItemNotFound
+		return nil, err
+	}
+	if len(created) != len(items) {
+		return nil, fmt.Errorf("%w: expected %d created items, found %d", domainErrors.ErrDatabaseError, len(items), len(created))
+	}
+
+	return created, nil
+}
+
+// DeleteBatchは指定したIDのアイテムを1つのDELETE文で削除する。
+// 1件でも存在しないIDが含まれていた場合は、最初に見つからなかったIDの位置を示すBatchItemErrorで
+// ErrItemNotFoundを返す（トランザクション内で呼び出すこと）
+func (r *ItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	// 存在を確認してから削除するまでに他のリクエストに削除されないよう、対象の行をロックする
+	found, err := r.lockExistingIDs(ctx, tenantID, ids)
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if !found[id] {
+			return &domainErrors.BatchItemError{Index: i, Err: domainErrors.ErrItemNotFound}
 		}
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return item, nil
+	query := fmt.Sprintf(`DELETE FROM items WHERE id IN (%s) AND tenant_id = ?`, placeholders(len(ids)))
+
+	result, err := r.Execute(ctx, query, append(int64sToArgs(ids), tenantID)...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected != int64(len(ids)) {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
 }
 
-func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
-	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
-    `
+// lockExistingIDsは指定したIDのうち存在する行をロックし、そのIDを返す
+func (r *ItemRepository) lockExistingIDs(ctx context.Context, tenantID int64, ids []int64) (map[int64]bool, error) {
+	query := fmt.Sprintf(`SELECT id FROM items WHERE id IN (%s) AND tenant_id = ? FOR UPDATE`, placeholders(len(ids)))
 
-	result, err := r.Execute(ctx, query,
-		item.Name,
-		item.Category,
-		item.Brand,
-		item.PurchasePrice,
-		item.PurchaseDate,
-	)
+	rows, err := r.Query(ctx, query, append(int64sToArgs(ids), tenantID)...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
+	defer rows.Close()
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	found := make(map[int64]bool, len(ids))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		found[id] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return r.FindByID(ctx, id)
+	return found, nil
 }
 
 func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM items WHERE id = ?`
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM items WHERE id = ? AND tenant_id = ?`
 
-	result, err := r.Execute(ctx, query, id)
+	result, err := r.Execute(ctx, query, id, tenantID)
 	if err != nil {
 		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
@@ -109,14 +383,101 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// MergeIntoは重複アイテムのタグ・統合履歴・移動履歴・貸出記録を残すアイテムに付け替え、統合履歴を記録してから重複アイテムを削除する
+func (r *ItemRepository) MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(duplicate)
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode merged item: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		// 付与済みのタグは重複させない
+		{`INSERT IGNORE INTO item_tags (tenant_id, item_id, tag_id) SELECT tenant_id, ?, tag_id FROM item_tags WHERE item_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`UPDATE item_merges SET survivor_id = ? WHERE survivor_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`UPDATE item_movements SET item_id = ? WHERE item_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`UPDATE loans SET item_id = ? WHERE item_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`UPDATE maintenance_records SET item_id = ? WHERE item_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`UPDATE item_valuations SET item_id = ? WHERE item_id = ? AND tenant_id = ?`, []interface{}{survivorID, duplicate.ID, tenantID}},
+		{`INSERT INTO item_merges (tenant_id, survivor_id, merged_item_id, merged_item) VALUES (?, ?, ?, ?)`, []interface{}{tenantID, survivorID, duplicate.ID, string(snapshot)}},
+	}
+	// 統合先が保険に入っていなければ、重複アイテムの保険契約を引き継ぐ
+	if duplicate.InsurancePolicyID != nil {
+		statements = append(statements, struct {
+			query string
+			args  []interface{}
+		}{`UPDATE items SET insurance_policy_id = ? WHERE id = ? AND tenant_id = ? AND insurance_policy_id IS NULL`, []interface{}{*duplicate.InsurancePolicyID, survivorID, tenantID}})
+	}
+	for _, stmt := range statements {
+		if _, err := r.Execute(ctx, stmt.query, stmt.args...); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	// 重複アイテムに紐づく行（item_tags, item_attributes）は外部キーのON DELETE CASCADEで削除される
+	return r.Delete(ctx, duplicate.ID)
+}
+
+// FindMergeHistoryは指定したアイテムに統合されたアイテムの履歴を新しい順で取得する
+func (r *ItemRepository) FindMergeHistory(ctx context.Context, survivorID int64) ([]*entity.ItemMerge, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, survivor_id, merged_item_id, merged_item, merged_at
+        FROM item_merges
+        WHERE survivor_id = ? AND tenant_id = ?
+        ORDER BY merged_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, survivorID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	merges := []*entity.ItemMerge{}
+	for rows.Next() {
+		var merge entity.ItemMerge
+		var snapshot string
+		if err := rows.Scan(&merge.ID, &merge.SurvivorID, &merge.MergedItemID, &snapshot, &merge.MergedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if err := json.Unmarshal([]byte(snapshot), &merge.MergedItem); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode merged item: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		merges = append(merges, &merge)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return merges, nil
+}
+
 func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
         SELECT category, COUNT(*) as count
         FROM items
+        WHERE tenant_id = ?
         GROUP BY category
     `
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
@@ -139,63 +500,406 @@ func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]i
 	return summary, nil
 }
 
-// 💡 新規追加: Updateメソッド (PATCH対応)
-func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
-    // PATCHリクエストは部分更新であるため、動的にクエリを構築する
-    // ここでは、更新対象フィールド（name, brand, purchase_price）がitemに設定されていると仮定する
-    
-    // UPDATE句とWHERE句の基本を定義
-    updates := []string{}
-    params := []interface{}{}
-
-    // 更新対象フィールドのチェックとクエリの構築
-    // 注意: 本来、entity.Itemはnilを許容するポインタ型フィールドを持つべきですが、
-    // ここではユースケース層から渡されたitemが更新対象フィールドのみを持つと仮定して進めます。
-    
-    if item.Name != "" {
-        updates = append(updates, "name = ?")
-        params = append(params, item.Name)
-    }
-    if item.Brand != "" {
-        updates = append(updates, "brand = ?")
-        params = append(params, item.Brand)
-    }
-    if item.PurchasePrice >= 0 { // 0円以上の場合は更新対象とする（値が設定されたとみなす）
-        updates = append(updates, "purchase_price = ?")
-        params = append(params, item.PurchasePrice)
-    }
-    
-    // updated_at は必ず更新する
-    updates = append(updates, "updated_at = ?")
-    params = append(params, time.Now())
+func (r *ItemRepository) GetSummaryByStatus(ctx context.Context) (map[string]int, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-    if len(updates) == 1 && updates[0] == "updated_at = ?" {
-        // 更新対象フィールドがない場合は、処理を行わないかエラーとする
-        // 今回はユースケース層でチェック済みのため、ここでは更新対象が少なくとも一つあると仮定する
-        // 念のため、更新しない場合は元のアイテムを返す
-        return r.FindByID(ctx, item.ID)
-    }
+	query := `
+        SELECT status, COUNT(*) as count
+        FROM items
+        WHERE tenant_id = ?
+        GROUP BY status
+    `
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	summary := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		summary[status] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return summary, nil
+}
+
+// GetSalesByCategoryは売却済みアイテムの件数・購入価格の合計・売却価格の合計をカテゴリーごとに取得する
+func (r *ItemRepository) GetSalesByCategory(ctx context.Context) (map[string]*usecase.SalesTotals, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT category, COUNT(*), COALESCE(SUM(purchase_price), 0), COALESCE(SUM(sale_price), 0)
+        FROM items
+        WHERE tenant_id = ? AND status = ?
+        GROUP BY category
+    `
+
+	rows, err := r.Query(ctx, query, tenantID, entity.ItemStatusSold)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	sales := make(map[string]*usecase.SalesTotals)
+	for rows.Next() {
+		var category string
+		var totals usecase.SalesTotals
+		if err := rows.Scan(&category, &totals.SoldCount, &totals.PurchaseTotal, &totals.SaleTotal); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		sales[category] = &totals
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return sales, nil
+}
+
+// GetSummaryByLocationは保管場所ごとに直接保管されているアイテムの件数と購入価格の合計を取得する。
+// 末尾に保管場所が未設定のアイテムの集計を加える
+func (r *ItemRepository) GetSummaryByLocation(ctx context.Context) ([]*usecase.LocationSummary, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT l.id, l.name, l.type, l.parent_id, COUNT(i.id), COALESCE(SUM(i.purchase_price), 0)
+        FROM locations l
+        LEFT JOIN items i ON i.location_id = l.id AND i.tenant_id = l.tenant_id
+        WHERE l.tenant_id = ?
+        GROUP BY l.id, l.name, l.type, l.parent_id
+        ORDER BY l.id
+    `
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	summaries := []*usecase.LocationSummary{}
+	for rows.Next() {
+		var summary usecase.LocationSummary
+		var locationID int64
+		var parentID sql.NullInt64
+		if err := rows.Scan(&locationID, &summary.Name, &summary.Type, &parentID, &summary.ItemCount, &summary.TotalValue); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		summary.LocationID = &locationID
+		summary.ParentID = nullableInt64Ptr(parentID)
+		summaries = append(summaries, &summary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	unassigned := usecase.LocationSummary{Name: "unassigned"}
+	err = r.QueryRow(ctx, `SELECT COUNT(*), COALESCE(SUM(purchase_price), 0) FROM items WHERE tenant_id = ? AND location_id IS NULL`, tenantID).
+		Scan(&unassigned.ItemCount, &unassigned.TotalValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
 
-    // SQLクエリの構築
-    query := fmt.Sprintf("UPDATE items SET %s WHERE id = ?", strings.Join(updates, ", "))
-    params = append(params, item.ID) // IDをWHERE句のパラメータとして追加
+	return append(summaries, &unassigned), nil
+}
 
-    result, err := r.Execute(ctx, query, params...)
+// Updateはエンティティが変更として記録したカラムだけを更新する
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+    changes := item.ChangedFields()
+    if len(changes) == 0 {
+        return r.FindByID(ctx, item.ID)
+    }
+    tenantID, err := tenantFrom(ctx)
     if err != nil {
-        return nil, fmt.Errorf("%w: failed to execute update: %s", domainErrors.ErrDatabaseError, err.Error())
+        return nil, err
     }
 
-    rowsAffected, err := result.RowsAffected()
-    if err != nil {
-        return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+    updates := make([]string, 0, len(changes)+1)
+    params := make([]interface{}, 0, len(changes)+2)
+    attributesChanged := false
+    for _, field := range changes {
+        // 属性は別テーブルで管理する
+        if field == entity.FieldAttributes {
+            attributesChanged = true
+            continue
+        }
+        column, value, err := itemColumn(item, field)
+        if err != nil {
+            return nil, err
+        }
+        updates = append(updates, column+" = ?")
+        params = append(params, value)
     }
 
-    if rowsAffected == 0 {
-        return nil, domainErrors.ErrItemNotFound
+    updates = append(updates, "updated_at = ?")
+    params = append(params, item.UpdatedAt)
+
+    query := fmt.Sprintf("UPDATE items SET %s WHERE id = ? AND tenant_id = ?", strings.Join(updates, ", "))
+    params = append(params, item.ID, tenantID)
+
+    err = r.Transaction(ctx, func(ctx context.Context) error {
+        result, err := r.Execute(ctx, query, params...)
+        if err != nil {
+            if errors.Is(err, ErrDuplicateKey) {
+                return translateItemWriteError(err, item)
+            }
+            return fmt.Errorf("%w: failed to execute update: %s", domainErrors.ErrDatabaseError, err.Error())
+        }
+
+        rowsAffected, err := result.RowsAffected()
+        if err != nil {
+            return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+        }
+
+        if rowsAffected == 0 {
+            return domainErrors.ErrItemNotFound
+        }
+
+        if attributesChanged {
+            return r.saveAttributes(ctx, tenantID, item.ID, item)
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
     }
 
+    item.ClearChanges()
+
     // 更新後のアイテムを取得して返す
-    return r.FindByID(ctx, item.ID)
+    return r.FindByID(WithPrimary(ctx), item.ID)
+}
+
+// 変更されたフィールドに対応するカラム名と値を返す
+func itemColumn(item *entity.Item, field entity.ItemField) (string, interface{}, error) {
+	switch field {
+	case entity.FieldName:
+		return "name", item.Name, nil
+	case entity.FieldCategory:
+		return "category", item.Category, nil
+	case entity.FieldBrand:
+		return "brand", item.Brand, nil
+	case entity.FieldPurchasePrice:
+		return "purchase_price", item.PurchasePrice, nil
+	case entity.FieldPurchaseDate:
+		return "purchase_date", item.PurchaseDate, nil
+	case entity.FieldSerialNumber:
+		return "serial_number", nullableString(item.SerialNumber), nil
+	case entity.FieldModelNumber:
+		return "model_number", item.ModelNumber, nil
+	case entity.FieldCondition:
+		return "condition_grade", item.Condition, nil
+	case entity.FieldAuthenticity:
+		return "authenticity", item.Authenticity, nil
+	case entity.FieldStatus:
+		return "status", item.Status, nil
+	case entity.FieldSalePrice:
+		if item.SalePrice == nil {
+			return "sale_price", nil, nil
+		}
+		return "sale_price", *item.SalePrice, nil
+	case entity.FieldSaleDate:
+		return "sale_date", nullableString(item.SaleDate), nil
+	case entity.FieldWarrantyExpiresAt:
+		return "warranty_expires_at", nullableString(item.WarrantyExpiresAt), nil
+	default:
+		return "", nil, fmt.Errorf("%w: unknown field %s", domainErrors.ErrDatabaseError, field)
+	}
+}
+
+// INSERTするカラムの値（itemsのINSERT文のカラム順）
+func itemInsertValues(tenantID int64, item *entity.Item) []interface{} {
+	authenticity := item.Authenticity
+	if authenticity == "" {
+		authenticity = entity.AuthenticityUnverified
+	}
+	return []interface{}{
+		tenantID,
+		item.Name,
+		item.Category,
+		item.Brand,
+		item.PurchasePrice,
+		item.PurchaseDate,
+		nullableString(item.SerialNumber),
+		item.ModelNumber,
+		item.Condition,
+		authenticity,
+		nullableString(item.WarrantyExpiresAt),
+	}
+}
+
+// シリアル番号の一意制約違反をErrDuplicateEntryに変換する
+func translateItemWriteError(err error, item *entity.Item) error {
+	if errors.Is(err, ErrDuplicateKey) {
+		return fmt.Errorf("%w: an item with brand %s and serial number %s already exists",
+			domainErrors.ErrDuplicateEntry, item.Brand, item.SerialNumber)
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+}
+
+// NULLを許可する整数カラムの値をポインタで返す
+func nullableInt64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+// 空文字をNULLとして保存する（NULLは一意制約の対象外になる）
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// アイテムの属性を保存する（既存の属性はすべて置き換える）
+func (r *ItemRepository) saveAttributes(ctx context.Context, tenantID, itemID int64, item *entity.Item) error {
+	if _, err := r.Execute(ctx, `DELETE FROM item_attributes WHERE item_id = ? AND tenant_id = ?`, itemID, tenantID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if len(item.Attributes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(item.Attributes))
+	for key := range item.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+	params := make([]interface{}, 0, len(keys)*4)
+	for _, key := range keys {
+		def, ok := entity.LookupAttribute(item.Category, key)
+		if !ok {
+			return fmt.Errorf("%w: attribute %s is not defined for category %s", domainErrors.ErrInvalidInput, key, item.Category)
+		}
+		values = append(values, "(?, ?, ?, ?)")
+		params = append(params, tenantID, itemID, key, def.Encode(item.Attributes[key]))
+	}
+
+	query := fmt.Sprintf(`INSERT INTO item_attributes (tenant_id, item_id, attr_key, attr_value) VALUES %s`, strings.Join(values, ", "))
+	if _, err := r.Execute(ctx, query, params...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+// アイテムのタグと属性をまとめて読み込む
+func (r *ItemRepository) loadRelations(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*entity.Item, len(items))
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		item.Tags = []string{}
+		item.Attributes = map[string]interface{}{}
+		byID[item.ID] = item
+		ids[i] = item.ID
+	}
+
+	tagQuery := fmt.Sprintf(`
+        SELECT it.item_id, t.name
+        FROM item_tags it
+        JOIN tags t ON t.id = it.tag_id
+        WHERE it.item_id IN (%s) AND it.tenant_id = ?
+        ORDER BY t.name
+    `, placeholders(len(ids)))
+
+	rows, err := r.Query(ctx, tagQuery, append(int64sToArgs(ids), tenantID)...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	for rows.Next() {
+		var itemID int64
+		var name string
+		if err := rows.Scan(&itemID, &name); err != nil {
+			rows.Close()
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		byID[itemID].Tags = append(byID[itemID].Tags, name)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	rows.Close()
+
+	attributeQuery := fmt.Sprintf(`
+        SELECT item_id, attr_key, attr_value
+        FROM item_attributes
+        WHERE item_id IN (%s) AND tenant_id = ?
+    `, placeholders(len(ids)))
+
+	rows, err = r.Query(ctx, attributeQuery, append(int64sToArgs(ids), tenantID)...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var itemID int64
+		var key, raw string
+		if err := rows.Scan(&itemID, &key, &raw); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		item := byID[itemID]
+		var value interface{} = raw
+		// スキーマから外れた属性は文字列のまま返す
+		if def, ok := entity.LookupAttribute(item.Category, key); ok {
+			if decoded, err := def.Decode(raw); err == nil {
+				value = decoded
+			}
+		}
+		item.Attributes[key] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+// n個のプレースホルダー "?, ?, ..." を生成する
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func int64sToArgs(ids []int64) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
 }
 
 func scanItem(scanner interface {
@@ -203,6 +907,11 @@ func scanItem(scanner interface {
 }) (*entity.Item, error) {
 	var item entity.Item
 	var purchaseDate string
+	var serialNumber sql.NullString
+	var locationID, insurancePolicyID sql.NullInt64
+	var status string
+	var salePrice sql.NullInt64
+	var saleDate, warrantyExpiresAt sql.NullString
 	var createdAt, updatedAt time.Time
 
 	err := scanner.Scan(
@@ -212,6 +921,16 @@ func scanItem(scanner interface {
 		&item.Brand,
 		&item.PurchasePrice,
 		&purchaseDate,
+		&serialNumber,
+		&item.ModelNumber,
+		&item.Condition,
+		&item.Authenticity,
+		&locationID,
+		&status,
+		&salePrice,
+		&saleDate,
+		&warrantyExpiresAt,
+		&insurancePolicyID,
 		&createdAt,
 		&updatedAt,
 	)
@@ -219,16 +938,34 @@ func scanItem(scanner interface {
 		return nil, err
 	}
 
-	if purchaseDate != "" {
-		if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
-			item.PurchaseDate = parsedDate.Format("2006-01-02")
-		} else {
-			item.PurchaseDate = purchaseDate
-		}
+	item.SerialNumber = serialNumber.String
+	item.LocationID = nullableInt64Ptr(locationID)
+	item.Status = entity.ItemStatus(status)
+	if salePrice.Valid {
+		price := int(salePrice.Int64)
+		item.SalePrice = &price
 	}
+	item.SaleDate = formatDate(saleDate.String)
+	item.WarrantyExpiresAt = formatDate(warrantyExpiresAt.String)
+	item.InsurancePolicyID = nullableInt64Ptr(insurancePolicyID)
+
+	item.PurchaseDate = formatDate(purchaseDate)
 
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
 
 	return &item, nil
 }
+
+// DATEカラムの値をYYYY-MM-DD形式にする（parseTime=trueの場合はRFC3339形式で読み込まれる）
+func formatDate(date string) string {
+	if date == "" {
+		return ""
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339Nano} {
+		if parsedDate, err := time.Parse(layout, date); err == nil {
+			return parsedDate.Format("2006-01-02")
+		}
+	}
+	return date
+}
@@ -0,0 +1,367 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeSqlHandler は実行されたSQLと引数を記録するテスト用のSqlHandler
+type fakeSqlHandler struct {
+	statements []string
+	args       [][]interface{}
+	// QueryRowで返す行の値
+	row []interface{}
+	// Executeで返す影響行数
+	rowsAffected int64
+	// Executeで返すエラー
+	execErr error
+	// Executeごとに順に返すLastInsertId
+	insertIDs []int64
+}
+
+func (h *fakeSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	if h.execErr != nil {
+		return nil, h.execErr
+	}
+	var insertID int64
+	if len(h.insertIDs) > 0 {
+		insertID, h.insertIDs = h.insertIDs[0], h.insertIDs[1:]
+	}
+	return fakeResult{rowsAffected: h.rowsAffected, insertID: insertID}, nil
+}
+
+// Query はタグ・属性の読み込みで使われるため、常に空の結果を返す
+func (h *fakeSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return fakeRows{}, nil
+}
+
+func (h *fakeSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return fakeRow{values: h.row}
+}
+
+func (h *fakeSqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (h *fakeSqlHandler) Close() error {
+	return nil
+}
+
+type fakeResult struct {
+	rowsAffected int64
+	insertID     int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.insertID, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Next() bool                     { return false }
+func (fakeRows) Scan(dest ...interface{}) error { return errors.New("no rows") }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Err() error                     { return nil }
+
+type fakeRow struct {
+	values []interface{}
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.values == nil {
+		return errors.New("no rows")
+	}
+	for i, d := range dest {
+		switch p := d.(type) {
+		case *int64:
+			*p = r.values[i].(int64)
+		case *int:
+			*p = r.values[i].(int)
+		case *string:
+			*p = r.values[i].(string)
+		case *sql.NullString:
+			p.String, p.Valid = r.values[i].(string), r.values[i].(string) != ""
+		case *sql.NullInt64:
+			p.Int64, p.Valid = r.values[i].(int64)
+		case *time.Time:
+			*p = r.values[i].(time.Time)
+		}
+	}
+	return nil
+}
+
+func storedItemRow(item *entity.Item) []interface{} {
+	var salePrice interface{}
+	if item.SalePrice != nil {
+		salePrice = int64(*item.SalePrice)
+	}
+	return []interface{}{
+		item.ID, item.Name, item.Category, item.Brand, item.PurchasePrice,
+		item.PurchaseDate, item.SerialNumber, item.ModelNumber, item.Condition, item.Authenticity,
+		nullableInt64(item.LocationID), string(item.Status), salePrice, item.SaleDate, item.WarrantyExpiresAt,
+		nullableInt64(item.InsurancePolicyID), item.CreatedAt, item.UpdatedAt,
+	}
+}
+
+func TestItemRepository_Update(t *testing.T) {
+	tests := []struct {
+		name            string
+		patch           entity.ItemPatch
+		expectedColumns []string
+		expectedValues  []interface{}
+	}{
+		{
+			name:            "正常系: 0円への変更でもpurchase_priceが書き込まれる",
+			patch:           entity.ItemPatch{PurchasePrice: intPtr(0)},
+			expectedColumns: []string{"purchase_price = ?"},
+			expectedValues:  []interface{}{0},
+		},
+		{
+			name:            "正常系: 変更されたカラムだけが書き込まれる",
+			patch:           entity.ItemPatch{Category: strPtr("ジュエリー"), Name: strPtr("ロレックス"), PurchaseDate: strPtr("2022-10-10")},
+			expectedColumns: []string{"category = ?", "purchase_date = ?"},
+			expectedValues:  []interface{}{"ジュエリー", "2022-10-10"},
+		},
+		{
+			name:            "正常系: 保証期限を空にするとNULLが書き込まれる",
+			patch:           entity.ItemPatch{WarrantyExpiresAt: strPtr("")},
+			expectedColumns: []string{"warranty_expires_at = ?"},
+			expectedValues:  []interface{}{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{
+				ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+				PurchaseDate: "2023-01-01", WarrantyExpiresAt: "2025-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+			}
+			require.NoError(t, item.Apply(tt.patch))
+
+			handler := &fakeSqlHandler{row: storedItemRow(item), rowsAffected: 1}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			updated, err := repo.Update(usecase.WithTenant(context.Background(), 1), item)
+			require.NoError(t, err)
+			assert.Equal(t, item.ID, updated.ID)
+			assert.False(t, item.HasChanges())
+
+			require.Len(t, handler.statements, 1)
+			expectedSet := strings.Join(append(tt.expectedColumns, "updated_at = ?"), ", ")
+			assert.Equal(t, "UPDATE items SET "+expectedSet+" WHERE id = ? AND tenant_id = ?", handler.statements[0])
+
+			args := handler.args[0]
+			require.Len(t, args, len(tt.expectedValues)+3)
+			assert.Equal(t, tt.expectedValues, args[:len(tt.expectedValues)])
+			assert.Equal(t, item.ID, args[len(args)-2])
+			assert.Equal(t, int64(1), args[len(args)-1])
+		})
+	}
+}
+
+func TestItemRepository_Update_NoChanges(t *testing.T) {
+	item := &entity.Item{
+		ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 0,
+		PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	handler := &fakeSqlHandler{row: storedItemRow(item), rowsAffected: 1}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	updated, err := repo.Update(usecase.WithTenant(context.Background(), 1), item)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated.PurchasePrice)
+	// 変更がなければUPDATEは発行されない
+	assert.Empty(t, handler.statements)
+}
+
+func TestItemRepository_Update_NotFound(t *testing.T) {
+	item := &entity.Item{
+		ID: 999, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+		PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	require.NoError(t, item.Apply(entity.ItemPatch{Name: strPtr("新しい名前")}))
+
+	handler := &fakeSqlHandler{rowsAffected: 0}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	updated, err := repo.Update(usecase.WithTenant(context.Background(), 1), item)
+
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	assert.Nil(t, updated)
+}
+
+func TestItemRepository_DuplicateSerialNumber(t *testing.T) {
+	item, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+	require.NoError(t, err)
+	require.NoError(t, item.Apply(entity.ItemPatch{SerialNumber: strPtr("Z123456")}))
+
+	handler := &fakeSqlHandler{execErr: fmt.Errorf("%w: Duplicate entry 'ROLEX-Z123456'", ErrDuplicateKey)}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	ctx := usecase.WithTenant(context.Background(), 1)
+	created, err := repo.Create(ctx, item)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	assert.Nil(t, created)
+
+	item.ID = 1
+	updated, err := repo.Update(ctx, item)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	assert.Nil(t, updated)
+}
+
+func TestItemRepository_Create_EmptySerialNumberIsNull(t *testing.T) {
+	item, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+	require.NoError(t, err)
+
+	handler := &fakeSqlHandler{row: storedItemRow(item), rowsAffected: 1}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	_, err = repo.Create(usecase.WithTenant(context.Background(), 1), item)
+	require.NoError(t, err)
+
+	// 空のシリアル番号はNULLで保存し、(tenant_id, brand, serial_number)の一意制約の対象外にする
+	args := handler.args[0]
+	assert.Nil(t, args[6])
+	assert.Equal(t, entity.AuthenticityUnverified, args[9])
+}
+
+func TestItemRepository_MergeInto_CarriesInsurancePolicy(t *testing.T) {
+	policyID := int64(7)
+	duplicate := &entity.Item{ID: 3, Name: "ロレックス", Category: "時計", Brand: "ROLEX", InsurancePolicyID: &policyID}
+
+	handler := &fakeSqlHandler{rowsAffected: 1}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	require.NoError(t, repo.MergeInto(usecase.WithTenant(context.Background(), 2), 1, duplicate))
+
+	// 統合先が保険に入っていない場合だけ引き継ぎ、重複アイテムはその後に削除する
+	n := len(handler.statements)
+	require.GreaterOrEqual(t, n, 2)
+	assert.Equal(t, `UPDATE items SET insurance_policy_id = ? WHERE id = ? AND tenant_id = ? AND insurance_policy_id IS NULL`, handler.statements[n-2])
+	assert.Equal(t, []interface{}{int64(7), int64(1), int64(2)}, handler.args[n-2])
+	assert.Equal(t, `DELETE FROM items WHERE id = ? AND tenant_id = ?`, handler.statements[n-1])
+}
+
+// failingExecSqlHandler はmatchを含むExecuteのうちfailAt番目（0始まり）だけをerrで失敗させるSqlHandler
+type failingExecSqlHandler struct {
+	*fakeSqlHandler
+	match   string
+	failAt  int
+	err     error
+	matched int
+}
+
+func (h *failingExecSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	if strings.Contains(statement, h.match) {
+		h.matched++
+		if h.matched-1 == h.failAt {
+			h.statements = append(h.statements, statement)
+			h.args = append(h.args, args)
+			return nil, h.err
+		}
+	}
+	return h.fakeSqlHandler.Execute(ctx, statement, args...)
+}
+
+func TestItemRepository_CreateBatch_ReportsFailedItem(t *testing.T) {
+	newItem := func(serialNumber string) *entity.Item {
+		item, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		require.NoError(t, err)
+		require.NoError(t, item.Apply(entity.ItemPatch{SerialNumber: strPtr(serialNumber)}))
+		return item
+	}
+	handler := &failingExecSqlHandler{
+		fakeSqlHandler: &fakeSqlHandler{insertIDs: []int64{1, 2}},
+		match:          "INSERT INTO items",
+		failAt:         1,
+		err:            fmt.Errorf("%w: Duplicate entry 'ROLEX-Z2'", ErrDuplicateKey),
+	}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	created, err := repo.CreateBatch(usecase.WithTenant(context.Background(), 1), []*entity.Item{newItem("Z1"), newItem("Z2"), newItem("Z3")})
+
+	var itemErr *domainErrors.BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	assert.Nil(t, created)
+	// 失敗した行より後は登録しない
+	assert.Equal(t, 2, handler.matched)
+}
+
+// idRows はidの列だけを返すRows
+type idRows struct {
+	ids []int64
+}
+
+func (r *idRows) Next() bool {
+	return len(r.ids) > 0
+}
+
+func (r *idRows) Scan(dest ...interface{}) error {
+	*dest[0].(*int64), r.ids = r.ids[0], r.ids[1:]
+	return nil
+}
+
+func (r *idRows) Close() error { return nil }
+func (r *idRows) Err() error   { return nil }
+
+// existingIDsSqlHandler はQueryでexistingのIDだけが存在するものとして返すSqlHandler
+type existingIDsSqlHandler struct {
+	*fakeSqlHandler
+	existing []int64
+}
+
+func (h *existingIDsSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return &idRows{ids: h.existing}, nil
+}
+
+func TestItemRepository_DeleteBatch(t *testing.T) {
+	t.Run("正常系: 対象の行をロックしてから削除する", func(t *testing.T) {
+		handler := &existingIDsSqlHandler{fakeSqlHandler: &fakeSqlHandler{rowsAffected: 2}, existing: []int64{2, 3}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		require.NoError(t, repo.DeleteBatch(usecase.WithTenant(context.Background(), 1), []int64{2, 3}))
+
+		require.Len(t, handler.statements, 2)
+		assert.True(t, strings.HasSuffix(handler.statements[0], "FOR UPDATE"))
+		assert.Equal(t, `DELETE FROM items WHERE id IN (?, ?) AND tenant_id = ?`, handler.statements[1])
+	})
+
+	t.Run("異常系: 存在しないIDの位置を返し、削除しない", func(t *testing.T) {
+		handler := &existingIDsSqlHandler{fakeSqlHandler: &fakeSqlHandler{rowsAffected: 2}, existing: []int64{2, 3}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.DeleteBatch(usecase.WithTenant(context.Background(), 1), []int64{2, 999, 3})
+
+		var itemErr *domainErrors.BatchItemError
+		require.ErrorAs(t, err, &itemErr)
+		assert.Equal(t, 1, itemErr.Index)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Len(t, handler.statements, 1)
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	// アイテムを所有するテナント
+	ownerTenantID int64 = 10
+	// 他のテナント
+	otherTenantID int64 = 20
+)
+
+// tenantSqlHandler はアイテムを1件だけ持つownerTenantIDのデータベースの代わりに、すべてのSQLを記録する。
+// 引数にownerTenantIDを含むSQLだけがその行を読み書きできる
+type tenantSqlHandler struct {
+	fakeSqlHandler
+	ownerTenantID int64
+}
+
+func newTenantSqlHandler() *tenantSqlHandler {
+	item := &entity.Item{
+		ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+		PurchaseDate: "2023-01-15", SerialNumber: "Z123456", Status: entity.ItemStatusOwned,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	return &tenantSqlHandler{fakeSqlHandler: fakeSqlHandler{row: storedItemRow(item)}, ownerTenantID: ownerTenantID}
+}
+
+func (h *tenantSqlHandler) owns(args []interface{}) bool {
+	for _, arg := range args {
+		if arg == h.ownerTenantID {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *tenantSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	if h.owns(args) {
+		return fakeResult{rowsAffected: 1}, nil
+	}
+	return fakeResult{}, nil
+}
+
+func (h *tenantSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return fakeRows{}, nil
+}
+
+func (h *tenantSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return tenantRow{fakeRow: fakeRow{values: h.row}, found: h.owns(args)}
+}
+
+type tenantRow struct {
+	fakeRow
+	found bool
+}
+
+func (r tenantRow) Scan(dest ...interface{}) error {
+	if !r.found {
+		return sql.ErrNoRows
+	}
+	return r.fakeRow.Scan(dest...)
+}
+
+func itemRepositoryCalls() []struct {
+	name string
+	call func(ctx context.Context, repo *ItemRepository) error
+} {
+	newItem := func() *entity.Item {
+		return &entity.Item{Name: "カルティエ タンク", Category: "時計", Brand: "Cartier", PurchaseDate: "2023-01-15",
+			Attributes: map[string]interface{}{"case_size_mm": 40.0}}
+	}
+	return []struct {
+		name string
+		call func(ctx context.Context, repo *ItemRepository) error
+	}{
+		{"FindAll", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindAll(ctx)
+			return err
+		}},
+		{"FindByFilter", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindByFilter(ctx, usecase.ItemFilter{
+				Category: "時計", Tags: []string{"限定"}, Attributes: map[string]string{"movement": "automatic"},
+				Status: entity.ItemStatusOwned, LocationID: 1,
+			})
+			return err
+		}},
+		{"FindByID", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindByID(ctx, 1)
+			return err
+		}},
+		{"FindByIDForUpdate", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindByIDForUpdate(ctx, 1)
+			return err
+		}},
+		{"FindByIDs", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindByIDs(ctx, []int64{1, 2})
+			return err
+		}},
+		{"FindBySerialNumber", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindBySerialNumber(ctx, "Z123456", "ROLEX")
+			return err
+		}},
+		{"Create", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.Create(ctx, newItem())
+			return err
+		}},
+		{"CreateBatch", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.CreateBatch(ctx, []*entity.Item{newItem(), newItem()})
+			return err
+		}},
+		{"Update", func(ctx context.Context, repo *ItemRepository) error {
+			item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"}
+			if err := item.Apply(entity.ItemPatch{Name: strPtr("ロレックス デイトナ"), Attributes: map[string]interface{}{"case_size_mm": 40.0}}); err != nil {
+				return err
+			}
+			_, err := repo.Update(ctx, item)
+			return err
+		}},
+		{"Delete", func(ctx context.Context, repo *ItemRepository) error {
+			return repo.Delete(ctx, 1)
+		}},
+		{"DeleteBatch", func(ctx context.Context, repo *ItemRepository) error {
+			return repo.DeleteBatch(ctx, []int64{1})
+		}},
+		{"MergeInto", func(ctx context.Context, repo *ItemRepository) error {
+			return repo.MergeInto(ctx, 1, &entity.Item{ID: 2})
+		}},
+		{"FindMergeHistory", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.FindMergeHistory(ctx, 1)
+			return err
+		}},
+		{"GetSummaryByCategory", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.GetSummaryByCategory(ctx)
+			return err
+		}},
+		{"GetSummaryByLocation", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.GetSummaryByLocation(ctx)
+			return err
+		}},
+		{"GetSummaryByStatus", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.GetSummaryByStatus(ctx)
+			return err
+		}},
+		{"GetSalesByCategory", func(ctx context.Context, repo *ItemRepository) error {
+			_, err := repo.GetSalesByCategory(ctx)
+			return err
+		}},
+	}
+}
+
+// すべてのSQLがctxのテナントで絞り込まれ、他のテナントのIDを引数に含まない
+func TestItemRepository_ScopesEveryStatementToTenant(t *testing.T) {
+	for _, tt := range itemRepositoryCalls() {
+		t.Run("正常系: "+tt.name, func(t *testing.T) {
+			handler := newTenantSqlHandler()
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_ = tt.call(usecase.WithTenant(context.Background(), otherTenantID), repo)
+
+			require.NotEmpty(t, handler.statements)
+			for i, statement := range handler.statements {
+				assert.Contains(t, statement, "tenant_id", "statement: %s", statement)
+				assert.Contains(t, handler.args[i], otherTenantID, "statement: %s", statement)
+				assert.NotContains(t, handler.args[i], ownerTenantID, "statement: %s", statement)
+			}
+		})
+	}
+}
+
+// テナントのないctxではSQLを実行せずにエラーを返す
+func TestItemRepository_RequiresTenant(t *testing.T) {
+	for _, tt := range itemRepositoryCalls() {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			handler := newTenantSqlHandler()
+			repo := &ItemRepository{SqlHandler: handler}
+
+			err := tt.call(context.Background(), repo)
+
+			assert.ErrorIs(t, err, domainErrors.ErrTenantRequired)
+			assert.Empty(t, handler.statements)
+		})
+	}
+}
+
+// 他のテナントのアイテムは読み込みも変更もできない
+func TestItemRepository_CrossTenantAccess(t *testing.T) {
+	owner := usecase.WithTenant(context.Background(), ownerTenantID)
+	other := usecase.WithTenant(context.Background(), otherTenantID)
+
+	t.Run("正常系: 所有するテナントは読み込み・変更できる", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: newTenantSqlHandler()}
+
+		item, err := repo.FindByID(owner, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+
+		require.NoError(t, item.Apply(entity.ItemPatch{Name: strPtr("ロレックス")}))
+		_, err = repo.Update(owner, item)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(owner, 1))
+	})
+
+	t.Run("異常系: 他のテナントからは存在しないアイテムとして扱う", func(t *testing.T) {
+		handler := newTenantSqlHandler()
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.FindByID(other, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"}
+		require.NoError(t, item.Apply(entity.ItemPatch{Name: strPtr("乗っ取り")}))
+		_, err = repo.Update(other, item)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		assert.ErrorIs(t, repo.Delete(other, 1), domainErrors.ErrItemNotFound)
+		assert.ErrorIs(t, repo.DeleteBatch(other, []int64{1}), domainErrors.ErrItemNotFound)
+
+		// 更新・削除はどのテナントの行にも当たっていない
+		for i, statement := range handler.statements {
+			if strings.HasPrefix(strings.TrimSpace(statement), "UPDATE") || strings.HasPrefix(strings.TrimSpace(statement), "DELETE") {
+				assert.NotContains(t, handler.args[i], ownerTenantID)
+			}
+		}
+	})
+
+	t.Run("正常系: 作成したアイテムはctxのテナントに属する", func(t *testing.T) {
+		handler := newTenantSqlHandler()
+		repo := &ItemRepository{SqlHandler: handler}
+
+		// このテストのデータベースは作成した行を保存しないため、作成後の再取得の結果は確認しない
+		_, _ = repo.Create(other, &entity.Item{Name: "カルティエ タンク", Category: "時計", Brand: "Cartier", PurchaseDate: "2023-01-15"})
+
+		require.NotEmpty(t, handler.statements)
+		assert.Contains(t, handler.statements[0], "INSERT INTO items (tenant_id,")
+		assert.Equal(t, otherTenantID, handler.args[0][0])
+	})
+}
@@ -0,0 +1,294 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type LoanRepository struct {
+	SqlHandler
+}
+
+// scanLoanで読み込むカラム（loansをl、borrowersをbとして結合する）
+const loanSelectColumns = `l.id, l.item_id, l.borrower_id, l.lent_at, l.due_date, l.returned_at, l.note, l.last_notified_at,
+            b.id, b.name, b.contact, b.created_at`
+
+func (r *LoanRepository) FindBorrowers(ctx context.Context) ([]*entity.Borrower, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Query(ctx, `SELECT id, name, contact, created_at FROM borrowers WHERE tenant_id = ? ORDER BY name, id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	borrowers := []*entity.Borrower{}
+	for rows.Next() {
+		var borrower entity.Borrower
+		if err := rows.Scan(&borrower.ID, &borrower.Name, &borrower.Contact, &borrower.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		borrowers = append(borrowers, &borrower)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return borrowers, nil
+}
+
+func (r *LoanRepository) FindBorrowerByID(ctx context.Context, id int64) (*entity.Borrower, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var borrower entity.Borrower
+	err = r.QueryRow(ctx, `SELECT id, name, contact, created_at FROM borrowers WHERE id = ? AND tenant_id = ?`, id, tenantID).
+		Scan(&borrower.ID, &borrower.Name, &borrower.Contact, &borrower.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrBorrowerNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &borrower, nil
+}
+
+func (r *LoanRepository) CreateBorrower(ctx context.Context, borrower *entity.Borrower) (*entity.Borrower, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Execute(ctx, `INSERT INTO borrowers (tenant_id, name, contact) VALUES (?, ?, ?)`, tenantID, borrower.Name, borrower.Contact)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindBorrowerByID(ctx, id)
+}
+
+func (r *LoanRepository) FindLoans(ctx context.Context, filter usecase.LoanFilter) ([]*entity.Loan, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"l.tenant_id = ?"}
+	params := []interface{}{tenantID}
+
+	if filter.ItemID != 0 {
+		conditions = append(conditions, "l.item_id = ?")
+		params = append(params, filter.ItemID)
+	}
+	if filter.ActiveOnly {
+		conditions = append(conditions, "l.returned_at IS NULL")
+	}
+	if filter.DueBefore != "" {
+		conditions = append(conditions, "l.due_date < ?")
+		params = append(params, filter.DueBefore)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM loans l
+        JOIN borrowers b ON b.id = l.borrower_id
+        WHERE %s
+        ORDER BY l.lent_at DESC, l.id DESC
+    `, loanSelectColumns, strings.Join(conditions, " AND "))
+
+	rows, err := r.Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	loans := []*entity.Loan{}
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		loans = append(loans, loan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return loans, nil
+}
+
+func (r *LoanRepository) FindLoanByID(ctx context.Context, id int64) (*entity.Loan, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM loans l
+        JOIN borrowers b ON b.id = l.borrower_id
+        WHERE l.id = ? AND l.tenant_id = ?
+    `, loanSelectColumns)
+
+	loan, err := scanLoan(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrLoanNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return loan, nil
+}
+
+func (r *LoanRepository) FindActiveLoanByItem(ctx context.Context, itemID int64) (*entity.Loan, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM loans l
+        JOIN borrowers b ON b.id = l.borrower_id
+        WHERE l.item_id = ? AND l.tenant_id = ? AND l.returned_at IS NULL
+        ORDER BY l.id DESC
+        LIMIT 1
+    `, loanSelectColumns)
+
+	loan, err := scanLoan(r.QueryRow(ctx, query, itemID, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrLoanNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return loan, nil
+}
+
+func (r *LoanRepository) CreateLoan(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO loans (tenant_id, item_id, borrower_id, lent_at, due_date, note)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, tenantID, loan.ItemID, loan.BorrowerID, loan.LentAt, loan.DueDate, loan.Note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindLoanByID(ctx, id)
+}
+
+func (r *LoanRepository) ReturnLoan(ctx context.Context, loan *entity.Loan) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 同時に返却された場合に返却日時を上書きしないよう、未返却の行だけを更新する
+	result, err := r.Execute(ctx, `UPDATE loans SET returned_at = ? WHERE id = ? AND tenant_id = ? AND returned_at IS NULL`,
+		loan.ReturnedAt, loan.ID, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrLoanNotFound
+	}
+
+	return nil
+}
+
+func (r *LoanRepository) MarkNotified(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE loans SET last_notified_at = ? WHERE id IN (%s) AND tenant_id = ?`, placeholders(len(ids)))
+	params := append(append([]interface{}{at}, int64sToArgs(ids)...), tenantID)
+
+	if _, err := r.Execute(ctx, query, params...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func scanLoan(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Loan, error) {
+	var loan entity.Loan
+	var borrower entity.Borrower
+	var dueDate string
+	var returnedAt, lastNotifiedAt sql.NullTime
+
+	err := scanner.Scan(
+		&loan.ID,
+		&loan.ItemID,
+		&loan.BorrowerID,
+		&loan.LentAt,
+		&dueDate,
+		&returnedAt,
+		&loan.Note,
+		&lastNotifiedAt,
+		&borrower.ID,
+		&borrower.Name,
+		&borrower.Contact,
+		&borrower.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	loan.DueDate = formatDate(dueDate)
+	if returnedAt.Valid {
+		loan.ReturnedAt = &returnedAt.Time
+	}
+	if lastNotifiedAt.Valid {
+		loan.LastNotifiedAt = &lastNotifiedAt.Time
+	}
+	loan.Borrower = &borrower
+
+	return &loan, nil
+}
@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type LocationRepository struct {
+	SqlHandler
+}
+
+func (r *LocationRepository) FindAll(ctx context.Context) ([]*entity.Location, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, name, type, parent_id, created_at, updated_at
+        FROM locations
+        WHERE tenant_id = ?
+        ORDER BY id
+    `
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	locations := []*entity.Location{}
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return locations, nil
+}
+
+func (r *LocationRepository) FindByID(ctx context.Context, id int64) (*entity.Location, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, name, type, parent_id, created_at, updated_at
+        FROM locations
+        WHERE id = ? AND tenant_id = ?
+    `
+
+	location, err := scanLocation(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return location, nil
+}
+
+func (r *LocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO locations (tenant_id, name, type, parent_id) VALUES (?, ?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, tenantID, location.Name, location.Type, nullableInt64(location.ParentID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(WithPrimary(ctx), id)
+}
+
+func (r *LocationRepository) Update(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE locations SET name = ?, parent_id = ?, updated_at = ? WHERE id = ? AND tenant_id = ?`
+
+	_, err = r.Execute(ctx, query, location.Name, nullableInt64(location.ParentID), location.UpdatedAt, location.ID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 値が変わらない場合は影響行数が0になるため、存在確認は再取得で行う
+	return r.FindByID(WithPrimary(ctx), location.ID)
+}
+
+func (r *LocationRepository) Delete(ctx context.Context, id int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM locations WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrLocationNotFound
+	}
+
+	return nil
+}
+
+// CountUsageは直下の保管場所の数と、直接保管されているアイテムの数を返す
+func (r *LocationRepository) CountUsage(ctx context.Context, id int64) (int, int, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	query := `
+        SELECT
+            (SELECT COUNT(*) FROM locations WHERE parent_id = ? AND tenant_id = ?),
+            (SELECT COUNT(*) FROM items WHERE location_id = ? AND tenant_id = ?)
+    `
+
+	var children, items int
+	if err := r.QueryRow(ctx, query, id, tenantID, id, tenantID).Scan(&children, &items); err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return children, items, nil
+}
+
+// MoveItemはアイテムの保管場所を更新し、移動履歴を記録する
+func (r *LocationRepository) MoveItem(ctx context.Context, movement *entity.ItemMovement) (*entity.ItemMovement, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int64
+	err = r.Transaction(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, `UPDATE items SET location_id = ?, updated_at = ? WHERE id = ? AND tenant_id = ?`,
+			nullableInt64(movement.ToLocationID), time.Now(), movement.ItemID, tenantID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if rowsAffected == 0 {
+			return domainErrors.ErrItemNotFound
+		}
+
+		result, err = r.Execute(ctx, `
+            INSERT INTO item_movements (tenant_id, item_id, from_location_id, to_location_id, note)
+            VALUES (?, ?, ?, ?, ?)
+        `, tenantID, movement.ItemID, nullableInt64(movement.FromLocationID), nullableInt64(movement.ToLocationID), movement.Note)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := *movement
+	recorded.ID = id
+	recorded.MovedAt = time.Now()
+	return &recorded, nil
+}
+
+// FindMovementsはアイテムの移動履歴を新しい順で取得する
+func (r *LocationRepository) FindMovements(ctx context.Context, itemID int64) ([]*entity.ItemMovement, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, item_id, from_location_id, to_location_id, note, moved_at
+        FROM item_movements
+        WHERE item_id = ? AND tenant_id = ?
+        ORDER BY moved_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, itemID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	movements := []*entity.ItemMovement{}
+	for rows.Next() {
+		var movement entity.ItemMovement
+		var from, to sql.NullInt64
+		if err := rows.Scan(&movement.ID, &movement.ItemID, &from, &to, &movement.Note, &movement.MovedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		movement.FromLocationID = nullableInt64Ptr(from)
+		movement.ToLocationID = nullableInt64Ptr(to)
+		movements = append(movements, &movement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return movements, nil
+}
+
+// nilをNULLとして保存する
+func nullableInt64(id *int64) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+func scanLocation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Location, error) {
+	var location entity.Location
+	var parentID sql.NullInt64
+	var createdAt, updatedAt time.Time
+
+	if err := scanner.Scan(&location.ID, &location.Name, &location.Type, &parentID, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	location.ParentID = nullableInt64Ptr(parentID)
+	location.CreatedAt = createdAt
+	location.UpdatedAt = updatedAt
+	return &location, nil
+}
@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MaintenanceRepository struct {
+	SqlHandler
+}
+
+func (r *MaintenanceRepository) FindByItem(ctx context.Context, itemID int64) ([]*entity.MaintenanceRecord, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, item_id, serviced_at, vendor, cost, note, created_at
+        FROM maintenance_records
+        WHERE item_id = ? AND tenant_id = ?
+        ORDER BY serviced_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, itemID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	records := []*entity.MaintenanceRecord{}
+	for rows.Next() {
+		record, err := scanMaintenanceRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return records, nil
+}
+
+func (r *MaintenanceRepository) FindByID(ctx context.Context, id int64) (*entity.MaintenanceRecord, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, item_id, serviced_at, vendor, cost, note, created_at
+        FROM maintenance_records
+        WHERE id = ? AND tenant_id = ?
+    `
+
+	record, err := scanMaintenanceRecord(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrMaintenanceRecordNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return record, nil
+}
+
+func (r *MaintenanceRepository) Create(ctx context.Context, record *entity.MaintenanceRecord) (*entity.MaintenanceRecord, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO maintenance_records (tenant_id, item_id, serviced_at, vendor, cost, note)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, tenantID, record.ItemID, record.ServicedAt, record.Vendor, record.Cost, record.Note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(WithPrimary(ctx), id)
+}
+
+func (r *MaintenanceRepository) Delete(ctx context.Context, id int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM maintenance_records WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrMaintenanceRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *MaintenanceRepository) FindLastServiceDates(ctx context.Context) (map[int64]string, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Query(ctx, `SELECT item_id, MAX(serviced_at) FROM maintenance_records WHERE tenant_id = ? GROUP BY item_id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	dates := make(map[int64]string)
+	for rows.Next() {
+		var itemID int64
+		var servicedAt string
+		if err := rows.Scan(&itemID, &servicedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		dates[itemID] = formatDate(servicedAt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return dates, nil
+}
+
+func (r *MaintenanceRepository) SumCostsByItem(ctx context.Context, itemIDs []int64) (map[int64]int64, error) {
+	costs := make(map[int64]int64)
+	if len(itemIDs) == 0 {
+		return costs, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT item_id, SUM(cost)
+        FROM maintenance_records
+        WHERE item_id IN (%s) AND tenant_id = ?
+        GROUP BY item_id
+    `, placeholders(len(itemIDs)))
+
+	rows, err := r.Query(ctx, query, append(int64sToArgs(itemIDs), tenantID)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID, cost int64
+		if err := rows.Scan(&itemID, &cost); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		costs[itemID] = cost
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return costs, nil
+}
+
+func scanMaintenanceRecord(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.MaintenanceRecord, error) {
+	var record entity.MaintenanceRecord
+	var servicedAt string
+
+	err := scanner.Scan(
+		&record.ID,
+		&record.ItemID,
+		&servicedAt,
+		&record.Vendor,
+		&record.Cost,
+		&record.Note,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.ServicedAt = formatDate(servicedAt)
+
+	return &record, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type OutboxRepository struct {
+	SqlHandler
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, events []*entity.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 複数行INSERTでは先頭行以外のIDを推測できないため、1件ずつINSERTしてLastInsertIdを取得する
+	query := `INSERT INTO item_events (tenant_id, event_type, item_id, payload, occurred_at) VALUES (?, ?, ?, ?, ?)`
+	return r.Transaction(ctx, func(ctx context.Context) error {
+		for _, event := range events {
+			result, err := r.Execute(ctx, query, tenantID, string(event.EventType), event.ItemID, string(event.Payload), event.OccurredAt)
+			if err != nil {
+				return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+
+			event.ID, err = result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+			event.TenantID = tenantID
+		}
+		return nil
+	})
+}
+
+func (r *OutboxRepository) FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.OutboxEvent, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, tenant_id, event_type, item_id, payload, occurred_at
+        FROM item_events
+        WHERE id > ? AND tenant_id = ?
+        ORDER BY id
+        LIMIT ?
+    `
+
+	return r.queryEvents(ctx, query, afterID, tenantID, limit)
+}
+
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 他のワーカーがロック中のイベントは飛ばして、同じイベントを二重に振り分けないようにする
+	query := `
+        SELECT id, tenant_id, event_type, item_id, payload, occurred_at
+        FROM item_events
+        WHERE dispatched_at IS NULL AND tenant_id = ?
+        ORDER BY id
+        LIMIT ?
+        FOR UPDATE SKIP LOCKED
+    `
+
+	return r.queryEvents(ctx, query, tenantID, limit)
+}
+
+func (r *OutboxRepository) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*entity.OutboxEvent, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	events := []*entity.OutboxEvent{}
+	for rows.Next() {
+		var event entity.OutboxEvent
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(&event.ID, &event.TenantID, &eventType, &event.ItemID, &payload, &event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		event.EventType = entity.EventType(eventType)
+		event.Payload = json.RawMessage(payload)
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE item_events SET dispatched_at = ? WHERE id IN (%s) AND tenant_id = ?`, placeholders(len(ids)))
+	params := append(append([]interface{}{at}, int64sToArgs(ids)...), tenantID)
+
+	if _, err := r.Execute(ctx, query, params...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
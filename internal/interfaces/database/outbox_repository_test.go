@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestOutboxRepository_Append(t *testing.T) {
+	// auto_increment_increment=2の環境のように、IDが連続しない場合でも採番されたIDを使う
+	h := &fakeSqlHandler{insertIDs: []int64{11, 13}}
+	repo := &OutboxRepository{SqlHandler: h}
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []*entity.OutboxEvent{
+		{EventType: entity.EventItemCreated, ItemID: 1, Payload: json.RawMessage(`{"id":1}`), OccurredAt: at},
+		{EventType: entity.EventItemCreated, ItemID: 2, Payload: json.RawMessage(`{"id":2}`), OccurredAt: at},
+	}
+
+	require.NoError(t, repo.Append(usecase.WithTenant(context.Background(), 7), events))
+
+	assert.Len(t, h.statements, 2)
+	assert.Equal(t, int64(11), events[0].ID)
+	assert.Equal(t, int64(13), events[1].ID)
+	assert.Equal(t, int64(7), events[1].TenantID)
+}
@@ -0,0 +1,296 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueryLogConfig はLoggingSqlHandlerの設定
+type QueryLogConfig struct {
+	// すべてのクエリをログに出すか（falseの場合は遅いクエリだけ）
+	LogQueries bool
+	// この時間以上かかったクエリを遅いクエリとして記録する（0で記録しない）
+	SlowThreshold time.Duration
+	// 遅いSELECTの実行計画（EXPLAIN）をログに出すか。同じフィンガープリントにつき1回だけ、リクエストとは別に実行する
+	Explain bool
+	// ログの出力先（デフォルト: log.Printf）
+	Logf func(format string, args ...interface{})
+}
+
+// QueryStats はフィンガープリントごとのクエリの統計
+type QueryStats struct {
+	// リテラルとプレースホルダーの並びをまとめたSQL
+	Fingerprint string  `json:"fingerprint"`
+	Count       int64   `json:"count"`
+	SlowCount   int64   `json:"slow_count"`
+	Errors      int64   `json:"errors"`
+	TotalMs     float64 `json:"total_ms"`
+	AvgMs       float64 `json:"avg_ms"`
+	MaxMs       float64 `json:"max_ms"`
+	// 遅いSELECTの実行計画（EXPLAIN FORMAT=JSON）
+	Plan json.RawMessage `json:"plan,omitempty"`
+}
+
+type queryStats struct {
+	count, slowCount, errors int64
+	total, max               time.Duration
+	plan                     json.RawMessage
+	// EXPLAINを実行済み（実行中）か
+	explained bool
+}
+
+// LoggingSqlHandler はSqlHandlerのデコレーター。クエリの実行時間と行数を記録し、遅いクエリをログに出す。
+// 引数はログに出さず、SQLのリテラルもフィンガープリントに置き換える
+type LoggingSqlHandler struct {
+	SqlHandler
+	config QueryLogConfig
+
+	mu    sync.Mutex
+	stats map[string]*queryStats
+	// 実行中のEXPLAIN
+	explains sync.WaitGroup
+	// テストで時刻を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewLoggingSqlHandler(handler SqlHandler, config QueryLogConfig) *LoggingSqlHandler {
+	if config.Logf == nil {
+		config.Logf = log.Printf
+	}
+	return &LoggingSqlHandler{
+		SqlHandler: handler,
+		config:     config,
+		stats:      make(map[string]*queryStats),
+		now:        time.Now,
+	}
+}
+
+func (h *LoggingSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	start := h.now()
+	result, err := h.SqlHandler.Execute(ctx, statement, args...)
+	var rows int64
+	if err == nil {
+		rows, _ = result.RowsAffected()
+	}
+	h.record(statement, args, h.now().Sub(start), rows, err)
+	return result, err
+}
+
+// Query の実行時間は行を読み終えてCloseするまでの時間
+func (h *LoggingSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	start := h.now()
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	if err != nil {
+		h.record(statement, args, h.now().Sub(start), 0, err)
+		return nil, err
+	}
+	return &loggingRows{Rows: rows, handler: h, statement: statement, args: args, start: start}, nil
+}
+
+// QueryRow の実行時間はScanするまでの時間
+func (h *LoggingSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	start := h.now()
+	return &loggingRow{
+		Row:       h.SqlHandler.QueryRow(ctx, statement, args...),
+		handler:   h,
+		statement: statement,
+		args:      args,
+		start:     start,
+	}
+}
+
+// SlowQueries は最大の実行時間が長い順にlimit件のフィンガープリントの統計を返す（limitが0以下の場合はすべて）
+func (h *LoggingSqlHandler) SlowQueries(limit int) []QueryStats {
+	h.mu.Lock()
+	result := make([]QueryStats, 0, len(h.stats))
+	for fingerprint, s := range h.stats {
+		result = append(result, QueryStats{
+			Fingerprint: fingerprint,
+			Count:       s.count,
+			SlowCount:   s.slowCount,
+			Errors:      s.errors,
+			TotalMs:     milliseconds(s.total),
+			AvgMs:       milliseconds(s.total / time.Duration(s.count)),
+			MaxMs:       milliseconds(s.max),
+			Plan:        s.plan,
+		})
+	}
+	h.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MaxMs != result[j].MaxMs {
+			return result[i].MaxMs > result[j].MaxMs
+		}
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// Close は実行中のEXPLAINを待ってから接続を閉じる
+func (h *LoggingSqlHandler) Close() error {
+	h.explains.Wait()
+	return h.SqlHandler.Close()
+}
+
+func (h *LoggingSqlHandler) record(statement string, args []interface{}, elapsed time.Duration, rows int64, err error) {
+	fingerprint := Fingerprint(statement)
+	slow := h.config.SlowThreshold > 0 && elapsed >= h.config.SlowThreshold
+
+	h.mu.Lock()
+	s, ok := h.stats[fingerprint]
+	if !ok {
+		s = &queryStats{}
+		h.stats[fingerprint] = s
+	}
+	s.count++
+	s.total += elapsed
+	if elapsed > s.max {
+		s.max = elapsed
+	}
+	if err != nil {
+		s.errors++
+	}
+	explain := false
+	if slow {
+		s.slowCount++
+		explain = h.config.Explain && err == nil && !s.explained && isSelect(fingerprint)
+		if explain {
+			s.explained = true
+		}
+	}
+	h.mu.Unlock()
+
+	message := "%s query %.1fms rows=%d args=%d: %s"
+	marker := "🗄️ "
+	if slow {
+		marker = "🐢 slow"
+	}
+	switch {
+	case err != nil && (slow || h.config.LogQueries):
+		h.config.Logf(message+" (error: %v)", marker, milliseconds(elapsed), rows, len(args), fingerprint, err)
+	case slow || h.config.LogQueries:
+		h.config.Logf(message, marker, milliseconds(elapsed), rows, len(args), fingerprint)
+	}
+
+	if explain {
+		h.explains.Add(1)
+		go h.explain(fingerprint, statement, args)
+	}
+}
+
+// EXPLAINのタイムアウト
+const explainTimeout = 5 * time.Second
+
+// explain は遅いSELECTの実行計画をログに出し、統計に保存する。リクエストの応答を遅らせないよう別のgoroutineで実行し、
+// リクエストのctx（終了しているかもしれないトランザクション）は使わずにexplainTimeoutで打ち切る
+func (h *LoggingSqlHandler) explain(fingerprint, statement string, args []interface{}) {
+	defer h.explains.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+	defer cancel()
+
+	var plan string
+	if err := h.SqlHandler.QueryRow(ctx, "EXPLAIN FORMAT=JSON "+statement, args...).Scan(&plan); err != nil {
+		h.config.Logf("⚠️  explain %s: %v", fingerprint, err)
+		return
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(plan)); err != nil {
+		h.config.Logf("⚠️  explain %s: %v", fingerprint, err)
+		return
+	}
+	h.config.Logf("🐢 plan for %s: %s", fingerprint, compacted.String())
+
+	h.mu.Lock()
+	h.stats[fingerprint].plan = compacted.Bytes()
+	h.mu.Unlock()
+}
+
+// loggingRows は読み込んだ行数を数え、Closeしたときに記録する
+type loggingRows struct {
+	Rows
+	handler   *LoggingSqlHandler
+	statement string
+	args      []interface{}
+	start     time.Time
+	count     int64
+	closed    bool
+}
+
+func (r *loggingRows) Next() bool {
+	if r.Rows.Next() {
+		r.count++
+		return true
+	}
+	return false
+}
+
+func (r *loggingRows) Close() error {
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		queryErr := r.Rows.Err()
+		if queryErr == nil {
+			queryErr = err
+		}
+		r.handler.record(r.statement, r.args, r.handler.now().Sub(r.start), r.count, queryErr)
+	}
+	return err
+}
+
+type loggingRow struct {
+	Row
+	handler   *LoggingSqlHandler
+	statement string
+	args      []interface{}
+	start     time.Time
+}
+
+func (r *loggingRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	switch {
+	case err == nil:
+		r.handler.record(r.statement, r.args, r.handler.now().Sub(r.start), 1, nil)
+	case errors.Is(err, sql.ErrNoRows):
+		r.handler.record(r.statement, r.args, r.handler.now().Sub(r.start), 0, nil)
+	default:
+		r.handler.record(r.statement, r.args, r.handler.now().Sub(r.start), 0, err)
+	}
+	return err
+}
+
+var (
+	stringLiteralPattern   = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+	numberLiteralPattern   = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	placeholderListPattern = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	repeatedListPattern    = regexp.MustCompile(`\(\?, \.\.\.\)(?:\s*,\s*\(\?, \.\.\.\))+`)
+)
+
+// Fingerprint はSQLのリテラルを?に置き換え、プレースホルダーの並び（IN (?, ?) やVALUES (?, ?), (?, ?)）を
+// 1つにまとめる。引数の数だけが異なるクエリは同じフィンガープリントになる
+func Fingerprint(statement string) string {
+	fingerprint := strings.Join(strings.Fields(statement), " ")
+	fingerprint = stringLiteralPattern.ReplaceAllString(fingerprint, "?")
+	fingerprint = numberLiteralPattern.ReplaceAllString(fingerprint, "?")
+	fingerprint = placeholderListPattern.ReplaceAllString(fingerprint, "(?, ...)")
+	return repeatedListPattern.ReplaceAllString(fingerprint, "(?, ...)")
+}
+
+func isSelect(fingerprint string) bool {
+	return len(fingerprint) >= 6 && strings.EqualFold(fingerprint[:6], "SELECT")
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
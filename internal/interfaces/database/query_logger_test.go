@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{
+			name:      "正常系: 空白をまとめる",
+			statement: "SELECT id\n        FROM items\n        WHERE id = ?",
+			expected:  "SELECT id FROM items WHERE id = ?",
+		},
+		{
+			name:      "正常系: リテラルを置き換える",
+			statement: `SELECT id FROM items WHERE status = 'owned' AND name <> 'it''s' LIMIT 10`,
+			expected:  "SELECT id FROM items WHERE status = ? AND name <> ? LIMIT ?",
+		},
+		{
+			name:      "正常系: 識別子の数字は置き換えない",
+			statement: "SELECT t1.id FROM items t1",
+			expected:  "SELECT t1.id FROM items t1",
+		},
+		{
+			name:      "正常系: INの引数の数が異なっても同じになる",
+			statement: "DELETE FROM items WHERE id IN (?, ?, ?)",
+			expected:  "DELETE FROM items WHERE id IN (?, ...)",
+		},
+		{
+			name:      "正常系: VALUESの行数が異なっても同じになる",
+			statement: "INSERT INTO item_attributes (item_id, attr_key, attr_value) VALUES (?, ?, ?), (?, ?, ?)",
+			expected:  "INSERT INTO item_attributes (item_id, attr_key, attr_value) VALUES (?, ...)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Fingerprint(tt.statement))
+		})
+	}
+}
+
+// newTestLoggingSqlHandler は時刻を呼ばれるたびにstepずつ進め、ログを記録するLoggingSqlHandlerを返す。
+// EXPLAINのログは別のgoroutineから記録されるため、Closeしてから確認する
+func newTestLoggingSqlHandler(inner SqlHandler, config QueryLogConfig, step *time.Duration) (*LoggingSqlHandler, *[]string) {
+	var mu sync.Mutex
+	var logs []string
+	config.Logf = func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	h := NewLoggingSqlHandler(inner, config)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time {
+		now = now.Add(*step)
+		return now
+	}
+	return h, &logs
+}
+
+// blockingExplainSqlHandler はreleaseが閉じられるまでEXPLAINを完了しないSqlHandler
+type blockingExplainSqlHandler struct {
+	*fakeSqlHandler
+	release chan struct{}
+	// EXPLAINを実行したときのctxの状態
+	explains []explainContext
+}
+
+type explainContext struct {
+	err         error
+	primary     bool
+	hasDeadline bool
+}
+
+func (h *blockingExplainSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	if strings.HasPrefix(statement, "EXPLAIN") {
+		<-h.release
+		_, hasDeadline := ctx.Deadline()
+		h.explains = append(h.explains, explainContext{err: ctx.Err(), primary: ReadFromPrimary(ctx), hasDeadline: hasDeadline})
+	}
+	return h.fakeSqlHandler.QueryRow(ctx, statement, args...)
+}
+
+// slowQueryRowSqlHandler はQueryRowの中で時刻をelapsedだけ進めるSqlHandler。MySQLドライバーのQueryRowと同じく、
+// Scanより前にクエリを実行し終える
+type slowQueryRowSqlHandler struct {
+	*fakeSqlHandler
+	now     *time.Time
+	elapsed time.Duration
+}
+
+func (h *slowQueryRowSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	*h.now = h.now.Add(h.elapsed)
+	return h.fakeSqlHandler.QueryRow(ctx, statement, args...)
+}
+
+func TestLoggingSqlHandler(t *testing.T) {
+	t.Run("正常系: 遅いクエリだけを引数を伏せてログに出す", func(t *testing.T) {
+		step := 10 * time.Millisecond
+		h, logs := newTestLoggingSqlHandler(&fakeSqlHandler{rowsAffected: 2}, QueryLogConfig{SlowThreshold: 100 * time.Millisecond}, &step)
+		ctx := context.Background()
+
+		_, err := h.Execute(ctx, "UPDATE items SET name = ? WHERE id = ?", "秘密の名前", int64(1))
+		require.NoError(t, err)
+		assert.Empty(t, *logs)
+
+		step = 150 * time.Millisecond
+		_, err = h.Execute(ctx, "UPDATE items SET name = ? WHERE id = ?", "秘密の名前", int64(1))
+		require.NoError(t, err)
+
+		require.Len(t, *logs, 1)
+		assert.Equal(t, "🐢 slow query 150.0ms rows=2 args=2: UPDATE items SET name = ? WHERE id = ?", (*logs)[0])
+		assert.NotContains(t, (*logs)[0], "秘密の名前")
+	})
+
+	t.Run("正常系: LogQueriesの場合はすべてのクエリをログに出す", func(t *testing.T) {
+		step := time.Millisecond
+		h, logs := newTestLoggingSqlHandler(&fakeSqlHandler{}, QueryLogConfig{LogQueries: true, SlowThreshold: time.Second}, &step)
+
+		rows, err := h.Query(context.Background(), "SELECT name FROM tags WHERE item_id IN (?, ?)", int64(1), int64(2))
+		require.NoError(t, err)
+		for rows.Next() {
+		}
+		require.NoError(t, rows.Close())
+
+		require.Len(t, *logs, 1)
+		assert.Equal(t, "🗄️  query 1.0ms rows=0 args=2: SELECT name FROM tags WHERE item_id IN (?, ...)", (*logs)[0])
+	})
+
+	t.Run("正常系: 遅いSELECTの実行計画をフィンガープリントごとに1回だけ取得する", func(t *testing.T) {
+		step := 500 * time.Millisecond
+		inner := &fakeSqlHandler{row: []interface{}{"{\n  \"query_block\": {\"select_id\": 1}\n}"}}
+		h, logs := newTestLoggingSqlHandler(inner, QueryLogConfig{SlowThreshold: 100 * time.Millisecond, Explain: true}, &step)
+
+		var plan string
+		for i := 0; i < 2; i++ {
+			require.NoError(t, h.QueryRow(context.Background(), "SELECT name FROM items WHERE id = ?", int64(1)).Scan(&plan))
+		}
+		require.NoError(t, h.Close())
+
+		assert.ElementsMatch(t, []string{
+			"🐢 slow query 500.0ms rows=1 args=1: SELECT name FROM items WHERE id = ?",
+			`🐢 plan for SELECT name FROM items WHERE id = ?: {"query_block":{"select_id":1}}`,
+			"🐢 slow query 500.0ms rows=1 args=1: SELECT name FROM items WHERE id = ?",
+		}, *logs)
+		stats := h.SlowQueries(0)
+		require.Len(t, stats, 1)
+		assert.JSONEq(t, `{"query_block":{"select_id":1}}`, string(stats[0].Plan))
+	})
+
+	t.Run("正常系: 実行計画の取得を待たずにクエリの結果を返す", func(t *testing.T) {
+		step := 500 * time.Millisecond
+		release := make(chan struct{})
+		inner := &blockingExplainSqlHandler{
+			fakeSqlHandler: &fakeSqlHandler{row: []interface{}{`{"query_block": {"select_id": 1}}`}},
+			release:        release,
+		}
+		h, logs := newTestLoggingSqlHandler(inner, QueryLogConfig{SlowThreshold: 100 * time.Millisecond, Explain: true}, &step)
+		ctx, cancel := context.WithCancel(WithPrimary(context.Background()))
+
+		var name string
+		require.NoError(t, h.QueryRow(ctx, "SELECT name FROM items WHERE id = ?", int64(1)).Scan(&name))
+		// リクエストが終了しても実行計画は取得する
+		cancel()
+		assert.Nil(t, h.SlowQueries(0)[0].Plan)
+
+		close(release)
+		require.NoError(t, h.Close())
+
+		assert.Len(t, *logs, 2)
+		assert.JSONEq(t, `{"query_block":{"select_id":1}}`, string(h.SlowQueries(0)[0].Plan))
+		// リクエストのctxの値は引き継がず、タイムアウトで打ち切る
+		assert.Equal(t, []explainContext{{hasDeadline: true}}, inner.explains)
+	})
+
+	t.Run("正常系: QueryRowの実行時間にクエリの実行を含める", func(t *testing.T) {
+		step := time.Duration(0)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		inner := &slowQueryRowSqlHandler{fakeSqlHandler: &fakeSqlHandler{row: []interface{}{"時計"}}, now: &now, elapsed: 300 * time.Millisecond}
+		h, logs := newTestLoggingSqlHandler(inner, QueryLogConfig{SlowThreshold: 100 * time.Millisecond}, &step)
+		h.now = func() time.Time { return now }
+
+		var name string
+		require.NoError(t, h.QueryRow(context.Background(), "SELECT name FROM items WHERE id = ?", int64(1)).Scan(&name))
+
+		require.Len(t, *logs, 1)
+		assert.Equal(t, "🐢 slow query 300.0ms rows=1 args=1: SELECT name FROM items WHERE id = ?", (*logs)[0])
+		assert.Equal(t, int64(1), h.SlowQueries(0)[0].SlowCount)
+	})
+
+	t.Run("正常系: 書き込みの実行計画は取得しない", func(t *testing.T) {
+		step := 500 * time.Millisecond
+		h, logs := newTestLoggingSqlHandler(&fakeSqlHandler{}, QueryLogConfig{SlowThreshold: 100 * time.Millisecond, Explain: true}, &step)
+
+		_, err := h.Execute(context.Background(), "DELETE FROM items WHERE id = ?", int64(1))
+		require.NoError(t, err)
+
+		require.Len(t, *logs, 1)
+		assert.Nil(t, h.SlowQueries(0)[0].Plan)
+	})
+
+	t.Run("異常系: 失敗したクエリはエラーを記録する", func(t *testing.T) {
+		step := 500 * time.Millisecond
+		inner := &fakeSqlHandler{execErr: ErrDuplicateKey}
+		h, logs := newTestLoggingSqlHandler(inner, QueryLogConfig{SlowThreshold: 100 * time.Millisecond}, &step)
+
+		_, err := h.Execute(context.Background(), "INSERT INTO tags (name) VALUES (?)", "vintage")
+		assert.ErrorIs(t, err, ErrDuplicateKey)
+
+		require.Len(t, *logs, 1)
+		assert.True(t, strings.HasSuffix((*logs)[0], "(error: duplicate key)"))
+		assert.Equal(t, int64(1), h.SlowQueries(0)[0].Errors)
+	})
+}
+
+func TestLoggingSqlHandler_SlowQueries(t *testing.T) {
+	step := time.Duration(0)
+	h, _ := newTestLoggingSqlHandler(&fakeSqlHandler{}, QueryLogConfig{SlowThreshold: 100 * time.Millisecond}, &step)
+	ctx := context.Background()
+
+	run := func(statement string, elapsed time.Duration) {
+		step = elapsed
+		_, err := h.Execute(ctx, statement)
+		require.NoError(t, err)
+	}
+	run("UPDATE items SET status = 'owned'", 20*time.Millisecond)
+	run("UPDATE items SET status = 'sold'", 300*time.Millisecond)
+	run("DELETE FROM tags WHERE id = 1", 200*time.Millisecond)
+	run("UPDATE loans SET returned_at = NOW()", 10*time.Millisecond)
+
+	stats := h.SlowQueries(2)
+
+	require.Len(t, stats, 2)
+	assert.Equal(t, QueryStats{
+		Fingerprint: "UPDATE items SET status = ?",
+		Count:       2,
+		SlowCount:   1,
+		TotalMs:     320,
+		AvgMs:       160,
+		MaxMs:       300,
+	}, stats[0])
+	assert.Equal(t, "DELETE FROM tags WHERE id = ?", stats[1].Fingerprint)
+}
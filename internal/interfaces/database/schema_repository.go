@@ -0,0 +1,270 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// SchemaRepository はsql/init.sqlのスキーマとサンプルデータを適用し、テーブルの状態を確認する
+type SchemaRepository struct {
+	SqlHandler
+}
+
+// TableStatus はスクリプトで定義したテーブルの状態
+type TableStatus struct {
+	Name   string `json:"name"`
+	Exists bool   `json:"exists"`
+	Rows   int64  `json:"rows"`
+}
+
+var createTablePattern = regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + "`?" + `(\w+)`)
+
+// Migrate はスクリプトのサンプルデータ（INSERT）以外の文を順に実行し、実行した文の数を返す。
+// テナントのINSERTはサンプルデータではなく、他のテーブルが参照する既定のテナントの作成として実行する。
+// CREATE TABLE IF NOT EXISTSは既存のテーブルを変更しないため、テナント対応より前に作成したテーブルには
+// 続けてtenant_idの列とテナントごとのユニークキーを追加する（実行したALTER TABLEも数に含める）
+func (r *SchemaRepository) Migrate(ctx context.Context, script string) (int, error) {
+	count := 0
+	var tables []string
+	for _, statement := range splitStatements(script) {
+		if isSampleData(statement) {
+			continue
+		}
+		if _, err := r.Execute(ctx, statement); err != nil {
+			return count, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		count++
+		if tenantColumnPattern.MatchString(statement) && createTablePattern.MatchString(statement) {
+			tables = append(tables, statement)
+		}
+	}
+
+	for _, statement := range tables {
+		altered, err := r.migrateTenant(ctx, statement)
+		count += altered
+		if err != nil {
+			return count, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+	return count, nil
+}
+
+var (
+	// CREATE TABLEのtenant_idの列の定義
+	tenantColumnPattern = regexp.MustCompile(`(?m)^\s*tenant_id\s+(.+?),?\s*$`)
+	// CREATE TABLEのユニークキーの名前と列
+	uniqueKeyPattern = regexp.MustCompile(`(?m)^\s*UNIQUE\s+KEY\s+(\w+)\s*\(([^)]*)\)`)
+)
+
+// migrateTenant はテナント対応より前に作成したテーブルを、CREATE TABLEの定義に合わせて変更し、実行したALTER TABLEの数を返す。
+// tenant_idがない場合は既定値（既定のテナント）で既存の行を埋めて追加し、インデックスと外部キーも追加する。
+// tenant_idを含むユニークキーがない場合は追加し、tenant_idを除いた同じ列の古いユニークキーを削除する。
+// 変更済みのテーブルには何もしないため、何度実行してもよい
+func (r *SchemaRepository) migrateTenant(ctx context.Context, statement string) (int, error) {
+	table := createTablePattern.FindStringSubmatch(statement)[1]
+	count := 0
+
+	var columns int64
+	query := `
+        SELECT COUNT(*)
+        FROM information_schema.columns
+        WHERE table_schema = DATABASE() AND table_name = ? AND column_name = 'tenant_id'
+    `
+	if err := r.QueryRow(ctx, query, table).Scan(&columns); err != nil {
+		return count, err
+	}
+	if columns == 0 {
+		// テーブル名・列の定義はスクリプトのもののため埋め込んでよい
+		alter := fmt.Sprintf(
+			"ALTER TABLE `%s` ADD COLUMN tenant_id %s %s, ADD INDEX idx_tenant_id (tenant_id), "+
+				"ADD CONSTRAINT fk_%s_tenant_id FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT",
+			table, tenantColumnPattern.FindStringSubmatch(statement)[1], tenantColumnPosition(statement), table,
+		)
+		if _, err := r.Execute(ctx, alter); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	for _, key := range uniqueKeyPattern.FindAllStringSubmatch(statement, -1) {
+		name, keyColumns := key[1], splitColumns(key[2])
+		if !slices.Contains(keyColumns, "tenant_id") {
+			continue
+		}
+
+		var indexes int64
+		query := `
+            SELECT COUNT(*)
+            FROM information_schema.statistics
+            WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?
+        `
+		if err := r.QueryRow(ctx, query, table, name).Scan(&indexes); err != nil {
+			return count, err
+		}
+		if indexes > 0 {
+			continue
+		}
+
+		previous, err := r.uniqueKeys(ctx, table, slices.DeleteFunc(slices.Clone(keyColumns), func(column string) bool {
+			return column == "tenant_id"
+		}))
+		if err != nil {
+			return count, err
+		}
+		alter := fmt.Sprintf("ALTER TABLE `%s` ADD UNIQUE KEY %s (%s)", table, name, strings.Join(keyColumns, ", "))
+		for _, index := range previous {
+			alter += fmt.Sprintf(", DROP INDEX `%s`", index)
+		}
+		if _, err := r.Execute(ctx, alter); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// uniqueKeys はテーブルの主キー以外のユニークキーのうち、columnsと同じ列（同じ順序）のものの名前を返す
+func (r *SchemaRepository) uniqueKeys(ctx context.Context, table string, columns []string) ([]string, error) {
+	query := `
+        SELECT index_name
+        FROM information_schema.statistics
+        WHERE table_schema = DATABASE() AND table_name = ? AND non_unique = 0 AND index_name <> 'PRIMARY'
+        GROUP BY index_name
+        HAVING GROUP_CONCAT(column_name ORDER BY seq_in_index) = ?
+    `
+	rows, err := r.Query(ctx, query, table, strings.Join(columns, ","))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// tenantColumnPosition はCREATE TABLEと同じ位置にtenant_idを追加するための FIRST / AFTER <列> を返す
+func tenantColumnPosition(statement string) string {
+	lines := strings.Split(statement, "\n")
+	for i, line := range lines {
+		if i == 0 || !tenantColumnPattern.MatchString(line) {
+			continue
+		}
+		if previous := strings.Fields(lines[i-1]); i > 1 && len(previous) > 0 {
+			return "AFTER " + strings.Trim(previous[0], "`")
+		}
+		break
+	}
+	return "FIRST"
+}
+
+func splitColumns(list string) []string {
+	var columns []string
+	for _, column := range strings.Split(list, ",") {
+		columns = append(columns, strings.Trim(strings.TrimSpace(column), "`"))
+	}
+	return columns
+}
+
+// Seed はスクリプトのサンプルデータ（INSERT）を1つのトランザクションで実行し、追加した行数を返す
+func (r *SchemaRepository) Seed(ctx context.Context, script string) (int64, error) {
+	var inserted int64
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		for _, statement := range splitStatements(script) {
+			if !isSampleData(statement) {
+				continue
+			}
+			result, err := r.Execute(ctx, statement)
+			if err != nil {
+				return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("%w: failed to get affected rows: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+			inserted += rows
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
+
+// CheckTables はスクリプトで定義したテーブルが存在するかと、その行数を返す
+func (r *SchemaRepository) CheckTables(ctx context.Context, script string) ([]*TableStatus, error) {
+	var tables []*TableStatus
+	for _, statement := range splitStatements(script) {
+		match := createTablePattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+
+		table := &TableStatus{Name: match[1]}
+		var count int64
+		query := `
+            SELECT COUNT(*)
+            FROM information_schema.tables
+            WHERE table_schema = DATABASE() AND table_name = ?
+        `
+		if err := r.QueryRow(ctx, query, table.Name).Scan(&count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		table.Exists = count > 0
+
+		// テーブル名はスクリプトの識別子（\w+）のみのため埋め込んでよい
+		if table.Exists {
+			if err := r.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM `%s`", table.Name)).Scan(&table.Rows); err != nil {
+				return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// splitStatements はスクリプトを文に分割する。行末の;を文の区切りとし、--で始まる行はコメントとして除く
+func splitStatements(script string) []string {
+	var statements []string
+	var current []string
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current = append(current, line)
+		if strings.HasSuffix(trimmed, ";") {
+			statement := strings.TrimSpace(strings.Join(current, "\n"))
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+			current = nil
+		}
+	}
+	if statement := strings.TrimSpace(strings.Join(current, "\n")); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+func isInsert(statement string) bool {
+	fields := strings.Fields(statement)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "INSERT")
+}
+
+var insertTenantsPattern = regexp.MustCompile(`(?i)^INSERT\s+(?:IGNORE\s+)?INTO\s+` + "`?" + `tenants\b`)
+
+func isSampleData(statement string) bool {
+	return isInsert(statement) && !insertTenantsPattern.MatchString(statement)
+}
@@ -0,0 +1,327 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const testScript = `-- コメント
+SET NAMES utf8mb4;
+
+CREATE TABLE IF NOT EXISTS items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL COMMENT 'Item name'
+);
+
+-- サンプルデータ
+INSERT INTO items (name) VALUES
+('ロレックス デイトナ'),
+('エルメス バーキン');
+`
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements(testScript)
+
+	require.Len(t, statements, 3)
+	assert.Equal(t, "SET NAMES utf8mb4", statements[0])
+	assert.True(t, strings.HasPrefix(statements[1], "CREATE TABLE IF NOT EXISTS items ("))
+	assert.True(t, strings.HasSuffix(statements[1], ")"))
+	assert.True(t, isInsert(statements[2]))
+}
+
+func TestSchemaRepository_Migrate(t *testing.T) {
+	handler := &fakeSqlHandler{}
+	repo := &SchemaRepository{SqlHandler: handler}
+
+	count, err := repo.Migrate(context.Background(), testScript)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	// サンプルデータは実行しない
+	for _, statement := range handler.statements {
+		assert.False(t, isInsert(statement))
+	}
+}
+
+// 既定のテナントはサンプルデータではなくスキーマとして作成する
+func TestSchemaRepository_Migrate_CreatesDefaultTenant(t *testing.T) {
+	script := testScript + `
+INSERT IGNORE INTO tenants (id, slug, name) VALUES (1, 'default', 'Default');
+`
+	handler := &fakeSqlHandler{rowsAffected: 2}
+	repo := &SchemaRepository{SqlHandler: handler}
+
+	count, err := repo.Migrate(context.Background(), script)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.True(t, strings.HasPrefix(handler.statements[2], "INSERT IGNORE INTO tenants"))
+
+	handler.statements = nil
+	_, err = repo.Seed(context.Background(), script)
+
+	require.NoError(t, err)
+	require.Len(t, handler.statements, 1)
+	assert.True(t, strings.HasPrefix(handler.statements[0], "INSERT INTO items"))
+}
+
+func TestSchemaRepository_Seed(t *testing.T) {
+	handler := &fakeSqlHandler{rowsAffected: 2}
+	repo := &SchemaRepository{SqlHandler: handler}
+
+	inserted, err := repo.Seed(context.Background(), testScript)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+	require.Len(t, handler.statements, 1)
+	assert.True(t, isInsert(handler.statements[0]))
+}
+
+func TestSchemaRepository_CheckTables(t *testing.T) {
+	handler := &fakeSqlHandler{row: []interface{}{int64(1)}}
+	repo := &SchemaRepository{SqlHandler: handler}
+
+	tables, err := repo.CheckTables(context.Background(), testScript)
+
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "items", tables[0].Name)
+	assert.True(t, tables[0].Exists)
+}
+
+// sql/init.sqlのすべてのテーブルを文として分割できる
+func TestSplitStatements_InitScript(t *testing.T) {
+	script, err := os.ReadFile("../../../sql/init.sql")
+	require.NoError(t, err)
+
+	var tables []string
+	for _, statement := range splitStatements(string(script)) {
+		if match := createTablePattern.FindStringSubmatch(statement); match != nil {
+			tables = append(tables, match[1])
+		}
+	}
+
+	assert.Equal(t, strings.Count(string(script), "CREATE TABLE"), len(tables))
+	assert.Contains(t, tables, "items")
+	assert.Contains(t, tables, "webhook_attempts")
+}
+
+const tenantScript = `
+CREATE TABLE IF NOT EXISTS tenants (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    slug VARCHAR(63) NOT NULL
+);
+
+INSERT IGNORE INTO tenants (id, slug, name) VALUES (1, 'default', 'Default');
+
+CREATE TABLE IF NOT EXISTS items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    brand VARCHAR(100) NOT NULL,
+    serial_number VARCHAR(100) NULL,
+
+    UNIQUE KEY uk_tenant_id_brand_serial_number (tenant_id, brand, serial_number),
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+);
+
+INSERT INTO items (brand) VALUES ('ROLEX');
+`
+
+// schemaSqlHandler はinformation_schemaの列・ユニークキーを保持し、ALTER TABLEでそれを変更するテスト用のSqlHandler
+type schemaSqlHandler struct {
+	fakeSqlHandler
+	// tenant_idがあるテーブル
+	tenantColumns map[string]bool
+	// テーブルごとのユニークキーの名前 → 列（,区切り）
+	uniqueKeys map[string]map[string]string
+	// ALTER TABLEで返すエラー
+	alterErr error
+}
+
+var (
+	addColumnPattern    = regexp.MustCompile("^ALTER TABLE `(\\w+)` ADD COLUMN tenant_id ")
+	addUniqueKeyPattern = regexp.MustCompile("^ALTER TABLE `(\\w+)` ADD UNIQUE KEY (\\w+) \\(([^)]*)\\)")
+	dropIndexPattern    = regexp.MustCompile("DROP INDEX `(\\w+)`")
+)
+
+func (h *schemaSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	if h.alterErr != nil && strings.HasPrefix(statement, "ALTER TABLE") {
+		return nil, h.alterErr
+	}
+	if match := addColumnPattern.FindStringSubmatch(statement); match != nil {
+		h.tenantColumns[match[1]] = true
+	}
+	if match := addUniqueKeyPattern.FindStringSubmatch(statement); match != nil {
+		h.uniqueKeys[match[1]][match[2]] = strings.ReplaceAll(match[3], " ", "")
+		for _, drop := range dropIndexPattern.FindAllStringSubmatch(statement, -1) {
+			delete(h.uniqueKeys[match[1]], drop[1])
+		}
+	}
+	return h.fakeSqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *schemaSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	table := args[0].(string)
+	if strings.Contains(statement, "information_schema.columns") {
+		if h.tenantColumns[table] {
+			return fakeRow{values: []interface{}{int64(1)}}
+		}
+		return fakeRow{values: []interface{}{int64(0)}}
+	}
+	if _, ok := h.uniqueKeys[table][args[1].(string)]; ok {
+		return fakeRow{values: []interface{}{int64(1)}}
+	}
+	return fakeRow{values: []interface{}{int64(0)}}
+}
+
+func (h *schemaSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	var names []string
+	for name, columns := range h.uniqueKeys[args[0].(string)] {
+		if columns == args[1].(string) {
+			names = append(names, name)
+		}
+	}
+	return &stringRows{values: names}, nil
+}
+
+type stringRows struct {
+	values []string
+	index  int
+}
+
+func (r *stringRows) Next() bool {
+	r.index++
+	return r.index <= len(r.values)
+}
+
+func (r *stringRows) Scan(dest ...interface{}) error {
+	*dest[0].(*string) = r.values[r.index-1]
+	return nil
+}
+
+func (r *stringRows) Close() error { return nil }
+func (r *stringRows) Err() error   { return nil }
+
+func (h *schemaSqlHandler) alters() []string {
+	var alters []string
+	for _, statement := range h.statements {
+		if strings.HasPrefix(statement, "ALTER TABLE") {
+			alters = append(alters, statement)
+		}
+	}
+	return alters
+}
+
+func TestSchemaRepository_Migrate_TenantColumns(t *testing.T) {
+	t.Run("正常系: テナント対応より前のテーブルにtenant_idとテナントごとのユニークキーを追加する", func(t *testing.T) {
+		handler := &schemaSqlHandler{
+			tenantColumns: map[string]bool{},
+			uniqueKeys: map[string]map[string]string{
+				"items": {"uk_brand_serial_number": "brand,serial_number"},
+			},
+		}
+		repo := &SchemaRepository{SqlHandler: handler}
+
+		count, err := repo.Migrate(context.Background(), tenantScript)
+
+		require.NoError(t, err)
+		alters := handler.alters()
+		require.Len(t, alters, 3)
+		assert.Equal(t, 4+len(alters), count)
+		// 既存の行は既定値（既定のテナント）で埋まる
+		assert.Equal(t, "ALTER TABLE `items` ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant' AFTER id, "+
+			"ADD INDEX idx_tenant_id (tenant_id), "+
+			"ADD CONSTRAINT fk_items_tenant_id FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT", alters[0])
+		assert.Equal(t, "ALTER TABLE `items` ADD UNIQUE KEY uk_tenant_id_brand_serial_number (tenant_id, brand, serial_number), "+
+			"DROP INDEX `uk_brand_serial_number`", alters[1])
+		assert.True(t, strings.HasPrefix(alters[2], "ALTER TABLE `item_tags` ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant' FIRST, "))
+		assert.Equal(t, map[string]string{"uk_tenant_id_brand_serial_number": "tenant_id,brand,serial_number"}, handler.uniqueKeys["items"])
+
+		t.Run("正常系: 再度実行してもテーブルを変更しない", func(t *testing.T) {
+			handler.statements = nil
+
+			count, err := repo.Migrate(context.Background(), tenantScript)
+
+			require.NoError(t, err)
+			assert.Equal(t, 4, count)
+			assert.Empty(t, handler.alters())
+		})
+	})
+
+	t.Run("正常系: tenant_idがあるテーブルにはユニークキーだけを追加する", func(t *testing.T) {
+		handler := &schemaSqlHandler{
+			tenantColumns: map[string]bool{"items": true, "item_tags": true},
+			uniqueKeys:    map[string]map[string]string{"items": {}},
+		}
+		repo := &SchemaRepository{SqlHandler: handler}
+
+		_, err := repo.Migrate(context.Background(), tenantScript)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ALTER TABLE `items` ADD UNIQUE KEY uk_tenant_id_brand_serial_number (tenant_id, brand, serial_number)",
+		}, handler.alters())
+	})
+
+	t.Run("異常系: ALTER TABLEに失敗した", func(t *testing.T) {
+		handler := &schemaSqlHandler{
+			tenantColumns: map[string]bool{},
+			uniqueKeys:    map[string]map[string]string{"items": {}},
+			alterErr:      errors.New("Cannot add foreign key constraint"),
+		}
+		repo := &SchemaRepository{SqlHandler: handler}
+
+		count, err := repo.Migrate(context.Background(), tenantScript)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		// スクリプトの文は実行済み
+		assert.Equal(t, 4, count)
+	})
+}
+
+// テナント対応より前のsql/init.sqlで作成したデータベースのすべてのテーブルを変更できる
+func TestSchemaRepository_Migrate_InitScriptTenantColumns(t *testing.T) {
+	script, err := os.ReadFile("../../../sql/init.sql")
+	require.NoError(t, err)
+	handler := &schemaSqlHandler{
+		tenantColumns: map[string]bool{},
+		uniqueKeys: map[string]map[string]string{
+			"items": {"uk_brand_serial_number": "brand,serial_number"},
+			"tags":  {"uk_name": "name"},
+		},
+	}
+	repo := &SchemaRepository{SqlHandler: handler}
+
+	_, err = repo.Migrate(context.Background(), string(script))
+
+	require.NoError(t, err)
+	var added int
+	for _, alter := range handler.alters() {
+		if addColumnPattern.MatchString(alter) {
+			added++
+		}
+	}
+	assert.Equal(t, strings.Count(string(script), "tenant_id BIGINT NOT NULL DEFAULT 1"), added)
+	assert.Equal(t, map[string]string{"uk_tenant_id_brand_serial_number": "tenant_id,brand,serial_number"}, handler.uniqueKeys["items"])
+	assert.Equal(t, map[string]string{"uk_tenant_id_name": "tenant_id,name"}, handler.uniqueKeys["tags"])
+}
@@ -1,11 +1,24 @@
 package database
 
-import "context"
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
 
+// ErrDuplicateKey はユニーク制約違反を表す。SqlHandlerの実装はドライバー固有のエラーをこれでラップして返す
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// SqlHandler はSQLの実行。レプリカを使う実装では、Query/QueryRowはトランザクション外かつ
+// ReadFromPrimaryがfalseの場合にレプリカに送られ、Executeとトランザクション内の処理はプライマリに送られる
 type SqlHandler interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	// Transaction はfnを1つのトランザクション内で実行する。
+	// fnに渡されるctxを使ったExecute/Query/QueryRowはすべて同じトランザクションに参加し、
+	// fnがエラーを返した場合はロールバックされる。既にトランザクション中のctxで呼ばれた場合はそのまま参加する。
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 	Close() error
 }
 
@@ -24,3 +37,43 @@ type Rows interface {
 type Row interface {
 	Scan(dest ...interface{}) error
 }
+
+// 読み込みをプライマリに送るかをctxに保持するためのキー
+type readPreferenceKey struct{}
+
+type readPreference struct {
+	forced bool
+	// WithReadYourWritesのctxで書き込んだか
+	written atomic.Bool
+	// WithPrimaryで上書きする前の設定。書き込みはこちらにも記録する
+	parent *readPreference
+}
+
+func readPreferenceFrom(ctx context.Context) *readPreference {
+	preference, _ := ctx.Value(readPreferenceKey{}).(*readPreference)
+	return preference
+}
+
+// WithPrimary はctxを使った読み込みをレプリカではなくプライマリに送る。
+// 書き込んだ直後に読み込む場合（CreateのあとのFindByIDなど）にレプリカの遅延の影響を受けないようにする
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPreferenceKey{}, &readPreference{forced: true, parent: readPreferenceFrom(ctx)})
+}
+
+// WithReadYourWrites はctx（から派生したctx）で書き込んだ後の読み込みをプライマリに送る。リクエストごとに設定する
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPreferenceKey{}, &readPreference{})
+}
+
+// MarkWritten はctxで書き込んだことを記録する。SqlHandlerの実装が書き込みの後に呼ぶ
+func MarkWritten(ctx context.Context) {
+	for preference := readPreferenceFrom(ctx); preference != nil; preference = preference.parent {
+		preference.written.Store(true)
+	}
+}
+
+// ReadFromPrimary はctxの読み込みをプライマリに送るか
+func ReadFromPrimary(ctx context.Context) bool {
+	preference := readPreferenceFrom(ctx)
+	return preference != nil && (preference.forced || preference.written.Load())
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFromPrimary(t *testing.T) {
+	t.Run("正常系: 設定がなければレプリカから読み込む", func(t *testing.T) {
+		ctx := context.Background()
+		MarkWritten(ctx)
+		assert.False(t, ReadFromPrimary(ctx))
+	})
+
+	t.Run("正常系: WithPrimaryのctxはプライマリから読み込む", func(t *testing.T) {
+		assert.True(t, ReadFromPrimary(WithPrimary(context.Background())))
+	})
+
+	t.Run("正常系: 書き込んだ後はプライマリから読み込む", func(t *testing.T) {
+		ctx := WithReadYourWrites(context.Background())
+		assert.False(t, ReadFromPrimary(ctx))
+
+		// 派生したctxでの書き込みもリクエスト全体に記録する
+		MarkWritten(context.WithValue(ctx, struct{}{}, "child"))
+		assert.True(t, ReadFromPrimary(ctx))
+	})
+
+	t.Run("正常系: WithPrimaryの中での書き込みも元のctxに記録する", func(t *testing.T) {
+		ctx := WithReadYourWrites(context.Background())
+		MarkWritten(WithPrimary(ctx))
+		assert.True(t, ReadFromPrimary(ctx))
+	})
+
+	t.Run("正常系: 別のリクエストの書き込みは影響しない", func(t *testing.T) {
+		base := context.Background()
+		first, second := WithReadYourWrites(base), WithReadYourWrites(base)
+		MarkWritten(first)
+		assert.False(t, ReadFromPrimary(second))
+	})
+}
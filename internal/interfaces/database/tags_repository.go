@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TagRepository struct {
+	SqlHandler
+}
+
+func (r *TagRepository) FindAll(ctx context.Context) ([]*entity.Tag, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT t.id, t.name, COUNT(it.item_id) AS item_count, t.created_at
+        FROM tags t
+        LEFT JOIN item_tags it ON it.tag_id = t.id
+        WHERE t.tenant_id = ?
+        GROUP BY t.id, t.name, t.created_at
+        ORDER BY t.name
+    `
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	tags := []*entity.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT t.id, t.name, (SELECT COUNT(*) FROM item_tags it WHERE it.tag_id = t.id) AS item_count, t.created_at
+        FROM tags t
+        WHERE t.id = ? AND t.tenant_id = ?
+    `
+
+	tag, err := scanTag(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	if len(names) == 0 {
+		return []*entity.Tag{}, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, 0, len(names)+1)
+	for _, name := range names {
+		args = append(args, name)
+	}
+	args = append(args, tenantID)
+
+	query := fmt.Sprintf(`
+        SELECT t.id, t.name, (SELECT COUNT(*) FROM item_tags it WHERE it.tag_id = t.id) AS item_count, t.created_at
+        FROM tags t
+        WHERE t.name IN (%s) AND t.tenant_id = ?
+        ORDER BY t.name
+    `, placeholders(len(names)))
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	tags := []*entity.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Execute(ctx, `INSERT INTO tags (tenant_id, name) VALUES (?, ?)`, tenantID, tag.Name)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, fmt.Errorf("%w: tag %s already exists", domainErrors.ErrDuplicateEntry, tag.Name)
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(WithPrimary(ctx), id)
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Execute(ctx, `UPDATE tags SET name = ? WHERE id = ? AND tenant_id = ?`, tag.Name, tag.ID, tenantID)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, fmt.Errorf("%w: tag %s already exists", domainErrors.ErrDuplicateEntry, tag.Name)
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if _, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 名前が変わらない場合は影響行数が0になるため、存在確認は再取得で行う
+	return r.FindByID(WithPrimary(ctx), tag.ID)
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM tags WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *TagRepository) AttachToItem(ctx context.Context, itemID int64, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 他のテナントのアイテムやタグは結び付けない
+	query := fmt.Sprintf(`
+        INSERT IGNORE INTO item_tags (tenant_id, item_id, tag_id)
+        SELECT i.tenant_id, i.id, t.id
+        FROM items i
+        JOIN tags t ON t.tenant_id = i.tenant_id
+        WHERE i.id = ? AND i.tenant_id = ? AND t.id IN (%s)
+    `, placeholders(len(tagIDs)))
+	params := append([]interface{}{itemID, tenantID}, int64sToArgs(tagIDs)...)
+	if _, err := r.Execute(ctx, query, params...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *TagRepository) DetachFromItem(ctx context.Context, itemID, tagID int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM item_tags WHERE item_id = ? AND tag_id = ? AND tenant_id = ?`, itemID, tagID, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func scanTag(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Tag, error) {
+	var tag entity.Tag
+	var createdAt time.Time
+
+	if err := scanner.Scan(&tag.ID, &tag.Name, &tag.ItemCount, &createdAt); err != nil {
+		return nil, err
+	}
+
+	tag.CreatedAt = createdAt
+	return &tag, nil
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// tenantFrom はctxのテナントを返す。テナントのないctxでは他のテナントのデータに触れないよう、SQLを実行する前にエラーにする
+func tenantFrom(ctx context.Context) (int64, error) {
+	tenantID, ok := usecase.TenantIDFromContext(ctx)
+	if !ok {
+		return 0, domainErrors.ErrTenantRequired
+	}
+	return tenantID, nil
+}
+
+// TenantRepository はテナント自体の管理。他のリポジトリと異なりctxのテナントでは絞り込まない
+type TenantRepository struct {
+	SqlHandler
+}
+
+func (r *TenantRepository) FindAll(ctx context.Context) ([]*entity.Tenant, error) {
+	rows, err := r.Query(ctx, `SELECT id, slug, name, created_at FROM tenants ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	tenants := []*entity.Tenant{}
+	for rows.Next() {
+		var tenant entity.Tenant
+		if err := rows.Scan(&tenant.ID, &tenant.Slug, &tenant.Name, &tenant.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tenants = append(tenants, &tenant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tenants, nil
+}
+
+func (r *TenantRepository) FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	err := r.QueryRow(ctx, `SELECT id, slug, name, created_at FROM tenants WHERE slug = ?`, slug).
+		Scan(&tenant.ID, &tenant.Slug, &tenant.Name, &tenant.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &tenant, nil
+}
+
+func (r *TenantRepository) Create(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error) {
+	_, err := r.Execute(ctx, `INSERT INTO tenants (slug, name) VALUES (?, ?)`, tenant.Slug, tenant.Name)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, fmt.Errorf("%w: tenant %s already exists", domainErrors.ErrDuplicateEntry, tenant.Slug)
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindBySlug(WithPrimary(ctx), tenant.Slug)
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ValuationRepository struct {
+	SqlHandler
+}
+
+func (r *ValuationRepository) FindByItem(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, item_id, valued_at, amount, source, created_at
+        FROM item_valuations
+        WHERE item_id = ? AND tenant_id = ?
+        ORDER BY valued_at DESC, id DESC
+    `
+
+	return r.queryValuations(ctx, query, itemID, tenantID)
+}
+
+func (r *ValuationRepository) Create(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO item_valuations (tenant_id, item_id, valued_at, amount, source)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, tenantID, valuation.ItemID, valuation.ValuedAt, valuation.Amount, valuation.Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *valuation
+	created.ID = id
+	return &created, nil
+}
+
+func (r *ValuationRepository) FindByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error) {
+	byItem := make(map[int64][]*entity.ItemValuation)
+	if len(itemIDs) == 0 {
+		return byItem, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT id, item_id, valued_at, amount, source, created_at
+        FROM item_valuations
+        WHERE item_id IN (%s) AND tenant_id = ?
+        ORDER BY item_id, valued_at DESC, id DESC
+    `, placeholders(len(itemIDs)))
+
+	valuations, err := r.queryValuations(ctx, query, append(int64sToArgs(itemIDs), tenantID)...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, valuation := range valuations {
+		byItem[valuation.ItemID] = append(byItem[valuation.ItemID], valuation)
+	}
+
+	return byItem, nil
+}
+
+func (r *ValuationRepository) FindLatestByItems(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemValuation, error) {
+	latest := make(map[int64]*entity.ItemValuation)
+	if len(itemIDs) == 0 {
+		return latest, nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 評価日の新しい順（同じ日は後から登録した順）に並べ、アイテムごとに先頭の評価額を使う
+	query := fmt.Sprintf(`
+        SELECT id, item_id, valued_at, amount, source, created_at
+        FROM item_valuations
+        WHERE item_id IN (%s) AND tenant_id = ?
+        ORDER BY item_id, valued_at DESC, id DESC
+    `, placeholders(len(itemIDs)))
+
+	valuations, err := r.queryValuations(ctx, query, append(int64sToArgs(itemIDs), tenantID)...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, valuation := range valuations {
+		if _, exists := latest[valuation.ItemID]; !exists {
+			latest[valuation.ItemID] = valuation
+		}
+	}
+
+	return latest, nil
+}
+
+func (r *ValuationRepository) queryValuations(ctx context.Context, query string, args ...interface{}) ([]*entity.ItemValuation, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	valuations := []*entity.ItemValuation{}
+	for rows.Next() {
+		var valuation entity.ItemValuation
+		var valuedAt string
+		if err := rows.Scan(&valuation.ID, &valuation.ItemID, &valuedAt, &valuation.Amount, &valuation.Source, &valuation.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		valuation.ValuedAt = formatDate(valuedAt)
+		valuations = append(valuations, &valuation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return valuations, nil
+}
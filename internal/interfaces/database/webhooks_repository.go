@@ -0,0 +1,465 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type WebhookRepository struct {
+	SqlHandler
+}
+
+// scanWebhookSubscriptionで読み込むカラム
+const webhookSubscriptionSelectColumns = `id, url, events, secret, active, created_at, updated_at`
+
+// scanWebhookDeliveryで読み込むカラム（webhook_deliveriesをd、item_eventsをeとして結合する）
+const webhookDeliverySelectColumns = `d.id, d.subscription_id, d.event_id, e.event_type, e.payload, e.occurred_at,
+            d.status, d.attempts, d.next_attempt_at, d.last_error, d.delivered_at, d.created_at`
+
+func (r *WebhookRepository) FindSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM webhook_subscriptions WHERE tenant_id = ? ORDER BY id`, webhookSubscriptionSelectColumns)
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	subscriptions := []*entity.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) FindSubscriptionByID(ctx context.Context, id int64) (*entity.WebhookSubscription, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM webhook_subscriptions WHERE id = ? AND tenant_id = ?`, webhookSubscriptionSelectColumns)
+
+	subscription, err := scanWebhookSubscription(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return subscription, nil
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO webhook_subscriptions (tenant_id, url, events, secret, active) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, tenantID, subscription.URL, joinEvents(subscription.Events), subscription.Secret, subscription.Active)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindSubscriptionByID(ctx, id)
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE webhook_subscriptions SET url = ?, events = ?, secret = ?, active = ? WHERE id = ? AND tenant_id = ?`
+
+	_, err = r.Execute(ctx, query, subscription.URL, joinEvents(subscription.Events), subscription.Secret, subscription.Active, subscription.ID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindSubscriptionByID(ctx, subscription.ID)
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM webhook_subscriptions WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	values := make([]string, len(deliveries))
+	params := make([]interface{}, 0, len(deliveries)*6)
+	for i, delivery := range deliveries {
+		values[i] = "(?, ?, ?, ?, ?, ?)"
+		params = append(params, tenantID, delivery.SubscriptionID, delivery.EventID, string(delivery.Status), delivery.NextAttemptAt, delivery.CreatedAt)
+	}
+
+	query := fmt.Sprintf(`
+        INSERT INTO webhook_deliveries (tenant_id, subscription_id, event_id, status, next_attempt_at, created_at)
+        VALUES %s
+    `, strings.Join(values, ", "))
+
+	if _, err := r.Execute(ctx, query, params...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, filter usecase.DeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"d.tenant_id = ?"}
+	params := []interface{}{tenantID}
+
+	if filter.SubscriptionID != 0 {
+		conditions = append(conditions, "d.subscription_id = ?")
+		params = append(params, filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "d.status = ?")
+		params = append(params, string(filter.Status))
+	}
+
+	limit := ""
+	if filter.Limit > 0 {
+		limit = "LIMIT ?"
+		params = append(params, filter.Limit)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM webhook_deliveries d
+        JOIN item_events e ON e.id = d.event_id
+        WHERE %s
+        ORDER BY d.id DESC
+        %s
+    `, webhookDeliverySelectColumns, strings.Join(conditions, " AND "), limit)
+
+	return r.queryDeliveries(ctx, query, params...)
+}
+
+func (r *WebhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM webhook_deliveries d
+        JOIN item_events e ON e.id = d.event_id
+        WHERE d.id = ? AND d.tenant_id = ?
+    `, webhookDeliverySelectColumns)
+
+	delivery, err := scanWebhookDelivery(r.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return delivery, nil
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	err = r.Transaction(ctx, func(ctx context.Context) error {
+		// 他のワーカーがロック中の配信は飛ばす
+		query := fmt.Sprintf(`
+            SELECT %s
+            FROM webhook_deliveries d
+            JOIN item_events e ON e.id = d.event_id
+            WHERE d.tenant_id = ? AND d.status = ? AND d.next_attempt_at <= ?
+            ORDER BY d.next_attempt_at, d.id
+            LIMIT ?
+            FOR UPDATE OF d SKIP LOCKED
+        `, webhookDeliverySelectColumns)
+
+		var err error
+		deliveries, err = r.queryDeliveries(ctx, query, tenantID, string(entity.DeliveryPending), now, limit)
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+			delivery.NextAttemptAt = &leaseUntil
+		}
+
+		update := fmt.Sprintf(`UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id IN (%s) AND tenant_id = ?`, placeholders(len(ids)))
+		params := append(append([]interface{}{leaseUntil}, int64sToArgs(ids)...), tenantID)
+		if _, err := r.Execute(ctx, update, params...); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE webhook_deliveries
+        SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, delivered_at = ?
+        WHERE id = ? AND tenant_id = ?
+    `
+
+	_, err = r.Execute(ctx, query,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.ID,
+		tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) CreateAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO webhook_attempts (tenant_id, delivery_id, attempted_at, response_status, error, duration_ms)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	var responseStatus interface{}
+	if attempt.ResponseStatus != nil {
+		responseStatus = *attempt.ResponseStatus
+	}
+
+	result, err := r.Execute(ctx, query, tenantID, attempt.DeliveryID, attempt.AttemptedAt, responseStatus, attempt.Error, attempt.DurationMs)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	attempt.ID = id
+
+	return nil
+}
+
+func (r *WebhookRepository) FindAttempts(ctx context.Context, deliveryID int64) ([]*entity.WebhookAttempt, error) {
+	tenantID, err := tenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, delivery_id, attempted_at, response_status, error, duration_ms
+        FROM webhook_attempts
+        WHERE delivery_id = ? AND tenant_id = ?
+        ORDER BY id
+    `
+
+	rows, err := r.Query(ctx, query, deliveryID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	attempts := []*entity.WebhookAttempt{}
+	for rows.Next() {
+		var attempt entity.WebhookAttempt
+		var responseStatus sql.NullInt64
+		if err := rows.Scan(&attempt.ID, &attempt.DeliveryID, &attempt.AttemptedAt, &responseStatus, &attempt.Error, &attempt.DurationMs); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if responseStatus.Valid {
+			status := int(responseStatus.Int64)
+			attempt.ResponseStatus = &status
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return attempts, nil
+}
+
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, params ...interface{}) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	deliveries := []*entity.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return deliveries, nil
+}
+
+// イベントの種類をカンマ区切りで保存する
+func joinEvents(events []entity.EventType) string {
+	values := make([]string, len(events))
+	for i, event := range events {
+		values[i] = string(event)
+	}
+	return strings.Join(values, ",")
+}
+
+func scanWebhookSubscription(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	var events string
+
+	err := scanner.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&events,
+		&subscription.Secret,
+		&subscription.Active,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Events = []entity.EventType{}
+	for _, event := range strings.Split(events, ",") {
+		if event != "" {
+			subscription.Events = append(subscription.Events, entity.EventType(event))
+		}
+	}
+
+	return &subscription, nil
+}
+
+func scanWebhookDelivery(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	var eventType, status string
+	var payload []byte
+	var nextAttemptAt, deliveredAt sql.NullTime
+
+	err := scanner.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&eventType,
+		&payload,
+		&delivery.OccurredAt,
+		&status,
+		&delivery.Attempts,
+		&nextAttemptAt,
+		&delivery.LastError,
+		&deliveredAt,
+		&delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.EventType = entity.EventType(eventType)
+	delivery.Payload = json.RawMessage(payload)
+	delivery.Status = entity.DeliveryStatus(status)
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+
+	return &delivery, nil
+}
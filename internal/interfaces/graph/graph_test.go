@@ -0,0 +1,406 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// MockItemUsecase はテストで使うメソッドだけを実装したモック
+type MockItemUsecase struct {
+	usecase.ItemUsecase
+	mock.Mock
+}
+
+func (m *MockItemUsecase) ListItems(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemUsecase) IncludeBookValue(items []*entity.Item) {
+	for _, item := range items {
+		value := int64(item.PurchasePrice)
+		item.BookValue = &value
+	}
+}
+
+func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
+}
+
+type MockValuationUsecase struct {
+	usecase.ValuationUsecase
+	mock.Mock
+}
+
+func (m *MockValuationUsecase) GetValuationsByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]*entity.ItemValuation), args.Error(1)
+}
+
+func newTestItem(id int64, name string) *entity.Item {
+	item, _ := entity.NewItem(name, "時計", "ROLEX", 1500000, "2023-01-15")
+	item.ID = id
+	item.Tags = []string{"限定"}
+	item.Attributes = map[string]interface{}{"movement": "自動巻き"}
+	return item
+}
+
+// execute はクエリを送り、レスポンスを返す
+func execute(t *testing.T, h *Handler, query string, variables map[string]interface{}) *GraphQLResponse {
+	t.Helper()
+
+	body, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.Query(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res GraphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return &res
+}
+
+func newTestHandler(t *testing.T, itemUsecase *MockItemUsecase, valuationUsecase *MockValuationUsecase, limits Limits) *Handler {
+	t.Helper()
+
+	h, err := NewHandler(itemUsecase, valuationUsecase, limits)
+	require.NoError(t, err)
+	return h
+}
+
+func TestHandler_Items(t *testing.T) {
+	t.Run("正常系: 一覧の評価額とアイテムをまとめて取得する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		valuationUsecase := new(MockValuationUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{Category: "時計", Status: entity.ItemStatusOwned}).
+			Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ"), newTestItem(2, "オメガ スピードマスター"), newTestItem(3, "カルティエ タンク")}, nil)
+		valuationUsecase.On("GetValuationsByItems", mock.Anything, []int64{1, 2, 3}).Return(map[int64][]*entity.ItemValuation{
+			1: {{ID: 10, ItemID: 1, ValuedAt: "2024-06-01", Amount: 2000000, Source: "査定"}, {ID: 9, ItemID: 1, ValuedAt: "2024-01-01", Amount: 1800000, Source: "相場"}},
+			2: {{ID: 11, ItemID: 2, ValuedAt: "2024-05-01", Amount: 900000, Source: "査定"}},
+		}, nil)
+		h := newTestHandler(t, itemUsecase, valuationUsecase, Limits{})
+
+		res := execute(t, h, `{
+			items(filter: {category: "時計", status: OWNED}) {
+				id
+				name
+				status
+				tags
+				attributes
+				bookValue
+				valuations(limit: 1) { amount item { name } }
+				latestValuation { valuedAt }
+			}
+		}`, nil)
+
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"items": [
+			{"id": "1", "name": "ロレックス デイトナ", "status": "OWNED", "tags": ["限定"], "attributes": {"movement": "自動巻き"}, "bookValue": 1500000,
+			 "valuations": [{"amount": 2000000, "item": {"name": "ロレックス デイトナ"}}], "latestValuation": {"valuedAt": "2024-06-01"}},
+			{"id": "2", "name": "オメガ スピードマスター", "status": "OWNED", "tags": ["限定"], "attributes": {"movement": "自動巻き"}, "bookValue": 1500000,
+			 "valuations": [{"amount": 900000, "item": {"name": "オメガ スピードマスター"}}], "latestValuation": {"valuedAt": "2024-05-01"}},
+			{"id": "3", "name": "カルティエ タンク", "status": "OWNED", "tags": ["限定"], "attributes": {"movement": "自動巻き"}, "bookValue": 1500000,
+			 "valuations": [], "latestValuation": null}
+		]}`, string(res.Data))
+		// 評価額は1回で取得し、評価額のアイテムは一覧で取得済みのものを使う
+		valuationUsecase.AssertNumberOfCalls(t, "GetValuationsByItems", 1)
+		itemUsecase.AssertNotCalled(t, "GetItemsByIDs", mock.Anything, mock.Anything)
+		itemUsecase.AssertNotCalled(t, "GetItemByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: IDを指定した場合はまとめて取得し、存在するアイテムだけを返す", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("GetItemsByIDs", mock.Anything, []int64{2, 1, 999}).
+			Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ"), newTestItem(2, "オメガ スピードマスター")}, nil)
+		h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+		res := execute(t, h, `query($ids: [ID!]) { items(ids: $ids) { id } }`, map[string]interface{}{"ids": []string{"2", "1", "999"}})
+
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"items": [{"id": "2"}, {"id": "1"}]}`, string(res.Data))
+		itemUsecase.AssertNumberOfCalls(t, "GetItemsByIDs", 1)
+	})
+
+	t.Run("正常系: 存在しないアイテムはnull", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("GetItemsByIDs", mock.Anything, []int64{999}).Return([]*entity.Item{}, nil)
+		h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+		res := execute(t, h, `{ item(id: "999") { id } }`, nil)
+
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"item": null}`, string(res.Data))
+	})
+
+	t.Run("異常系: データベースエラーは内容を返さない", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{}).Return(nil, fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError))
+		h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+		res := execute(t, h, `{ items { id } }`, nil)
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "failed to retrieve items", res.Errors[0].Message)
+		assert.Equal(t, codeInternal, res.Errors[0].Extensions["code"])
+	})
+}
+
+func TestHandler_Limits(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         Limits
+		query          string
+		expectedErrors []string
+	}{
+		{
+			name:   "正常系: 上限以内",
+			limits: Limits{MaxDepth: 4, MaxComplexity: 200},
+			query:  `{ items(limit: 2) { id valuations { amount item { id } } } }`,
+		},
+		{
+			name:   "正常系: イントロスペクションは数えない",
+			limits: Limits{MaxDepth: 2, MaxComplexity: 10},
+			query:  `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`,
+		},
+		{
+			name:           "異常系: 深さの上限を超える（フラグメントを展開する）",
+			limits:         Limits{MaxDepth: 3},
+			query:          `{ items(limit: 1) { ...valuations } } fragment valuations on Item { valuations { item { name } } }`,
+			expectedErrors: []string{"query depth 4 exceeds the limit of 3"},
+		},
+		{
+			name:   "異常系: 計算量の上限を超える",
+			limits: Limits{MaxComplexity: 100},
+			// items: 1 + 20 * (id: 1 + valuations: 1 + 20 * amount: 1) = 441
+			query:          `{ items { id valuations { amount } } }`,
+			expectedErrors: []string{"query complexity 441 exceeds the limit of 100"},
+		},
+		{
+			name:   "異常系: 変数のlimitで見積もる",
+			limits: Limits{MaxComplexity: 100},
+			// items: 1 + 50 * (id: 1 + name: 1) = 101
+			query:          `query($limit: Int) { items(limit: $limit) { id name } }`,
+			expectedErrors: []string{"query complexity 101 exceeds the limit of 100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := new(MockItemUsecase)
+			itemUsecase.On("ListItems", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+			h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), tt.limits)
+
+			res := execute(t, h, tt.query, map[string]interface{}{"limit": 50})
+
+			var messages []string
+			for _, err := range res.Errors {
+				messages = append(messages, err.Message)
+				assert.Equal(t, codeQueryTooComplex, err.Extensions["code"])
+			}
+			assert.Equal(t, tt.expectedErrors, messages)
+			if tt.expectedErrors != nil {
+				assert.Nil(t, res.Data)
+				itemUsecase.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_Mutations(t *testing.T) {
+	t.Run("正常系: アイテムを登録する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		itemUsecase.On("CreateItem", mock.Anything, usecase.CreateItemInput{
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 3000000000,
+			PurchaseDate:  "2023-01-15",
+			Attributes:    map[string]interface{}{"movement": "自動巻き"},
+		}).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+		h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+		res := execute(t, h, `mutation($input: CreateItemInput!) { createItem(input: $input) { id name } }`, map[string]interface{}{
+			"input": map[string]interface{}{
+				"name": "ロレックス デイトナ", "category": "時計", "brand": "ROLEX", "purchasePrice": 3000000000, "purchaseDate": "2023-01-15",
+				"attributes": map[string]interface{}{"movement": "自動巻き"},
+			},
+		})
+
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"createItem": {"id": "1", "name": "ロレックス デイトナ"}}`, string(res.Data))
+		itemUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 指定したフィールドだけを更新する", func(t *testing.T) {
+		itemUsecase := new(MockItemUsecase)
+		name := "ロレックス サブマリーナ"
+		itemUsecase.On("UpdateItem", mock.Anything, int64(1), usecase.UpdateItemInput{
+			Name:       &name,
+			Attributes: map[string]interface{}{"movement": nil},
+		}).Return(newTestItem(1, name), nil)
+		h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+		res := execute(t, h, `mutation { updateItem(id: "1", input: {name: "ロレックス サブマリーナ", attributes: {movement: null}}) { name } }`, nil)
+
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"updateItem": {"name": "ロレックス サブマリーナ"}}`, string(res.Data))
+		itemUsecase.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name         string
+		setupMock    func(*MockItemUsecase)
+		query        string
+		expectedCode string
+	}{
+		{
+			name: "異常系: 入力が不正な場合はBAD_USER_INPUT",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("CreateItem", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput))
+			},
+			query:        `mutation { createItem(input: {name: "", category: "時計", brand: "ROLEX", purchasePrice: 1, purchaseDate: "2023-01-15"}) { id } }`,
+			expectedCode: codeBadUserInput,
+		},
+		{
+			name: "異常系: シリアル番号が重複する場合はCONFLICT",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return(nil, fmt.Errorf("%w: serial number", domainErrors.ErrDuplicateEntry))
+			},
+			query:        `mutation { updateItem(id: "1", input: {serialNumber: "ABC"}) { id } }`,
+			expectedCode: codeConflict,
+		},
+		{
+			name: "異常系: 存在しないアイテムの削除はNOT_FOUND",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("DeleteItem", mock.Anything, int64(999)).Return(domainErrors.ErrItemNotFound)
+			},
+			query:        `mutation { deleteItem(id: "999") }`,
+			expectedCode: codeNotFound,
+		},
+		{
+			name:         "異常系: 不正なID",
+			setupMock:    func(m *MockItemUsecase) {},
+			query:        `mutation { deleteItem(id: "abc") }`,
+			expectedCode: codeBadUserInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := new(MockItemUsecase)
+			tt.setupMock(itemUsecase)
+			h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+			res := execute(t, h, tt.query, nil)
+
+			require.Len(t, res.Errors, 1)
+			assert.Equal(t, tt.expectedCode, res.Errors[0].Extensions["code"])
+			itemUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Summary(t *testing.T) {
+	itemUsecase := new(MockItemUsecase)
+	itemUsecase.On("GetCategorySummary", mock.Anything).Return(&usecase.CategorySummary{
+		Categories: map[string]int{"時計": 2, "バッグ": 1},
+		Total:      3,
+		Locations:  []*usecase.LocationSummary{{Name: "未設定", ItemCount: 3, TotalValue: 4000000000}},
+		Statuses:   map[string]int{"owned": 2, "in_repair": 1},
+		Sales: &usecase.SalesSummary{
+			SalesTotals: usecase.SalesTotals{},
+			Categories:  map[string]*usecase.SalesTotals{},
+		},
+	}, nil)
+	h := newTestHandler(t, itemUsecase, new(MockValuationUsecase), Limits{})
+
+	res := execute(t, h, `{ summary { total categories { category count } statuses { status count } locations { locationId name totalValue } sales { total { soldCount } } } }`, nil)
+
+	require.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"summary": {
+		"total": 3,
+		"categories": [{"category": "バッグ", "count": 1}, {"category": "時計", "count": 2}],
+		"statuses": [{"status": "IN_REPAIR", "count": 1}, {"status": "OWNED", "count": 2}],
+		"locations": [{"locationId": null, "name": "未設定", "totalValue": 4000000000}],
+		"sales": {"total": {"soldCount": 0}}
+	}}`, string(res.Data))
+}
+
+func TestHandler_InvalidRequest(t *testing.T) {
+	h := newTestHandler(t, new(MockItemUsecase), new(MockValuationUsecase), Limits{})
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": ""}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.Query(e.NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error": "validation failed", "details": ["query is required"]}`, rec.Body.String())
+}
@@ -0,0 +1,135 @@
+// Package graph はアイテムAPIのGraphQLエンドポイント（schema.graphql）を実装する
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// 上限を超えるクエリのエラーの分類（extensions.code）
+const codeQueryTooComplex = "QUERY_TOO_COMPLEX"
+
+// エラーレスポンスの形式（GraphQLのリクエストとして解釈できない場合）
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// GraphQLRequest はPOST /graphqlのリクエストボディ
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse はPOST /graphqlのレスポンスボディ。クエリのエラーもステータスコード200のerrorsで返す
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []*GraphQLError `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Handler struct {
+	schema           *graphql.Schema
+	limiter          *limiter
+	itemUsecase      usecase.ItemUsecase
+	valuationUsecase usecase.ValuationUsecase
+}
+
+func NewHandler(itemUsecase usecase.ItemUsecase, valuationUsecase usecase.ValuationUsecase, limits Limits) (*Handler, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{itemUsecase: itemUsecase},
+		graphql.UseFieldResolvers(),
+		graphql.UseStringDescriptions(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter, err := newLimiter(schemaSDL, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		schema:           schema,
+		limiter:          limiter,
+		itemUsecase:      itemUsecase,
+		valuationUsecase: valuationUsecase,
+	}, nil
+}
+
+// POST /graphql
+func (h *Handler) Query(c echo.Context) error {
+	var req GraphQLRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request format",
+		})
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: []string{"query is required"},
+		})
+	}
+
+	// 深さと計算量が上限を超えるクエリは実行しない
+	if problems := h.limiter.check(req.Query, req.OperationName, req.Variables); len(problems) > 0 {
+		res := &GraphQLResponse{}
+		for _, problem := range problems {
+			res.Errors = append(res.Errors, &GraphQLError{
+				Message:    problem,
+				Extensions: map[string]interface{}{"code": codeQueryTooComplex},
+			})
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+
+	// ローダーはリクエストごとに作り、同じリクエストの中でだけ取得結果を共有する
+	ctx := withLoaders(c.Request().Context(), newLoaders(h.itemUsecase, h.valuationUsecase))
+	result := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	return c.JSON(http.StatusOK, newGraphQLResponse(result))
+}
+
+func newGraphQLResponse(result *graphql.Response) *GraphQLResponse {
+	res := &GraphQLResponse{Data: result.Data}
+	for _, err := range result.Errors {
+		res.Errors = append(res.Errors, newGraphQLError(err))
+	}
+	return res
+}
+
+func newGraphQLError(err *gqlerrors.QueryError) *GraphQLError {
+	e := &GraphQLError{
+		Message:    err.Message,
+		Path:       err.Path,
+		Extensions: err.Extensions,
+	}
+	for _, location := range err.Locations {
+		e.Locations = append(e.Locations, GraphQLErrorLocation{Line: location.Line, Column: location.Column})
+	}
+	return e
+}
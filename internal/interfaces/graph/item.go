@@ -0,0 +1,309 @@
+package graph
+
+import (
+	"context"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+type itemResolver struct {
+	item *entity.Item
+}
+
+// アイテムをキャッシュし、子のフィールド（評価額）で使うIDをまとめて取得するよう登録する
+func newItemResolvers(ctx context.Context, items []*entity.Item) []*itemResolver {
+	l := loadersFrom(ctx)
+	ids := make([]int64, len(items))
+	resolvers := make([]*itemResolver, len(items))
+	for i, item := range items {
+		l.items.Add(item.ID, item)
+		ids[i] = item.ID
+		resolvers[i] = &itemResolver{item: item}
+	}
+	l.valuations.Prime(ids...)
+	return resolvers
+}
+
+func (r *itemResolver) ID() graphql.ID {
+	return formatID(r.item.ID)
+}
+
+func (r *itemResolver) Name() string {
+	return r.item.Name
+}
+
+func (r *itemResolver) Category() string {
+	return r.item.Category
+}
+
+func (r *itemResolver) Brand() string {
+	return r.item.Brand
+}
+
+func (r *itemResolver) PurchasePrice() Int64 {
+	return Int64(r.item.PurchasePrice)
+}
+
+func (r *itemResolver) PurchaseDate() string {
+	return r.item.PurchaseDate
+}
+
+func (r *itemResolver) SerialNumber() string {
+	return r.item.SerialNumber
+}
+
+func (r *itemResolver) ModelNumber() string {
+	return r.item.ModelNumber
+}
+
+func (r *itemResolver) Condition() string {
+	return r.item.Condition
+}
+
+func (r *itemResolver) Authenticity() string {
+	return r.item.Authenticity
+}
+
+func (r *itemResolver) LocationID() *graphql.ID {
+	return optionalID(r.item.LocationID)
+}
+
+func (r *itemResolver) Status() string {
+	return statusToEnum(r.item.Status)
+}
+
+func (r *itemResolver) SalePrice() *Int64 {
+	if r.item.SalePrice == nil {
+		return nil
+	}
+	price := Int64(*r.item.SalePrice)
+	return &price
+}
+
+func (r *itemResolver) SaleDate() *string {
+	return optionalString(r.item.SaleDate)
+}
+
+func (r *itemResolver) WarrantyExpiresAt() *string {
+	return optionalString(r.item.WarrantyExpiresAt)
+}
+
+func (r *itemResolver) InsurancePolicyID() *graphql.ID {
+	return optionalID(r.item.InsurancePolicyID)
+}
+
+func (r *itemResolver) Tags() []string {
+	if r.item.Tags == nil {
+		return []string{}
+	}
+	return r.item.Tags
+}
+
+func (r *itemResolver) Attributes() JSON {
+	return JSON{Value: r.item.Attributes}
+}
+
+func (r *itemResolver) BookValue() *Int64 {
+	if r.item.BookValue == nil {
+		return nil
+	}
+	value := Int64(*r.item.BookValue)
+	return &value
+}
+
+// Valuations は一覧のアイテムの評価額をまとめて取得する
+func (r *itemResolver) Valuations(ctx context.Context, args struct{ Limit *int32 }) ([]*valuationResolver, error) {
+	if args.Limit != nil && *args.Limit < 0 {
+		return nil, badUserInput("limit must be 0 or greater")
+	}
+
+	valuations, _, err := loadersFrom(ctx).valuations.Load(ctx, r.item.ID)
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to retrieve valuations")
+	}
+	if args.Limit != nil && int(*args.Limit) < len(valuations) {
+		valuations = valuations[:*args.Limit]
+	}
+
+	resolvers := make([]*valuationResolver, len(valuations))
+	for i, valuation := range valuations {
+		resolvers[i] = &valuationResolver{valuation: valuation}
+	}
+	return resolvers, nil
+}
+
+func (r *itemResolver) LatestValuation(ctx context.Context) (*valuationResolver, error) {
+	valuations, _, err := loadersFrom(ctx).valuations.Load(ctx, r.item.ID)
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to retrieve valuations")
+	}
+	if len(valuations) == 0 {
+		return nil, nil
+	}
+	// 評価額は評価日の新しい順
+	return &valuationResolver{valuation: valuations[0]}, nil
+}
+
+func (r *itemResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.CreatedAt}
+}
+
+func (r *itemResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.UpdatedAt}
+}
+
+type valuationResolver struct {
+	valuation *entity.ItemValuation
+}
+
+func (r *valuationResolver) ID() graphql.ID {
+	return formatID(r.valuation.ID)
+}
+
+func (r *valuationResolver) ValuedAt() string {
+	return r.valuation.ValuedAt
+}
+
+func (r *valuationResolver) Amount() Int64 {
+	return Int64(r.valuation.Amount)
+}
+
+func (r *valuationResolver) Source() string {
+	return r.valuation.Source
+}
+
+func (r *valuationResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.valuation.CreatedAt}
+}
+
+// Item は評価額のアイテム。一覧から辿った場合は取得済みのアイテムを使う
+func (r *valuationResolver) Item(ctx context.Context) (*itemResolver, error) {
+	item, ok, err := loadersFrom(ctx).items.Load(ctx, r.valuation.ItemID)
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to retrieve item")
+	}
+	if !ok {
+		return nil, &resolverError{message: "item not found", code: codeNotFound}
+	}
+	return &itemResolver{item: item}, nil
+}
+
+// categorySummary はGetCategorySummaryの結果。マップはキーの順のリストにする
+type categorySummary struct {
+	Total      int32
+	Categories []*categoryCount
+	Statuses   []*statusCount
+	Locations  []*locationSummary
+	Sales      *salesSummary
+}
+
+type categoryCount struct {
+	Category string
+	Count    int32
+}
+
+type statusCount struct {
+	Status string
+	Count  int32
+}
+
+type locationSummary struct {
+	LocationID *graphql.ID
+	Name       string
+	Type       *string
+	ParentID   *graphql.ID
+	ItemCount  int32
+	TotalValue Int64
+}
+
+type salesSummary struct {
+	Total      *salesTotals
+	Categories []*categorySales
+}
+
+type categorySales struct {
+	Category string
+	Totals   *salesTotals
+}
+
+type salesTotals struct {
+	SoldCount     int32
+	PurchaseTotal Int64
+	SaleTotal     Int64
+	RealizedGain  Int64
+}
+
+func newCategorySummary(summary *usecase.CategorySummary) *categorySummary {
+	result := &categorySummary{
+		Total:      int32(summary.Total),
+		Categories: []*categoryCount{},
+		Statuses:   []*statusCount{},
+		Locations:  []*locationSummary{},
+		Sales:      &salesSummary{Total: &salesTotals{}, Categories: []*categorySales{}},
+	}
+
+	for _, category := range sortedKeys(summary.Categories) {
+		result.Categories = append(result.Categories, &categoryCount{Category: category, Count: int32(summary.Categories[category])})
+	}
+	for _, status := range sortedKeys(summary.Statuses) {
+		result.Statuses = append(result.Statuses, &statusCount{Status: statusToEnum(entity.ItemStatus(status)), Count: int32(summary.Statuses[status])})
+	}
+	for _, location := range summary.Locations {
+		result.Locations = append(result.Locations, &locationSummary{
+			LocationID: optionalID(location.LocationID),
+			Name:       location.Name,
+			Type:       optionalString(string(location.Type)),
+			ParentID:   optionalID(location.ParentID),
+			ItemCount:  int32(location.ItemCount),
+			TotalValue: Int64(location.TotalValue),
+		})
+	}
+	if summary.Sales != nil {
+		result.Sales.Total = newSalesTotals(&summary.Sales.SalesTotals)
+		for _, category := range sortedKeys(summary.Sales.Categories) {
+			result.Sales.Categories = append(result.Sales.Categories, &categorySales{
+				Category: category,
+				Totals:   newSalesTotals(summary.Sales.Categories[category]),
+			})
+		}
+	}
+
+	return result
+}
+
+func newSalesTotals(totals *usecase.SalesTotals) *salesTotals {
+	return &salesTotals{
+		SoldCount:     int32(totals.SoldCount),
+		PurchaseTotal: Int64(totals.PurchaseTotal),
+		SaleTotal:     Int64(totals.SaleTotal),
+		RealizedGain:  Int64(totals.RealizedGain),
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func optionalID(id *int64) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	formatted := formatID(*id)
+	return &formatted
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
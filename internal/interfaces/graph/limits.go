@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Limits はクエリの深さと計算量の上限
+type Limits struct {
+	// フィールドの入れ子の深さの上限
+	MaxDepth int
+	// フィールドの数の見積もりの上限。リストのフィールドは子の計算量にlimit（未指定の場合はDefaultListSize）を掛ける
+	MaxComplexity int
+}
+
+// limitを指定していないリストのフィールドの件数の見積もり
+const DefaultListSize = 20
+
+// queryCost はクエリの深さと計算量
+type queryCost struct {
+	depth      int
+	complexity int
+}
+
+// limiter は実行前にクエリの深さと計算量を見積もる
+type limiter struct {
+	schema *ast.Schema
+	limits Limits
+}
+
+func newLimiter(sdl string, limits Limits) (*limiter, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+	return &limiter{schema: schema, limits: limits}, nil
+}
+
+// check は上限を超える場合にエラーのメッセージを返す。構文などのエラーは実行時に返すため、ここでは見積もらない
+func (l *limiter) check(query, operationName string, variables map[string]interface{}) []string {
+	doc, errs := gqlparser.LoadQuery(l.schema, query)
+	if len(errs) > 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, op := range doc.Operations {
+		if operationName != "" && op.Name != operationName {
+			continue
+		}
+
+		cost := measure(op.SelectionSet, variables)
+		if l.limits.MaxDepth > 0 && cost.depth > l.limits.MaxDepth {
+			problems = append(problems, fmt.Sprintf("query depth %d exceeds the limit of %d", cost.depth, l.limits.MaxDepth))
+		}
+		if l.limits.MaxComplexity > 0 && cost.complexity > l.limits.MaxComplexity {
+			problems = append(problems, fmt.Sprintf("query complexity %d exceeds the limit of %d", cost.complexity, l.limits.MaxComplexity))
+		}
+	}
+	return problems
+}
+
+// フラグメントを展開して深さと計算量を数える。イントロスペクション（__で始まるフィールド）は数えない
+func measure(selections ast.SelectionSet, variables map[string]interface{}) queryCost {
+	var total queryCost
+	for _, selection := range selections {
+		var cost queryCost
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			children := measure(s.SelectionSet, variables)
+			cost.depth = children.depth + 1
+			cost.complexity = 1 + children.complexity*listSize(s, variables)
+		case *ast.InlineFragment:
+			cost = measure(s.SelectionSet, variables)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				cost = measure(s.Definition.SelectionSet, variables)
+			}
+		}
+
+		total.complexity += cost.complexity
+		if cost.depth > total.depth {
+			total.depth = cost.depth
+		}
+	}
+	return total
+}
+
+// リストのフィールドの件数の見積もり。limitまたはidsの数（両方ある場合は小さいほう）を使い、どちらもなければDefaultListSize
+func listSize(field *ast.Field, variables map[string]interface{}) int {
+	if field.Definition == nil || field.Definition.Type.Elem == nil {
+		return 1
+	}
+
+	args := field.ArgumentMap(variables)
+	size := DefaultListSize
+	ids, hasIDs := args["ids"].([]interface{})
+	if hasIDs {
+		size = len(ids)
+	}
+	if limit, ok := toInt(args["limit"]); ok && (!hasIDs || limit < size) {
+		size = limit
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// リテラルはint64、変数はJSONの数値（float64）になる
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// loader はリクエストの中でキーごとの取得を1回のバッチにまとめ、結果をキャッシュする。
+// 一覧を解決したときに子のフィールドで使うキーをPrimeしておくと、最初のLoadでまとめて取得する
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	results map[K]V
+	// 取得済みのキー（結果がないキーを含む）
+	loaded map[K]bool
+	// 次の取得に含めるキー
+	pending []K
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		results: make(map[K]V),
+		loaded:  make(map[K]bool),
+	}
+}
+
+// Prime は次の取得でまとめて取得するキーを登録する
+func (l *loader[K, V]) Prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if !l.loaded[key] {
+			l.pending = append(l.pending, key)
+		}
+	}
+}
+
+// Add は取得済みの値をキャッシュする
+func (l *loader[K, V]) Add(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.results[key] = value
+	l.loaded[key] = true
+}
+
+// Load はキーの値を返す。値がない場合はokがfalse
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	results, err := l.LoadMany(ctx, []K{key})
+	value, ok := results[key]
+	return value, ok, err
+}
+
+// LoadMany は未取得のキーを登録済みのキーと合わせて1回で取得し、値があるキーの結果を返す
+func (l *loader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	// 取得中は他のgoroutineを待たせ、同じキーを重複して取得しない
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var missing []K
+	seen := make(map[K]bool)
+	for _, key := range append(l.pending, keys...) {
+		if !l.loaded[key] && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := l.fetch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range missing {
+			if value, ok := fetched[key]; ok {
+				l.results[key] = value
+			}
+			l.loaded[key] = true
+		}
+		l.pending = nil
+	}
+
+	results := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := l.results[key]; ok {
+			results[key] = value
+		}
+	}
+	return results, nil
+}
+
+// loaders はリクエストごとのローダー
+type loaders struct {
+	items      *loader[int64, *entity.Item]
+	valuations *loader[int64, []*entity.ItemValuation]
+}
+
+func newLoaders(itemUsecase usecase.ItemUsecase, valuationUsecase usecase.ValuationUsecase) *loaders {
+	return &loaders{
+		items: newLoader(func(ctx context.Context, ids []int64) (map[int64]*entity.Item, error) {
+			items, err := itemUsecase.GetItemsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			itemUsecase.IncludeBookValue(items)
+
+			byID := make(map[int64]*entity.Item, len(items))
+			for _, item := range items {
+				byID[item.ID] = item
+			}
+			return byID, nil
+		}),
+		valuations: newLoader(valuationUsecase.GetValuationsByItems),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,311 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// resolver はQueryとMutationのフィールドを解決する
+type resolver struct {
+	itemUsecase usecase.ItemUsecase
+}
+
+func (r *resolver) Item(ctx context.Context, args struct{ ID graphql.ID }) (*itemResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok, err := loadersFrom(ctx).items.Load(ctx, id)
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to retrieve item")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return newItemResolvers(ctx, []*entity.Item{item})[0], nil
+}
+
+type itemsArgs struct {
+	Filter *itemFilterInput
+	IDs    *[]graphql.ID
+	Limit  *int32
+}
+
+type itemFilterInput struct {
+	Category   *string
+	Tags       *[]string
+	Status     *string
+	LocationID *graphql.ID
+	Attributes *[]attributeFilterInput
+}
+
+type attributeFilterInput struct {
+	Key   string
+	Value string
+}
+
+func (r *resolver) Items(ctx context.Context, args itemsArgs) ([]*itemResolver, error) {
+	if args.Limit != nil && *args.Limit < 0 {
+		return nil, badUserInput("limit must be 0 or greater")
+	}
+
+	var items []*entity.Item
+	if args.IDs != nil {
+		if args.Filter != nil {
+			return nil, badUserInput("filter and ids cannot be used together")
+		}
+
+		ids := make([]int64, len(*args.IDs))
+		for i, raw := range *args.IDs {
+			id, err := parseID(raw)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+
+		// 指定した順に、存在するアイテムだけを返す
+		found, err := loadersFrom(ctx).items.LoadMany(ctx, ids)
+		if err != nil {
+			return nil, resolverErrorFrom(err, "failed to retrieve items")
+		}
+		for _, id := range ids {
+			if item, ok := found[id]; ok {
+				items = append(items, item)
+			}
+		}
+	} else {
+		filter, err := args.Filter.toItemFilter()
+		if err != nil {
+			return nil, err
+		}
+		if items, err = r.itemUsecase.ListItems(ctx, filter); err != nil {
+			return nil, resolverErrorFrom(err, "failed to retrieve items")
+		}
+		r.itemUsecase.IncludeBookValue(items)
+	}
+
+	if args.Limit != nil && int(*args.Limit) < len(items) {
+		items = items[:*args.Limit]
+	}
+	return newItemResolvers(ctx, items), nil
+}
+
+func (f *itemFilterInput) toItemFilter() (usecase.ItemFilter, error) {
+	var filter usecase.ItemFilter
+	if f == nil {
+		return filter, nil
+	}
+
+	if f.Category != nil {
+		filter.Category = *f.Category
+	}
+	if f.Tags != nil {
+		filter.Tags = *f.Tags
+	}
+	if f.Status != nil {
+		filter.Status = statusFromEnum(*f.Status)
+	}
+	if f.LocationID != nil {
+		locationID, err := parseID(*f.LocationID)
+		if err != nil {
+			return filter, err
+		}
+		filter.LocationID = locationID
+	}
+	if f.Attributes != nil {
+		filter.Attributes = make(map[string]string, len(*f.Attributes))
+		for _, attribute := range *f.Attributes {
+			filter.Attributes[attribute.Key] = attribute.Value
+		}
+	}
+	return filter, nil
+}
+
+func (r *resolver) Summary(ctx context.Context) (*categorySummary, error) {
+	summary, err := r.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to retrieve summary")
+	}
+	return newCategorySummary(summary), nil
+}
+
+type createItemInput struct {
+	Name              string
+	Category          string
+	Brand             string
+	PurchasePrice     Int64
+	PurchaseDate      string
+	SerialNumber      *string
+	ModelNumber       *string
+	Condition         *string
+	Authenticity      *string
+	WarrantyExpiresAt *string
+	Attributes        *JSON
+}
+
+func (r *resolver) CreateItem(ctx context.Context, args struct{ Input createItemInput }) (*itemResolver, error) {
+	input := args.Input
+	item, err := r.itemUsecase.CreateItem(ctx, usecase.CreateItemInput{
+		Name:              input.Name,
+		Category:          input.Category,
+		Brand:             input.Brand,
+		PurchasePrice:     int(input.PurchasePrice),
+		PurchaseDate:      input.PurchaseDate,
+		SerialNumber:      valueOf(input.SerialNumber),
+		ModelNumber:       valueOf(input.ModelNumber),
+		Condition:         valueOf(input.Condition),
+		Authenticity:      valueOf(input.Authenticity),
+		WarrantyExpiresAt: valueOf(input.WarrantyExpiresAt),
+		Attributes:        input.Attributes.value(),
+	})
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to create item")
+	}
+	r.itemUsecase.IncludeBookValue([]*entity.Item{item})
+	return newItemResolvers(ctx, []*entity.Item{item})[0], nil
+}
+
+type updateItemInput struct {
+	Name              *string
+	Category          *string
+	Brand             *string
+	PurchasePrice     *Int64
+	PurchaseDate      *string
+	SerialNumber      *string
+	ModelNumber       *string
+	Condition         *string
+	Authenticity      *string
+	WarrantyExpiresAt *string
+	Attributes        *JSON
+}
+
+func (r *resolver) UpdateItem(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateItemInput
+}) (*itemResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := usecase.UpdateItemInput{
+		Name:              args.Input.Name,
+		Category:          args.Input.Category,
+		Brand:             args.Input.Brand,
+		PurchaseDate:      args.Input.PurchaseDate,
+		SerialNumber:      args.Input.SerialNumber,
+		ModelNumber:       args.Input.ModelNumber,
+		Condition:         args.Input.Condition,
+		Authenticity:      args.Input.Authenticity,
+		WarrantyExpiresAt: args.Input.WarrantyExpiresAt,
+		Attributes:        args.Input.Attributes.value(),
+	}
+	if args.Input.PurchasePrice != nil {
+		price := int(*args.Input.PurchasePrice)
+		input.PurchasePrice = &price
+	}
+
+	var item *entity.Item
+	if input.IsEmpty() {
+		// 変更がない場合は現在のアイテムをそのまま返す
+		item, err = r.itemUsecase.GetItemByID(ctx, id)
+	} else {
+		item, err = r.itemUsecase.UpdateItem(ctx, id, input)
+	}
+	if err != nil {
+		return nil, resolverErrorFrom(err, "failed to update item")
+	}
+	r.itemUsecase.IncludeBookValue([]*entity.Item{item})
+	return newItemResolvers(ctx, []*entity.Item{item})[0], nil
+}
+
+func (r *resolver) DeleteItem(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.itemUsecase.DeleteItem(ctx, id); err != nil {
+		return "", resolverErrorFrom(err, "failed to delete item")
+	}
+	return args.ID, nil
+}
+
+// resolverError はextensions.codeに分類を含めるエラー
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// エラーの分類（extensions.code）
+const (
+	codeBadUserInput = "BAD_USER_INPUT"
+	codeNotFound     = "NOT_FOUND"
+	codeConflict     = "CONFLICT"
+	codeInternal     = "INTERNAL_SERVER_ERROR"
+)
+
+func badUserInput(message string) error {
+	return &resolverError{message: message, code: codeBadUserInput}
+}
+
+// ドメインエラーをGraphQLのエラーに変換する。想定外のエラーは内容を返さずmessageにする
+func resolverErrorFrom(err error, message string) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return &resolverError{message: "item not found", code: codeNotFound}
+	case domainErrors.IsValidationError(err):
+		return badUserInput(err.Error())
+	case domainErrors.IsDuplicateError(err), domainErrors.IsConflictError(err):
+		return &resolverError{message: err.Error(), code: codeConflict}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	}
+	return &resolverError{message: message, code: codeInternal}
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	parsed, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, badUserInput("ID must be a positive integer: " + string(id))
+	}
+	return parsed, nil
+}
+
+func formatID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+// ItemStatusのenum（IN_REPAIRなど）とドメインの値（in_repairなど）を変換する
+func statusFromEnum(status string) entity.ItemStatus {
+	return entity.ItemStatus(strings.ToLower(status))
+}
+
+func statusToEnum(status entity.ItemStatus) string {
+	return strings.ToUpper(string(status))
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
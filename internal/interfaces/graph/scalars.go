@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Int64 は32ビットを超える金額を表すスカラー。GraphQLのIntは32ビットのため使わない
+type Int64 int64
+
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+// クエリのリテラルはint32、変数はJSONの数値（float64）になる。32ビットを超えるリテラルは文字列でも受け付ける
+func (n *Int64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*n = Int64(v)
+	case int64:
+		*n = Int64(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return fmt.Errorf("Int64 must be an integer: %v", v)
+		}
+		*n = Int64(v)
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Int64 must be an integer: %q", v)
+		}
+		*n = Int64(parsed)
+	default:
+		return fmt.Errorf("Int64 must be an integer: %v", input)
+	}
+	return nil
+}
+
+func (n Int64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(n), 10), nil
+}
+
+// JSON はカスタム属性のオブジェクトを表すスカラー
+type JSON struct {
+	Value map[string]interface{}
+}
+
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	value, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("JSON must be an object: %v", input)
+	}
+	j.Value = value
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j.Value == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(j.Value)
+}
+
+// 未指定の場合はnil
+func (j *JSON) value() map[string]interface{} {
+	if j == nil {
+		return nil
+	}
+	return j.Value
+}
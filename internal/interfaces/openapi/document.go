@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ドキュメントで使うContent-Type
+const (
+	MIMEApplicationJSON = "application/json"
+	MIMETextEventStream = "text/event-stream"
+	MIMETextHTML        = "text/html"
+)
+
+// Parameter はパス・クエリ・ヘッダーのパラメーター
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	// 配列のクエリパラメーターは ?tag=a&tag=b のように繰り返す
+	Explode *bool `json:"explode,omitempty"`
+}
+
+// Operation はエンドポイント1つ分の定義
+type Operation struct {
+	Method string
+	// Echoに登録したパス（/items/:id）
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string
+	Parameters  []*Parameter
+	// リクエストボディのContent-Typeごとのスキーマ。ボディを受け付けない場合はnil
+	RequestBody map[string]*Schema
+	// 成功時のステータスコードとレスポンスのスキーマ（ボディがない場合はnil）
+	Status      int
+	Response    *Schema
+	ContentType string
+	// ストリーミングのレスポンス（SSE）はレスポンスを検証しない
+	Stream bool
+}
+
+// OpenAPIのパス（/items/{id}）
+func (o *Operation) openAPIPath() string {
+	segments := strings.Split(o.Path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Document はAPI全体のOpenAPIドキュメント
+type Document struct {
+	operations map[string]*Operation
+	components map[string]*Schema
+	raw        []byte
+}
+
+func operationKey(method, path string) string {
+	return method + " " + path
+}
+
+// Operation はEchoのメソッドとパスに対応する定義を返す。定義されていない場合はnil
+func (d *Document) Operation(method, path string) *Operation {
+	return d.operations[operationKey(method, path)]
+}
+
+// JSON はOpenAPI 3.1のドキュメントを返す
+func (d *Document) JSON() []byte {
+	return d.raw
+}
+
+// CheckRoutes はEchoに登録されたルートとドキュメントの定義が一致しているかを確認する
+func (d *Document) CheckRoutes(routes []*echo.Route) error {
+	registered := make(map[string]bool)
+	var errs []string
+	for _, route := range routes {
+		key := operationKey(route.Method, route.Path)
+		registered[key] = true
+		if d.operations[key] == nil {
+			errs = append(errs, "undocumented route: "+key)
+		}
+	}
+	for key := range d.operations {
+		if !registered[key] {
+			errs = append(errs, "documented route is not registered: "+key)
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ドキュメントのJSONの形式
+type spec struct {
+	OpenAPI    string                               `json:"openapi"`
+	Info       specInfo                             `json:"info"`
+	Tags       []specTag                            `json:"tags"`
+	Paths      map[string]map[string]*specOperation `json:"paths"`
+	Components specComponents                       `json:"components"`
+}
+
+type specInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type specTag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type specOperation struct {
+	OperationID string                   `json:"operationId"`
+	Summary     string                   `json:"summary"`
+	Tags        []string                 `json:"tags"`
+	Parameters  []*Parameter             `json:"parameters,omitempty"`
+	RequestBody *specRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*specResponse `json:"responses"`
+}
+
+type specRequestBody struct {
+	Required bool                     `json:"required"`
+	Content  map[string]specMediaType `json:"content"`
+}
+
+type specResponse struct {
+	Description string                   `json:"description"`
+	Content     map[string]specMediaType `json:"content,omitempty"`
+}
+
+type specMediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type specComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// エラーレスポンスのスキーマ名
+const errorResponseSchema = "ErrorResponse"
+
+func errorResponse() *Schema {
+	return &Schema{Ref: "#/components/schemas/" + errorResponseSchema}
+}
+
+// build はルートの定義からドキュメントを組み立てる
+func build(routes []route, g *generator) (*Document, error) {
+	g.components[errorResponseSchema] = &Schema{
+		Type: SchemaType{"object"},
+		Properties: map[string]*Schema{
+			"error":   stringSchema(),
+			"details": arrayOf(stringSchema()),
+		},
+		Required: []string{"error"},
+	}
+
+	d := &Document{
+		operations: make(map[string]*Operation),
+		components: g.components,
+	}
+	s := spec{
+		OpenAPI: "3.1.0",
+		Info: specInfo{
+			Title:       "Aicon Items API",
+			Version:     "1.0.0",
+			Description: "高級品の所有アイテムを管理するAPI",
+		},
+		Tags:       apiTags,
+		Paths:      make(map[string]map[string]*specOperation),
+		Components: specComponents{Schemas: g.components},
+	}
+
+	for _, r := range routes {
+		op := r.operation(g)
+		key := operationKey(op.Method, op.Path)
+		if d.operations[key] != nil {
+			return nil, fmt.Errorf("duplicate operation: %s", key)
+		}
+		d.operations[key] = op
+
+		path := op.openAPIPath()
+		if s.Paths[path] == nil {
+			s.Paths[path] = make(map[string]*specOperation)
+		}
+		s.Paths[path][strings.ToLower(op.Method)] = op.spec()
+	}
+
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	d.raw = raw
+	return d, nil
+}
+
+func (o *Operation) spec() *specOperation {
+	s := &specOperation{
+		OperationID: o.OperationID,
+		Summary:     o.Summary,
+		Tags:        []string{o.Tag},
+		Parameters:  o.Parameters,
+		Responses:   make(map[string]*specResponse),
+	}
+
+	if o.RequestBody != nil {
+		s.RequestBody = &specRequestBody{Required: true, Content: make(map[string]specMediaType)}
+		for contentType, schema := range o.RequestBody {
+			s.RequestBody.Content[contentType] = specMediaType{Schema: schema}
+		}
+	}
+
+	success := &specResponse{Description: http.StatusText(o.Status)}
+	if o.Response != nil || o.ContentType != MIMEApplicationJSON {
+		success.Content = map[string]specMediaType{o.ContentType: {Schema: o.Response}}
+	}
+	s.Responses[strconv.Itoa(o.Status)] = success
+	s.Responses["default"] = &specResponse{
+		Description: "エラー",
+		Content:     map[string]specMediaType{MIMEApplicationJSON: {Schema: errorResponse()}},
+	}
+	return s
+}
+
+// route はルート表の1行
+type route struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	tag         string
+	query       []*Parameter
+	header      []*Parameter
+	// リクエストボディの値（型だけを使う）。application/json以外も受け付ける場合はbodiesに指定する
+	body   interface{}
+	bodies map[string]*Schema
+	// 成功時のステータスコードとレスポンスの値（型だけを使う）
+	status      int
+	response    interface{}
+	contentType string
+	stream      bool
+}
+
+func (r route) operation(g *generator) *Operation {
+	op := &Operation{
+		Method:      r.method,
+		Path:        r.path,
+		OperationID: r.operationID,
+		Summary:     r.summary,
+		Tag:         r.tag,
+		Status:      r.status,
+		ContentType: r.contentType,
+		Stream:      r.stream,
+	}
+	if op.Status == 0 {
+		op.Status = http.StatusOK
+	}
+	if op.ContentType == "" {
+		op.ContentType = MIMEApplicationJSON
+	}
+
+	for _, segment := range strings.Split(r.path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: SchemaType{"integer"}, Format: "int64"},
+			})
+		}
+	}
+	op.Parameters = append(op.Parameters, r.query...)
+	op.Parameters = append(op.Parameters, r.header...)
+
+	if r.body != nil || r.bodies != nil {
+		op.RequestBody = make(map[string]*Schema)
+		if r.body != nil {
+			op.RequestBody[MIMEApplicationJSON] = g.schemaFor(reflect.TypeOf(r.body), true)
+		}
+		for contentType, schema := range r.bodies {
+			op.RequestBody[contentType] = schema
+		}
+	}
+	if r.response != nil {
+		op.Response = g.schemaFor(reflect.TypeOf(r.response), false)
+	}
+	return op
+}
+
+func queryParam(name string, schema *Schema, description string) *Parameter {
+	p := &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+	if schema.Type.has("array") {
+		explode := true
+		p.Explode = &explode
+	}
+	return p
+}
+
+func headerParam(name string, schema *Schema, description string) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Schema: schema}
+}
@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// エラーメッセージで使うボディの位置
+const (
+	requestBodyPath  = "request body"
+	responseBodyPath = "response body"
+)
+
+// エラーレスポンスの形式
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// ValidatorConfig はドキュメントによる検証の設定
+type ValidatorConfig struct {
+	// レスポンスも検証する（テスト環境向け）。ドキュメントと一致しないレスポンスは500に置き換える
+	ValidateResponses bool
+}
+
+// Validator はリクエストのパス・クエリ・ヘッダーのパラメーターとボディをドキュメントで検証し、
+// 違反している場合はハンドラーを呼ばずに400（Content-Typeが対応していない場合は415）を返す。
+// ドキュメントにないルート（404など）はそのまま通す
+func (d *Document) Validator(config ValidatorConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			op := d.Operation(c.Request().Method, c.Path())
+			if op == nil {
+				return next(c)
+			}
+
+			if status, response := d.checkRequest(c, op); response != nil {
+				return c.JSON(status, response)
+			}
+
+			if !config.ValidateResponses || op.Stream {
+				return next(c)
+			}
+			return d.checkResponse(c, op, next)
+		}
+	}
+}
+
+func (d *Document) checkRequest(c echo.Context, op *Operation) (int, *ErrorResponse) {
+	var errs []string
+	for _, param := range op.Parameters {
+		errs = append(errs, d.checkParameter(c, param)...)
+	}
+	if len(errs) > 0 {
+		return http.StatusBadRequest, &ErrorResponse{Error: "validation failed", Details: errs}
+	}
+
+	if op.RequestBody == nil {
+		return 0, nil
+	}
+
+	req := c.Request()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return http.StatusBadRequest, &ErrorResponse{Error: "failed to read request body"}
+	}
+	// ハンドラーがもう一度読めるように戻す
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return http.StatusBadRequest, &ErrorResponse{Error: "validation failed", Details: []string{requestBodyPath + " is required"}}
+	}
+
+	schema, ok := op.RequestBody[mediaType(req.Header.Get(echo.HeaderContentType))]
+	if !ok {
+		return http.StatusUnsupportedMediaType, &ErrorResponse{
+			Error:   "unsupported content type",
+			Details: []string{"Content-Type must be one of: " + strings.Join(contentTypes(op.RequestBody), ", ")},
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return http.StatusBadRequest, &ErrorResponse{Error: "invalid request format"}
+	}
+	if errs := d.validate(schema, value, requestBodyPath); len(errs) > 0 {
+		return http.StatusBadRequest, &ErrorResponse{Error: "validation failed", Details: errs}
+	}
+	return 0, nil
+}
+
+func (d *Document) checkParameter(c echo.Context, param *Parameter) []string {
+	var values []string
+	switch param.In {
+	case "path":
+		values = []string{c.Param(param.Name)}
+	case "query":
+		values = c.QueryParams()[param.Name]
+	case "header":
+		values = c.Request().Header.Values(param.Name)
+	}
+	if len(values) == 0 || values[0] == "" {
+		if param.Required {
+			return []string{param.Name + " is required"}
+		}
+		return nil
+	}
+
+	schema := param.Schema
+	if schema.Type.has("array") {
+		items := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			parsed, ok := parseParameter(schema.Items, value)
+			if !ok {
+				return []string{fmt.Sprintf("%s must be %s", param.Name, typeName(schema.Items.Type))}
+			}
+			items = append(items, parsed)
+		}
+		return d.validate(schema, items, param.Name)
+	}
+
+	parsed, ok := parseParameter(schema, values[0])
+	if !ok {
+		return []string{fmt.Sprintf("%s must be %s", param.Name, typeName(schema.Type))}
+	}
+	return d.validate(schema, parsed, param.Name)
+}
+
+// ハンドラーのレスポンスを溜めてから検証し、ドキュメントと一致する場合だけそのまま送る
+func (d *Document) checkResponse(c echo.Context, op *Operation, next echo.HandlerFunc) error {
+	res := c.Response()
+	writer := res.Writer
+	recorder := &responseRecorder{ResponseWriter: writer}
+	res.Writer = recorder
+
+	err := next(c)
+	res.Writer = writer
+	if !recorder.written {
+		// ハンドラーがエラーを返した場合はEchoのエラーハンドラーが書き込む
+		return err
+	}
+
+	errs := d.validateResponse(op, recorder.status, res.Header().Get(echo.HeaderContentType), recorder.body.Bytes())
+	if len(errs) > 0 {
+		log.Printf("⚠️  %s %s: response does not match the OpenAPI document: %s", op.Method, op.Path, strings.Join(errs, "; "))
+		header := writer.Header()
+		header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		header.Del(echo.HeaderContentLength)
+		writer.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(writer).Encode(ErrorResponse{
+			Error:   "response does not match the OpenAPI document",
+			Details: errs,
+		})
+		return err
+	}
+
+	writer.WriteHeader(recorder.status)
+	if _, writeErr := writer.Write(recorder.body.Bytes()); writeErr != nil && err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+func (d *Document) validateResponse(op *Operation, status int, contentType string, body []byte) []string {
+	schema, expectedType := op.Response, op.ContentType
+	switch {
+	case status == op.Status:
+	case status >= http.StatusBadRequest:
+		schema, expectedType = errorResponse(), MIMEApplicationJSON
+	default:
+		return []string{fmt.Sprintf("response status %d is not documented", status)}
+	}
+
+	if expectedType != MIMEApplicationJSON {
+		if mediaType(contentType) != expectedType {
+			return []string{"Content-Type must be " + expectedType}
+		}
+		return nil
+	}
+	if schema == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return []string{responseBodyPath + " must be empty"}
+		}
+		return nil
+	}
+	if mediaType(contentType) != MIMEApplicationJSON {
+		return []string{"Content-Type must be " + MIMEApplicationJSON}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{responseBodyPath + " must be valid JSON"}
+	}
+	return d.validate(schema, value, responseBodyPath)
+}
+
+// responseRecorder はステータスコードとボディを書き込まずに記録する
+type responseRecorder struct {
+	http.ResponseWriter
+	status  int
+	body    bytes.Buffer
+	written bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.written = true
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.written {
+		r.WriteHeader(http.StatusOK)
+	}
+	return r.body.Write(b)
+}
+
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return parsed
+}
+
+func contentTypes(bodies map[string]*Schema) []string {
+	types := make([]string, 0, len(bodies))
+	for contentType := range bodies {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,384 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func newTestDocument(t *testing.T) *Document {
+	t.Helper()
+	doc, err := New()
+	require.NoError(t, err)
+	return doc
+}
+
+func testItem() *entity.Item {
+	return &entity.Item{
+		ID:            1,
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		Status:        entity.ItemStatusOwned,
+		Tags:          []string{"ヴィンテージ"},
+		CreatedAt:     time.Date(2023, 1, 15, 9, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2023, 1, 15, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNew(t *testing.T) {
+	doc := newTestDocument(t)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(doc.JSON(), &spec))
+	assert.Equal(t, "3.1.0", spec["openapi"])
+
+	t.Run("正常系: operationIdが重複しない", func(t *testing.T) {
+		ids := make(map[string]string)
+		for key, op := range doc.operations {
+			require.NotEmpty(t, op.OperationID, key)
+			if other, ok := ids[op.OperationID]; ok {
+				t.Errorf("operationId %s is used by both %s and %s", op.OperationID, other, key)
+			}
+			ids[op.OperationID] = key
+		}
+	})
+
+	t.Run("正常系: PATCHは3つのContent-Typeを受け付ける", func(t *testing.T) {
+		op := doc.Operation(http.MethodPatch, "/items/:id")
+		require.NotNil(t, op)
+		assert.Len(t, op.RequestBody, 3)
+		assert.Equal(t, "/items/{id}", op.openAPIPath())
+	})
+
+	t.Run("正常系: 構造体のスキーマはjsonタグに従う", func(t *testing.T) {
+		item := doc.components["Item"]
+		require.NotNil(t, item)
+		assert.Contains(t, item.Required, "name")
+		assert.NotContains(t, item.Required, "book_value")
+		assert.Equal(t, SchemaType{"integer", "null"}, item.Properties["location_id"].Type)
+		assert.Equal(t, "date-time", item.Properties["created_at"].Format)
+		assert.Contains(t, item.Properties["status"].Enum, "owned")
+
+		// 埋め込みの構造体は展開する
+		coverage := doc.components["PolicyCoverage"]
+		require.NotNil(t, coverage)
+		assert.Contains(t, coverage.Properties, "policy_number")
+		assert.Contains(t, coverage.Properties, "insured_value")
+
+		// シークレットはレスポンスに含めない
+		assert.NotContains(t, doc.components["WebhookSubscription"].Properties, "secret")
+	})
+
+	t.Run("正常系: テナントに属するエンドポイントだけがX-Tenantヘッダーを受け付ける", func(t *testing.T) {
+		hasTenantHeader := func(op *Operation) bool {
+			for _, parameter := range op.Parameters {
+				if parameter.In == "header" && parameter.Name == "X-Tenant" {
+					return true
+				}
+			}
+			return false
+		}
+		assert.True(t, hasTenantHeader(doc.Operation(http.MethodGet, "/items")))
+		assert.True(t, hasTenantHeader(doc.Operation(http.MethodGet, "/items/events")))
+		assert.False(t, hasTenantHeader(doc.Operation(http.MethodGet, "/health")))
+		assert.False(t, hasTenantHeader(doc.Operation(http.MethodGet, "/admin/cache")))
+	})
+}
+
+func TestDocument_CheckRoutes(t *testing.T) {
+	doc := newTestDocument(t)
+
+	t.Run("正常系: すべてのルートがドキュメントにある", func(t *testing.T) {
+		routes := make([]*echo.Route, 0, len(doc.operations))
+		for _, op := range doc.operations {
+			routes = append(routes, &echo.Route{Method: op.Method, Path: op.Path})
+		}
+		assert.NoError(t, doc.CheckRoutes(routes))
+	})
+
+	t.Run("異常系: ドキュメントにないルートと登録されていないルート", func(t *testing.T) {
+		e := echo.New()
+		e.GET("/items", func(c echo.Context) error { return nil })
+		e.GET("/unknown", func(c echo.Context) error { return nil })
+
+		err := doc.CheckRoutes(e.Routes())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undocumented route: GET /unknown")
+		assert.Contains(t, err.Error(), "documented route is not registered: POST /items")
+		assert.NotContains(t, err.Error(), "GET /items;")
+	})
+}
+
+func TestDocument_Validator_Request(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		contentType     string
+		body            string
+		header          map[string]string
+		expectedStatus  int
+		expectedError   string
+		expectedDetails []string
+	}{
+		{
+			name:           "正常系: 必須フィールドがそろったアイテムの登録",
+			method:         http.MethodPost,
+			target:         "/items",
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"name":"ロレックス","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:            "異常系: 必須フィールドがない",
+			method:          http.MethodPost,
+			target:          "/items",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name":"ロレックス","purchase_date":"2023-01-01"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"category is required", "brand is required"},
+		},
+		{
+			name:            "異常系: フィールドの型が違う",
+			method:          http.MethodPost,
+			target:          "/items",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name":"ロレックス","category":"時計","brand":"ROLEX","purchase_price":"高い","purchase_date":"2023-01-01","attributes":[]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"attributes must be an object", "purchase_price must be an integer"},
+		},
+		{
+			name:           "異常系: JSONとして読めない",
+			method:         http.MethodPost,
+			target:         "/items",
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request format",
+		},
+		{
+			name:            "異常系: ボディがない",
+			method:          http.MethodPost,
+			target:          "/items",
+			contentType:     echo.MIMEApplicationJSON,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"request body is required"},
+		},
+		{
+			name:            "異常系: 対応していないContent-Type",
+			method:          http.MethodPost,
+			target:          "/items",
+			contentType:     echo.MIMETextPlain,
+			body:            `name=ロレックス`,
+			expectedStatus:  http.StatusUnsupportedMediaType,
+			expectedError:   "unsupported content type",
+			expectedDetails: []string{"Content-Type must be one of: application/json"},
+		},
+		{
+			name:           "正常系: JSON Patchによる更新",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op":"replace","path":"/name","value":"ロレックス デイトナ"}]`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: JSON Patchの操作が不正",
+			method:          http.MethodPatch,
+			target:          "/items/1",
+			contentType:     "application/json-patch+json",
+			body:            `[{"op":"rename"}]`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"request body[0].path is required", "request body[0].op must be one of: add, remove, replace, move, copy, test"},
+		},
+		{
+			name:            "異常系: 配列の要素の型が違う",
+			method:          http.MethodPost,
+			target:          "/webhooks",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"url":"https://example.com/hook","events":["item.created","item.sold"]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"events[1] must be one of: item.created, item.updated, item.deleted"},
+		},
+		{
+			name:            "異常系: パスパラメーターが整数でない",
+			method:          http.MethodGet,
+			target:          "/items/abc",
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"id must be an integer"},
+		},
+		{
+			name:           "正常系: 繰り返し指定したクエリパラメーター",
+			method:         http.MethodGet,
+			target:         "/items?tag=a&tag=b&status=owned&location_id=3",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: クエリパラメーターが不正",
+			method:          http.MethodGet,
+			target:          "/items?status=broken&location_id=0",
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"status must be one of: owned, lent, in_repair, consigned, sold, lost", "location_id must be 1 or greater"},
+		},
+		{
+			name:            "異常系: 真偽値でないクエリパラメーター",
+			method:          http.MethodGet,
+			target:          "/loans?overdue=maybe",
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"overdue must be a boolean"},
+		},
+		{
+			name:            "異常系: 日付でないクエリパラメーター",
+			method:          http.MethodGet,
+			target:          "/reports/book-value?as_of=2024/12/31",
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"as_of must be a date (YYYY-MM-DD)"},
+		},
+		{
+			name:            "異常系: 負のLast-Event-IDヘッダー",
+			method:          http.MethodGet,
+			target:          "/items/events",
+			header:          map[string]string{"Last-Event-ID": "-1"},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"Last-Event-ID must be 0 or greater"},
+		},
+		{
+			name:           "正常系: ドキュメントにないルートはそのまま通す",
+			method:         http.MethodGet,
+			target:         "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	doc := newTestDocument(t)
+	e := echo.New()
+	e.Use(doc.Validator(ValidatorConfig{}))
+	ok := func(status int) echo.HandlerFunc {
+		return func(c echo.Context) error { return c.NoContent(status) }
+	}
+	e.GET("/items", ok(http.StatusOK))
+	e.POST("/items", ok(http.StatusCreated))
+	e.GET("/items/events", ok(http.StatusOK))
+	e.GET("/items/:id", ok(http.StatusOK))
+	e.PATCH("/items/:id", ok(http.StatusOK))
+	e.GET("/loans", ok(http.StatusOK))
+	e.POST("/webhooks", ok(http.StatusCreated))
+	e.GET("/reports/book-value", ok(http.StatusOK))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set(echo.HeaderContentType, tt.contentType)
+			}
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+				assert.ElementsMatch(t, tt.expectedDetails, response.Details)
+			}
+		})
+	}
+}
+
+func TestDocument_Validator_Response(t *testing.T) {
+	tests := []struct {
+		name            string
+		handler         echo.HandlerFunc
+		expectedStatus  int
+		expectedDetails []string
+	}{
+		{
+			name: "正常系: ドキュメントと一致するレスポンス",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusOK, testItem())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: ドキュメントの形式のエラーレスポンス",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusNotFound, ErrorResponse{Error: "item not found"})
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "異常系: 必須フィールドがないレスポンス",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusOK, map[string]interface{}{"id": 1, "name": "ロレックス"})
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedDetails: []string{"category is required"},
+		},
+		{
+			name: "異常系: 値がドキュメントと一致しないレスポンス",
+			handler: func(c echo.Context) error {
+				item := testItem()
+				item.Status = "broken"
+				return c.JSON(http.StatusOK, item)
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedDetails: []string{"status must be one of: owned, lent, in_repair, consigned, sold, lost"},
+		},
+		{
+			name: "異常系: ドキュメントにないステータスコード",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusCreated, testItem())
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedDetails: []string{"response status 201 is not documented"},
+		},
+	}
+
+	doc := newTestDocument(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(doc.Validator(ValidatorConfig{ValidateResponses: true}))
+			e.GET("/items/:id", tt.handler)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedDetails != nil {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, "response does not match the OpenAPI document", response.Error)
+				assert.Subset(t, response.Details, tt.expectedDetails)
+			}
+		})
+	}
+}
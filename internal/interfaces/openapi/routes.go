@@ -0,0 +1,282 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/graph"
+	"Aicon-assignment/internal/usecase"
+)
+
+var apiTags = []specTag{
+	{Name: "items", Description: "アイテム"},
+	{Name: "tags", Description: "タグ"},
+	{Name: "locations", Description: "保管場所と移動履歴"},
+	{Name: "loans", Description: "貸出先と貸出"},
+	{Name: "maintenance", Description: "整備記録と保証"},
+	{Name: "valuations", Description: "評価額"},
+	{Name: "insurance", Description: "保険契約"},
+	{Name: "webhooks", Description: "Webhookの購読と配信"},
+	{Name: "events", Description: "アイテムの変更のイベントストリーム"},
+	{Name: "reports", Description: "レポート"},
+	{Name: "graphql", Description: "GraphQL（アイテム・サマリーの取得と更新）"},
+	{Name: "admin", Description: "運用のための統計"},
+	{Name: "system", Description: "ヘルスチェックとAPIドキュメント"},
+}
+
+// 名前付きの文字列型の取り得る値
+func enums() map[reflect.Type][]string {
+	return map[reflect.Type][]string{
+		reflect.TypeOf(entity.ItemStatus("")):         stringValues(entity.ValidItemStatuses),
+		reflect.TypeOf(entity.LocationType("")):       stringValues(entity.ValidLocationTypes),
+		reflect.TypeOf(entity.EventType("")):          stringValues(entity.ValidEventTypes),
+		reflect.TypeOf(entity.DeliveryStatus("")):     stringValues(entity.ValidDeliveryStatuses),
+		reflect.TypeOf(entity.AttributeType("")):      stringValues([]entity.AttributeType{entity.AttributeTypeString, entity.AttributeTypeNumber, entity.AttributeTypeBoolean, entity.AttributeTypeEnum}),
+		reflect.TypeOf(entity.DepreciationMethod("")): stringValues([]entity.DepreciationMethod{entity.DepreciationStraightLine, entity.DepreciationDecliningBalance, entity.DepreciationAppreciation}),
+	}
+}
+
+func stringValues[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
+
+// リクエストボディの必須フィールド。ドメインのバリデーションで必須としているものに合わせる
+func requiredFields() map[reflect.Type][]string {
+	return map[reflect.Type][]string{
+		reflect.TypeOf(usecase.CreateItemInput{}):              {"name", "category", "brand", "purchase_date"},
+		reflect.TypeOf(usecase.ReplaceItemInput{}):             {"name", "category", "brand", "purchase_date"},
+		reflect.TypeOf(itemController.BatchRequest{}):          {"operations"},
+		reflect.TypeOf(itemController.BatchOperationRequest{}): {"op"},
+		reflect.TypeOf(usecase.MergeItemsInput{}):              {"duplicate_id"},
+		reflect.TypeOf(usecase.SellItemInput{}):                {"sale_price"},
+		reflect.TypeOf(usecase.TagInput{}):                     {"name"},
+		reflect.TypeOf(usecase.AddItemTagsInput{}):             {"tags"},
+		reflect.TypeOf(usecase.CreateLocationInput{}):          {"name", "type"},
+		reflect.TypeOf(usecase.UpdateLocationInput{}):          {"name"},
+		reflect.TypeOf(usecase.BorrowerInput{}):                {"name"},
+		reflect.TypeOf(usecase.CreateLoanInput{}):              {"borrower_id", "due_date"},
+		reflect.TypeOf(usecase.MaintenanceRecordInput{}):       {"serviced_at"},
+		reflect.TypeOf(usecase.ValuationInput{}):               {"valued_at"},
+		reflect.TypeOf(usecase.PolicyInput{}):                  {"insurer", "policy_number", "starts_on", "ends_on"},
+		reflect.TypeOf(usecase.WebhookInput{}):                 {"url", "events"},
+		reflect.TypeOf(graph.GraphQLRequest{}):                 {"query"},
+	}
+}
+
+// JSON Patch (RFC 6902) のドキュメント
+func jsonPatchSchema() *Schema {
+	return arrayOf(&Schema{
+		Type: SchemaType{"object"},
+		Properties: map[string]*Schema{
+			"op":    enumSchema("add", "remove", "replace", "move", "copy", "test"),
+			"path":  describe(stringSchema(), "JSON Pointer（/name、/attributes/color など）"),
+			"from":  stringSchema(),
+			"value": {},
+		},
+		Required: []string{"op", "path"},
+	})
+}
+
+// New はserver.Runで登録するすべてのルートのドキュメントを作成する
+func New() (*Document, error) {
+	g := newGenerator(enums(), requiredFields())
+
+	// PATCH /items/{id} はapplication/jsonと同じフィールドのMerge Patchも受け付ける
+	updateItem := g.schemaFor(reflect.TypeOf(usecase.UpdateItemInput{}), true)
+
+	return build(withTenantHeader(routes(updateItem)), g)
+}
+
+// withTenantHeader はテナントに属するエンドポイント（システムと運用以外）にX-Tenantヘッダーを追加する
+func withTenantHeader(routes []route) []route {
+	tenant := headerParam("X-Tenant", stringSchema(), "テナントのスラッグ（省略時はAPIキーのテナント、サブドメイン、デフォルトのテナントの順）")
+	for i := range routes {
+		if routes[i].tag != "system" && routes[i].tag != "admin" {
+			routes[i].header = append(routes[i].header, tenant)
+		}
+	}
+	return routes
+}
+
+func routes(updateItem *Schema) []route {
+	id := minimum(&Schema{Type: SchemaType{"integer"}, Format: "int64"}, 1)
+	eventID := minimum(&Schema{Type: SchemaType{"integer"}, Format: "int64"}, 0)
+	// 運用のエンドポイントはキーがないと401を返す（キーの確認はmiddleware.Adminで行う）
+	adminKey := headerParam("X-Admin-Key", stringSchema(), "運用のキー（ADMIN_API_KEY）")
+
+	return []route{
+		// システム
+		{method: http.MethodGet, path: "/health", operationID: "health", summary: "ヘルスチェック", tag: "system"},
+		{method: http.MethodGet, path: "/openapi.json", operationID: "getOpenAPIDocument", summary: "OpenAPIドキュメント", tag: "system", response: map[string]interface{}{}},
+		{method: http.MethodGet, path: "/docs", operationID: "getAPIDocs", summary: "Swagger UI", tag: "system", contentType: MIMETextHTML},
+
+		// アイテム
+		{
+			method: http.MethodGet, path: "/items", operationID: "listItems", summary: "アイテム一覧", tag: "items",
+			query: []*Parameter{
+				queryParam("category", stringSchema(), "カテゴリーで絞り込む"),
+				queryParam("tag", arrayOf(stringSchema()), "指定したすべてのタグを持つアイテムに絞り込む（複数指定可）"),
+				queryParam("status", enumSchema(stringValues(entity.ValidItemStatuses)...), "状態で絞り込む"),
+				queryParam("location_id", id, "保管場所（配下の保管場所を含む）で絞り込む"),
+				queryParam("include", enumSchema("tco"), "tcoで総保有コストを含める"),
+			},
+			response: []*entity.Item{},
+		},
+		{method: http.MethodPost, path: "/items", operationID: "createItem", summary: "アイテムの登録", tag: "items", body: usecase.CreateItemInput{}, status: http.StatusCreated, response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/batch", operationID: "batchItems", summary: "アイテムの一括操作", tag: "items", body: itemController.BatchRequest{}, response: itemController.BatchResponse{}},
+		{
+			method: http.MethodGet, path: "/items/events", operationID: "streamItemEvents", summary: "アイテムの変更のイベントストリーム（SSE）", tag: "events",
+			query:       []*Parameter{queryParam("last_event_id", eventID, "Last-Event-IDヘッダーの代わりに指定する")},
+			header:      []*Parameter{headerParam("Last-Event-ID", eventID, "このイベント以降を再送してから配信を続ける")},
+			contentType: MIMETextEventStream, stream: true,
+		},
+		{
+			method: http.MethodGet, path: "/items/:id", operationID: "getItem", summary: "アイテムの取得", tag: "items",
+			query:    []*Parameter{queryParam("include", enumSchema("tco"), "tcoで総保有コストを含める")},
+			response: entity.Item{},
+		},
+		{method: http.MethodDelete, path: "/items/:id", operationID: "deleteItem", summary: "アイテムの削除", tag: "items", status: http.StatusNoContent},
+		{method: http.MethodGet, path: "/items/summary", operationID: "getItemSummary", summary: "カテゴリー・保管場所・状態ごとの集計", tag: "items", response: usecase.CategorySummary{}},
+		{
+			method: http.MethodPatch, path: "/items/:id", operationID: "updateItem", summary: "アイテムの部分更新", tag: "items",
+			body: usecase.UpdateItemInput{},
+			bodies: map[string]*Schema{
+				itemController.MIMEApplicationMergePatch: updateItem,
+				itemController.MIMEApplicationJSONPatch:  jsonPatchSchema(),
+			},
+			response: entity.Item{},
+		},
+		{method: http.MethodPut, path: "/items/:id", operationID: "replaceItem", summary: "アイテムの置き換え", tag: "items", body: usecase.ReplaceItemInput{}, response: entity.Item{}},
+		{method: http.MethodGet, path: "/items/attributes", operationID: "getAttributeSchemas", summary: "カテゴリーごとのカスタム属性", tag: "items", response: map[string][]entity.AttributeDefinition{}},
+		{
+			method: http.MethodGet, path: "/items/lookup", operationID: "lookupItems", summary: "シリアル番号による検索", tag: "items",
+			query: []*Parameter{
+				queryParam("serial", stringSchema(), "シリアル番号"),
+				queryParam("brand", stringSchema(), "ブランドで絞り込む"),
+			},
+			response: []*entity.Item{},
+		},
+		{
+			method: http.MethodGet, path: "/items/duplicates", operationID: "getDuplicates", summary: "重複の候補", tag: "items",
+			query: []*Parameter{
+				queryParam("min_score", numberSchema(), "候補とするスコアの下限"),
+				queryParam("limit", integerSchema(), "返す候補の最大数"),
+				queryParam("category", stringSchema(), "カテゴリーで絞り込む"),
+			},
+			response: []*usecase.DuplicateCandidate{},
+		},
+		{method: http.MethodPost, path: "/items/:id/merge", operationID: "mergeItem", summary: "重複したアイテムの統合", tag: "items", body: usecase.MergeItemsInput{}, response: entity.Item{}},
+		{method: http.MethodGet, path: "/items/:id/merges", operationID: "getMergeHistory", summary: "統合の履歴", tag: "items", response: []*entity.ItemMerge{}},
+		{method: http.MethodPost, path: "/items/:id/sell", operationID: "sellItem", summary: "売却", tag: "items", body: usecase.SellItemInput{}, response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/lend", operationID: "lendItemStatus", summary: "貸出中にする", tag: "items", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/repair", operationID: "repairItem", summary: "修理中にする", tag: "items", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/consign", operationID: "consignItem", summary: "委託中にする", tag: "items", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/return", operationID: "returnItem", summary: "所有中に戻す", tag: "items", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/lose", operationID: "loseItem", summary: "紛失にする", tag: "items", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/tags", operationID: "addItemTags", summary: "タグの付与", tag: "tags", body: usecase.AddItemTagsInput{}, response: entity.Item{}},
+		{method: http.MethodDelete, path: "/items/:id/tags/:tagId", operationID: "removeItemTag", summary: "タグの解除", tag: "tags", response: entity.Item{}},
+		{method: http.MethodPost, path: "/items/:id/move", operationID: "moveItem", summary: "保管場所の移動", tag: "locations", body: usecase.MoveItemInput{}, response: entity.Item{}},
+		{method: http.MethodGet, path: "/items/:id/movements", operationID: "getItemMovements", summary: "移動履歴", tag: "locations", response: []*entity.ItemMovement{}},
+		{method: http.MethodPost, path: "/items/:id/loans", operationID: "lendItem", summary: "貸出", tag: "loans", body: usecase.CreateLoanInput{}, status: http.StatusCreated, response: entity.Loan{}},
+		{method: http.MethodGet, path: "/items/:id/loans", operationID: "getItemLoans", summary: "アイテムの貸出履歴", tag: "loans", response: []*entity.Loan{}},
+		{method: http.MethodGet, path: "/items/:id/maintenance", operationID: "getItemMaintenance", summary: "整備記録と保証", tag: "maintenance", response: usecase.ItemMaintenance{}},
+		{method: http.MethodPost, path: "/items/:id/maintenance", operationID: "addMaintenanceRecord", summary: "整備記録の追加", tag: "maintenance", body: usecase.MaintenanceRecordInput{}, status: http.StatusCreated, response: entity.MaintenanceRecord{}},
+		{method: http.MethodGet, path: "/items/:id/valuations", operationID: "getItemValuations", summary: "評価額の履歴", tag: "valuations", response: []*entity.ItemValuation{}},
+		{method: http.MethodPost, path: "/items/:id/valuations", operationID: "addValuation", summary: "評価額の追加", tag: "valuations", body: usecase.ValuationInput{}, status: http.StatusCreated, response: entity.ItemValuation{}},
+		{method: http.MethodPut, path: "/items/:id/insurance", operationID: "assignInsurance", summary: "保険契約への割り当て", tag: "insurance", body: usecase.AssignPolicyInput{}, response: entity.Item{}},
+
+		// タグ
+		{method: http.MethodGet, path: "/tags", operationID: "listTags", summary: "タグ一覧", tag: "tags", response: []*entity.Tag{}},
+		{method: http.MethodPost, path: "/tags", operationID: "createTag", summary: "タグの作成", tag: "tags", body: usecase.TagInput{}, status: http.StatusCreated, response: entity.Tag{}},
+		{method: http.MethodGet, path: "/tags/:id", operationID: "getTag", summary: "タグの取得", tag: "tags", response: entity.Tag{}},
+		{method: http.MethodPut, path: "/tags/:id", operationID: "updateTag", summary: "タグの名前の変更", tag: "tags", body: usecase.TagInput{}, response: entity.Tag{}},
+		{method: http.MethodDelete, path: "/tags/:id", operationID: "deleteTag", summary: "タグの削除", tag: "tags", status: http.StatusNoContent},
+
+		// 保管場所
+		{method: http.MethodGet, path: "/locations", operationID: "listLocations", summary: "保管場所一覧", tag: "locations", response: []*entity.Location{}},
+		{method: http.MethodPost, path: "/locations", operationID: "createLocation", summary: "保管場所の作成", tag: "locations", body: usecase.CreateLocationInput{}, status: http.StatusCreated, response: entity.Location{}},
+		{method: http.MethodGet, path: "/locations/:id", operationID: "getLocation", summary: "保管場所の取得", tag: "locations", response: entity.Location{}},
+		{method: http.MethodPut, path: "/locations/:id", operationID: "updateLocation", summary: "保管場所の更新", tag: "locations", body: usecase.UpdateLocationInput{}, response: entity.Location{}},
+		{method: http.MethodDelete, path: "/locations/:id", operationID: "deleteLocation", summary: "保管場所の削除", tag: "locations", status: http.StatusNoContent},
+
+		// 貸出先・貸出
+		{method: http.MethodGet, path: "/borrowers", operationID: "listBorrowers", summary: "貸出先一覧", tag: "loans", response: []*entity.Borrower{}},
+		{method: http.MethodPost, path: "/borrowers", operationID: "createBorrower", summary: "貸出先の登録", tag: "loans", body: usecase.BorrowerInput{}, status: http.StatusCreated, response: entity.Borrower{}},
+		{method: http.MethodGet, path: "/borrowers/:id", operationID: "getBorrower", summary: "貸出先の取得", tag: "loans", response: entity.Borrower{}},
+		{
+			method: http.MethodGet, path: "/loans", operationID: "listLoans", summary: "貸出一覧", tag: "loans",
+			query: []*Parameter{
+				queryParam("overdue", booleanSchema(), "trueで返却期限を過ぎた貸出に絞り込む"),
+				queryParam("active", booleanSchema(), "trueで返却されていない貸出に絞り込む"),
+			},
+			response: []*entity.Loan{},
+		},
+		{method: http.MethodPost, path: "/loans/:id/return", operationID: "returnLoan", summary: "返却", tag: "loans", response: entity.Loan{}},
+
+		// 整備
+		{
+			method: http.MethodGet, path: "/maintenance/upcoming", operationID: "getUpcomingMaintenance", summary: "整備予定日が近いアイテム", tag: "maintenance",
+			query:    []*Parameter{queryParam("days", integerSchema(), "何日以内に整備予定日を迎えるか（既定30日）")},
+			response: []*usecase.UpcomingMaintenance{},
+		},
+		{method: http.MethodDelete, path: "/maintenance/:id", operationID: "deleteMaintenanceRecord", summary: "整備記録の削除", tag: "maintenance", status: http.StatusNoContent},
+
+		// 保険
+		{method: http.MethodGet, path: "/insurance/policies", operationID: "listPolicies", summary: "保険契約一覧", tag: "insurance", response: []*usecase.PolicyCoverage{}},
+		{method: http.MethodPost, path: "/insurance/policies", operationID: "createPolicy", summary: "保険契約の登録", tag: "insurance", body: usecase.PolicyInput{}, status: http.StatusCreated, response: entity.InsurancePolicy{}},
+		{method: http.MethodGet, path: "/insurance/policies/:id", operationID: "getPolicy", summary: "保険契約と補償の状況", tag: "insurance", response: usecase.PolicyCoverage{}},
+		{method: http.MethodPut, path: "/insurance/policies/:id", operationID: "updatePolicy", summary: "保険契約の更新", tag: "insurance", body: usecase.PolicyInput{}, response: entity.InsurancePolicy{}},
+		{method: http.MethodDelete, path: "/insurance/policies/:id", operationID: "deletePolicy", summary: "保険契約の削除", tag: "insurance", status: http.StatusNoContent},
+
+		// Webhook
+		{method: http.MethodGet, path: "/webhooks", operationID: "listWebhooks", summary: "Webhookの購読一覧", tag: "webhooks", response: []*entity.WebhookSubscription{}},
+		{method: http.MethodPost, path: "/webhooks", operationID: "createWebhook", summary: "Webhookの購読の登録", tag: "webhooks", body: usecase.WebhookInput{}, status: http.StatusCreated, response: entity.WebhookSubscription{}},
+		{method: http.MethodGet, path: "/webhooks/dead-letters", operationID: "getDeadLetters", summary: "デッドレター一覧", tag: "webhooks", response: []*entity.WebhookDelivery{}},
+		{method: http.MethodGet, path: "/webhooks/deliveries/:deliveryId", operationID: "getDelivery", summary: "配信と試行のログ", tag: "webhooks", response: usecase.DeliveryLog{}},
+		{method: http.MethodPost, path: "/webhooks/deliveries/:deliveryId/retry", operationID: "retryDelivery", summary: "デッドレターの再送", tag: "webhooks", response: entity.WebhookDelivery{}},
+		{method: http.MethodGet, path: "/webhooks/:id", operationID: "getWebhook", summary: "Webhookの購読の取得", tag: "webhooks", response: entity.WebhookSubscription{}},
+		{method: http.MethodPut, path: "/webhooks/:id", operationID: "updateWebhook", summary: "Webhookの購読の更新", tag: "webhooks", body: usecase.WebhookInput{}, response: entity.WebhookSubscription{}},
+		{method: http.MethodDelete, path: "/webhooks/:id", operationID: "deleteWebhook", summary: "Webhookの購読の削除", tag: "webhooks", status: http.StatusNoContent},
+		{
+			method: http.MethodGet, path: "/webhooks/:id/deliveries", operationID: "getDeliveries", summary: "購読の配信一覧", tag: "webhooks",
+			query:    []*Parameter{queryParam("status", enumSchema(stringValues(entity.ValidDeliveryStatuses)...), "状態で絞り込む")},
+			response: []*entity.WebhookDelivery{},
+		},
+
+		// レポート
+		{
+			method: http.MethodGet, path: "/reports/insurance", operationID: "getCoverageReport", summary: "保険の補償状況", tag: "reports",
+			query: []*Parameter{
+				queryParam("threshold", integerSchema(), "未加入として報告する評価額の下限"),
+				queryParam("days", integerSchema(), "何日以内に終了する保険契約を報告するか"),
+			},
+			response: usecase.CoverageReport{},
+		},
+		{
+			method: http.MethodGet, path: "/reports/book-value", operationID: "getBookValueReport", summary: "帳簿価額", tag: "reports",
+			query:    []*Parameter{queryParam("as_of", &Schema{Type: SchemaType{"string"}, Format: "date"}, "集計する日付（YYYY-MM-DD、既定は今日）")},
+			response: usecase.BookValueReport{},
+		},
+
+		// GraphQL（クエリのエラーもステータスコード200のerrorsで返す）
+		{method: http.MethodPost, path: "/graphql", operationID: "graphql", summary: "GraphQLのクエリ・ミューテーションの実行", tag: "graphql", body: graph.GraphQLRequest{}, response: graph.GraphQLResponse{}},
+
+		// 運用
+		{method: http.MethodGet, path: "/admin/cache", operationID: "getCacheStats", summary: "アイテムのキャッシュのヒット・ミスの回数", tag: "admin", header: []*Parameter{adminKey}, response: database.CacheStats{}},
+		{
+			method: http.MethodGet, path: "/admin/slow-queries", operationID: "getSlowQueries", summary: "実行時間が長いクエリ", tag: "admin",
+			query: []*Parameter{
+				queryParam("limit", integerSchema(), "返すフィンガープリントの最大数（デフォルト: 10）"),
+			},
+			header:   []*Parameter{adminKey},
+			response: []database.QueryStats{},
+		},
+	}
+}
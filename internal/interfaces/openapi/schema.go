@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema はJSON Schema（OpenAPI 3.1で使う範囲）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// SchemaType はJSON Schemaのtype。nullを許可する場合は ["string", "null"] のように複数になる
+type SchemaType []string
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t SchemaType) has(name string) bool {
+	for _, v := range t {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func typed(name string) *Schema {
+	return &Schema{Type: SchemaType{name}}
+}
+
+func stringSchema() *Schema  { return typed("string") }
+func integerSchema() *Schema { return typed("integer") }
+func numberSchema() *Schema  { return typed("number") }
+func booleanSchema() *Schema { return typed("boolean") }
+func objectSchema() *Schema  { return typed("object") }
+
+func arrayOf(items *Schema) *Schema {
+	return &Schema{Type: SchemaType{"array"}, Items: items}
+}
+
+func enumSchema(values ...string) *Schema {
+	s := stringSchema()
+	for _, v := range values {
+		s.Enum = append(s.Enum, v)
+	}
+	return s
+}
+
+func minimum(s *Schema, min float64) *Schema {
+	s.Minimum = &min
+	return s
+}
+
+func describe(s *Schema, description string) *Schema {
+	s.Description = description
+	return s
+}
+
+// nullを許可する。参照（$ref）はanyOfでnullと組み合わせる
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AnyOf: []*Schema{s, typed("null")}}
+	}
+	if len(s.Type) == 0 || s.Type.has("null") {
+		return s
+	}
+	copied := *s
+	copied.Type = append(append(SchemaType{}, s.Type...), "null")
+	if copied.Enum != nil {
+		copied.Enum = append(append([]interface{}{}, s.Enum...), nil)
+	}
+	return &copied
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// generator はGoの型からjsonタグに従ってスキーマを生成し、名前付きの構造体をcomponentsに登録する
+type generator struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+	// 名前付きの文字列型の取り得る値
+	enums map[reflect.Type][]string
+	// リクエストの型の必須フィールド。リクエストの型はjsonタグから必須を判定しない
+	required map[reflect.Type][]string
+}
+
+func newGenerator(enums map[reflect.Type][]string, required map[reflect.Type][]string) *generator {
+	return &generator{
+		components: make(map[string]*Schema),
+		types:      make(map[string]reflect.Type),
+		enums:      enums,
+		required:   required,
+	}
+}
+
+// schemaFor はtの値をencoding/jsonで変換したJSONのスキーマを返す。
+// requestの場合、フィールドの必須はrequiredに登録したものだけになる
+func (g *generator) schemaFor(t reflect.Type, request bool) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: SchemaType{"string"}, Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schemaFor(t.Elem(), request))
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.String:
+		if values, ok := g.enums[t]; ok {
+			return enumSchema(values...)
+		}
+		return stringSchema()
+	case reflect.Bool:
+		return booleanSchema()
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: SchemaType{"integer"}, Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return integerSchema()
+	case reflect.Float32, reflect.Float64:
+		return numberSchema()
+	case reflect.Slice, reflect.Array:
+		// nilのスライスはnullになる。要素のポインターはnilにならないものとして扱う
+		return nullable(arrayOf(g.schemaFor(elemType(t), request)))
+	case reflect.Map:
+		s := objectSchema()
+		if elem := g.schemaFor(elemType(t), request); !isAny(elem) {
+			s.AdditionalProperties = elem
+		}
+		return nullable(s)
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t, request)
+		}
+		return g.component(t, request)
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+// 名前付きの構造体をcomponentsに登録して参照を返す
+func (g *generator) component(t reflect.Type, request bool) *Schema {
+	name := t.Name()
+	if registered, ok := g.types[name]; ok {
+		if registered != t {
+			panic(fmt.Sprintf("openapi: schema name %s is used by both %s and %s", name, registered, t))
+		}
+	} else {
+		g.types[name] = t
+		// 再帰的な型に備えて先に登録する
+		g.components[name] = &Schema{}
+		*g.components[name] = *g.structSchema(t, request)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *generator) structSchema(t reflect.Type, request bool) *Schema {
+	s := objectSchema()
+	s.Properties = make(map[string]*Schema)
+	g.addFields(s, t, request)
+	if request {
+		s.Required = g.required[t]
+	}
+	return s
+}
+
+// フィールドをプロパティに追加する。埋め込みの構造体はencoding/jsonと同じく展開する
+func (g *generator) addFields(s *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(s, embedded, request)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = g.schemaFor(field.Type, request)
+		if !request && !strings.Contains(options, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t.Elem().Kind() == reflect.Pointer {
+		return t.Elem().Elem()
+	}
+	return t.Elem()
+}
+
+func isAny(s *Schema) bool {
+	return s.Ref == "" && len(s.Type) == 0 && s.AnyOf == nil
+}
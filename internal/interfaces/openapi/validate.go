@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validate はJSONをデコードした値（encoding/jsonでinterface{}にデコードしたもの）をスキーマで検証し、
+// 違反した内容を返す。pathはエラーメッセージに使う値の位置
+func (d *Document) validate(schema *Schema, value interface{}, path string) []string {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		return d.validate(d.components[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], value, path)
+	}
+	if len(schema.AnyOf) > 0 {
+		var first []string
+		for i, candidate := range schema.AnyOf {
+			errs := d.validate(candidate, value, path)
+			if len(errs) == 0 {
+				return nil
+			}
+			if i == 0 {
+				first = errs
+			}
+		}
+		return first
+	}
+
+	if len(schema.Type) > 0 {
+		if value == nil {
+			if schema.Type.has("null") {
+				return nil
+			}
+			return []string{fmt.Sprintf("%s must not be null", path)}
+		}
+		if !matchesType(schema.Type, value) {
+			return []string{fmt.Sprintf("%s must be %s", path, typeName(schema.Type))}
+		}
+	}
+
+	var errs []string
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		errs = append(errs, fmt.Sprintf("%s must be one of: %s", path, enumValues(schema.Enum)))
+	}
+	if number, ok := value.(float64); ok && schema.Minimum != nil && number < *schema.Minimum {
+		errs = append(errs, fmt.Sprintf("%s must be %s or greater", path, formatNumber(*schema.Minimum)))
+	}
+	if text, ok := value.(string); ok && !matchesFormat(schema.Format, text) {
+		errs = append(errs, fmt.Sprintf("%s must be a %s", path, formatName(schema.Format)))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s is required", join(path, name)))
+			}
+		}
+		// エラーの順序を安定させる
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := schema.Properties[name]; ok {
+				errs = append(errs, d.validate(property, v[name], join(path, name))...)
+			} else if schema.AdditionalProperties != nil {
+				errs = append(errs, d.validate(schema.AdditionalProperties, v[name], join(path, name))...)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			errs = append(errs, d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return errs
+}
+
+// ルートの値はエラーメッセージでフィールド名だけになるようにする
+func join(path, name string) string {
+	if path == "" || path == requestBodyPath || path == responseBodyPath {
+		return name
+	}
+	return path + "." + name
+}
+
+func matchesType(types SchemaType, value interface{}) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case string:
+			if t == "string" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeName(types SchemaType) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		switch t {
+		case "null":
+			continue
+		case "integer", "array", "object":
+			names = append(names, "an "+t)
+		default:
+			names = append(names, "a "+t)
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+func inEnum(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func enumValues(values []interface{}) string {
+	names := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil {
+			names = append(names, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	}
+	return true
+}
+
+func formatName(format string) string {
+	switch format {
+	case "date":
+		return "date (YYYY-MM-DD)"
+	case "date-time":
+		return "date-time (RFC 3339)"
+	}
+	return format
+}
+
+// parseParameter はパス・クエリ・ヘッダーの文字列をスキーマの型に変換する
+func parseParameter(schema *Schema, value string) (interface{}, bool) {
+	switch {
+	case schema.Type.has("integer"):
+		n, err := strconv.ParseInt(value, 10, 64)
+		return float64(n), err == nil
+	case schema.Type.has("number"):
+		n, err := strconv.ParseFloat(value, 64)
+		return n, err == nil
+	case schema.Type.has("boolean"):
+		b, err := strconv.ParseBool(value)
+		return b, err == nil
+	}
+	return value, true
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemsv1"
+	"Aicon-assignment/internal/usecase"
+)
+
+// エンティティをprotobufのメッセージに変換する
+func toPBItem(item *entity.Item) (*itemsv1.Item, error) {
+	var attributes *structpb.Struct
+	if len(item.Attributes) > 0 {
+		var err error
+		if attributes, err = structpb.NewStruct(item.Attributes); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode attributes of item %d", item.ID)
+		}
+	}
+
+	return &itemsv1.Item{
+		Id:                   item.ID,
+		Name:                 item.Name,
+		Category:             item.Category,
+		Brand:                item.Brand,
+		PurchasePrice:        int64(item.PurchasePrice),
+		PurchaseDate:         item.PurchaseDate,
+		SerialNumber:         item.SerialNumber,
+		ModelNumber:          item.ModelNumber,
+		Condition:            item.Condition,
+		Authenticity:         item.Authenticity,
+		LocationId:           item.LocationID,
+		Status:               string(item.Status),
+		SalePrice:            int64Ptr(item.SalePrice),
+		SaleDate:             item.SaleDate,
+		WarrantyExpiresAt:    item.WarrantyExpiresAt,
+		InsurancePolicyId:    item.InsurancePolicyID,
+		Tags:                 item.Tags,
+		Attributes:           attributes,
+		CreatedAt:            timestamppb.New(item.CreatedAt),
+		UpdatedAt:            timestamppb.New(item.UpdatedAt),
+		TotalCostOfOwnership: item.TotalCostOfOwnership,
+		BookValue:            item.BookValue,
+	}, nil
+}
+
+// 属性を変換する。未指定の場合はnil（更新では属性を変更しない）。nullの値はnilになり、更新では属性を削除する
+func fromPBAttributes(attributes *structpb.Struct) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+	return attributes.AsMap()
+}
+
+func toPBSummary(summary *usecase.CategorySummary) *itemsv1.GetSummaryResponse {
+	res := &itemsv1.GetSummaryResponse{
+		Categories: toInt64Map(summary.Categories),
+		Total:      int64(summary.Total),
+		Statuses:   toInt64Map(summary.Statuses),
+	}
+
+	for _, location := range summary.Locations {
+		res.Locations = append(res.Locations, &itemsv1.LocationSummary{
+			LocationId: location.LocationID,
+			Name:       location.Name,
+			Type:       string(location.Type),
+			ParentId:   location.ParentID,
+			ItemCount:  int64(location.ItemCount),
+			TotalValue: location.TotalValue,
+		})
+	}
+
+	if summary.Sales != nil {
+		res.Sales = &itemsv1.SalesSummary{
+			Total:      toPBSalesTotals(&summary.Sales.SalesTotals),
+			Categories: make(map[string]*itemsv1.SalesTotals, len(summary.Sales.Categories)),
+		}
+		for category, totals := range summary.Sales.Categories {
+			res.Sales.Categories[category] = toPBSalesTotals(totals)
+		}
+	}
+
+	return res
+}
+
+func toPBSalesTotals(totals *usecase.SalesTotals) *itemsv1.SalesTotals {
+	return &itemsv1.SalesTotals{
+		SoldCount:     int64(totals.SoldCount),
+		PurchaseTotal: totals.PurchaseTotal,
+		SaleTotal:     totals.SaleTotal,
+		RealizedGain:  totals.RealizedGain,
+	}
+}
+
+func toInt64Map(counts map[string]int) map[string]int64 {
+	result := make(map[string]int64, len(counts))
+	for key, count := range counts {
+		result[key] = int64(count)
+	}
+	return result
+}
+
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	value := int64(*v)
+	return &value
+}
@@ -0,0 +1,180 @@
+// Package rpc はアイテムAPIのgRPCサービス（proto/items/v1/items.proto）を実装する
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/rpc/itemsv1"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemService はRESTのハンドラーと同じユースケースをgRPCで提供する
+type ItemService struct {
+	itemsv1.UnimplementedItemServiceServer
+	itemUsecase usecase.ItemUsecase
+}
+
+func NewItemService(itemUsecase usecase.ItemUsecase) *ItemService {
+	return &ItemService{
+		itemUsecase: itemUsecase,
+	}
+}
+
+func (s *ItemService) GetItem(ctx context.Context, req *itemsv1.GetItemRequest) (*itemsv1.GetItemResponse, error) {
+	item, err := s.itemUsecase.GetItemByID(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, "failed to retrieve item")
+	}
+
+	if req.GetIncludeTotalCostOfOwnership() {
+		if err := s.itemUsecase.IncludeTotalCostOfOwnership(ctx, []*entity.Item{item}); err != nil {
+			return nil, statusError(err, "failed to retrieve item")
+		}
+	}
+	s.itemUsecase.IncludeBookValue([]*entity.Item{item})
+
+	pbItem, err := toPBItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return &itemsv1.GetItemResponse{Item: pbItem}, nil
+}
+
+// ListItems は条件に一致するアイテムを1件ずつ送信する
+func (s *ItemService) ListItems(req *itemsv1.ListItemsRequest, stream grpc.ServerStreamingServer[itemsv1.ListItemsResponse]) error {
+	ctx := stream.Context()
+	if req.GetLocationId() < 0 {
+		return status.Error(codes.InvalidArgument, "location_id must be a positive integer")
+	}
+
+	items, err := s.itemUsecase.ListItems(ctx, usecase.ItemFilter{
+		Category:   req.GetCategory(),
+		Tags:       req.GetTags(),
+		Attributes: req.GetAttributes(),
+		LocationID: req.GetLocationId(),
+		Status:     entity.ItemStatus(req.GetStatus()),
+	})
+	if err != nil {
+		return statusError(err, "failed to retrieve items")
+	}
+
+	if req.GetIncludeTotalCostOfOwnership() {
+		if err := s.itemUsecase.IncludeTotalCostOfOwnership(ctx, items); err != nil {
+			return statusError(err, "failed to retrieve items")
+		}
+	}
+	s.itemUsecase.IncludeBookValue(items)
+
+	for _, item := range items {
+		pbItem, err := toPBItem(item)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&itemsv1.ListItemsResponse{Item: pbItem}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ItemService) CreateItem(ctx context.Context, req *itemsv1.CreateItemRequest) (*itemsv1.CreateItemResponse, error) {
+	item, err := s.itemUsecase.CreateItem(ctx, usecase.CreateItemInput{
+		Name:              req.GetName(),
+		Category:          req.GetCategory(),
+		Brand:             req.GetBrand(),
+		PurchasePrice:     int(req.GetPurchasePrice()),
+		PurchaseDate:      req.GetPurchaseDate(),
+		SerialNumber:      req.GetSerialNumber(),
+		ModelNumber:       req.GetModelNumber(),
+		Condition:         req.GetCondition(),
+		Authenticity:      req.GetAuthenticity(),
+		WarrantyExpiresAt: req.GetWarrantyExpiresAt(),
+		Attributes:        fromPBAttributes(req.GetAttributes()),
+	})
+	if err != nil {
+		return nil, statusError(err, "failed to create item")
+	}
+
+	pbItem, err := toPBItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return &itemsv1.CreateItemResponse{Item: pbItem}, nil
+}
+
+// UpdateItem は設定されたフィールドだけを更新する（PATCH /items/{id} と同じ）
+func (s *ItemService) UpdateItem(ctx context.Context, req *itemsv1.UpdateItemRequest) (*itemsv1.UpdateItemResponse, error) {
+	input := usecase.UpdateItemInput{
+		Name:              req.Name,
+		Category:          req.Category,
+		Brand:             req.Brand,
+		PurchaseDate:      req.PurchaseDate,
+		SerialNumber:      req.SerialNumber,
+		ModelNumber:       req.ModelNumber,
+		Condition:         req.Condition,
+		Authenticity:      req.Authenticity,
+		WarrantyExpiresAt: req.WarrantyExpiresAt,
+		Attributes:        fromPBAttributes(req.GetAttributes()),
+	}
+	if req.PurchasePrice != nil {
+		price := int(req.GetPurchasePrice())
+		input.PurchasePrice = &price
+	}
+
+	var item *entity.Item
+	var err error
+	if input.IsEmpty() {
+		// 変更がない場合は現在のアイテムをそのまま返す
+		item, err = s.itemUsecase.GetItemByID(ctx, req.GetId())
+	} else {
+		item, err = s.itemUsecase.UpdateItem(ctx, req.GetId(), input)
+	}
+	if err != nil {
+		return nil, statusError(err, "failed to update item")
+	}
+
+	pbItem, err := toPBItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return &itemsv1.UpdateItemResponse{Item: pbItem}, nil
+}
+
+func (s *ItemService) DeleteItem(ctx context.Context, req *itemsv1.DeleteItemRequest) (*itemsv1.DeleteItemResponse, error) {
+	if err := s.itemUsecase.DeleteItem(ctx, req.GetId()); err != nil {
+		return nil, statusError(err, "failed to delete item")
+	}
+	return &itemsv1.DeleteItemResponse{}, nil
+}
+
+func (s *ItemService) GetSummary(ctx context.Context, req *itemsv1.GetSummaryRequest) (*itemsv1.GetSummaryResponse, error) {
+	summary, err := s.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		return nil, statusError(err, "failed to retrieve summary")
+	}
+	return toPBSummary(summary), nil
+}
+
+// ドメインエラーをgRPCのステータスに変換する。想定外のエラーは内容を返さずmessageでINTERNALにする
+func statusError(err error, message string) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return status.Error(codes.NotFound, "item not found")
+	case domainErrors.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case domainErrors.IsDuplicateError(err):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case domainErrors.IsConflictError(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, message)
+}
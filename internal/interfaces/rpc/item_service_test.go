@@ -0,0 +1,438 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/rpc/itemsv1"
+	"Aicon-assignment/internal/usecase"
+)
+
+// MockItemUsecase はテストで使うメソッドだけを実装したモック
+type MockItemUsecase struct {
+	usecase.ItemUsecase
+	mock.Mock
+}
+
+func (m *MockItemUsecase) ListItems(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemUsecase) IncludeTotalCostOfOwnership(ctx context.Context, items []*entity.Item) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockItemUsecase) IncludeBookValue(items []*entity.Item) {
+	m.Called(items)
+}
+
+func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
+}
+
+// newTestClient はメモリ上の接続でItemServiceを提供するサーバーのクライアントを作成する
+func newTestClient(t *testing.T, itemUsecase usecase.ItemUsecase, opts ...grpc.ServerOption) itemsv1.ItemServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(opts...)
+	itemsv1.RegisterItemServiceServer(server, NewItemService(itemUsecase))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return itemsv1.NewItemServiceClient(conn)
+}
+
+func newTestItem(id int64, name string) *entity.Item {
+	item, _ := entity.NewItem(name, "時計", "ROLEX", 1500000, "2023-01-15")
+	item.ID = id
+	item.Attributes = map[string]interface{}{"movement": "自動巻き"}
+	return item
+}
+
+func TestItemService_GetItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(*MockItemUsecase)
+		expectedCode codes.Code
+	}{
+		{
+			name: "正常系: アイテムを取得できる",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(1)).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+				m.On("IncludeBookValue", mock.Anything).Return()
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "異常系: 存在しない場合はNOT_FOUND",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "異常系: データベースエラーは内容を返さずINTERNAL",
+			setupMock: func(m *MockItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(1)).Return(nil, fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			client := newTestClient(t, mockUsecase)
+
+			res, err := client.GetItem(context.Background(), &itemsv1.GetItemRequest{Id: 1})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				require.NoError(t, err)
+				assert.Equal(t, "ロレックス デイトナ", res.GetItem().GetName())
+				assert.Equal(t, "自動巻き", res.GetItem().GetAttributes().AsMap()["movement"])
+				assert.Equal(t, "owned", res.GetItem().GetStatus())
+			} else {
+				assert.NotContains(t, status.Convert(err).Message(), "connection refused")
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_ListItems(t *testing.T) {
+	t.Run("正常系: 条件に一致するアイテムを1件ずつ受信する", func(t *testing.T) {
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("ListItems", mock.Anything, usecase.ItemFilter{
+			Category:   "時計",
+			Tags:       []string{"限定"},
+			Attributes: map[string]string{"movement": "自動巻き"},
+			Status:     entity.ItemStatusOwned,
+		}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ"), newTestItem(2, "オメガ スピードマスター")}, nil)
+		mockUsecase.On("IncludeTotalCostOfOwnership", mock.Anything, mock.Anything).Return(nil)
+		mockUsecase.On("IncludeBookValue", mock.Anything).Return()
+		client := newTestClient(t, mockUsecase)
+
+		stream, err := client.ListItems(context.Background(), &itemsv1.ListItemsRequest{
+			Category:                    "時計",
+			Tags:                        []string{"限定"},
+			Status:                      "owned",
+			Attributes:                  map[string]string{"movement": "自動巻き"},
+			IncludeTotalCostOfOwnership: true,
+		})
+		require.NoError(t, err)
+
+		var names []string
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, res.GetItem().GetName())
+		}
+
+		assert.Equal(t, []string{"ロレックス デイトナ", "オメガ スピードマスター"}, names)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正な状態はINVALID_ARGUMENT", func(t *testing.T) {
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("ListItems", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: invalid status", domainErrors.ErrInvalidInput))
+		client := newTestClient(t, mockUsecase)
+
+		stream, err := client.ListItems(context.Background(), &itemsv1.ListItemsRequest{Status: "broken"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// fakeTenantUsecase はdefault（ID: 1）とacme（ID: 2）のテナントを持つTenantUsecase
+type fakeTenantUsecase struct {
+	usecase.TenantUsecase
+}
+
+func (fakeTenantUsecase) GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	switch slug {
+	case "default":
+		return &entity.Tenant{ID: 1, Slug: slug}, nil
+	case "acme":
+		return &entity.Tenant{ID: 2, Slug: slug}, nil
+	}
+	return nil, domainErrors.ErrTenantNotFound
+}
+
+// サーバーと同じインターセプターを通したストリーミングの呼び出しにもテナントと読み込み先が設定される
+func TestItemService_ListItems_Interceptors(t *testing.T) {
+	resolver := middleware.NewTenantResolver(middleware.TenantConfig{
+		Tenants:       fakeTenantUsecase{},
+		APIKeys:       map[string]string{"acme-key": "acme"},
+		DefaultTenant: "default",
+	})
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			middleware.ReadYourWritesUnaryInterceptor(),
+			middleware.TenantUnaryInterceptor(resolver),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.ReadYourWritesStreamInterceptor(),
+			middleware.TenantStreamInterceptor(resolver),
+		),
+	}
+
+	tests := []struct {
+		name             string
+		md               metadata.MD
+		expectedTenantID int64
+		expectedCode     codes.Code
+	}{
+		{name: "正常系: APIキーがない場合は既定のテナント", md: metadata.MD{}, expectedTenantID: 1},
+		{name: "正常系: APIキーのテナント", md: metadata.Pairs("x-api-key", "acme-key"), expectedTenantID: 2},
+		{name: "異常系: APIキーと異なるテナントはPERMISSION_DENIED", md: metadata.Pairs("x-api-key", "acme-key", "x-tenant", "default"), expectedCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("ListItems", mock.MatchedBy(func(ctx context.Context) bool {
+				tenantID, ok := usecase.TenantIDFromContext(ctx)
+				// 書き込みを記録できるctxであれば、以降の読み込みはプライマリに送られる
+				database.MarkWritten(ctx)
+				return ok && tenantID == tt.expectedTenantID && database.ReadFromPrimary(ctx)
+			}), usecase.ItemFilter{}).Return([]*entity.Item{newTestItem(1, "ロレックス デイトナ")}, nil).Maybe()
+			mockUsecase.On("IncludeBookValue", mock.Anything).Return().Maybe()
+			client := newTestClient(t, mockUsecase, opts...)
+
+			stream, err := client.ListItems(metadata.NewOutgoingContext(context.Background(), tt.md), &itemsv1.ListItemsRequest{})
+			require.NoError(t, err)
+			res, err := stream.Recv()
+
+			if tt.expectedCode != codes.OK {
+				assert.Equal(t, tt.expectedCode, status.Code(err))
+				mockUsecase.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ロレックス デイトナ", res.GetItem().GetName())
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_CreateItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "正常系: アイテムを登録できる", expectedCode: codes.OK},
+		{name: "異常系: 入力が不正な場合はINVALID_ARGUMENT", err: fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput), expectedCode: codes.InvalidArgument},
+		{name: "異常系: シリアル番号が重複する場合はALREADY_EXISTS", err: fmt.Errorf("%w: serial number", domainErrors.ErrDuplicateEntry), expectedCode: codes.AlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			input := usecase.CreateItemInput{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Attributes:    map[string]interface{}{"movement": "自動巻き"},
+			}
+			if tt.err != nil {
+				mockUsecase.On("CreateItem", mock.Anything, input).Return(nil, tt.err)
+			} else {
+				mockUsecase.On("CreateItem", mock.Anything, input).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+			}
+			client := newTestClient(t, mockUsecase)
+			attributes, err := structpb.NewStruct(map[string]interface{}{"movement": "自動巻き"})
+			require.NoError(t, err)
+
+			res, err := client.CreateItem(context.Background(), &itemsv1.CreateItemRequest{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Attributes:    attributes,
+			})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, int64(1), res.GetItem().GetId())
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_UpdateItem(t *testing.T) {
+	t.Run("正常系: 指定したフィールドだけを更新する", func(t *testing.T) {
+		mockUsecase := new(MockItemUsecase)
+		name := "ロレックス サブマリーナ"
+		price := 1200000
+		mockUsecase.On("UpdateItem", mock.Anything, int64(1), usecase.UpdateItemInput{
+			Name:          &name,
+			PurchasePrice: &price,
+			Attributes:    map[string]interface{}{"movement": nil},
+		}).Return(newTestItem(1, name), nil)
+		client := newTestClient(t, mockUsecase)
+
+		res, err := client.UpdateItem(context.Background(), &itemsv1.UpdateItemRequest{
+			Id:            1,
+			Name:          proto.String(name),
+			PurchasePrice: proto.Int64(1200000),
+			Attributes:    &structpb.Struct{Fields: map[string]*structpb.Value{"movement": structpb.NewNullValue()}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, name, res.GetItem().GetName())
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 変更がない場合は現在のアイテムを返す", func(t *testing.T) {
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(newTestItem(1, "ロレックス デイトナ"), nil)
+		client := newTestClient(t, mockUsecase)
+
+		res, err := client.UpdateItem(context.Background(), &itemsv1.UpdateItemRequest{Id: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", res.GetItem().GetName())
+		mockUsecase.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 状態により変更できない場合はFAILED_PRECONDITION", func(t *testing.T) {
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return(nil, fmt.Errorf("%w: item is sold", domainErrors.ErrConflict))
+		client := newTestClient(t, mockUsecase)
+
+		_, err := client.UpdateItem(context.Background(), &itemsv1.UpdateItemRequest{Id: 1, Name: proto.String("ロレックス")})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestItemService_DeleteItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "正常系: アイテムを削除できる", expectedCode: codes.OK},
+		{name: "異常系: 存在しない場合はNOT_FOUND", err: domainErrors.ErrItemNotFound, expectedCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemUsecase)
+			mockUsecase.On("DeleteItem", mock.Anything, int64(1)).Return(tt.err)
+			client := newTestClient(t, mockUsecase)
+
+			_, err := client.DeleteItem(context.Background(), &itemsv1.DeleteItemRequest{Id: 1})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_GetSummary(t *testing.T) {
+	locationID := int64(3)
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetCategorySummary", mock.Anything).Return(&usecase.CategorySummary{
+		Categories: map[string]int{"時計": 2, "バッグ": 1},
+		Total:      3,
+		Locations:  []*usecase.LocationSummary{{LocationID: &locationID, Name: "金庫", Type: entity.LocationTypeContainer, ItemCount: 2, TotalValue: 3000000}},
+		Statuses:   map[string]int{"owned": 2, "sold": 1},
+		Sales: &usecase.SalesSummary{
+			SalesTotals: usecase.SalesTotals{SoldCount: 1, PurchaseTotal: 100000, SaleTotal: 150000, RealizedGain: 50000},
+			Categories:  map[string]*usecase.SalesTotals{"バッグ": {SoldCount: 1, PurchaseTotal: 100000, SaleTotal: 150000, RealizedGain: 50000}},
+		},
+	}, nil)
+	client := newTestClient(t, mockUsecase)
+
+	res, err := client.GetSummary(context.Background(), &itemsv1.GetSummaryRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"時計": 2, "バッグ": 1}, res.GetCategories())
+	assert.Equal(t, int64(3), res.GetTotal())
+	require.Len(t, res.GetLocations(), 1)
+	assert.Equal(t, locationID, res.GetLocations()[0].GetLocationId())
+	assert.Equal(t, "container", res.GetLocations()[0].GetType())
+	assert.Equal(t, int64(50000), res.GetSales().GetTotal().GetRealizedGain())
+	assert.Equal(t, int64(1), res.GetSales().GetCategories()["バッグ"].GetSoldCount())
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/pkg/client"
+)
+
+// Backend はTUIが使うアイテムの操作。ユースケースを直接呼ぶか、REST APIでサーバーを呼ぶ
+type Backend interface {
+	ListItems(ctx context.Context, category string) ([]*entity.Item, error)
+	UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error)
+	DeleteItem(ctx context.Context, id int64) error
+}
+
+// usecaseBackend はデータベースに直接接続したユースケースを使う
+type usecaseBackend struct {
+	itemUsecase usecase.ItemUsecase
+}
+
+func NewUsecaseBackend(itemUsecase usecase.ItemUsecase) Backend {
+	return &usecaseBackend{itemUsecase: itemUsecase}
+}
+
+func (b *usecaseBackend) ListItems(ctx context.Context, category string) ([]*entity.Item, error) {
+	items, err := b.itemUsecase.ListItems(ctx, usecase.ItemFilter{Category: category})
+	if err != nil {
+		return nil, err
+	}
+	b.itemUsecase.IncludeBookValue(items)
+	return items, nil
+}
+
+func (b *usecaseBackend) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	item, err := b.itemUsecase.UpdateItem(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	b.itemUsecase.IncludeBookValue([]*entity.Item{item})
+	return item, nil
+}
+
+func (b *usecaseBackend) DeleteItem(ctx context.Context, id int64) error {
+	return b.itemUsecase.DeleteItem(ctx, id)
+}
+
+// clientBackend はREST APIでリモートのサーバーを使う
+type clientBackend struct {
+	client *client.Client
+}
+
+func NewClientBackend(c *client.Client) Backend {
+	return &clientBackend{client: c}
+}
+
+func (b *clientBackend) ListItems(ctx context.Context, category string) ([]*entity.Item, error) {
+	items, err := b.client.ListItems(ctx, client.ListItemsOptions{Category: category})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Item, len(items))
+	for i, item := range items {
+		result[i] = fromClientItem(item)
+	}
+	return result, nil
+}
+
+func (b *clientBackend) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	item, err := b.client.UpdateItem(ctx, id, client.UpdateItemInput{
+		Name:              input.Name,
+		Category:          input.Category,
+		Brand:             input.Brand,
+		PurchasePrice:     input.PurchasePrice,
+		PurchaseDate:      input.PurchaseDate,
+		SerialNumber:      input.SerialNumber,
+		ModelNumber:       input.ModelNumber,
+		Condition:         input.Condition,
+		Authenticity:      input.Authenticity,
+		WarrantyExpiresAt: input.WarrantyExpiresAt,
+		Attributes:        input.Attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromClientItem(item), nil
+}
+
+func (b *clientBackend) DeleteItem(ctx context.Context, id int64) error {
+	return b.client.DeleteItem(ctx, id)
+}
+
+func fromClientItem(item *client.Item) *entity.Item {
+	return &entity.Item{
+		ID:                item.ID,
+		Name:              item.Name,
+		Category:          item.Category,
+		Brand:             item.Brand,
+		PurchasePrice:     item.PurchasePrice,
+		PurchaseDate:      item.PurchaseDate,
+		SerialNumber:      item.SerialNumber,
+		ModelNumber:       item.ModelNumber,
+		Condition:         item.Condition,
+		Authenticity:      item.Authenticity,
+		LocationID:        item.LocationID,
+		Status:            entity.ItemStatus(item.Status),
+		SalePrice:         item.SalePrice,
+		SaleDate:          item.SaleDate,
+		WarrantyExpiresAt: item.WarrantyExpiresAt,
+		InsurancePolicyID: item.InsurancePolicyID,
+		Tags:              item.Tags,
+		Attributes:        item.Attributes,
+		CreatedAt:         item.CreatedAt,
+		UpdatedAt:         item.UpdatedAt,
+		BookValue:         item.BookValue,
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// editField は編集フォームの1項目
+type editField struct {
+	label string
+	// 編集前の値
+	original string
+	input    textinput.Model
+	// 変更した値をUpdateItemInputに設定する
+	apply func(input *usecase.UpdateItemInput, value string) error
+}
+
+// editForm はアイテムの編集フォーム。変更した項目だけを更新する
+type editForm struct {
+	item    *entity.Item
+	fields  []*editField
+	focused int
+	saving  bool
+	err     error
+}
+
+func newEditForm(item *entity.Item) *editForm {
+	form := &editForm{item: item}
+	add := func(label, value string, apply func(input *usecase.UpdateItemInput, value string) error) {
+		input := textinput.New()
+		input.Prompt = ""
+		input.SetValue(value)
+		input.Cursor.SetMode(cursor.CursorStatic)
+		form.fields = append(form.fields, &editField{label: label, original: value, input: input, apply: apply})
+	}
+	text := func(set func(input *usecase.UpdateItemInput, value *string)) func(*usecase.UpdateItemInput, string) error {
+		return func(input *usecase.UpdateItemInput, value string) error {
+			set(input, &value)
+			return nil
+		}
+	}
+
+	add("名前", item.Name, text(func(input *usecase.UpdateItemInput, value *string) { input.Name = value }))
+	add("カテゴリー", item.Category, text(func(input *usecase.UpdateItemInput, value *string) { input.Category = value }))
+	add("ブランド", item.Brand, text(func(input *usecase.UpdateItemInput, value *string) { input.Brand = value }))
+	add("購入価格", strconv.Itoa(item.PurchasePrice), func(input *usecase.UpdateItemInput, value string) error {
+		price, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("購入価格は整数で入力してください: %s", value)
+		}
+		input.PurchasePrice = &price
+		return nil
+	})
+	add("購入日", item.PurchaseDate, text(func(input *usecase.UpdateItemInput, value *string) { input.PurchaseDate = value }))
+	add("シリアル番号", item.SerialNumber, text(func(input *usecase.UpdateItemInput, value *string) { input.SerialNumber = value }))
+	add("型番", item.ModelNumber, text(func(input *usecase.UpdateItemInput, value *string) { input.ModelNumber = value }))
+	add("コンディション", item.Condition, text(func(input *usecase.UpdateItemInput, value *string) { input.Condition = value }))
+	add("真贋", item.Authenticity, text(func(input *usecase.UpdateItemInput, value *string) { input.Authenticity = value }))
+	add("保証期限", item.WarrantyExpiresAt, text(func(input *usecase.UpdateItemInput, value *string) { input.WarrantyExpiresAt = value }))
+
+	form.fields[0].input.Focus()
+	return form
+}
+
+// focus は入力する項目を前後に移動する
+func (f *editForm) focus(delta int) {
+	f.fields[f.focused].input.Blur()
+	f.focused = (f.focused + delta + len(f.fields)) % len(f.fields)
+	f.fields[f.focused].input.Focus()
+}
+
+// updateInput は編集前から変更した項目のUpdateItemInputを作る
+func (f *editForm) updateInput() (usecase.UpdateItemInput, error) {
+	var input usecase.UpdateItemInput
+	for _, field := range f.fields {
+		value := strings.TrimSpace(field.input.Value())
+		if value == field.original {
+			continue
+		}
+		if err := field.apply(&input, value); err != nil {
+			return input, err
+		}
+	}
+	return input, nil
+}
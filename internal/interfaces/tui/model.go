@@ -0,0 +1,426 @@
+// Package tui はアイテムを閲覧・編集する対話的なターミナルUI（main tui）を実装する
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// mode は表示中の画面
+type mode int
+
+const (
+	modeList mode = iota
+	// 一覧で検索語を入力中
+	modeSearch
+	modeDetail
+	modeEdit
+	modeConfirmDelete
+)
+
+// カテゴリーの絞り込みの候補（先頭の空文字はすべて）
+var categories = append([]string{""}, entity.ValidCategories...)
+
+// 画面の高さが分からない場合に表示する行数
+const defaultVisibleRows = 20
+
+// Model はTUIの状態。bubbleteaのtea.Modelを実装する
+type Model struct {
+	ctx     context.Context
+	backend Backend
+
+	mode mode
+	// 編集・削除の確認を終えたときに戻る画面（一覧または詳細）
+	previous mode
+
+	// バックエンドから取得した一覧と、検索語で絞り込んだ表示中の一覧
+	items   []*entity.Item
+	visible []*entity.Item
+	cursor  int
+	offset  int
+	// categoriesのインデックス
+	category int
+	search   textinput.Model
+	form     *editForm
+
+	loading bool
+	// 直前の操作の結果とエラー
+	status string
+	err    error
+
+	width  int
+	height int
+}
+
+type itemsLoadedMsg struct {
+	items []*entity.Item
+	err   error
+}
+
+type itemUpdatedMsg struct {
+	item *entity.Item
+	err  error
+}
+
+type itemDeletedMsg struct {
+	id  int64
+	err error
+}
+
+func New(ctx context.Context, backend Backend) Model {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "名前・ブランド・シリアル番号・型番・タグ"
+	search.Cursor.SetMode(cursor.CursorStatic)
+
+	return Model{
+		ctx:     ctx,
+		backend: backend,
+		search:  search,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// load は選択中のカテゴリーの一覧を取得する
+func (m Model) load() tea.Cmd {
+	ctx, backend, category := m.ctx, m.backend, categories[m.category]
+	return func() tea.Msg {
+		items, err := backend.ListItems(ctx, category)
+		return itemsLoadedMsg{items: items, err: err}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+		return m, nil
+
+	case itemsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.items = msg.items
+		m.applySearch()
+		return m, nil
+
+	case itemUpdatedMsg:
+		if msg.err != nil {
+			m.form.saving = false
+			m.form.err = msg.err
+			return m, nil
+		}
+		m.replace(msg.item)
+		m.form = nil
+		m.mode = m.previous
+		m.status = fmt.Sprintf("アイテム %d を更新しました", msg.item.ID)
+		m.err = nil
+		return m, nil
+
+	case itemDeletedMsg:
+		m.loading = false
+		m.mode = m.previous
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.items = slices.DeleteFunc(m.items, func(item *entity.Item) bool { return item.ID == msg.id })
+		m.applySearch()
+		m.mode = modeList
+		m.status = fmt.Sprintf("アイテム %d を削除しました", msg.id)
+		m.err = nil
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeSearch:
+			return m.updateSearch(msg)
+		case modeDetail:
+			return m.updateDetail(msg)
+		case modeEdit:
+			return m.updateEdit(msg)
+		case modeConfirmDelete:
+			return m.updateConfirmDelete(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.visibleRows())
+	case "pgdown":
+		m.move(m.visibleRows())
+	case "home", "g":
+		m.move(-len(m.visible))
+	case "end", "G":
+		m.move(len(m.visible))
+	case "enter":
+		if m.selected() != nil {
+			m.mode = modeDetail
+		}
+	case "/":
+		m.mode = modeSearch
+		m.search.Focus()
+	case "esc":
+		m.search.SetValue("")
+		m.applySearch()
+	case "c", "C":
+		// カテゴリーを順に切り替えて一覧を取得し直す
+		step := 1
+		if msg.String() == "C" {
+			step = len(categories) - 1
+		}
+		m.category = (m.category + step) % len(categories)
+		m.loading = true
+		return m, m.load()
+	case "r":
+		m.loading = true
+		m.status, m.err = "", nil
+		return m, m.load()
+	case "e":
+		return m.startEdit()
+	case "d":
+		return m.startDelete()
+	}
+	return m, nil
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.search.SetValue("")
+		m.search.Blur()
+		m.mode = modeList
+		m.applySearch()
+		return m, nil
+	case tea.KeyEnter:
+		m.search.Blur()
+		m.mode = modeList
+		return m, nil
+	}
+
+	// 入力のたびに一覧を絞り込む
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.applySearch()
+	return m, cmd
+}
+
+func (m Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "backspace", "left", "h":
+		m.mode = modeList
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "e":
+		return m.startEdit()
+	case "d":
+		return m.startDelete()
+	}
+	return m, nil
+}
+
+func (m Model) startEdit() (tea.Model, tea.Cmd) {
+	item := m.selected()
+	if item == nil {
+		return m, nil
+	}
+	m.previous = m.mode
+	m.mode = modeEdit
+	m.form = newEditForm(item)
+	m.status, m.err = "", nil
+	return m, nil
+}
+
+func (m Model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.form.saving {
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.form = nil
+		m.mode = m.previous
+		return m, nil
+	case tea.KeyTab, tea.KeyDown:
+		m.form.focus(1)
+		return m, nil
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.form.focus(-1)
+		return m, nil
+	case tea.KeyCtrlS:
+		return m.save()
+	case tea.KeyEnter:
+		// 最後の項目でEnterを押すと保存する
+		if m.form.focused == len(m.form.fields)-1 {
+			return m.save()
+		}
+		m.form.focus(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	field := m.form.fields[m.form.focused]
+	field.input, cmd = field.input.Update(msg)
+	return m, cmd
+}
+
+// save は変更した項目だけをUpdateItemで更新する
+func (m Model) save() (tea.Model, tea.Cmd) {
+	input, err := m.form.updateInput()
+	if err != nil {
+		m.form.err = err
+		return m, nil
+	}
+	if input.IsEmpty() {
+		m.form = nil
+		m.mode = m.previous
+		m.status = "変更はありません"
+		return m, nil
+	}
+
+	m.form.saving = true
+	m.form.err = nil
+	ctx, backend, id := m.ctx, m.backend, m.form.item.ID
+	return m, func() tea.Msg {
+		item, err := backend.UpdateItem(ctx, id, input)
+		return itemUpdatedMsg{item: item, err: err}
+	}
+}
+
+func (m Model) startDelete() (tea.Model, tea.Cmd) {
+	if m.selected() == nil {
+		return m, nil
+	}
+	m.previous = m.mode
+	m.mode = modeConfirmDelete
+	m.status, m.err = "", nil
+	return m, nil
+}
+
+func (m Model) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		if m.loading {
+			return m, nil
+		}
+		m.loading = true
+		ctx, backend, id := m.ctx, m.backend, m.selected().ID
+		return m, func() tea.Msg {
+			return itemDeletedMsg{id: id, err: backend.DeleteItem(ctx, id)}
+		}
+	case "n", "N", "esc", "q":
+		m.mode = m.previous
+	}
+	return m, nil
+}
+
+// applySearch は検索語で表示する一覧を絞り込み、できるだけ同じアイテムを選択したままにする
+func (m *Model) applySearch() {
+	var selectedID int64
+	if item := m.selected(); item != nil {
+		selectedID = item.ID
+	}
+
+	query := strings.ToLower(strings.TrimSpace(m.search.Value()))
+	visible := make([]*entity.Item, 0, len(m.items))
+	for _, item := range m.items {
+		if query == "" || matches(item, query) {
+			visible = append(visible, item)
+		}
+	}
+	m.visible = visible
+
+	m.cursor = 0
+	for i, item := range m.visible {
+		if item.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.scroll()
+}
+
+func matches(item *entity.Item, query string) bool {
+	fields := append([]string{item.Name, item.Brand, item.SerialNumber, item.ModelNumber}, item.Tags...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// replace は更新したアイテムを一覧に反映する
+func (m *Model) replace(updated *entity.Item) {
+	for i, item := range m.items {
+		if item.ID == updated.ID {
+			m.items[i] = updated
+		}
+	}
+	m.applySearch()
+}
+
+func (m *Model) selected() *entity.Item {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+func (m *Model) move(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.visible)-1, 0))
+	m.scroll()
+}
+
+// scroll は選択中の行が表示される範囲に一覧をスクロールする
+func (m *Model) scroll() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = min(m.offset, max(len(m.visible)-rows, 0))
+}
+
+// 一覧に表示できる行数（ヘッダー・列名・フッターの行を除く）
+func (m *Model) visibleRows() int {
+	if m.height == 0 {
+		return defaultVisibleRows
+	}
+	return max(m.height-5, 1)
+}
@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/pkg/client"
+)
+
+// fakeBackend は呼び出しを記録するBackend
+type fakeBackend struct {
+	items      []*entity.Item
+	categories []string
+	updates    []usecase.UpdateItemInput
+	deleted    []int64
+	updateErr  error
+}
+
+func (b *fakeBackend) ListItems(ctx context.Context, category string) ([]*entity.Item, error) {
+	b.categories = append(b.categories, category)
+	var items []*entity.Item
+	for _, item := range b.items {
+		if category == "" || item.Category == category {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (b *fakeBackend) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	if b.updateErr != nil {
+		return nil, b.updateErr
+	}
+	b.updates = append(b.updates, input)
+	for _, item := range b.items {
+		if item.ID == id {
+			updated := *item
+			if input.Name != nil {
+				updated.Name = *input.Name
+			}
+			if input.PurchasePrice != nil {
+				updated.PurchasePrice = *input.PurchasePrice
+			}
+			return &updated, nil
+		}
+	}
+	return nil, domainErrors.ErrItemNotFound
+}
+
+func (b *fakeBackend) DeleteItem(ctx context.Context, id int64) error {
+	b.deleted = append(b.deleted, id)
+	return nil
+}
+
+func newTestItem(id int64, name, category, brand string) *entity.Item {
+	item, _ := entity.NewItem(name, category, brand, 1500000, "2023-01-15")
+	item.ID = id
+	return item
+}
+
+func newTestBackend() *fakeBackend {
+	return &fakeBackend{items: []*entity.Item{
+		newTestItem(1, "ロレックス デイトナ", "時計", "ROLEX"),
+		newTestItem(2, "エルメス バーキン", "バッグ", "HERMÈS"),
+		newTestItem(3, "オメガ スピードマスター", "時計", "OMEGA"),
+	}}
+}
+
+// start はモデルを作成して一覧を読み込む
+func start(t *testing.T, backend Backend) Model {
+	t.Helper()
+	m := New(context.Background(), backend)
+	return send(m, m.Init()())
+}
+
+// send はメッセージを処理し、返されたコマンドの結果を続けて処理する
+func send(m Model, msg tea.Msg) Model {
+	for msg != nil {
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		if cmd == nil {
+			break
+		}
+		msg = cmd()
+		if _, ok := msg.(tea.QuitMsg); ok {
+			break
+		}
+	}
+	return m
+}
+
+// press はキー入力を順に送る。1文字ずつの文字列は文字の入力として扱う
+func press(m Model, keys ...string) Model {
+	special := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab, "up": tea.KeyUp, "down": tea.KeyDown,
+		"ctrl+s": tea.KeyCtrlS, "ctrl+u": tea.KeyCtrlU, "backspace": tea.KeyBackspace,
+	}
+	for _, key := range keys {
+		if keyType, ok := special[key]; ok {
+			m = send(m, tea.KeyMsg{Type: keyType})
+			continue
+		}
+		for _, r := range key {
+			m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	return m
+}
+
+func TestModel_List(t *testing.T) {
+	t.Run("正常系: 一覧を表示し、キーで選択を移動する", func(t *testing.T) {
+		m := start(t, newTestBackend())
+
+		assert.Len(t, m.visible, 3)
+		assert.Equal(t, int64(1), m.selected().ID)
+
+		m = press(m, "j", "j", "j")
+		assert.Equal(t, int64(3), m.selected().ID)
+
+		m = press(m, "k")
+		assert.Equal(t, int64(2), m.selected().ID)
+
+		view := m.View()
+		assert.Contains(t, view, "ロレックス デイトナ")
+		assert.Contains(t, view, "¥1,500,000")
+	})
+
+	t.Run("正常系: 検索語で絞り込む", func(t *testing.T) {
+		m := start(t, newTestBackend())
+
+		m = press(m, "/", "omega", "enter")
+
+		require.Len(t, m.visible, 1)
+		assert.Equal(t, int64(3), m.selected().ID)
+		assert.Contains(t, m.View(), "検索: omega")
+
+		// escで検索を解除しても選択中のアイテムを維持する
+		m = press(m, "esc")
+		assert.Len(t, m.visible, 3)
+		assert.Equal(t, int64(3), m.selected().ID)
+	})
+
+	t.Run("正常系: カテゴリーを切り替えて取得し直す", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		m = press(m, "c")
+
+		assert.Equal(t, []string{"", "時計"}, backend.categories)
+		assert.Len(t, m.visible, 2)
+		assert.Contains(t, m.View(), "カテゴリー: 時計")
+
+		m = press(m, "C")
+		assert.Equal(t, "", backend.categories[2])
+		assert.Len(t, m.visible, 3)
+	})
+}
+
+func TestModel_Detail(t *testing.T) {
+	m := start(t, newTestBackend())
+
+	m = press(m, "j", "enter")
+
+	assert.Equal(t, modeDetail, m.mode)
+	assert.Contains(t, m.View(), "エルメス バーキン")
+	assert.Contains(t, m.View(), "HERMÈS")
+
+	m = press(m, "esc")
+	assert.Equal(t, modeList, m.mode)
+}
+
+func TestModel_Edit(t *testing.T) {
+	t.Run("正常系: 変更した項目だけを更新する", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		// 名前を書き換え、購入価格の項目に移動して書き換える
+		m = press(m, "e", "ctrl+u", "ロレックス サブマリーナ", "tab", "tab", "tab", "ctrl+u", "1800000", "ctrl+s")
+
+		require.Len(t, backend.updates, 1)
+		name, price := "ロレックス サブマリーナ", 1800000
+		assert.Equal(t, usecase.UpdateItemInput{Name: &name, PurchasePrice: &price}, backend.updates[0])
+		assert.Equal(t, modeList, m.mode)
+		assert.Equal(t, "ロレックス サブマリーナ", m.selected().Name)
+		assert.Contains(t, m.View(), "アイテム 1 を更新しました")
+	})
+
+	t.Run("正常系: 変更がない場合は更新しない", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		m = press(m, "enter", "e", "ctrl+s")
+
+		assert.Empty(t, backend.updates)
+		assert.Equal(t, modeDetail, m.mode)
+		assert.Contains(t, m.View(), "変更はありません")
+	})
+
+	t.Run("異常系: 購入価格が整数でない", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		m = press(m, "e", "tab", "tab", "tab", "ctrl+u", "高い", "ctrl+s")
+
+		assert.Empty(t, backend.updates)
+		assert.Equal(t, modeEdit, m.mode)
+		assert.Contains(t, m.View(), "購入価格は整数で入力してください")
+	})
+
+	t.Run("異常系: 更新のエラーを表示して編集を続ける", func(t *testing.T) {
+		backend := newTestBackend()
+		backend.updateErr = errors.New("category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		m := start(t, backend)
+
+		m = press(m, "e", "tab", "ctrl+u", "家具", "ctrl+s")
+
+		assert.Equal(t, modeEdit, m.mode)
+		assert.Contains(t, m.View(), "category must be one of")
+	})
+}
+
+func TestModel_Delete(t *testing.T) {
+	t.Run("正常系: 確認してから削除する", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		m = press(m, "j", "d")
+		assert.Contains(t, m.View(), "アイテム 2（エルメス バーキン）を削除しますか？")
+
+		m = press(m, "y")
+
+		assert.Equal(t, []int64{2}, backend.deleted)
+		assert.Len(t, m.visible, 2)
+		assert.Equal(t, modeList, m.mode)
+	})
+
+	t.Run("正常系: キャンセルした場合は削除しない", func(t *testing.T) {
+		backend := newTestBackend()
+		m := start(t, backend)
+
+		m = press(m, "enter", "d", "n")
+
+		assert.Empty(t, backend.deleted)
+		assert.Equal(t, modeDetail, m.mode)
+	})
+}
+
+// REST APIのバックエンドはサーバーのJSONをアイテムに変換する
+func TestClientBackend(t *testing.T) {
+	var patched string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "時計", r.URL.Query().Get("category"))
+			w.Write([]byte(`[{"id": 1, "name": "ロレックス デイトナ", "category": "時計", "status": "owned", "book_value": 1600000}]`))
+		case http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			patched = string(body)
+			w.Write([]byte(`{"id": 1, "name": "ロレックス サブマリーナ", "category": "時計", "status": "owned"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.New(server.URL)
+	require.NoError(t, err)
+	backend := NewClientBackend(c)
+
+	items, err := backend.ListItems(context.Background(), "時計")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, entity.ItemStatusOwned, items[0].Status)
+	assert.Equal(t, int64(1600000), *items[0].BookValue)
+
+	name := "ロレックス サブマリーナ"
+	item, err := backend.UpdateItem(context.Background(), 1, usecase.UpdateItemInput{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, name, item.Name)
+	assert.JSONEq(t, `{"name": "ロレックス サブマリーナ"}`, patched)
+}
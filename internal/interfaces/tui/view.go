@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	headerStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	statusStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+// 一覧の列（幅は表示上の文字幅）
+var listColumns = []struct {
+	title string
+	width int
+	value func(item *entity.Item) string
+	right bool
+}{
+	{title: "ID", width: 6, value: func(item *entity.Item) string { return strconv.FormatInt(item.ID, 10) }, right: true},
+	{title: "名前", width: 30, value: func(item *entity.Item) string { return item.Name }},
+	{title: "カテゴリー", width: 12, value: func(item *entity.Item) string { return item.Category }},
+	{title: "ブランド", width: 20, value: func(item *entity.Item) string { return item.Brand }},
+	{title: "購入価格", width: 12, value: func(item *entity.Item) string { return formatYen(int64(item.PurchasePrice)) }, right: true},
+	{title: "状態", width: 10, value: func(item *entity.Item) string { return string(item.Status) }},
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(m.header())
+	b.WriteString("\n\n")
+
+	switch m.mode {
+	case modeDetail:
+		b.WriteString(m.detailView())
+	case modeEdit:
+		b.WriteString(m.editView())
+	case modeConfirmDelete:
+		item := m.selected()
+		b.WriteString(m.listView())
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render(fmt.Sprintf("アイテム %d（%s）を削除しますか？ [y/N]", item.ID, item.Name)))
+	default:
+		b.WriteString(m.listView())
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.err != nil:
+		b.WriteString(errorStyle.Render("エラー: " + m.err.Error()))
+	case m.loading:
+		b.WriteString(helpStyle.Render("読み込み中..."))
+	case m.status != "":
+		b.WriteString(statusStyle.Render(m.status))
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(m.help()))
+	return b.String()
+}
+
+func (m Model) header() string {
+	category := categories[m.category]
+	if category == "" {
+		category = "すべて"
+	}
+	header := fmt.Sprintf("所持品管理  カテゴリー: %s  %d/%d件", category, len(m.visible), len(m.items))
+	if m.mode == modeSearch {
+		return titleStyle.Render(header) + "  " + m.search.View()
+	}
+	if query := m.search.Value(); query != "" {
+		header += "  検索: " + query
+	}
+	return titleStyle.Render(header)
+}
+
+func (m Model) listView() string {
+	var b strings.Builder
+	titles := make([]string, len(listColumns))
+	for i, column := range listColumns {
+		titles[i] = pad(column.title, column.width, column.right)
+	}
+	b.WriteString(headerStyle.Render(strings.Join(titles, " ")))
+	b.WriteString("\n")
+
+	if len(m.visible) == 0 && !m.loading {
+		b.WriteString(helpStyle.Render("アイテムがありません"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	end := min(m.offset+m.visibleRows(), len(m.visible))
+	for i := m.offset; i < end; i++ {
+		item := m.visible[i]
+		cells := make([]string, len(listColumns))
+		for j, column := range listColumns {
+			cells[j] = pad(column.value(item), column.width, column.right)
+		}
+		line := strings.Join(cells, " ")
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m Model) detailView() string {
+	item := m.selected()
+	if item == nil {
+		return ""
+	}
+
+	rows := [][2]string{
+		{"ID", strconv.FormatInt(item.ID, 10)},
+		{"名前", item.Name},
+		{"カテゴリー", item.Category},
+		{"ブランド", item.Brand},
+		{"購入価格", formatYen(int64(item.PurchasePrice))},
+		{"購入日", item.PurchaseDate},
+		{"状態", string(item.Status)},
+		{"シリアル番号", item.SerialNumber},
+		{"型番", item.ModelNumber},
+		{"コンディション", item.Condition},
+		{"真贋", item.Authenticity},
+		{"保証期限", item.WarrantyExpiresAt},
+		{"タグ", strings.Join(item.Tags, ", ")},
+	}
+	if item.LocationID != nil {
+		rows = append(rows, [2]string{"保管場所ID", strconv.FormatInt(*item.LocationID, 10)})
+	}
+	if item.BookValue != nil {
+		rows = append(rows, [2]string{"帳簿価額", formatYen(*item.BookValue)})
+	}
+	for _, key := range sortedKeys(item.Attributes) {
+		value, _ := json.Marshal(item.Attributes[key])
+		rows = append(rows, [2]string{"属性 " + key, string(value)})
+	}
+	rows = append(rows,
+		[2]string{"登録日時", item.CreatedAt.Format("2006-01-02 15:04:05")},
+		[2]string{"更新日時", item.UpdatedAt.Format("2006-01-02 15:04:05")},
+	)
+
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(titleStyle.Render(pad(row[0], 16, false)))
+		b.WriteString(" ")
+		b.WriteString(row[1])
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m Model) editView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("アイテム %d の編集", m.form.item.ID)))
+	b.WriteString("\n\n")
+	for i, field := range m.form.fields {
+		marker := "  "
+		if i == m.form.focused {
+			marker = "> "
+		}
+		b.WriteString(marker)
+		b.WriteString(pad(field.label, 14, false))
+		b.WriteString(" ")
+		b.WriteString(field.input.View())
+		b.WriteString("\n")
+	}
+	if m.form.saving {
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("保存中..."))
+	}
+	if m.form.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("エラー: " + m.form.err.Error()))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m Model) help() string {
+	switch m.mode {
+	case modeSearch:
+		return "文字を入力して絞り込み  enter: 確定  esc: 検索を解除"
+	case modeDetail:
+		return "↑/↓: 前後のアイテム  e: 編集  d: 削除  esc: 一覧に戻る  q: 終了"
+	case modeEdit:
+		return "tab/↑/↓: 項目を移動  ctrl+s: 保存（最後の項目ではenterでも保存）  esc: キャンセル"
+	case modeConfirmDelete:
+		return "y: 削除する  n/esc: キャンセル"
+	}
+	return "↑/↓: 選択  enter: 詳細  /: 検索  c/C: カテゴリー  e: 編集  d: 削除  r: 再読み込み  q: 終了"
+}
+
+// pad は表示上の文字幅をwidthに揃える（全角文字は2文字分として数える）
+func pad(s string, width int, right bool) string {
+	s = runewidth.Truncate(s, width, "…")
+	if right {
+		return runewidth.FillLeft(s, width)
+	}
+	return runewidth.FillRight(s, width)
+}
+
+// formatYen は金額を3桁区切りで表示する
+func formatYen(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + "¥" + b.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
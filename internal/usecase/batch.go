@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 1回のバッチで受け付ける操作数の上限
+const MaxBatchOperations = 500
+
+type BatchOperationType string
+
+const (
+	BatchOperationCreate BatchOperationType = "create"
+	BatchOperationUpdate BatchOperationType = "update"
+	BatchOperationDelete BatchOperationType = "delete"
+)
+
+// BatchOperation はバッチ内の1操作。Opに応じてCreate/Update/IDのいずれかを使用する。
+// Errを設定した操作（リクエストの形式の誤りなど）は実行せず、そのエラーを結果として返す
+type BatchOperation struct {
+	Op     BatchOperationType
+	ID     int64
+	Create *CreateItemInput
+	Update *UpdateItemInput
+	Err    error
+}
+
+// BatchInput はバッチ処理の入力。操作はリクエストの順に実行する。
+// Atomic=trueの場合はすべての操作を1トランザクションで実行し、1件でも失敗すれば全件ロールバックする。
+// Atomic=falseの場合は操作ごとに成否を返す。
+type BatchInput struct {
+	Atomic     bool
+	Operations []BatchOperation
+}
+
+// BatchOperationResult は1操作の実行結果。Errがnilなら成功
+type BatchOperationResult struct {
+	Index int
+	Op    BatchOperationType
+	ID    int64
+	Item  *entity.Item
+	Err   error
+}
+
+type BatchResult struct {
+	Atomic    bool
+	Committed bool
+	Results   []BatchOperationResult
+}
+
+// 実行する操作を、連続する同じ種類の操作ごとのステップにまとめたもの。ステップはリクエストの順に実行する
+type batchPlan struct {
+	operations []BatchOperation
+	steps      []*batchStep
+}
+
+// 連続する同じ種類の操作。アトミック実行では作成と削除をステップごとにまとめて実行する
+type batchStep struct {
+	op      BatchOperationType
+	indexes []int          // Operationsのインデックス
+	items   []*entity.Item // 作成するアイテム（作成のみ）
+}
+
+// add は操作を直前のステップに加える。直前のステップと種類が異なる場合は新しいステップにする
+func (p *batchPlan) add(op BatchOperationType, index int, item *entity.Item) {
+	if len(p.steps) == 0 || p.steps[len(p.steps)-1].op != op {
+		p.steps = append(p.steps, &batchStep{op: op})
+	}
+	step := p.steps[len(p.steps)-1]
+	step.indexes = append(step.indexes, index)
+	if item != nil {
+		step.items = append(step.items, item)
+	}
+}
+
+func (u *itemUsecase) ExecuteBatch(ctx context.Context, input BatchInput) (*BatchResult, error) {
+	if len(input.Operations) == 0 {
+		return nil, fmt.Errorf("%w: operations must not be empty", domainErrors.ErrInvalidInput)
+	}
+	if len(input.Operations) > MaxBatchOperations {
+		return nil, fmt.Errorf("%w: operations must be %d or fewer", domainErrors.ErrInvalidInput, MaxBatchOperations)
+	}
+
+	result := &BatchResult{
+		Atomic:  input.Atomic,
+		Results: make([]BatchOperationResult, len(input.Operations)),
+	}
+	plan := u.planBatch(input.Operations, result.Results)
+
+	if input.Atomic {
+		return u.executeAtomicBatch(ctx, plan, result)
+	}
+	return u.executePartialBatch(ctx, plan, result)
+}
+
+// 各操作を検証し、連続する同じ種類の操作ごとのステップにまとめる。検証エラーはresultsに直接書き込む
+func (u *itemUsecase) planBatch(operations []BatchOperation, results []BatchOperationResult) *batchPlan {
+	plan := &batchPlan{operations: operations}
+	deleteIDs := make(map[int64]bool)
+	serials := make(map[string]bool)
+
+	for i, op := range operations {
+		results[i] = BatchOperationResult{Index: i, Op: op.Op, ID: op.ID}
+		if op.Err != nil {
+			results[i].Err = op.Err
+			continue
+		}
+
+		switch op.Op {
+		case BatchOperationCreate:
+			if op.Create == nil {
+				results[i].Err = fmt.Errorf("%w: data is required for create", domainErrors.ErrInvalidInput)
+				continue
+			}
+			item, err := newItemFromInput(*op.Create)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			// 同じバッチ内でのシリアル番号の重複は、DBに書き込む前に後の操作のエラーとして返す
+			if item.SerialNumber != "" {
+				key := strings.ToLower(item.Brand + "\x00" + item.SerialNumber)
+				if serials[key] {
+					results[i].Err = fmt.Errorf("%w: serial number %s of brand %s appears more than once", domainErrors.ErrDuplicateEntry, item.SerialNumber, item.Brand)
+					continue
+				}
+				serials[key] = true
+			}
+			plan.add(op.Op, i, item)
+		case BatchOperationUpdate:
+			if op.ID <= 0 {
+				results[i].Err = domainErrors.ErrInvalidInput
+				continue
+			}
+			if op.Update == nil {
+				results[i].Err = fmt.Errorf("%w: data is required for update", domainErrors.ErrInvalidInput)
+				continue
+			}
+			plan.add(op.Op, i, nil)
+		case BatchOperationDelete:
+			if op.ID <= 0 {
+				results[i].Err = domainErrors.ErrInvalidInput
+				continue
+			}
+			if deleteIDs[op.ID] {
+				results[i].Err = fmt.Errorf("%w: item %d is deleted more than once", domainErrors.ErrInvalidInput, op.ID)
+				continue
+			}
+			deleteIDs[op.ID] = true
+			plan.add(op.Op, i, nil)
+		default:
+			results[i].Err = fmt.Errorf("%w: unknown op %q", domainErrors.ErrInvalidInput, op.Op)
+		}
+	}
+
+	return plan
+}
+
+// 1トランザクションですべての操作を実行する。失敗した操作以外はErrBatchAbortedとなる
+func (u *itemUsecase) executeAtomicBatch(ctx context.Context, plan *batchPlan, result *BatchResult) (*BatchResult, error) {
+	failedIndex := -1
+	for i := range result.Results {
+		if result.Results[i].Err != nil {
+			failedIndex = i
+			break
+		}
+	}
+
+	if failedIndex < 0 {
+		err := u.mutate(ctx, func(ctx context.Context) error {
+			index, err := u.applyBatch(ctx, plan, result.Results)
+			if err != nil && index >= 0 {
+				failedIndex = index
+				result.Results[index].Err = err
+			}
+			return err
+		})
+		if err == nil {
+			result.Committed = true
+			return result, nil
+		}
+		if failedIndex < 0 {
+			// コミットやイベントの記録の失敗などで操作を特定できない場合
+			return nil, fmt.Errorf("failed to execute batch: %w", err)
+		}
+	}
+
+	for i := range result.Results {
+		if i != failedIndex {
+			// ロールバックされたため採番済みのIDも無効になる
+			result.Results[i].ID = plan.operations[i].ID
+			result.Results[i].Item = nil
+			result.Results[i].Err = domainErrors.ErrBatchAborted
+		}
+	}
+
+	return result, nil
+}
+
+// ステップを順に実行し、最初に失敗した操作のインデックスとエラーを返す。
+// 失敗した操作を特定できない場合のインデックスは-1
+func (u *itemUsecase) applyBatch(ctx context.Context, plan *batchPlan, results []BatchOperationResult) (int, error) {
+	for _, step := range plan.steps {
+		switch step.op {
+		case BatchOperationCreate:
+			created, err := u.itemRepo.CreateBatch(ctx, step.items)
+			if err != nil {
+				return failedStepIndex(step, fmt.Errorf("failed to create items: %w", err))
+			}
+			if err := u.recordItemEvents(ctx, entity.EventItemCreated, created...); err != nil {
+				return -1, fmt.Errorf("failed to create items: %w", err)
+			}
+			for n, index := range step.indexes {
+				results[index].ID = created[n].ID
+				results[index].Item = created[n]
+			}
+		case BatchOperationUpdate:
+			for _, index := range step.indexes {
+				item, err := u.UpdateItem(ctx, results[index].ID, *plan.operations[index].Update)
+				if err != nil {
+					return index, err
+				}
+				results[index].Item = item
+			}
+		case BatchOperationDelete:
+			// DeleteBatchは対象の行をロックしてから存在を確認するため、事前に確認しない
+			ids := batchIDs(step.indexes, results)
+			if err := u.itemRepo.DeleteBatch(ctx, ids); err != nil {
+				return failedStepIndex(step, fmt.Errorf("failed to delete items: %w", err))
+			}
+			if err := u.recordDeletedEvents(ctx, ids...); err != nil {
+				return -1, fmt.Errorf("failed to delete items: %w", err)
+			}
+		}
+	}
+
+	return -1, nil
+}
+
+// リポジトリがBatchItemErrorで失敗した要素を示した場合は、その操作のインデックスと元のエラーを返す。
+// 示さなかった場合（DBエラーなど）はステップ全体の失敗として-1を返す
+func failedStepIndex(step *batchStep, err error) (int, error) {
+	var itemErr *domainErrors.BatchItemError
+	if errors.As(err, &itemErr) && itemErr.Index >= 0 && itemErr.Index < len(step.indexes) {
+		return step.indexes[itemErr.Index], itemErr.Err
+	}
+	return -1, err
+}
+
+// ステップを順に、操作ごとに独立して実行する。作成と更新は操作ごとにトランザクションを分け、
+// 削除は存在を確認してからステップごとにまとめて1文で実行する
+func (u *itemUsecase) executePartialBatch(ctx context.Context, plan *batchPlan, result *BatchResult) (*BatchResult, error) {
+	for _, step := range plan.steps {
+		switch step.op {
+		case BatchOperationCreate:
+			u.createPartial(ctx, step, result.Results)
+		case BatchOperationUpdate:
+			for _, index := range step.indexes {
+				item, err := u.UpdateItem(ctx, result.Results[index].ID, *plan.operations[index].Update)
+				result.Results[index].Item = item
+				result.Results[index].Err = err
+			}
+		case BatchOperationDelete:
+			u.deletePartial(ctx, step, result.Results)
+		}
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// 1件の失敗（既存アイテムとのシリアル番号の重複など）で他の作成をロールバックしないよう、1件ずつ登録する
+func (u *itemUsecase) createPartial(ctx context.Context, step *batchStep, results []BatchOperationResult) {
+	for n, index := range step.indexes {
+		item, err := u.createItem(ctx, step.items[n])
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+		results[index].ID = item.ID
+		results[index].Item = item
+	}
+}
+
+func (u *itemUsecase) deletePartial(ctx context.Context, step *batchStep, results []BatchOperationResult) {
+	missing, err := u.findMissing(ctx, step.indexes, results)
+	if err != nil {
+		for _, index := range step.indexes {
+			results[index].Err = fmt.Errorf("failed to check item existence: %w", err)
+		}
+		return
+	}
+
+	missingSet := make(map[int]bool, len(missing))
+	for _, index := range missing {
+		missingSet[index] = true
+		results[index].Err = domainErrors.ErrItemNotFound
+	}
+
+	var existing []int
+	for _, index := range step.indexes {
+		if !missingSet[index] {
+			existing = append(existing, index)
+		}
+	}
+	if len(existing) == 0 {
+		return
+	}
+
+	// 確認後に別リクエストで削除された場合にも件数がずれないようトランザクションで実行する
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		ids := batchIDs(existing, results)
+		if err := u.itemRepo.DeleteBatch(ctx, ids); err != nil {
+			return err
+		}
+		return u.recordDeletedEvents(ctx, ids...)
+	})
+	if err != nil {
+		for _, index := range existing {
+			if errors.Is(err, domainErrors.ErrItemNotFound) {
+				results[index].Err = err
+			} else {
+				results[index].Err = fmt.Errorf("failed to delete item: %w", err)
+			}
+		}
+	}
+}
+
+// 削除対象のうち存在しないアイテムの操作インデックスを返す
+func (u *itemUsecase) findMissing(ctx context.Context, indexes []int, results []BatchOperationResult) ([]int, error) {
+	items, err := u.itemRepo.FindByIDs(ctx, batchIDs(indexes, results))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]bool, len(items))
+	for _, item := range items {
+		found[item.ID] = true
+	}
+
+	var missing []int
+	for _, index := range indexes {
+		if !found[results[index].ID] {
+			missing = append(missing, index)
+		}
+	}
+	return missing, nil
+}
+
+func batchIDs(indexes []int, results []BatchOperationResult) []int64 {
+	ids := make([]int64, len(indexes))
+	for n, index := range indexes {
+		ids[n] = results[index].ID
+	}
+	return ids
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_ExecuteBatch(t *testing.T) {
+	existingItem := func(id int64) *entity.Item {
+		return &entity.Item{
+			ID: id, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+			PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+	}
+	validCreate := &CreateItemInput{
+		Name:          "エルメス バーキン",
+		Category:      "バッグ",
+		Brand:         "HERMÈS",
+		PurchasePrice: 2000000,
+		PurchaseDate:  "2023-02-20",
+	}
+
+	tests := []struct {
+		name              string
+		input             BatchInput
+		setupMock         func(*MockItemRepository)
+		expectedCommitted bool
+		expectedErrs      []error // 操作ごとの期待するエラー（nilは成功）
+		expectedIDs       []int64
+	}{
+		{
+			name: "正常系: アトミックに作成・更新・削除を実行",
+			input: BatchInput{
+				Atomic: true,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: validCreate},
+					{Op: BatchOperationUpdate, ID: 1, Update: &UpdateItemInput{Name: strPtr("新しい名前")}},
+					{Op: BatchOperationDelete, ID: 2},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				created := existingItem(10)
+				mockRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.Item")).Return([]*entity.Item{created}, nil).Once()
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem(1), nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(existingItem(1), nil).Once()
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{2}).Return(nil).Once()
+			},
+			expectedCommitted: true,
+			expectedErrs:      []error{nil, nil, nil},
+			expectedIDs:       []int64{10, 1, 2},
+		},
+		{
+			name: "異常系: アトミック実行で削除対象が存在しない場合は全体が中断される",
+			input: BatchInput{
+				Atomic: true,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: validCreate},
+					{Op: BatchOperationDelete, ID: 999},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.Item")).Return([]*entity.Item{existingItem(10)}, nil).Once()
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{999}).Return(&domainErrors.BatchItemError{Index: 0, Err: domainErrors.ErrItemNotFound}).Once()
+			},
+			expectedCommitted: false,
+			expectedErrs:      []error{domainErrors.ErrBatchAborted, domainErrors.ErrItemNotFound},
+			expectedIDs:       []int64{0, 999},
+		},
+		{
+			name: "異常系: アトミック実行では失敗した作成の操作だけにエラーを返す",
+			input: BatchInput{
+				Atomic: true,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: validCreate},
+					{Op: BatchOperationCreate, Create: &CreateItemInput{Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-01", SerialNumber: "Z123456"}},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.Item")).
+					Return(nil, &domainErrors.BatchItemError{Index: 1, Err: domainErrors.ErrDuplicateEntry}).Once()
+			},
+			expectedCommitted: false,
+			expectedErrs:      []error{domainErrors.ErrBatchAborted, domainErrors.ErrDuplicateEntry},
+			expectedIDs:       []int64{0, 0},
+		},
+		{
+			name: "異常系: アトミック実行では存在しない削除対象の操作だけにエラーを返す",
+			input: BatchInput{
+				Atomic: true,
+				Operations: []BatchOperation{
+					{Op: BatchOperationDelete, ID: 2},
+					{Op: BatchOperationDelete, ID: 999},
+					{Op: BatchOperationDelete, ID: 3},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{2, 999, 3}).
+					Return(&domainErrors.BatchItemError{Index: 1, Err: domainErrors.ErrItemNotFound}).Once()
+			},
+			expectedCommitted: false,
+			expectedErrs:      []error{domainErrors.ErrBatchAborted, domainErrors.ErrItemNotFound, domainErrors.ErrBatchAborted},
+			expectedIDs:       []int64{2, 999, 3},
+		},
+		{
+			name: "異常系: アトミック実行で検証エラーがある場合はリポジトリを呼ばない",
+			input: BatchInput{
+				Atomic: true,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: &CreateItemInput{Name: "アイテム", Category: "無効なカテゴリー", Brand: "ブランド", PurchaseDate: "2023-01-15"}},
+					{Op: BatchOperationDelete, ID: 2},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// 何も呼ばれない
+			},
+			expectedCommitted: false,
+			expectedErrs:      []error{domainErrors.ErrInvalidInput, domainErrors.ErrBatchAborted},
+			expectedIDs:       []int64{0, 2},
+		},
+		{
+			name: "正常系: 非アトミック実行では操作ごとに結果を返す",
+			input: BatchInput{
+				Atomic: false,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: &CreateItemInput{Name: "", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"}},
+					{Op: BatchOperationCreate, Create: validCreate},
+					{Op: BatchOperationUpdate, ID: 999, Update: &UpdateItemInput{Name: strPtr("新しい名前")}},
+					{Op: BatchOperationDelete, ID: 2},
+					{Op: BatchOperationDelete, ID: 3},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(existingItem(10), nil).Once()
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound).Once()
+				mockRepo.On("FindByIDs", mock.Anything, []int64{2, 3}).Return([]*entity.Item{existingItem(3)}, nil).Once()
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{3}).Return(nil).Once()
+			},
+			expectedCommitted: true,
+			expectedErrs:      []error{domainErrors.ErrInvalidInput, nil, domainErrors.ErrItemNotFound, domainErrors.ErrItemNotFound, nil},
+			expectedIDs:       []int64{0, 10, 999, 2, 3},
+		},
+		{
+			name: "正常系: 非アトミック実行では作成の失敗が他の作成に影響しない",
+			input: BatchInput{
+				Atomic: false,
+				Operations: []BatchOperation{
+					{Op: BatchOperationCreate, Create: validCreate},
+					{Op: BatchOperationCreate, Create: &CreateItemInput{Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-01", SerialNumber: "Z123456"}},
+					{Op: BatchOperationCreate, Create: validCreate},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// 既存アイテムとシリアル番号が重複する作成だけが失敗する
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.SerialNumber == ""
+				})).Return(existingItem(10), nil).Once()
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.SerialNumber == "Z123456"
+				})).Return(nil, domainErrors.ErrDuplicateEntry).Once()
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.SerialNumber == ""
+				})).Return(existingItem(11), nil).Once()
+			},
+			expectedCommitted: true,
+			expectedErrs:      []error{nil, domainErrors.ErrDuplicateEntry, nil},
+			expectedIDs:       []int64{10, 0, 11},
+		},
+		{
+			name: "正常系: 非アトミック実行ではErrを設定した操作だけを実行しない",
+			input: BatchInput{
+				Atomic: false,
+				Operations: []BatchOperation{
+					{Op: BatchOperationDelete, ID: 2},
+					{Op: BatchOperationCreate, Err: domainErrors.ErrInvalidInput},
+					{Op: BatchOperationDelete, ID: 3},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// 間の操作は実行しないため、削除は1つのステップにまとまる
+				mockRepo.On("FindByIDs", mock.Anything, []int64{2, 3}).Return([]*entity.Item{existingItem(2), existingItem(3)}, nil).Once()
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{2, 3}).Return(nil).Once()
+			},
+			expectedCommitted: true,
+			expectedErrs:      []error{nil, domainErrors.ErrInvalidInput, nil},
+			expectedIDs:       []int64{2, 0, 3},
+		},
+		{
+			name: "異常系: 同じIDを複数回削除",
+			input: BatchInput{
+				Atomic: false,
+				Operations: []BatchOperation{
+					{Op: BatchOperationDelete, ID: 2},
+					{Op: BatchOperationDelete, ID: 2},
+				},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDs", mock.Anything, []int64{2}).Return([]*entity.Item{existingItem(2)}, nil).Once()
+				mockRepo.On("DeleteBatch", mock.Anything, []int64{2}).Return(nil).Once()
+			},
+			expectedCommitted: true,
+			expectedErrs:      []error{nil, domainErrors.ErrInvalidInput},
+			expectedIDs:       []int64{2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			ctx := context.Background()
+			result, err := usecase.ExecuteBatch(ctx, tt.input)
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.input.Atomic, result.Atomic)
+			assert.Equal(t, tt.expectedCommitted, result.Committed)
+			require.Len(t, result.Results, len(tt.expectedErrs))
+
+			for i, expectedErr := range tt.expectedErrs {
+				assert.Equal(t, i, result.Results[i].Index)
+				assert.Equal(t, tt.expectedIDs[i], result.Results[i].ID)
+				if expectedErr == nil {
+					assert.NoError(t, result.Results[i].Err)
+				} else {
+					assert.ErrorIs(t, result.Results[i].Err, expectedErr)
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_ExecuteBatch_UnknownFailedOperation(t *testing.T) {
+	// 失敗した行を特定できないエラーは、特定の操作のせいにせずバッチ全体のエラーとして返す
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("DeleteBatch", mock.Anything, []int64{2, 3}).Return(domainErrors.ErrDatabaseError).Once()
+	usecase := NewItemUsecase(mockRepo)
+
+	result, err := usecase.ExecuteBatch(context.Background(), BatchInput{
+		Atomic: true,
+		Operations: []BatchOperation{
+			{Op: BatchOperationDelete, ID: 2},
+			{Op: BatchOperationDelete, ID: 3},
+		},
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_ExecuteBatch_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input BatchInput
+	}{
+		{
+			name:  "異常系: 操作が空",
+			input: BatchInput{},
+		},
+		{
+			name:  "異常系: 操作数が上限を超過",
+			input: BatchInput{Operations: make([]BatchOperation, MaxBatchOperations+1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.ExecuteBatch(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.Nil(t, result)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// BookValueTotals is the purchase price and book value totals of items held on the report date.
+// Gain is negative when the book value is below the purchase price.
+type BookValueTotals struct {
+	ItemCount      int   `json:"item_count"`
+	PurchaseTotal  int64 `json:"purchase_total"`
+	BookValueTotal int64 `json:"book_value_total"`
+	Gain           int64 `json:"gain"`
+}
+
+// CategoryBookValue is the book value totals of a category and the model used to compute them.
+// Model is nil when the category is carried at its purchase price.
+type CategoryBookValue struct {
+	BookValueTotals
+	Model *entity.DepreciationModel `json:"model"`
+}
+
+// BookValueReport is the book value of items held on AsOf, overall and by category.
+type BookValueReport struct {
+	AsOf string `json:"as_of"`
+	BookValueTotals
+	Categories map[string]*CategoryBookValue `json:"categories"`
+}
+
+// IncludeBookValueはアイテムに今日時点の帳簿価額を設定する（売却済み・紛失のアイテムは設定しない）
+func (u *itemUsecase) IncludeBookValue(items []*entity.Item) {
+	now := u.now()
+	for _, item := range items {
+		item.SetBookValue(u.depreciationModels, now)
+	}
+}
+
+// GetBookValueReportは指定日時点で所有しているアイテムの帳簿価額をカテゴリーごとに集計する。
+// asOfが空の場合は今日時点で集計する
+func (u *itemUsecase) GetBookValueReport(ctx context.Context, asOf string) (*BookValueReport, error) {
+	date := u.now()
+	if asOf = strings.TrimSpace(asOf); asOf != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", asOf, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: as_of must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+		}
+		date = parsed
+	}
+
+	items, err := u.itemRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	report := &BookValueReport{
+		AsOf:       date.Format("2006-01-02"),
+		Categories: make(map[string]*CategoryBookValue, len(entity.GetValidCategories())),
+	}
+	for _, category := range entity.GetValidCategories() {
+		totals := &CategoryBookValue{}
+		if model, ok := u.depreciationModels[category]; ok {
+			totals.Model = &model
+		}
+		report.Categories[category] = totals
+	}
+
+	for _, item := range items {
+		value, held := u.depreciationModels.BookValue(item, date)
+		if !held {
+			continue
+		}
+
+		totals, ok := report.Categories[item.Category]
+		if !ok {
+			continue
+		}
+		totals.ItemCount++
+		totals.PurchaseTotal += int64(item.PurchasePrice)
+		totals.BookValueTotal += value
+	}
+
+	for _, totals := range report.Categories {
+		totals.Gain = totals.BookValueTotal - totals.PurchaseTotal
+		report.ItemCount += totals.ItemCount
+		report.PurchaseTotal += totals.PurchaseTotal
+		report.BookValueTotal += totals.BookValueTotal
+	}
+	report.Gain = report.BookValueTotal - report.PurchaseTotal
+
+	return report, nil
+}
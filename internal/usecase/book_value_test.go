@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 2024-01-01 を現在日時としたユースケースを作成する
+func newTestBookValueUsecase(itemRepo ItemRepository) *itemUsecase {
+	usecase := NewItemUsecase(itemRepo, WithDepreciationModels(entity.DepreciationModels{
+		"時計":  {Method: entity.DepreciationAppreciation, Rate: 0.03},
+		"バッグ": {Method: entity.DepreciationDecliningBalance, Rate: 0.2},
+	})).(*itemUsecase)
+	usecase.now = func() time.Time {
+		return time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	}
+	return usecase
+}
+
+func TestItemUsecase_GetBookValueReport(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: entity.ItemStatusOwned},
+		{ID: 2, Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: entity.ItemStatusOwned},
+		// 計算方法のないカテゴリーは購入価格
+		{ID: 3, Category: "靴", PurchasePrice: 50000, PurchaseDate: "2023-06-01", Status: entity.ItemStatusOwned},
+		// 2023-01-01 以降に購入
+		{ID: 4, Category: "バッグ", PurchasePrice: 300000, PurchaseDate: "2023-03-01", Status: entity.ItemStatusOwned},
+		// 2023-06-30 に売却
+		{ID: 5, Category: "時計", PurchasePrice: 500000, PurchaseDate: "2022-01-01", Status: entity.ItemStatusSold, SaleDate: "2023-06-30"},
+	}
+
+	tests := []struct {
+		name                string
+		asOf                string
+		expectedAsOf        string
+		expectedCount       int
+		expectedBookValue   int64
+		expectedWatchValue  int64
+		expectedWatchCount  int
+		expectedBagCount    int
+		expectedShoeValue   int64
+		expectedPurchaseSum int64
+		expectedErr         error
+	}{
+		{
+			name:                "正常系: 未指定は今日時点",
+			expectedAsOf:        "2024-01-01",
+			expectedCount:       4,
+			expectedWatchCount:  1,
+			expectedWatchValue:  1060900,
+			expectedBagCount:    2,
+			expectedShoeValue:   50000,
+			expectedBookValue:   1060900 + 640000 + 50000 + 248815,
+			expectedPurchaseSum: 2350000,
+		},
+		{
+			name:                "正常系: 過去の日付時点（その後に購入・売却したアイテム）",
+			asOf:                "2023-01-01",
+			expectedAsOf:        "2023-01-01",
+			expectedCount:       3,
+			expectedWatchCount:  2,
+			expectedWatchValue:  1030000 + 515000,
+			expectedBagCount:    1,
+			expectedBookValue:   1030000 + 515000 + 800000,
+			expectedPurchaseSum: 2500000,
+		},
+		{
+			name:        "異常系: 日付の形式が不正",
+			asOf:        "2024/01/01",
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedErr == nil {
+				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+			}
+			usecase := newTestBookValueUsecase(mockRepo)
+
+			report, err := usecase.GetBookValueReport(context.Background(), tt.asOf)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+				mockRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAsOf, report.AsOf)
+			assert.Equal(t, tt.expectedCount, report.ItemCount)
+			assert.Equal(t, tt.expectedPurchaseSum, report.PurchaseTotal)
+			assert.Equal(t, tt.expectedBookValue, report.BookValueTotal)
+			assert.Equal(t, tt.expectedBookValue-tt.expectedPurchaseSum, report.Gain)
+			assert.Equal(t, tt.expectedWatchCount, report.Categories["時計"].ItemCount)
+			assert.Equal(t, tt.expectedWatchValue, report.Categories["時計"].BookValueTotal)
+			assert.Equal(t, entity.DepreciationAppreciation, report.Categories["時計"].Model.Method)
+			assert.Equal(t, tt.expectedBagCount, report.Categories["バッグ"].ItemCount)
+			assert.Equal(t, tt.expectedShoeValue, report.Categories["靴"].BookValueTotal)
+			assert.Nil(t, report.Categories["靴"].Model)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_IncludeBookValue(t *testing.T) {
+	owned := &entity.Item{ID: 1, Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: entity.ItemStatusOwned}
+	sold := &entity.Item{ID: 2, Category: "バッグ", PurchasePrice: 1000000, PurchaseDate: "2022-01-01", Status: entity.ItemStatusSold, SaleDate: "2023-01-01"}
+	usecase := newTestBookValueUsecase(new(MockItemRepository))
+
+	usecase.IncludeBookValue([]*entity.Item{owned, sold})
+
+	require.NotNil(t, owned.BookValue)
+	assert.Equal(t, int64(640000), *owned.BookValue)
+	assert.Nil(t, sold.BookValue)
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 重複候補の判定に使う重み（合計1.0）
+const (
+	duplicateWeightName          = 0.5
+	duplicateWeightBrand         = 0.2
+	duplicateWeightPurchaseDate  = 0.15
+	duplicateWeightPurchasePrice = 0.15
+)
+
+const (
+	DefaultDuplicateMinScore = 0.6
+	DefaultDuplicateLimit    = 50
+	MaxDuplicateLimit        = 200
+)
+
+// 類似していると判定する類似度の下限
+const (
+	duplicateSimilarNameThreshold  = 0.6
+	duplicateSimilarBrandThreshold = 0.8
+)
+
+// 重複候補と判定した理由
+const (
+	DuplicateReasonSimilarName       = "similar_name"
+	DuplicateReasonSameBrand         = "same_brand"
+	DuplicateReasonSimilarBrand      = "similar_brand"
+	DuplicateReasonSamePurchaseDate  = "same_purchase_date"
+	DuplicateReasonSamePurchasePrice = "same_purchase_price"
+)
+
+// DuplicateQuery is the condition for finding duplicate candidates.
+// Zero values fall back to the defaults.
+type DuplicateQuery struct {
+	MinScore float64
+	Limit    int
+	Category string
+}
+
+// DuplicateCandidate is a pair of items that are likely to be the same item.
+type DuplicateCandidate struct {
+	Items   []*entity.Item `json:"items"`
+	Score   float64        `json:"score"`
+	Reasons []string       `json:"reasons"`
+}
+
+// MergeItemsInput is the input for merging a duplicate into a survivor.
+type MergeItemsInput struct {
+	DuplicateID int64 `json:"duplicate_id"`
+}
+
+// FindDuplicateCandidatesは名前・ブランドの類似度と購入日・価格の一致からスコアを計算し、重複候補をスコアの高い順に返す
+func (u *itemUsecase) FindDuplicateCandidates(ctx context.Context, query DuplicateQuery) ([]*DuplicateCandidate, error) {
+	if query.MinScore == 0 {
+		query.MinScore = DefaultDuplicateMinScore
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultDuplicateLimit
+	}
+	if query.MinScore < 0 || query.MinScore > 1 {
+		return nil, fmt.Errorf("%w: min_score must be between 0 and 1", domainErrors.ErrInvalidInput)
+	}
+	if query.Limit < 0 || query.Limit > MaxDuplicateLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxDuplicateLimit)
+	}
+
+	filter, err := normalizeItemFilter(ItemFilter{Category: query.Category})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	// 正規化は1アイテムにつき1回だけ行う
+	type matchKey struct {
+		name  string
+		brand string
+	}
+	keys := make([]matchKey, len(items))
+	for i, item := range items {
+		keys[i] = matchKey{
+			name:  normalizeItemName(item.Name, item.Brand),
+			brand: canonicalBrand(item.Brand),
+		}
+	}
+
+	candidates := []*DuplicateCandidate{}
+	for i := 0; i < len(items); i++ {
+		for j := i + 1; j < len(items); j++ {
+			a, b := items[i], items[j]
+			// シリアル番号が異なるものは別の個体
+			if a.SerialNumber != "" && b.SerialNumber != "" && a.SerialNumber != b.SerialNumber {
+				continue
+			}
+
+			var score float64
+			var reasons []string
+
+			nameScore := similarity(keys[i].name, keys[j].name)
+			score += nameScore * duplicateWeightName
+			if nameScore >= duplicateSimilarNameThreshold {
+				reasons = append(reasons, DuplicateReasonSimilarName)
+			}
+
+			brandScore := similarity(keys[i].brand, keys[j].brand)
+			score += brandScore * duplicateWeightBrand
+			switch {
+			case brandScore == 1:
+				reasons = append(reasons, DuplicateReasonSameBrand)
+			case brandScore >= duplicateSimilarBrandThreshold:
+				reasons = append(reasons, DuplicateReasonSimilarBrand)
+			}
+
+			if a.PurchaseDate == b.PurchaseDate {
+				score += duplicateWeightPurchaseDate
+				reasons = append(reasons, DuplicateReasonSamePurchaseDate)
+			}
+			if a.PurchasePrice == b.PurchasePrice {
+				score += duplicateWeightPurchasePrice
+				reasons = append(reasons, DuplicateReasonSamePurchasePrice)
+			}
+
+			if score < query.MinScore {
+				continue
+			}
+
+			pair := []*entity.Item{a, b}
+			if b.ID < a.ID {
+				pair = []*entity.Item{b, a}
+			}
+			candidates = append(candidates, &DuplicateCandidate{
+				Items:   pair,
+				Score:   float64(int(score*1000+0.5)) / 1000,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].Items[0].ID != candidates[j].Items[0].ID {
+			return candidates[i].Items[0].ID < candidates[j].Items[0].ID
+		}
+		return candidates[i].Items[1].ID < candidates[j].Items[1].ID
+	})
+
+	if len(candidates) > query.Limit {
+		candidates = candidates[:query.Limit]
+	}
+
+	return candidates, nil
+}
+
+// MergeItemsは重複アイテムを残すアイテムに統合する。
+// タグと統合履歴は残すアイテムに引き継ぎ、残すアイテムで未設定の項目・属性は重複アイテムの値で補完してから重複アイテムを削除する
+func (u *itemUsecase) MergeItems(ctx context.Context, survivorID int64, input MergeItemsInput) (*entity.Item, error) {
+	if survivorID <= 0 || input.DuplicateID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if survivorID == input.DuplicateID {
+		return nil, fmt.Errorf("%w: an item cannot be merged into itself", domainErrors.ErrInvalidInput)
+	}
+
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		// 統合中に貸し出されたり状態を変更されたりしないよう、両方の行をロックする
+		survivor, err := u.itemRepo.FindByIDForUpdate(ctx, survivorID)
+		if err != nil {
+			return err
+		}
+		duplicate, err := u.itemRepo.FindByIDForUpdate(ctx, input.DuplicateID)
+		if err != nil {
+			return err
+		}
+		// 貸出記録は統合先に移るが、統合先の状態は変えないため、貸出中のアイテムは返却してから統合する
+		if duplicate.Status == entity.ItemStatusLent {
+			return fmt.Errorf("%w: item %d is on loan and must be returned before it is merged", domainErrors.ErrConflict, duplicate.ID)
+		}
+		// 売却価格・売却日は統合先に引き継げず、売却済みの記録が失われるため統合しない
+		if duplicate.Status == entity.ItemStatusSold {
+			return fmt.Errorf("%w: item %d has been sold and cannot be merged", domainErrors.ErrConflict, duplicate.ID)
+		}
+
+		// シリアル番号の一意制約に触れないよう、先に重複アイテムを削除してから補完する
+		if err := u.itemRepo.MergeInto(ctx, survivor.ID, duplicate); err != nil {
+			return err
+		}
+		if err := u.recordDeletedEvents(ctx, duplicate.ID); err != nil {
+			return err
+		}
+		// MergeIntoが引き継いだ保険契約をイベントの内容にも反映する
+		if survivor.InsurancePolicyID == nil {
+			survivor.InsurancePolicyID = duplicate.InsurancePolicyID
+		}
+
+		if err := survivor.Apply(mergeFillPatch(survivor, duplicate)); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		if !survivor.HasChanges() {
+			// 項目が変わらなくてもタグは引き継がれている
+			return u.recordItemEvents(ctx, entity.EventItemUpdated, survivor)
+		}
+		_, err = u.saveItem(ctx, survivor)
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsValidationError(err) || domainErrors.IsDuplicateError(err) || domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to merge items: %w", err)
+	}
+
+	return u.GetItemByID(ctx, survivorID)
+}
+
+// GetMergeHistoryはアイテムに統合されたアイテムの履歴を新しい順で返す
+func (u *itemUsecase) GetMergeHistory(ctx context.Context, id int64) ([]*entity.ItemMerge, error) {
+	if _, err := u.GetItemByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	merges, err := u.itemRepo.FindMergeHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve merge history: %w", err)
+	}
+
+	return merges, nil
+}
+
+// 残すアイテムで未設定の項目を重複アイテムの値で補完するパッチを作る
+func mergeFillPatch(survivor, duplicate *entity.Item) entity.ItemPatch {
+	var patch entity.ItemPatch
+
+	if survivor.SerialNumber == "" && duplicate.SerialNumber != "" {
+		patch.SerialNumber = &duplicate.SerialNumber
+	}
+	if survivor.ModelNumber == "" && duplicate.ModelNumber != "" {
+		patch.ModelNumber = &duplicate.ModelNumber
+	}
+	if survivor.Condition == "" && duplicate.Condition != "" {
+		patch.Condition = &duplicate.Condition
+	}
+	if (survivor.Authenticity == "" || survivor.Authenticity == entity.AuthenticityUnverified) &&
+		duplicate.Authenticity != "" && duplicate.Authenticity != entity.AuthenticityUnverified {
+		patch.Authenticity = &duplicate.Authenticity
+	}
+	if survivor.WarrantyExpiresAt == "" && duplicate.WarrantyExpiresAt != "" {
+		patch.WarrantyExpiresAt = &duplicate.WarrantyExpiresAt
+	}
+
+	// 残すアイテムのカテゴリーで定義されている属性だけを引き継ぐ
+	for key, value := range duplicate.Attributes {
+		if _, exists := survivor.Attributes[key]; exists {
+			continue
+		}
+		if _, ok := entity.LookupAttribute(survivor.Category, key); !ok {
+			continue
+		}
+		if patch.Attributes == nil {
+			patch.Attributes = make(map[string]interface{})
+		}
+		patch.Attributes[key] = value
+	}
+
+	return patch
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_FindDuplicateCandidates(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+		{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"},
+		{ID: 3, Name: "ROLEX Daytona", Category: "時計", Brand: "ロレックス", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+		{ID: 4, Name: "Birkin", Category: "バッグ", Brand: "HERMES", PurchasePrice: 2000000, PurchaseDate: "2023-02-20", SerialNumber: "A1"},
+		{ID: 5, Name: "バーキン", Category: "バッグ", Brand: "エルメス", PurchasePrice: 2000000, PurchaseDate: "2023-02-20", SerialNumber: "B2"},
+	}
+
+	tests := []struct {
+		name          string
+		query         DuplicateQuery
+		expectedPairs [][2]int64
+		expectedErr   error
+	}{
+		{
+			name:  "正常系: 表記の異なる同じアイテムを検出する",
+			query: DuplicateQuery{},
+			// 4と5はシリアル番号が異なるため別の個体として扱う
+			expectedPairs: [][2]int64{{1, 3}, {2, 4}, {2, 5}},
+		},
+		{
+			name:  "正常系: 件数の上限（スコアの高い順）",
+			query: DuplicateQuery{Limit: 1},
+			// 2と5はブランドを除いた名前が一致する
+			expectedPairs: [][2]int64{{2, 5}},
+		},
+		{
+			name:        "異常系: min_scoreが範囲外",
+			query:       DuplicateQuery{MinScore: 1.5},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedErr == nil {
+				mockRepo.On("FindByFilter", mock.Anything, ItemFilter{}).Return(items, nil)
+			}
+			usecase := NewItemUsecase(mockRepo)
+
+			candidates, err := usecase.FindDuplicateCandidates(context.Background(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, candidates)
+				return
+			}
+
+			require.NoError(t, err)
+			pairs := make([][2]int64, len(candidates))
+			for i, c := range candidates {
+				pairs[i] = [2]int64{c.Items[0].ID, c.Items[1].ID}
+				assert.GreaterOrEqual(t, c.Score, DefaultDuplicateMinScore)
+				assert.Contains(t, c.Reasons, DuplicateReasonSameBrand)
+			}
+			assert.ElementsMatch(t, tt.expectedPairs, pairs)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_MergeItems(t *testing.T) {
+	newSurvivor := func() *entity.Item {
+		return &entity.Item{
+			ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+			PurchaseDate: "2023-01-15", Authenticity: entity.AuthenticityUnverified,
+			Attributes: map[string]interface{}{"movement": "自動巻き"},
+		}
+	}
+	duplicate := &entity.Item{
+		ID: 3, Name: "ROLEX Daytona", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+		PurchaseDate: "2023-01-15", SerialNumber: "Z123456", Authenticity: entity.AuthenticityAuthentic,
+		Attributes: map[string]interface{}{"movement": "手巻き", "case_size_mm": 40.0, "dimensions": "30x20x10"},
+	}
+
+	tests := []struct {
+		name        string
+		survivorID  int64
+		input       MergeItemsInput
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:       "正常系: 未設定の項目と属性を補完して統合する",
+			survivorID: 1,
+			input:      MergeItemsInput{DuplicateID: 3},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(3)).Return(duplicate, nil)
+				mockRepo.On("MergeInto", mock.Anything, int64(1), duplicate).Return(nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					// 残すアイテムの値は上書きせず、カテゴリーにない属性は引き継がない
+					return item.SerialNumber == "Z123456" &&
+						item.Authenticity == entity.AuthenticityAuthentic &&
+						assert.ObjectsAreEqual(map[string]interface{}{"movement": "自動巻き", "case_size_mm": 40.0}, item.Attributes)
+				})).Return(newSurvivor(), nil)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+			},
+		},
+		{
+			name:       "正常系: 統合先が保険に入っていなければ重複アイテムの保険契約を引き継ぐ",
+			survivorID: 1,
+			input:      MergeItemsInput{DuplicateID: 3},
+			setupMock: func(mockRepo *MockItemRepository) {
+				policyID := int64(7)
+				insured := *duplicate
+				insured.InsurancePolicyID = &policyID
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(3)).Return(&insured, nil)
+				mockRepo.On("MergeInto", mock.Anything, int64(1), &insured).Return(nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.InsurancePolicyID != nil && *item.InsurancePolicyID == 7
+				})).Return(newSurvivor(), nil)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+			},
+		},
+		{
+			name:       "異常系: 貸出中の重複アイテム",
+			survivorID: 1,
+			input:      MergeItemsInput{DuplicateID: 3},
+			setupMock: func(mockRepo *MockItemRepository) {
+				lent := *duplicate
+				lent.Status = entity.ItemStatusLent
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(3)).Return(&lent, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:       "異常系: 売却済みの重複アイテム",
+			survivorID: 1,
+			input:      MergeItemsInput{DuplicateID: 3},
+			setupMock: func(mockRepo *MockItemRepository) {
+				salePrice := 1800000
+				sold := *duplicate
+				sold.Status = entity.ItemStatusSold
+				sold.SalePrice = &salePrice
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(3)).Return(&sold, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: 自分自身への統合",
+			survivorID:  1,
+			input:       MergeItemsInput{DuplicateID: 1},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:       "異常系: 重複アイテムが存在しない",
+			survivorID: 1,
+			input:      MergeItemsInput{DuplicateID: 999},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(newSurvivor(), nil)
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			item, err := usecase.MergeItems(context.Background(), tt.survivorID, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				mockRepo.AssertNotCalled(t, "MergeInto", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.survivorID, item.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
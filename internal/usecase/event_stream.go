@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// EventBus delivers committed item events to in-process subscribers such as SSE streams.
+// Implementations live in the infrastructure layer.
+type EventBus interface {
+	// Publish delivers the event to every current subscriber of the tenant of the event without blocking
+	Publish(event *entity.OutboxEvent)
+
+	// Subscribe returns a channel receiving the events of the tenant published after the call and a function
+	// to unsubscribe. The channel is closed when the subscriber falls behind, unsubscribes or the bus is closed.
+	Subscribe(tenantID int64) (<-chan *entity.OutboxEvent, func())
+}
+
+type EventStreamUsecase interface {
+	// Subscribe starts a stream of item events. When lastEventID is positive,
+	// the events recorded after it are replayed from the change log first.
+	Subscribe(ctx context.Context, lastEventID int64) (*EventStream, error)
+}
+
+// EventStream is a subscription to item events.
+// Events may repeat events already in Replay; skip events whose ID appeared in Replay.
+// Events are not ordered by ID, because IDs are assigned when they are recorded and events are published on commit.
+// For the same reason, an event recorded before lastEventID but committed after the client received lastEventID
+// is not replayed; it only reaches clients that were connected when it was published.
+type EventStream struct {
+	Replay []*entity.OutboxEvent
+	// Truncated reports that more events than maxReplayedEvents were recorded after lastEventID.
+	// Nothing is replayed then and the client must reload the items instead.
+	Truncated bool
+	Events    <-chan *entity.OutboxEvent
+	Close     func()
+}
+
+const (
+	// 変更履歴を1回の問い合わせで読み込むイベント数
+	replayPageSize = 500
+	// 再接続時に再送するイベントの上限。超える場合は再送せずクライアントに再読み込みさせる
+	maxReplayedEvents = 5000
+)
+
+type eventStreamUsecase struct {
+	bus        EventBus
+	outboxRepo OutboxRepository
+}
+
+func NewEventStreamUsecase(bus EventBus, outboxRepo OutboxRepository) EventStreamUsecase {
+	return &eventStreamUsecase{
+		bus:        bus,
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (u *eventStreamUsecase) Subscribe(ctx context.Context, lastEventID int64) (*EventStream, error) {
+	if lastEventID < 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	tenantID, ok := TenantIDFromContext(ctx)
+	if !ok {
+		return nil, domainErrors.ErrTenantRequired
+	}
+
+	// 再送するイベントの取得中に発行されたイベントを取りこぼさないよう、先に購読する
+	events, unsubscribe := u.bus.Subscribe(tenantID)
+	stream := &EventStream{Events: events, Close: unsubscribe}
+
+	if lastEventID == 0 || u.outboxRepo == nil {
+		return stream, nil
+	}
+
+	replay, err := u.findReplay(ctx, lastEventID)
+	if err != nil {
+		unsubscribe()
+		return nil, fmt.Errorf("failed to retrieve item events: %w", err)
+	}
+	if len(replay) > maxReplayedEvents {
+		stream.Truncated = true
+		return stream, nil
+	}
+	stream.Replay = replay
+
+	return stream, nil
+}
+
+// lastEventIDより後のイベントを変更履歴から順に読み込む。上限を超えたかわかるよう、超えた時点で読み込みをやめる
+func (u *eventStreamUsecase) findReplay(ctx context.Context, lastEventID int64) ([]*entity.OutboxEvent, error) {
+	var replay []*entity.OutboxEvent
+	afterID := lastEventID
+	for len(replay) <= maxReplayedEvents {
+		page, err := u.outboxRepo.FindAfter(ctx, afterID, replayPageSize)
+		if err != nil {
+			return nil, err
+		}
+		replay = append(replay, page...)
+		if len(page) < replayPageSize {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+	return replay, nil
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeEventBus は発行したイベントを記録する
+type fakeEventBus struct {
+	published    []*entity.OutboxEvent
+	tenantID     int64
+	unsubscribed bool
+}
+
+func (b *fakeEventBus) Publish(event *entity.OutboxEvent) {
+	b.published = append(b.published, event)
+}
+
+func (b *fakeEventBus) Subscribe(tenantID int64) (<-chan *entity.OutboxEvent, func()) {
+	b.tenantID = tenantID
+	return make(chan *entity.OutboxEvent), func() { b.unsubscribed = true }
+}
+
+// outboxEvents はfromからcount件の連続したIDのイベントを返す
+func outboxEvents(from int64, count int) []*entity.OutboxEvent {
+	events := make([]*entity.OutboxEvent, count)
+	for i := range events {
+		events[i] = &entity.OutboxEvent{ID: from + int64(i), EventType: entity.EventItemUpdated, ItemID: 1}
+	}
+	return events
+}
+
+func TestEventStreamUsecase_Subscribe(t *testing.T) {
+	replay := []*entity.OutboxEvent{
+		{ID: 11, EventType: entity.EventItemUpdated, ItemID: 1},
+		{ID: 12, EventType: entity.EventItemDeleted, ItemID: 2},
+	}
+	firstPage := outboxEvents(11, replayPageSize)
+	secondPage := outboxEvents(11+replayPageSize, 3)
+
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		lastEventID      int64
+		setupMock        func(*MockOutboxRepository)
+		expectedReplay   []*entity.OutboxEvent
+		wantTruncated    bool
+		expectedErr      error
+		wantErr          bool
+		wantUnsubscribed bool
+	}{
+		{
+			name:        "正常系: Last-Event-ID以降のイベントを再送する",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: 10,
+			setupMock: func(outboxRepo *MockOutboxRepository) {
+				outboxRepo.On("FindAfter", mock.Anything, int64(10), replayPageSize).Return(replay, nil)
+			},
+			expectedReplay: replay,
+		},
+		{
+			name:        "正常系: 1回で読み込みきれない変更履歴は続きから読み込む",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: 10,
+			setupMock: func(outboxRepo *MockOutboxRepository) {
+				outboxRepo.On("FindAfter", mock.Anything, int64(10), replayPageSize).Return(firstPage, nil).Once()
+				outboxRepo.On("FindAfter", mock.Anything, int64(10+replayPageSize), replayPageSize).Return(secondPage, nil).Once()
+			},
+			expectedReplay: append(append([]*entity.OutboxEvent{}, firstPage...), secondPage...),
+		},
+		{
+			name:        "正常系: 上限を超える変更があった場合は再送せず読み込み直させる",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: 10,
+			setupMock: func(outboxRepo *MockOutboxRepository) {
+				afterID := int64(10)
+				for n := 0; n <= maxReplayedEvents/replayPageSize; n++ {
+					outboxRepo.On("FindAfter", mock.Anything, afterID, replayPageSize).Return(outboxEvents(afterID+1, replayPageSize), nil).Once()
+					afterID += replayPageSize
+				}
+			},
+			wantTruncated: true,
+		},
+		{
+			name:        "正常系: Last-Event-IDがない場合は再送しない",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: 0,
+			setupMock:   func(outboxRepo *MockOutboxRepository) {},
+		},
+		{
+			name:        "異常系: 負のLast-Event-ID",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: -1,
+			setupMock:   func(outboxRepo *MockOutboxRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+			wantErr:     true,
+		},
+		{
+			name:        "異常系: テナントがない",
+			ctx:         context.Background(),
+			lastEventID: 10,
+			setupMock:   func(outboxRepo *MockOutboxRepository) {},
+			expectedErr: domainErrors.ErrTenantRequired,
+			wantErr:     true,
+		},
+		{
+			name:        "異常系: 変更履歴の取得に失敗した場合は購読を解除する",
+			ctx:         WithTenant(context.Background(), 2),
+			lastEventID: 10,
+			setupMock: func(outboxRepo *MockOutboxRepository) {
+				outboxRepo.On("FindAfter", mock.Anything, int64(10), replayPageSize).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr:      domainErrors.ErrDatabaseError,
+			wantErr:          true,
+			wantUnsubscribed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := &fakeEventBus{}
+			outboxRepo := new(MockOutboxRepository)
+			tt.setupMock(outboxRepo)
+			usecase := NewEventStreamUsecase(bus, outboxRepo)
+
+			stream, err := usecase.Subscribe(tt.ctx, tt.lastEventID)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, stream)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedReplay, stream.Replay)
+				assert.Equal(t, tt.wantTruncated, stream.Truncated)
+				assert.NotNil(t, stream.Events)
+				assert.Equal(t, int64(2), bus.tenantID)
+			}
+			assert.Equal(t, tt.wantUnsubscribed, bus.unsubscribed)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_PublishesItemEvents(t *testing.T) {
+	t.Run("正常系: 作成・更新・削除のコミット後にイベントを発行する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		bus := &fakeEventBus{}
+		usecase := NewItemUsecase(itemRepo, WithEventBus(bus))
+
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Return(&entity.Item{ID: 5, Name: "ロレックス", Category: "時計"}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(5)).
+			Return(&entity.Item{ID: 5, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}, nil)
+		itemRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Return(&entity.Item{ID: 5, Name: "ロレックス デイトナ", Category: "時計"}, nil)
+		itemRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+
+		ctx := WithTenant(context.Background(), 3)
+		_, err := usecase.CreateItem(ctx, CreateItemInput{
+			Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01",
+		})
+		require.NoError(t, err)
+		name := "ロレックス デイトナ"
+		_, err = usecase.UpdateItem(ctx, 5, UpdateItemInput{Name: &name})
+		require.NoError(t, err)
+		require.NoError(t, usecase.DeleteItem(ctx, 5))
+
+		require.Len(t, bus.published, 3)
+		assert.Equal(t, entity.EventItemCreated, bus.published[0].EventType)
+		assert.Equal(t, entity.EventItemUpdated, bus.published[1].EventType)
+		assert.Equal(t, entity.EventItemDeleted, bus.published[2].EventType)
+		assert.Equal(t, int64(5), bus.published[2].ItemID)
+		for _, event := range bus.published {
+			assert.Equal(t, int64(3), event.TenantID)
+		}
+	})
+
+	t.Run("異常系: 変更に失敗した場合は発行しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		bus := &fakeEventBus{}
+		usecase := NewItemUsecase(itemRepo, WithEventBus(bus))
+
+		itemRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Item{ID: 5}, nil)
+		itemRepo.On("Delete", mock.Anything, int64(5)).Return(domainErrors.ErrDatabaseError)
+
+		err := usecase.DeleteItem(context.Background(), 5)
+
+		assert.Error(t, err)
+		assert.Empty(t, bus.published)
+	})
+
+	t.Run("異常系: 外側のトランザクションが失敗した場合は内側の更新イベントも発行しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		loanRepo := new(MockLoanRepository)
+		bus := &fakeEventBus{}
+		usecase := NewItemUsecase(itemRepo, WithLoanRepository(loanRepo), WithEventBus(bus))
+
+		itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+			ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusLent,
+		}, nil)
+		itemRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1, Status: entity.ItemStatusOwned}, nil)
+		loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := usecase.ChangeItemStatus(context.Background(), 1, entity.ItemStatusOwned)
+
+		assert.Error(t, err)
+		assert.Empty(t, bus.published)
+	})
+}
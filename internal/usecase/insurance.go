@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type InsuranceUsecase interface {
+	GetPolicies(ctx context.Context) ([]*PolicyCoverage, error)
+	GetPolicy(ctx context.Context, id int64) (*PolicyCoverage, error)
+	CreatePolicy(ctx context.Context, input PolicyInput) (*entity.InsurancePolicy, error)
+	UpdatePolicy(ctx context.Context, id int64, input PolicyInput) (*entity.InsurancePolicy, error)
+	DeletePolicy(ctx context.Context, id int64) error
+	AssignItem(ctx context.Context, itemID int64, input AssignPolicyInput) (*entity.Item, error)
+	GetCoverageReport(ctx context.Context, query CoverageReportQuery) (*CoverageReport, error)
+}
+
+type PolicyInput struct {
+	Insurer       string `json:"insurer"`
+	PolicyNumber  string `json:"policy_number"`
+	CoverageLimit int64  `json:"coverage_limit"`
+	StartsOn      string `json:"starts_on"`
+	EndsOn        string `json:"ends_on"`
+	Note          string `json:"note"`
+}
+
+// AssignPolicyInput is the policy covering an item. A nil PolicyID removes the item from its policy.
+type AssignPolicyInput struct {
+	PolicyID *int64 `json:"policy_id"`
+}
+
+// InsuredItem is an item with the value used for insurance.
+// Value is the latest valuation, or the purchase price when the item has no valuation.
+type InsuredItem struct {
+	ItemID            int64  `json:"item_id"`
+	Name              string `json:"name"`
+	Category          string `json:"category"`
+	Brand             string `json:"brand"`
+	InsurancePolicyID *int64 `json:"insurance_policy_id"`
+	Value             int64  `json:"value"`
+	ValueSource       string `json:"value_source"` // valuation or purchase_price
+	ValuedAt          string `json:"valued_at,omitempty"`
+}
+
+// 評価額の根拠
+const (
+	ValueSourceValuation     = "valuation"
+	ValueSourcePurchasePrice = "purchase_price"
+)
+
+// PolicyCoverage is an insurance policy with the total value of the items assigned to it.
+// ExcessValue is the amount by which the item values exceed the coverage limit.
+type PolicyCoverage struct {
+	*entity.InsurancePolicy
+	Active        bool           `json:"active"`
+	ItemCount     int            `json:"item_count"`
+	InsuredValue  int64          `json:"insured_value"`
+	ExcessValue   int64          `json:"excess_value"`
+	DaysUntilEnds int            `json:"days_until_ends"`
+	Items         []*InsuredItem `json:"items,omitempty"`
+}
+
+// CoverageReportQuery is the condition of the coverage gap report.
+type CoverageReportQuery struct {
+	// Threshold is the minimum value of uninsured items to report
+	Threshold int64
+	// ExpiringWithinDays is the number of days for policies expiring soon
+	ExpiringWithinDays int
+}
+
+// CoverageReport lists the gaps in insurance coverage as of AsOf.
+type CoverageReport struct {
+	AsOf               string            `json:"as_of"`
+	Threshold          int64             `json:"threshold"`
+	ExpiringWithinDays int               `json:"expiring_within_days"`
+	UninsuredItems     []*InsuredItem    `json:"uninsured_items"`
+	ExceededPolicies   []*PolicyCoverage `json:"exceeded_policies"`
+	ExpiringPolicies   []*PolicyCoverage `json:"expiring_policies"`
+}
+
+// 補償不足レポートの既定値
+const (
+	DefaultUninsuredThreshold int64 = 100000
+	DefaultPolicyExpiringDays       = 30
+	MaxPolicyExpiringDays           = 3650
+)
+
+type insuranceUsecase struct {
+	insuranceRepo InsuranceRepository
+	valuationRepo ValuationRepository
+	itemRepo      ItemRepository
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewInsuranceUsecase(insuranceRepo InsuranceRepository, valuationRepo ValuationRepository, itemRepo ItemRepository) InsuranceUsecase {
+	return &insuranceUsecase{
+		insuranceRepo: insuranceRepo,
+		valuationRepo: valuationRepo,
+		itemRepo:      itemRepo,
+		now:           time.Now,
+	}
+}
+
+// GetPoliciesは保険契約の一覧を割り当てられたアイテムの評価額の合計とともに返す
+func (u *insuranceUsecase) GetPolicies(ctx context.Context) ([]*PolicyCoverage, error) {
+	coverages, _, err := u.coverages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, coverage := range coverages {
+		coverage.Items = nil
+	}
+	return coverages, nil
+}
+
+// GetPolicyは保険契約を割り当てられたアイテムとともに返す
+func (u *insuranceUsecase) GetPolicy(ctx context.Context, id int64) (*PolicyCoverage, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	coverages, _, err := u.coverages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, coverage := range coverages {
+		if coverage.ID == id {
+			return coverage, nil
+		}
+	}
+	return nil, domainErrors.ErrInsurancePolicyNotFound
+}
+
+func (u *insuranceUsecase) CreatePolicy(ctx context.Context, input PolicyInput) (*entity.InsurancePolicy, error) {
+	policy, err := entity.NewInsurancePolicy(input.Insurer, input.PolicyNumber, input.CoverageLimit, input.StartsOn, input.EndsOn, input.Note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.insuranceRepo.Create(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create insurance policy: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *insuranceUsecase) UpdatePolicy(ctx context.Context, id int64, input PolicyInput) (*entity.InsurancePolicy, error) {
+	policy, err := u.findPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := policy.Update(input.Insurer, input.PolicyNumber, input.CoverageLimit, input.StartsOn, input.EndsOn, input.Note); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	updated, err := u.insuranceRepo.Update(ctx, policy)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrInsurancePolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to update insurance policy: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeletePolicyは保険契約を削除する。アイテムが割り当てられている場合は削除できない
+func (u *insuranceUsecase) DeletePolicy(ctx context.Context, id int64) error {
+	if _, err := u.findPolicy(ctx, id); err != nil {
+		return err
+	}
+
+	count, err := u.insuranceRepo.CountItems(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count insured items: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d items are assigned to the insurance policy", domainErrors.ErrConflict, count)
+	}
+
+	if err := u.insuranceRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrInsurancePolicyNotFound
+		}
+		return fmt.Errorf("failed to delete insurance policy: %w", err)
+	}
+
+	return nil
+}
+
+// AssignItemはアイテムを保険契約に割り当てる（PolicyIDがnilの場合は割り当てを解除する）
+func (u *insuranceUsecase) AssignItem(ctx context.Context, itemID int64, input AssignPolicyInput) (*entity.Item, error) {
+	if _, err := findItemByID(ctx, u.itemRepo, itemID); err != nil {
+		return nil, err
+	}
+
+	if input.PolicyID != nil {
+		if _, err := u.insuranceRepo.FindByID(ctx, *input.PolicyID); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, fmt.Errorf("%w: insurance policy %d does not exist", domainErrors.ErrInvalidInput, *input.PolicyID)
+			}
+			return nil, fmt.Errorf("failed to retrieve insurance policy: %w", err)
+		}
+	}
+
+	if err := u.insuranceRepo.AssignItem(ctx, itemID, input.PolicyID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to assign insurance policy: %w", err)
+	}
+
+	return findItemByID(ctx, u.itemRepo, itemID)
+}
+
+// GetCoverageReportは補償不足を報告する。
+// 保険期間内の保険契約に割り当てられていない閾値以上のアイテム、割り当てられたアイテムの評価額が補償限度額を超える保険契約、
+// 指定日数以内に終了する保険契約を返す。売却済み・紛失のアイテムは対象外
+func (u *insuranceUsecase) GetCoverageReport(ctx context.Context, query CoverageReportQuery) (*CoverageReport, error) {
+	if query.Threshold < 0 {
+		return nil, fmt.Errorf("%w: threshold must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if query.ExpiringWithinDays <= 0 || query.ExpiringWithinDays > MaxPolicyExpiringDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", domainErrors.ErrInvalidInput, MaxPolicyExpiringDays)
+	}
+
+	coverages, uninsured, err := u.coverages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CoverageReport{
+		AsOf:               u.now().Format("2006-01-02"),
+		Threshold:          query.Threshold,
+		ExpiringWithinDays: query.ExpiringWithinDays,
+		UninsuredItems:     []*InsuredItem{},
+		ExceededPolicies:   []*PolicyCoverage{},
+		ExpiringPolicies:   []*PolicyCoverage{},
+	}
+
+	for _, item := range uninsured {
+		if item.Value >= query.Threshold {
+			report.UninsuredItems = append(report.UninsuredItems, item)
+		}
+	}
+	sort.SliceStable(report.UninsuredItems, func(i, j int) bool {
+		return report.UninsuredItems[i].Value > report.UninsuredItems[j].Value
+	})
+
+	for _, coverage := range coverages {
+		if !coverage.Active {
+			continue
+		}
+		if coverage.ExcessValue > 0 {
+			report.ExceededPolicies = append(report.ExceededPolicies, coverage)
+		}
+		if coverage.DaysUntilEnds <= query.ExpiringWithinDays {
+			report.ExpiringPolicies = append(report.ExpiringPolicies, coverage)
+		}
+	}
+	sort.SliceStable(report.ExceededPolicies, func(i, j int) bool {
+		return report.ExceededPolicies[i].ExcessValue > report.ExceededPolicies[j].ExcessValue
+	})
+
+	return report, nil
+}
+
+// 保険契約ごとの補償状況と、保険期間内の保険契約に割り当てられていないアイテムを返す
+func (u *insuranceUsecase) coverages(ctx context.Context) ([]*PolicyCoverage, []*InsuredItem, error) {
+	policies, err := u.insuranceRepo.FindAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve insurance policies: %w", err)
+	}
+
+	items, err := u.itemRepo.FindAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	valuations, err := u.valuationRepo.FindLatestByItems(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	now := u.now()
+	today, _ := time.Parse("2006-01-02", now.Format("2006-01-02"))
+
+	coverages := make([]*PolicyCoverage, len(policies))
+	byID := make(map[int64]*PolicyCoverage, len(policies))
+	for i, policy := range policies {
+		endsOn, _ := time.Parse("2006-01-02", policy.EndsOn)
+		coverages[i] = &PolicyCoverage{
+			InsurancePolicy: policy,
+			Active:          policy.IsActive(now),
+			DaysUntilEnds:   int(endsOn.Sub(today).Hours() / 24),
+			Items:           []*InsuredItem{},
+		}
+		byID[policy.ID] = coverages[i]
+	}
+
+	var uninsured []*InsuredItem
+	for _, item := range items {
+		if item.Status == entity.ItemStatusSold || item.Status == entity.ItemStatusLost {
+			continue
+		}
+
+		insured := newInsuredItem(item, valuations[item.ID])
+		var coverage *PolicyCoverage
+		if item.InsurancePolicyID != nil {
+			coverage = byID[*item.InsurancePolicyID]
+		}
+		if coverage != nil {
+			coverage.Items = append(coverage.Items, insured)
+			coverage.ItemCount++
+			coverage.InsuredValue += insured.Value
+		}
+		// 保険期間外の保険契約に割り当てられているアイテムも補償されていないものとして扱う
+		if coverage == nil || !coverage.Active {
+			uninsured = append(uninsured, insured)
+		}
+	}
+
+	for _, coverage := range coverages {
+		if coverage.InsuredValue > coverage.CoverageLimit {
+			coverage.ExcessValue = coverage.InsuredValue - coverage.CoverageLimit
+		}
+	}
+
+	return coverages, uninsured, nil
+}
+
+func newInsuredItem(item *entity.Item, latest *entity.ItemValuation) *InsuredItem {
+	insured := &InsuredItem{
+		ItemID:            item.ID,
+		Name:              item.Name,
+		Category:          item.Category,
+		Brand:             item.Brand,
+		InsurancePolicyID: item.InsurancePolicyID,
+		Value:             entity.CurrentValue(item, latest),
+		ValueSource:       ValueSourcePurchasePrice,
+	}
+	if latest != nil {
+		insured.ValueSource = ValueSourceValuation
+		insured.ValuedAt = latest.ValuedAt
+	}
+	return insured
+}
+
+func (u *insuranceUsecase) findPolicy(ctx context.Context, id int64) (*entity.InsurancePolicy, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	policy, err := u.insuranceRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrInsurancePolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve insurance policy: %w", err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockInsuranceRepository はtestify/mockを使用した保険契約のモックリポジトリ
+type MockInsuranceRepository struct {
+	mock.Mock
+}
+
+func (m *MockInsuranceRepository) FindAll(ctx context.Context) ([]*entity.InsurancePolicy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsuranceRepository) FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsuranceRepository) Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsuranceRepository) Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsuranceRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInsuranceRepository) CountItems(ctx context.Context, id int64) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInsuranceRepository) AssignItem(ctx context.Context, itemID int64, policyID *int64) error {
+	args := m.Called(ctx, itemID, policyID)
+	return args.Error(0)
+}
+
+// MockValuationRepository はtestify/mockを使用した評価額のモックリポジトリ
+type MockValuationRepository struct {
+	mock.Mock
+}
+
+func (m *MockValuationRepository) FindByItem(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) Create(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error) {
+	args := m.Called(ctx, valuation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) FindByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) FindLatestByItems(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemValuation, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*entity.ItemValuation), args.Error(1)
+}
+
+// 2024-06-15 を現在日時としたユースケースを作成する
+func newTestInsuranceUsecase(insuranceRepo InsuranceRepository, valuationRepo ValuationRepository, itemRepo ItemRepository) *insuranceUsecase {
+	usecase := NewInsuranceUsecase(insuranceRepo, valuationRepo, itemRepo).(*insuranceUsecase)
+	usecase.now = func() time.Time {
+		return time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+	}
+	return usecase
+}
+
+func TestInsuranceUsecase_GetCoverageReport(t *testing.T) {
+	policies := []*entity.InsurancePolicy{
+		// 補償限度額300万円に対して評価額の合計が350万円
+		{ID: 1, Insurer: "東京海上", PolicyNumber: "P-001", CoverageLimit: 3000000, StartsOn: "2024-01-01", EndsOn: "2024-12-31"},
+		// 20日後に終了する
+		{ID: 2, Insurer: "損保ジャパン", PolicyNumber: "P-002", CoverageLimit: 1000000, StartsOn: "2023-07-05", EndsOn: "2024-07-05"},
+		// 保険期間が終了している
+		{ID: 3, Insurer: "三井住友海上", PolicyNumber: "P-003", CoverageLimit: 1000000, StartsOn: "2023-01-01", EndsOn: "2023-12-31"},
+	}
+	items := []*entity.Item{
+		{ID: 1, Name: "ロレックス", PurchasePrice: 1500000, InsurancePolicyID: int64Ptr(1), Status: entity.ItemStatusOwned},
+		{ID: 2, Name: "バーキン", PurchasePrice: 2000000, InsurancePolicyID: int64Ptr(1), Status: entity.ItemStatusOwned},
+		{ID: 3, Name: "ティファニー", PurchasePrice: 300000, InsurancePolicyID: int64Ptr(2), Status: entity.ItemStatusOwned},
+		// 評価額が購入価格より高い未加入アイテム
+		{ID: 4, Name: "パテック", PurchasePrice: 50000, Status: entity.ItemStatusOwned},
+		// 保険期間が終了した保険契約のアイテム
+		{ID: 5, Name: "カルティエ", PurchasePrice: 800000, InsurancePolicyID: int64Ptr(3), Status: entity.ItemStatusOwned},
+		// 閾値未満の未加入アイテム
+		{ID: 6, Name: "アップルウォッチ", PurchasePrice: 50000, Status: entity.ItemStatusOwned},
+		// 売却済みは対象外
+		{ID: 7, Name: "オメガ", PurchasePrice: 900000, Status: entity.ItemStatusSold},
+	}
+
+	tests := []struct {
+		name                 string
+		query                CoverageReportQuery
+		expectedUninsuredIDs []int64
+		expectedExceededIDs  []int64
+		expectedExpiringIDs  []int64
+		expectedErr          error
+	}{
+		{
+			name:                 "正常系: 未加入・補償超過・終了間近を報告",
+			query:                CoverageReportQuery{Threshold: 100000, ExpiringWithinDays: 30},
+			expectedUninsuredIDs: []int64{4, 5},
+			expectedExceededIDs:  []int64{1},
+			expectedExpiringIDs:  []int64{2},
+		},
+		{
+			name:                 "正常系: 終了間近の日数を短くする",
+			query:                CoverageReportQuery{Threshold: 1000000, ExpiringWithinDays: 10},
+			expectedUninsuredIDs: []int64{4},
+			expectedExceededIDs:  []int64{1},
+			expectedExpiringIDs:  []int64{},
+		},
+		{
+			name:        "異常系: 閾値が負の値",
+			query:       CoverageReportQuery{Threshold: -1, ExpiringWithinDays: 30},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 日数が0",
+			query:       CoverageReportQuery{Threshold: 100000},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insuranceRepo := new(MockInsuranceRepository)
+			valuationRepo := new(MockValuationRepository)
+			itemRepo := new(MockItemRepository)
+			if tt.expectedErr == nil {
+				insuranceRepo.On("FindAll", mock.Anything).Return(policies, nil)
+				itemRepo.On("FindAll", mock.Anything).Return(items, nil)
+				valuationRepo.On("FindLatestByItems", mock.Anything, []int64{1, 2, 3, 4, 5, 6, 7}).Return(map[int64]*entity.ItemValuation{
+					4: {ItemID: 4, ValuedAt: "2024-05-01", Amount: 1200000},
+				}, nil)
+			}
+			usecase := newTestInsuranceUsecase(insuranceRepo, valuationRepo, itemRepo)
+
+			report, err := usecase.GetCoverageReport(context.Background(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "2024-06-15", report.AsOf)
+			assert.Equal(t, tt.expectedUninsuredIDs, uninsuredItemIDs(report.UninsuredItems))
+			assert.Equal(t, tt.expectedExceededIDs, policyIDs(report.ExceededPolicies))
+			assert.Equal(t, tt.expectedExpiringIDs, policyIDs(report.ExpiringPolicies))
+
+			// 評価額がある場合は購入価格より評価額を優先する
+			assert.Equal(t, int64(1200000), report.UninsuredItems[0].Value)
+			assert.Equal(t, ValueSourceValuation, report.UninsuredItems[0].ValueSource)
+			assert.Equal(t, int64(500000), report.ExceededPolicies[0].ExcessValue)
+		})
+	}
+}
+
+func uninsuredItemIDs(items []*InsuredItem) []int64 {
+	ids := []int64{}
+	for _, item := range items {
+		ids = append(ids, item.ItemID)
+	}
+	return ids
+}
+
+func policyIDs(coverages []*PolicyCoverage) []int64 {
+	ids := []int64{}
+	for _, coverage := range coverages {
+		ids = append(ids, coverage.ID)
+	}
+	return ids
+}
+
+func TestInsuranceUsecase_AssignItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       AssignPolicyInput
+		setupMock   func(*MockInsuranceRepository, *MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 保険契約に割り当てる",
+			input: AssignPolicyInput{PolicyID: int64Ptr(1)},
+			setupMock: func(insuranceRepo *MockInsuranceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				insuranceRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.InsurancePolicy{ID: 1}, nil)
+				insuranceRepo.On("AssignItem", mock.Anything, int64(1), int64Ptr(1)).Return(nil)
+			},
+		},
+		{
+			name:  "正常系: 割り当てを解除する",
+			input: AssignPolicyInput{},
+			setupMock: func(insuranceRepo *MockInsuranceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				insuranceRepo.On("AssignItem", mock.Anything, int64(1), (*int64)(nil)).Return(nil)
+			},
+		},
+		{
+			name:  "異常系: 存在しない保険契約",
+			input: AssignPolicyInput{PolicyID: int64Ptr(99)},
+			setupMock: func(insuranceRepo *MockInsuranceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				insuranceRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrInsurancePolicyNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			input: AssignPolicyInput{PolicyID: int64Ptr(1)},
+			setupMock: func(insuranceRepo *MockInsuranceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insuranceRepo := new(MockInsuranceRepository)
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(insuranceRepo, itemRepo)
+			usecase := newTestInsuranceUsecase(insuranceRepo, new(MockValuationRepository), itemRepo)
+
+			_, err := usecase.AssignItem(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				insuranceRepo.AssertNotCalled(t, "AssignItem", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+
+			insuranceRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInsuranceUsecase_DeletePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockInsuranceRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: アイテムが割り当てられていない保険契約を削除",
+			setupMock: func(insuranceRepo *MockInsuranceRepository) {
+				insuranceRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.InsurancePolicy{ID: 1}, nil)
+				insuranceRepo.On("CountItems", mock.Anything, int64(1)).Return(0, nil)
+				insuranceRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "異常系: アイテムが割り当てられている",
+			setupMock: func(insuranceRepo *MockInsuranceRepository) {
+				insuranceRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.InsurancePolicy{ID: 1}, nil)
+				insuranceRepo.On("CountItems", mock.Anything, int64(1)).Return(2, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name: "異常系: 存在しない保険契約",
+			setupMock: func(insuranceRepo *MockInsuranceRepository) {
+				insuranceRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrInsurancePolicyNotFound)
+			},
+			expectedErr: domainErrors.ErrInsurancePolicyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insuranceRepo := new(MockInsuranceRepository)
+			tt.setupMock(insuranceRepo)
+			usecase := newTestInsuranceUsecase(insuranceRepo, new(MockValuationRepository), new(MockItemRepository))
+
+			err := usecase.DeletePolicy(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				insuranceRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+
+			insuranceRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// mutate中に記録したイベントを保持するctxのキー
+type recordedEventsKey struct{}
+
+// アイテムの変更とイベントの記録を1つのトランザクションで行い、コミット後に記録したイベントをイベントバスに発行する。
+// 外側のmutateの中で呼ばれた場合は外側のトランザクションに参加し、発行は外側のコミット後に行う
+func (u *itemUsecase) mutate(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, nested := ctx.Value(recordedEventsKey{}).(*[]*entity.OutboxEvent); nested {
+		return u.itemRepo.Transaction(ctx, fn)
+	}
+
+	var recorded []*entity.OutboxEvent
+	ctx = context.WithValue(ctx, recordedEventsKey{}, &recorded)
+	if err := u.itemRepo.Transaction(ctx, fn); err != nil {
+		return err
+	}
+
+	if u.eventBus != nil {
+		for _, event := range recorded {
+			u.eventBus.Publish(event)
+		}
+	}
+	return nil
+}
+
+// 作成・更新したアイテムのイベントを記録する（mutateのfn内で呼ぶ）
+func (u *itemUsecase) recordItemEvents(ctx context.Context, eventType entity.EventType, items ...*entity.Item) error {
+	if !u.recordsEvents() || len(items) == 0 {
+		return nil
+	}
+
+	now := u.now()
+	events := make([]*entity.OutboxEvent, len(items))
+	for i, item := range items {
+		event, err := entity.NewItemEvent(eventType, item, now)
+		if err != nil {
+			return fmt.Errorf("failed to encode item event: %w", err)
+		}
+		events[i] = event
+	}
+
+	return u.recordEvents(ctx, events)
+}
+
+// 削除したアイテムのイベントを記録する（mutateのfn内で呼ぶ）
+func (u *itemUsecase) recordDeletedEvents(ctx context.Context, ids ...int64) error {
+	if !u.recordsEvents() || len(ids) == 0 {
+		return nil
+	}
+
+	now := u.now()
+	events := make([]*entity.OutboxEvent, len(ids))
+	for i, id := range ids {
+		events[i] = entity.NewItemDeletedEvent(id, now)
+	}
+
+	return u.recordEvents(ctx, events)
+}
+
+func (u *itemUsecase) recordsEvents() bool {
+	return u.outboxRepo != nil || u.eventBus != nil
+}
+
+// アウトボックスに追記してIDを採番し、コミット後に発行できるようmutateに渡す
+func (u *itemUsecase) recordEvents(ctx context.Context, events []*entity.OutboxEvent) error {
+	if tenantID, ok := TenantIDFromContext(ctx); ok {
+		for _, event := range events {
+			event.TenantID = tenantID
+		}
+	}
+
+	if u.outboxRepo != nil {
+		if err := u.outboxRepo.Append(ctx, events); err != nil {
+			return err
+		}
+	}
+
+	if recorded, ok := ctx.Value(recordedEventsKey{}).(*[]*entity.OutboxEvent); ok {
+		*recorded = append(*recorded, events...)
+	}
+	return nil
+}
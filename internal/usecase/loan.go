@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type LoanUsecase interface {
+	GetBorrowers(ctx context.Context) ([]*entity.Borrower, error)
+	GetBorrowerByID(ctx context.Context, id int64) (*entity.Borrower, error)
+	CreateBorrower(ctx context.Context, input BorrowerInput) (*entity.Borrower, error)
+	ListLoans(ctx context.Context, query LoanQuery) ([]*entity.Loan, error)
+	GetItemLoans(ctx context.Context, itemID int64) ([]*entity.Loan, error)
+	LendItem(ctx context.Context, itemID int64, input CreateLoanInput) (*entity.Loan, error)
+	ReturnLoan(ctx context.Context, loanID int64) (*entity.Loan, error)
+	NotifyOverdueLoans(ctx context.Context) (int, error)
+}
+
+type BorrowerInput struct {
+	Name    string `json:"name"`
+	Contact string `json:"contact"`
+}
+
+type CreateLoanInput struct {
+	BorrowerID int64  `json:"borrower_id"`
+	DueDate    string `json:"due_date"`
+	Note       string `json:"note"`
+}
+
+// LoanQuery is the condition for listing loans.
+// Overdue matches loans that are past their due date and not returned; Active matches loans not returned.
+type LoanQuery struct {
+	Overdue bool
+	Active  bool
+}
+
+type loanUsecase struct {
+	loanRepo LoanRepository
+	itemRepo ItemRepository
+	// 貸出・返却によるアイテムの状態の変更を、イベントの記録も含めてアイテムの更新として保存するために使う
+	items    *itemUsecase
+	notifier Notifier
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+// NewLoanUsecase creates the loan usecase. itemOpts configure how the item status changes made by
+// lending and returning are recorded, and should match the options of the item usecase (WithOutbox, WithEventBus).
+func NewLoanUsecase(loanRepo LoanRepository, itemRepo ItemRepository, notifier Notifier, itemOpts ...ItemUsecaseOption) LoanUsecase {
+	return &loanUsecase{
+		loanRepo: loanRepo,
+		itemRepo: itemRepo,
+		items:    newItemUsecase(itemRepo, itemOpts...),
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+func (u *loanUsecase) GetBorrowers(ctx context.Context) ([]*entity.Borrower, error) {
+	borrowers, err := u.loanRepo.FindBorrowers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve borrowers: %w", err)
+	}
+
+	return borrowers, nil
+}
+
+func (u *loanUsecase) GetBorrowerByID(ctx context.Context, id int64) (*entity.Borrower, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	borrower, err := u.loanRepo.FindBorrowerByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrBorrowerNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve borrower: %w", err)
+	}
+
+	return borrower, nil
+}
+
+func (u *loanUsecase) CreateBorrower(ctx context.Context, input BorrowerInput) (*entity.Borrower, error) {
+	borrower, err := entity.NewBorrower(input.Name, input.Contact)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.loanRepo.CreateBorrower(ctx, borrower)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create borrower: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *loanUsecase) ListLoans(ctx context.Context, query LoanQuery) ([]*entity.Loan, error) {
+	filter := LoanFilter{ActiveOnly: query.Active || query.Overdue}
+	if query.Overdue {
+		filter.DueBefore = u.today()
+	}
+
+	loans, err := u.loanRepo.FindLoans(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve loans: %w", err)
+	}
+
+	return loans, nil
+}
+
+func (u *loanUsecase) GetItemLoans(ctx context.Context, itemID int64) ([]*entity.Loan, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	loans, err := u.loanRepo.FindLoans(ctx, LoanFilter{ItemID: itemID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve loans: %w", err)
+	}
+
+	return loans, nil
+}
+
+// LendItemは貸出記録を作成し、アイテムを貸出中にする。所有中のアイテムだけを貸し出せる
+func (u *loanUsecase) LendItem(ctx context.Context, itemID int64, input CreateLoanInput) (*entity.Loan, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	loan, err := entity.NewLoan(itemID, input.BorrowerID, input.DueDate, input.Note, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	var loanID int64
+	err = u.items.mutate(ctx, func(ctx context.Context) error {
+		if _, err := u.loanRepo.FindBorrowerByID(ctx, input.BorrowerID); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return fmt.Errorf("%w: borrower %d does not exist", domainErrors.ErrInvalidInput, input.BorrowerID)
+			}
+			return err
+		}
+
+		// 同じアイテムを同時に貸し出さないよう、行をロックしてから状態と貸出中の記録を確認する
+		item, err := u.itemRepo.FindByIDForUpdate(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		if err := item.ChangeStatus(entity.ItemStatusLent); err != nil {
+			return statusChangeError(err)
+		}
+		if _, err := u.loanRepo.FindActiveLoanByItem(ctx, itemID); err == nil {
+			return fmt.Errorf("%w: item %d already has an active loan", domainErrors.ErrConflict, itemID)
+		} else if !errors.Is(err, domainErrors.ErrLoanNotFound) {
+			return err
+		}
+		if _, err := u.items.saveItem(ctx, item); err != nil {
+			return err
+		}
+
+		created, err := u.loanRepo.CreateLoan(ctx, loan)
+		if err != nil {
+			return err
+		}
+		loanID = created.ID
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsValidationError(err) || domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to lend item: %w", err)
+	}
+
+	return u.loanRepo.FindLoanByID(ctx, loanID)
+}
+
+// ReturnLoanは貸出を返却済みにし、貸出中のアイテムを所有中に戻す
+func (u *loanUsecase) ReturnLoan(ctx context.Context, loanID int64) (*entity.Loan, error) {
+	if loanID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	err := u.items.mutate(ctx, func(ctx context.Context) error {
+		loan, err := u.loanRepo.FindLoanByID(ctx, loanID)
+		if err != nil {
+			return err
+		}
+		if err := loan.Return(u.now()); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrConflict, err.Error())
+		}
+
+		// 貸出や状態の変更と同時に返却されないよう、アイテムの行をロックしてから返却する
+		item, err := u.itemRepo.FindByIDForUpdate(ctx, loan.ItemID)
+		if err != nil {
+			return err
+		}
+		if err := u.loanRepo.ReturnLoan(ctx, loan); err != nil {
+			return err
+		}
+
+		// 紛失などで既に貸出中でなくなっている場合は状態を変えない
+		if item.Status != entity.ItemStatusLent {
+			return nil
+		}
+		if err := item.ChangeStatus(entity.ItemStatusOwned); err != nil {
+			return statusChangeError(err)
+		}
+		_, err = u.items.saveItem(ctx, item)
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsValidationError(err) || domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to return loan: %w", err)
+	}
+
+	return u.loanRepo.FindLoanByID(ctx, loanID)
+}
+
+// NotifyOverdueLoansは返却期限を過ぎた貸出を通知し、通知した件数を返す。
+// 同じ貸出は1日に1回だけ通知する。通知に失敗した貸出は次回の実行で再度通知する
+func (u *loanUsecase) NotifyOverdueLoans(ctx context.Context) (int, error) {
+	now := u.now()
+	today := now.Format("2006-01-02")
+
+	loans, err := u.loanRepo.FindLoans(ctx, LoanFilter{ActiveOnly: true, DueBefore: today})
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve overdue loans: %w", err)
+	}
+
+	var pending []*entity.Loan
+	itemIDs := make([]int64, 0, len(loans))
+	for _, loan := range loans {
+		if loan.LastNotifiedAt != nil && loan.LastNotifiedAt.Format("2006-01-02") == today {
+			continue
+		}
+		pending = append(pending, loan)
+		itemIDs = append(itemIDs, loan.ItemID)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, itemIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	itemNames := make(map[int64]string, len(items))
+	for _, item := range items {
+		itemNames[item.ID] = item.Name
+	}
+
+	var notified []int64
+	var errs []error
+	for _, loan := range pending {
+		borrower := ""
+		if loan.Borrower != nil {
+			borrower = loan.Borrower.Name
+		}
+		err := u.notifier.Notify(ctx, Notification{
+			Type:      NotificationLoanOverdue,
+			Message:   fmt.Sprintf("%s (item %d) lent to %s was due on %s", itemNames[loan.ItemID], loan.ItemID, borrower, loan.DueDate),
+			Data:      loan,
+			CreatedAt: now,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loan %d: %w", loan.ID, err))
+			continue
+		}
+		notified = append(notified, loan.ID)
+	}
+
+	if len(notified) > 0 {
+		if err := u.loanRepo.MarkNotified(ctx, notified, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return len(notified), fmt.Errorf("failed to notify overdue loans: %w", errors.Join(errs...))
+	}
+
+	return len(notified), nil
+}
+
+func (u *loanUsecase) today() string {
+	return u.now().Format("2006-01-02")
+}
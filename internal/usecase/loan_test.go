@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockLoanRepository はtestify/mockを使用した貸出のモックリポジトリ
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) FindBorrowers(ctx context.Context) ([]*entity.Borrower, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Borrower), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindBorrowerByID(ctx context.Context, id int64) (*entity.Borrower, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Borrower), args.Error(1)
+}
+
+func (m *MockLoanRepository) CreateBorrower(ctx context.Context, borrower *entity.Borrower) (*entity.Borrower, error) {
+	args := m.Called(ctx, borrower)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Borrower), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindLoans(ctx context.Context, filter LoanFilter) ([]*entity.Loan, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindLoanByID(ctx context.Context, id int64) (*entity.Loan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindActiveLoanByItem(ctx context.Context, itemID int64) (*entity.Loan, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) CreateLoan(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	args := m.Called(ctx, loan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ReturnLoan(ctx context.Context, loan *entity.Loan) error {
+	args := m.Called(ctx, loan)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) MarkNotified(ctx context.Context, ids []int64, at time.Time) error {
+	args := m.Called(ctx, ids, at)
+	return args.Error(0)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockLoanRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeNotifier は送信した通知を記録する。failIDsに含まれる貸出の通知は失敗させる
+type fakeNotifier struct {
+	notifications []Notification
+	failIDs       map[int64]bool
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification Notification) error {
+	if loan, ok := notification.Data.(*entity.Loan); ok && n.failIDs[loan.ID] {
+		return errors.New("webhook unavailable")
+	}
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+// 2024-01-20 を現在日時としたユースケースを作成する
+func newTestLoanUsecase(loanRepo LoanRepository, itemRepo ItemRepository, notifier Notifier) *loanUsecase {
+	usecase := NewLoanUsecase(loanRepo, itemRepo, notifier).(*loanUsecase)
+	usecase.now = func() time.Time {
+		return time.Date(2024, 1, 20, 9, 0, 0, 0, time.Local)
+	}
+	return usecase
+}
+
+func TestLoanUsecase_LendItem(t *testing.T) {
+	borrower := &entity.Borrower{ID: 1, Name: "山田太郎"}
+	ownedItem := func() *entity.Item {
+		return &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusOwned}
+	}
+
+	tests := []struct {
+		name        string
+		input       CreateLoanInput
+		setupMock   func(*MockLoanRepository, *MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 所有中のアイテムを貸し出す",
+			input: CreateLoanInput{BorrowerID: 1, DueDate: "2024-02-01", Note: "撮影用"},
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindBorrowerByID", mock.Anything, int64(1)).Return(borrower, nil)
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(ownedItem(), nil)
+				loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrLoanNotFound)
+				itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == entity.ItemStatusLent
+				})).Return(&entity.Item{ID: 1, Status: entity.ItemStatusLent}, nil)
+				loanRepo.On("CreateLoan", mock.Anything, mock.MatchedBy(func(loan *entity.Loan) bool {
+					return loan.ItemID == 1 && loan.BorrowerID == 1 && loan.DueDate == "2024-02-01"
+				})).Return(&entity.Loan{ID: 10}, nil)
+				loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1, Borrower: borrower}, nil)
+			},
+		},
+		{
+			name:        "異常系: 返却期限が貸出日より前",
+			input:       CreateLoanInput{BorrowerID: 1, DueDate: "2024-01-19"},
+			setupMock:   func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しない貸出先",
+			input: CreateLoanInput{BorrowerID: 99, DueDate: "2024-02-01"},
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindBorrowerByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrBorrowerNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 既に貸出中のアイテム",
+			input: CreateLoanInput{BorrowerID: 1, DueDate: "2024-02-01"},
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindBorrowerByID", mock.Anything, int64(1)).Return(borrower, nil)
+				item := ownedItem()
+				item.Status = entity.ItemStatusLent
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:  "異常系: 貸出中の記録が残っているアイテム",
+			input: CreateLoanInput{BorrowerID: 1, DueDate: "2024-02-01"},
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindBorrowerByID", mock.Anything, int64(1)).Return(borrower, nil)
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(ownedItem(), nil)
+				loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(&entity.Loan{ID: 9, ItemID: 1}, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			input: CreateLoanInput{BorrowerID: 1, DueDate: "2024-02-01"},
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindBorrowerByID", mock.Anything, int64(1)).Return(borrower, nil)
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(loanRepo, itemRepo)
+			usecase := newTestLoanUsecase(loanRepo, itemRepo, &fakeNotifier{})
+
+			loan, err := usecase.LendItem(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, loan)
+				loanRepo.AssertNotCalled(t, "CreateLoan", mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(10), loan.ID)
+			}
+
+			loanRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoanUsecase_ReturnLoan(t *testing.T) {
+	returnedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		setupMock   func(*MockLoanRepository, *MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 返却して所有中に戻す",
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1, DueDate: "2024-02-01"}, nil)
+				loanRepo.On("ReturnLoan", mock.Anything, mock.MatchedBy(func(loan *entity.Loan) bool {
+					return loan.ReturnedAt != nil
+				})).Return(nil)
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+					ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusLent,
+				}, nil)
+				itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == entity.ItemStatusOwned
+				})).Return(&entity.Item{ID: 1, Status: entity.ItemStatusOwned}, nil)
+			},
+		},
+		{
+			name: "正常系: 紛失済みのアイテムは状態を変えない",
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1, DueDate: "2024-02-01"}, nil)
+				loanRepo.On("ReturnLoan", mock.Anything, mock.Anything).Return(nil)
+				itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Status: entity.ItemStatusLost}, nil)
+			},
+		},
+		{
+			name: "異常系: 返却済みの貸出",
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1, ReturnedAt: &returnedAt}, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name: "異常系: 貸出が存在しない",
+			setupMock: func(loanRepo *MockLoanRepository, itemRepo *MockItemRepository) {
+				loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(nil, domainErrors.ErrLoanNotFound)
+			},
+			expectedErr: domainErrors.ErrLoanNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(loanRepo, itemRepo)
+			usecase := newTestLoanUsecase(loanRepo, itemRepo, &fakeNotifier{})
+
+			_, err := usecase.ReturnLoan(context.Background(), 10)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+
+			loanRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoanUsecase_LendItem_Concurrent(t *testing.T) {
+	itemRepo := &rowLockingItemRepository{
+		MockItemRepository: new(MockItemRepository),
+		item:               entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusOwned},
+		locking:            make(chan struct{}, 2),
+		release:            make(chan struct{}),
+	}
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("FindBorrowerByID", mock.Anything, mock.Anything).Return(&entity.Borrower{ID: 1}, nil)
+	loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrLoanNotFound)
+	loanRepo.On("CreateLoan", mock.Anything, mock.Anything).Return(&entity.Loan{ID: 10}, nil).Once()
+	loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1}, nil)
+	usecase := newTestLoanUsecase(loanRepo, itemRepo, &fakeNotifier{})
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	lend := func(i int, borrowerID int64) {
+		defer wg.Done()
+		_, errs[i] = usecase.LendItem(context.Background(), 1, CreateLoanInput{BorrowerID: borrowerID, DueDate: "2024-02-01"})
+	}
+	wg.Add(2)
+	go lend(0, 1)
+	<-itemRepo.locking
+	// 1件目が行をロックして貸し出す前に、2件目が同じアイテムを貸し出そうとする
+	go lend(1, 2)
+	<-itemRepo.locking
+	close(itemRepo.release)
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], domainErrors.ErrConflict)
+	assert.Equal(t, entity.ItemStatusLent, itemRepo.item.Status)
+	loanRepo.AssertNumberOfCalls(t, "CreateLoan", 1)
+}
+
+// 貸出・返却による状態の変更はアイテムの更新イベントとして記録する
+func TestLoanUsecase_PublishesItemEvents(t *testing.T) {
+	borrower := &entity.Borrower{ID: 1, Name: "山田太郎"}
+	loanRepo := new(MockLoanRepository)
+	itemRepo := new(MockItemRepository)
+	bus := &fakeEventBus{}
+	usecase := NewLoanUsecase(loanRepo, itemRepo, &fakeNotifier{}, WithEventBus(bus))
+
+	loanRepo.On("FindBorrowerByID", mock.Anything, int64(1)).Return(borrower, nil)
+	itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+		ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusOwned,
+	}, nil).Once()
+	loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(nil, domainErrors.ErrLoanNotFound)
+	itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.Status == entity.ItemStatusLent
+	})).Return(&entity.Item{ID: 1, Status: entity.ItemStatusLent}, nil)
+	loanRepo.On("CreateLoan", mock.Anything, mock.Anything).Return(&entity.Loan{ID: 10}, nil)
+	loanRepo.On("FindLoanByID", mock.Anything, int64(10)).Return(&entity.Loan{ID: 10, ItemID: 1, DueDate: "2030-02-01"}, nil)
+
+	_, err := usecase.LendItem(context.Background(), 1, CreateLoanInput{BorrowerID: 1, DueDate: "2030-02-01"})
+	require.NoError(t, err)
+
+	itemRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+		ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusLent,
+	}, nil).Once()
+	loanRepo.On("ReturnLoan", mock.Anything, mock.Anything).Return(nil)
+	itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.Status == entity.ItemStatusOwned
+	})).Return(&entity.Item{ID: 1, Status: entity.ItemStatusOwned}, nil)
+
+	_, err = usecase.ReturnLoan(context.Background(), 10)
+	require.NoError(t, err)
+
+	require.Len(t, bus.published, 2)
+	assert.Equal(t, entity.EventItemUpdated, bus.published[0].EventType)
+	assert.Equal(t, entity.EventItemUpdated, bus.published[1].EventType)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestLoanUsecase_ListLoans_Overdue(t *testing.T) {
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("FindLoans", mock.Anything, LoanFilter{ActiveOnly: true, DueBefore: "2024-01-20"}).Return([]*entity.Loan{{ID: 10}}, nil)
+	usecase := newTestLoanUsecase(loanRepo, new(MockItemRepository), &fakeNotifier{})
+
+	loans, err := usecase.ListLoans(context.Background(), LoanQuery{Overdue: true})
+
+	require.NoError(t, err)
+	assert.Len(t, loans, 1)
+	loanRepo.AssertExpectations(t)
+}
+
+func TestLoanUsecase_NotifyOverdueLoans(t *testing.T) {
+	notifiedToday := time.Date(2024, 1, 20, 8, 0, 0, 0, time.Local)
+	notifiedYesterday := time.Date(2024, 1, 19, 8, 0, 0, 0, time.Local)
+	overdueLoans := []*entity.Loan{
+		{ID: 10, ItemID: 1, DueDate: "2024-01-15", Borrower: &entity.Borrower{Name: "山田太郎"}},
+		{ID: 11, ItemID: 2, DueDate: "2024-01-18", LastNotifiedAt: &notifiedYesterday},
+		{ID: 12, ItemID: 3, DueDate: "2024-01-18", LastNotifiedAt: &notifiedToday},
+	}
+
+	tests := []struct {
+		name             string
+		failIDs          map[int64]bool
+		expectedCount    int
+		expectedNotified []int64
+		wantErr          bool
+	}{
+		{
+			name:             "正常系: 本日未通知の貸出だけを通知",
+			expectedCount:    2,
+			expectedNotified: []int64{10, 11},
+		},
+		{
+			name:             "異常系: 通知に失敗した貸出は通知済みにしない",
+			failIDs:          map[int64]bool{11: true},
+			expectedCount:    1,
+			expectedNotified: []int64{10},
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			itemRepo := new(MockItemRepository)
+			notifier := &fakeNotifier{failIDs: tt.failIDs}
+			usecase := newTestLoanUsecase(loanRepo, itemRepo, notifier)
+
+			loanRepo.On("FindLoans", mock.Anything, LoanFilter{ActiveOnly: true, DueBefore: "2024-01-20"}).Return(overdueLoans, nil)
+			itemRepo.On("FindByIDs", mock.Anything, []int64{1, 2}).Return([]*entity.Item{
+				{ID: 1, Name: "ロレックス"},
+				{ID: 2, Name: "エルメス バーキン"},
+			}, nil)
+			loanRepo.On("MarkNotified", mock.Anything, tt.expectedNotified, usecase.now()).Return(nil)
+
+			count, err := usecase.NotifyOverdueLoans(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCount, count)
+			require.Len(t, notifier.notifications, tt.expectedCount)
+			assert.Equal(t, NotificationLoanOverdue, notifier.notifications[0].Type)
+			assert.Contains(t, notifier.notifications[0].Message, "ロレックス")
+			assert.Contains(t, notifier.notifications[0].Message, "山田太郎")
+
+			loanRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type LocationUsecase interface {
+	GetAllLocations(ctx context.Context) ([]*entity.Location, error)
+	GetLocationByID(ctx context.Context, id int64) (*entity.Location, error)
+	CreateLocation(ctx context.Context, input CreateLocationInput) (*entity.Location, error)
+	UpdateLocation(ctx context.Context, id int64, input UpdateLocationInput) (*entity.Location, error)
+	DeleteLocation(ctx context.Context, id int64) error
+	MoveItem(ctx context.Context, itemID int64, input MoveItemInput) (*entity.Item, error)
+	GetItemMovements(ctx context.Context, itemID int64) ([]*entity.ItemMovement, error)
+}
+
+type CreateLocationInput struct {
+	Name     string              `json:"name"`
+	Type     entity.LocationType `json:"type"`
+	ParentID *int64              `json:"parent_id"`
+}
+
+// UpdateLocationInput replaces the name and parent of a location. The type cannot be changed.
+type UpdateLocationInput struct {
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+// MoveItemInput is the destination of an item. A nil LocationID removes the item from its location.
+type MoveItemInput struct {
+	LocationID *int64 `json:"location_id"`
+	Note       string `json:"note"`
+}
+
+// 移動メモの最大長
+const maxMovementNoteLength = 255
+
+// LocationSummary is the number and purchase price total of items in a location.
+// The counts include sublocations; a nil LocationID stands for items without a location.
+type LocationSummary struct {
+	LocationID *int64              `json:"location_id"`
+	Name       string              `json:"name"`
+	Type       entity.LocationType `json:"type,omitempty"`
+	ParentID   *int64              `json:"parent_id"`
+	ItemCount  int                 `json:"item_count"`
+	TotalValue int64               `json:"total_value"`
+}
+
+type locationUsecase struct {
+	locationRepo LocationRepository
+	itemRepo     ItemRepository
+}
+
+func NewLocationUsecase(locationRepo LocationRepository, itemRepo ItemRepository) LocationUsecase {
+	return &locationUsecase{
+		locationRepo: locationRepo,
+		itemRepo:     itemRepo,
+	}
+}
+
+func (u *locationUsecase) GetAllLocations(ctx context.Context) ([]*entity.Location, error) {
+	locations, err := u.locationRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+
+	setLocationPaths(locations)
+	return locations, nil
+}
+
+func (u *locationUsecase) GetLocationByID(ctx context.Context, id int64) (*entity.Location, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	locations, err := u.GetAllLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range locations {
+		if location.ID == id {
+			return location, nil
+		}
+	}
+
+	return nil, domainErrors.ErrLocationNotFound
+}
+
+func (u *locationUsecase) CreateLocation(ctx context.Context, input CreateLocationInput) (*entity.Location, error) {
+	parent, err := u.findParent(ctx, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := entity.NewLocation(input.Name, input.Type, parent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.locationRepo.Create(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return u.GetLocationByID(ctx, created.ID)
+}
+
+func (u *locationUsecase) UpdateLocation(ctx context.Context, id int64, input UpdateLocationInput) (*entity.Location, error) {
+	location, err := u.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := u.findParent(ctx, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 種類ごとに階層が決まっているため、親の種類を検証すれば循環は起こらない
+	if err := location.Update(input.Name, parent); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if _, err := u.locationRepo.Update(ctx, location); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return u.GetLocationByID(ctx, id)
+}
+
+// DeleteLocationは保管場所を削除する。子の保管場所やアイテムがある場合は削除できない
+func (u *locationUsecase) DeleteLocation(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	err := u.locationRepo.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := u.locationRepo.FindByID(ctx, id); err != nil {
+			return err
+		}
+
+		children, items, err := u.locationRepo.CountUsage(ctx, id)
+		if err != nil {
+			return err
+		}
+		if children > 0 || items > 0 {
+			return fmt.Errorf("%w: location has %d child locations and %d items", domainErrors.ErrConflict, children, items)
+		}
+
+		return u.locationRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrLocationNotFound
+		}
+		if domainErrors.IsConflictError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	return nil
+}
+
+// MoveItemはアイテムを指定した保管場所に移動し、移動履歴を記録する
+func (u *locationUsecase) MoveItem(ctx context.Context, itemID int64, input MoveItemInput) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxMovementNoteLength {
+		return nil, fmt.Errorf("%w: note must be %d characters or less", domainErrors.ErrInvalidInput, maxMovementNoteLength)
+	}
+
+	err := u.locationRepo.Transaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			return err
+		}
+
+		if input.LocationID != nil {
+			if _, err := u.locationRepo.FindByID(ctx, *input.LocationID); err != nil {
+				if domainErrors.IsNotFoundError(err) {
+					return fmt.Errorf("%w: location %d does not exist", domainErrors.ErrInvalidInput, *input.LocationID)
+				}
+				return err
+			}
+		}
+
+		if sameLocation(item.LocationID, input.LocationID) {
+			return fmt.Errorf("%w: item is already in the location", domainErrors.ErrInvalidInput)
+		}
+
+		_, err = u.locationRepo.MoveItem(ctx, &entity.ItemMovement{
+			ItemID:         itemID,
+			FromLocationID: item.LocationID,
+			ToLocationID:   input.LocationID,
+			Note:           note,
+		})
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to move item: %w", err)
+	}
+
+	return u.itemRepo.FindByID(ctx, itemID)
+}
+
+func (u *locationUsecase) GetItemMovements(ctx context.Context, itemID int64) ([]*entity.ItemMovement, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	movements, err := u.locationRepo.FindMovements(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// 親の保管場所を取得する。存在しない親の指定は入力エラーとする
+func (u *locationUsecase) findParent(ctx context.Context, parentID *int64) (*entity.Location, error) {
+	if parentID == nil {
+		return nil, nil
+	}
+
+	parent, err := u.locationRepo.FindByID(ctx, *parentID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, fmt.Errorf("%w: parent location %d does not exist", domainErrors.ErrInvalidInput, *parentID)
+		}
+		return nil, fmt.Errorf("failed to retrieve parent location: %w", err)
+	}
+
+	return parent, nil
+}
+
+func sameLocation(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// 最上位からの名前をつないだパスを設定する
+func setLocationPaths(locations []*entity.Location) {
+	byID := make(map[int64]*entity.Location, len(locations))
+	for _, location := range locations {
+		byID[location.ID] = location
+	}
+
+	for _, location := range locations {
+		names := []string{location.Name}
+		// 階層は最大3段のため、壊れたデータで無限ループしないよう段数で打ち切る
+		current := location
+		for depth := 0; current.ParentID != nil && depth < len(entity.ValidLocationTypes); depth++ {
+			parent, ok := byID[*current.ParentID]
+			if !ok {
+				break
+			}
+			names = append([]string{parent.Name}, names...)
+			current = parent
+		}
+		location.Path = strings.Join(names, " > ")
+	}
+}
+
+// 保管場所ごとの件数・金額を親の保管場所にも積み上げる
+func rollUpLocationSummaries(summaries []*LocationSummary) []*LocationSummary {
+	byID := make(map[int64]*LocationSummary, len(summaries))
+	for _, summary := range summaries {
+		if summary.LocationID != nil {
+			byID[*summary.LocationID] = summary
+		}
+	}
+
+	// 直接保管されている件数を先に確定してから加算する
+	type direct struct {
+		count int
+		value int64
+	}
+	directs := make([]direct, len(summaries))
+	for i, summary := range summaries {
+		directs[i] = direct{count: summary.ItemCount, value: summary.TotalValue}
+	}
+
+	for i, summary := range summaries {
+		current := summary
+		for depth := 0; current.ParentID != nil && depth < len(entity.ValidLocationTypes); depth++ {
+			parent, ok := byID[*current.ParentID]
+			if !ok {
+				break
+			}
+			parent.ItemCount += directs[i].count
+			parent.TotalValue += directs[i].value
+			current = parent
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockLocationRepository はtestify/mockを使用した保管場所のモックリポジトリ
+type MockLocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockLocationRepository) FindAll(ctx context.Context) ([]*entity.Location, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) FindByID(ctx context.Context, id int64) (*entity.Location, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	args := m.Called(ctx, location)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) Update(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	args := m.Called(ctx, location)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLocationRepository) CountUsage(ctx context.Context, id int64) (int, int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockLocationRepository) MoveItem(ctx context.Context, movement *entity.ItemMovement) (*entity.ItemMovement, error) {
+	args := m.Called(ctx, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemMovement), args.Error(1)
+}
+
+func (m *MockLocationRepository) FindMovements(ctx context.Context, itemID int64) ([]*entity.ItemMovement, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemMovement), args.Error(1)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockLocationRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestLocationUsecase_GetAllLocations_Path(t *testing.T) {
+	locationRepo := new(MockLocationRepository)
+	locationRepo.On("FindAll", mock.Anything).Return([]*entity.Location{
+		{ID: 1, Name: "自宅", Type: entity.LocationTypeSite},
+		{ID: 2, Name: "書斎", Type: entity.LocationTypeRoom, ParentID: int64Ptr(1)},
+		{ID: 3, Name: "金庫", Type: entity.LocationTypeContainer, ParentID: int64Ptr(2)},
+	}, nil)
+	usecase := NewLocationUsecase(locationRepo, new(MockItemRepository))
+
+	locations, err := usecase.GetAllLocations(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "自宅", locations[0].Path)
+	assert.Equal(t, "自宅 > 書斎", locations[1].Path)
+	assert.Equal(t, "自宅 > 書斎 > 金庫", locations[2].Path)
+}
+
+func TestLocationUsecase_CreateLocation(t *testing.T) {
+	site := &entity.Location{ID: 1, Name: "自宅", Type: entity.LocationTypeSite}
+
+	tests := []struct {
+		name        string
+		input       CreateLocationInput
+		setupMock   func(*MockLocationRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 拠点の下に部屋を作成",
+			input: CreateLocationInput{Name: "書斎", Type: entity.LocationTypeRoom, ParentID: int64Ptr(1)},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(site, nil)
+				locationRepo.On("Create", mock.Anything, mock.MatchedBy(func(location *entity.Location) bool {
+					return location.Name == "書斎" && *location.ParentID == 1
+				})).Return(&entity.Location{ID: 2}, nil)
+				locationRepo.On("FindAll", mock.Anything).Return([]*entity.Location{
+					site,
+					{ID: 2, Name: "書斎", Type: entity.LocationTypeRoom, ParentID: int64Ptr(1)},
+				}, nil)
+			},
+		},
+		{
+			name:  "異常系: 存在しない親",
+			input: CreateLocationInput{Name: "書斎", Type: entity.LocationTypeRoom, ParentID: int64Ptr(99)},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrLocationNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 階層に合わない親",
+			input: CreateLocationInput{Name: "金庫", Type: entity.LocationTypeContainer, ParentID: int64Ptr(1)},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(site, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locationRepo := new(MockLocationRepository)
+			tt.setupMock(locationRepo)
+			usecase := NewLocationUsecase(locationRepo, new(MockItemRepository))
+
+			location, err := usecase.CreateLocation(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, location)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "自宅 > 書斎", location.Path)
+			}
+
+			locationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLocationUsecase_DeleteLocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockLocationRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 使われていない保管場所を削除",
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Location{ID: 1}, nil)
+				locationRepo.On("CountUsage", mock.Anything, int64(1)).Return(0, 0, nil)
+				locationRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "異常系: アイテムが保管されている",
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Location{ID: 1}, nil)
+				locationRepo.On("CountUsage", mock.Anything, int64(1)).Return(0, 2, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name: "異常系: 子の保管場所がある",
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Location{ID: 1}, nil)
+				locationRepo.On("CountUsage", mock.Anything, int64(1)).Return(1, 0, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name: "異常系: 存在しない保管場所",
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrLocationNotFound)
+			},
+			expectedErr: domainErrors.ErrLocationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locationRepo := new(MockLocationRepository)
+			tt.setupMock(locationRepo)
+			usecase := NewLocationUsecase(locationRepo, new(MockItemRepository))
+
+			err := usecase.DeleteLocation(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			locationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLocationUsecase_MoveItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *int64
+		input       MoveItemInput
+		setupMock   func(*MockLocationRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 保管場所に移動して履歴を記録",
+			input: MoveItemInput{LocationID: int64Ptr(3), Note: " 金庫へ "},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Location{ID: 3}, nil)
+				locationRepo.On("MoveItem", mock.Anything, mock.MatchedBy(func(m *entity.ItemMovement) bool {
+					return m.ItemID == 1 && m.FromLocationID == nil && *m.ToLocationID == 3 && m.Note == "金庫へ"
+				})).Return(&entity.ItemMovement{ID: 1}, nil)
+			},
+		},
+		{
+			name:    "正常系: 保管場所を解除",
+			current: int64Ptr(3),
+			input:   MoveItemInput{},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("MoveItem", mock.Anything, mock.MatchedBy(func(m *entity.ItemMovement) bool {
+					return *m.FromLocationID == 3 && m.ToLocationID == nil
+				})).Return(&entity.ItemMovement{ID: 2}, nil)
+			},
+		},
+		{
+			name:    "異常系: 同じ保管場所への移動",
+			current: int64Ptr(3),
+			input:   MoveItemInput{LocationID: int64Ptr(3)},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Location{ID: 3}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しない保管場所",
+			input: MoveItemInput{LocationID: int64Ptr(99)},
+			setupMock: func(locationRepo *MockLocationRepository) {
+				locationRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrLocationNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", LocationID: tt.current}
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			locationRepo := new(MockLocationRepository)
+			tt.setupMock(locationRepo)
+			usecase := NewLocationUsecase(locationRepo, itemRepo)
+
+			moved, err := usecase.MoveItem(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, moved)
+				locationRepo.AssertNotCalled(t, "MoveItem", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, moved)
+			}
+
+			locationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRollUpLocationSummaries(t *testing.T) {
+	summaries := rollUpLocationSummaries([]*LocationSummary{
+		{LocationID: int64Ptr(1), Name: "自宅", ItemCount: 1, TotalValue: 100},
+		{LocationID: int64Ptr(2), Name: "書斎", ParentID: int64Ptr(1), ItemCount: 2, TotalValue: 200},
+		{LocationID: int64Ptr(3), Name: "金庫", ParentID: int64Ptr(2), ItemCount: 3, TotalValue: 300},
+		{Name: "unassigned", ItemCount: 4, TotalValue: 400},
+	})
+
+	assert.Equal(t, 6, summaries[0].ItemCount)
+	assert.Equal(t, int64(600), summaries[0].TotalValue)
+	assert.Equal(t, 5, summaries[1].ItemCount)
+	assert.Equal(t, int64(500), summaries[1].TotalValue)
+	assert.Equal(t, 3, summaries[2].ItemCount)
+	assert.Equal(t, 4, summaries[3].ItemCount)
+}
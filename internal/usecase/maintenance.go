@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MaintenanceUsecase interface {
+	GetItemMaintenance(ctx context.Context, itemID int64) (*ItemMaintenance, error)
+	AddMaintenanceRecord(ctx context.Context, itemID int64, input MaintenanceRecordInput) (*entity.MaintenanceRecord, error)
+	DeleteMaintenanceRecord(ctx context.Context, id int64) error
+	GetUpcomingMaintenance(ctx context.Context, days int) ([]*UpcomingMaintenance, error)
+}
+
+type MaintenanceRecordInput struct {
+	ServicedAt string `json:"serviced_at"`
+	Vendor     string `json:"vendor"`
+	Cost       int    `json:"cost"`
+	Note       string `json:"note"`
+}
+
+// ItemMaintenance is the warranty, maintenance history and service schedule of an item.
+// NextServiceDue is empty when the category has no maintenance interval or the item is sold or lost.
+type ItemMaintenance struct {
+	ItemID               int64                       `json:"item_id"`
+	WarrantyExpiresAt    string                      `json:"warranty_expires_at"`
+	WarrantyActive       bool                        `json:"warranty_active"`
+	IntervalMonths       int                         `json:"interval_months"`
+	LastServicedAt       string                      `json:"last_serviced_at"`
+	NextServiceDue       string                      `json:"next_service_due"`
+	MaintenanceCost      int64                       `json:"maintenance_cost"`
+	TotalCostOfOwnership int64                       `json:"total_cost_of_ownership"`
+	Records              []*entity.MaintenanceRecord `json:"records"`
+}
+
+// UpcomingMaintenance is an item whose next service is due within the requested period.
+// DaysUntilDue is negative when the service is overdue.
+type UpcomingMaintenance struct {
+	ItemID            int64  `json:"item_id"`
+	Name              string `json:"name"`
+	Category          string `json:"category"`
+	Brand             string `json:"brand"`
+	LastServicedAt    string `json:"last_serviced_at"`
+	NextServiceDue    string `json:"next_service_due"`
+	DaysUntilDue      int    `json:"days_until_due"`
+	Overdue           bool   `json:"overdue"`
+	WarrantyExpiresAt string `json:"warranty_expires_at"`
+	WarrantyActive    bool   `json:"warranty_active"`
+}
+
+// 整備予定の取得期間（日数）
+const (
+	DefaultUpcomingMaintenanceDays = 30
+	MaxUpcomingMaintenanceDays     = 3650
+)
+
+type maintenanceUsecase struct {
+	maintenanceRepo MaintenanceRepository
+	itemRepo        ItemRepository
+	intervals       entity.MaintenanceIntervals
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewMaintenanceUsecase(maintenanceRepo MaintenanceRepository, itemRepo ItemRepository, intervals entity.MaintenanceIntervals) MaintenanceUsecase {
+	return &maintenanceUsecase{
+		maintenanceRepo: maintenanceRepo,
+		itemRepo:        itemRepo,
+		intervals:       intervals,
+		now:             time.Now,
+	}
+}
+
+func (u *maintenanceUsecase) GetItemMaintenance(ctx context.Context, itemID int64) (*ItemMaintenance, error) {
+	item, err := findItemByID(ctx, u.itemRepo, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := u.maintenanceRepo.FindByItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve maintenance records: %w", err)
+	}
+
+	maintenance := &ItemMaintenance{
+		ItemID:            item.ID,
+		WarrantyExpiresAt: item.WarrantyExpiresAt,
+		WarrantyActive:    item.IsWarrantyActive(u.now()),
+		IntervalMonths:    u.intervals[item.Category],
+		Records:           records,
+	}
+	for _, record := range records {
+		if record.ServicedAt > maintenance.LastServicedAt {
+			maintenance.LastServicedAt = record.ServicedAt
+		}
+		maintenance.MaintenanceCost += int64(record.Cost)
+	}
+	maintenance.NextServiceDue, _ = u.intervals.NextServiceDue(item, maintenance.LastServicedAt)
+	maintenance.TotalCostOfOwnership = int64(item.PurchasePrice) + maintenance.MaintenanceCost
+
+	return maintenance, nil
+}
+
+// AddMaintenanceRecordは整備記録を追加する。整備日は購入日から今日までの日付
+func (u *maintenanceUsecase) AddMaintenanceRecord(ctx context.Context, itemID int64, input MaintenanceRecordInput) (*entity.MaintenanceRecord, error) {
+	item, err := findItemByID(ctx, u.itemRepo, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := entity.NewMaintenanceRecord(item.ID, input.ServicedAt, input.Vendor, input.Cost, input.Note)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	// YYYY-MM-DD形式は文字列の比較で日付の前後を判定できる
+	if record.ServicedAt < item.PurchaseDate {
+		return nil, fmt.Errorf("%w: serviced_at must be on or after purchase_date", domainErrors.ErrInvalidInput)
+	}
+	if record.ServicedAt > u.today() {
+		return nil, fmt.Errorf("%w: serviced_at must not be in the future", domainErrors.ErrInvalidInput)
+	}
+
+	created, err := u.maintenanceRepo.Create(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create maintenance record: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *maintenanceUsecase) DeleteMaintenanceRecord(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.maintenanceRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrMaintenanceRecordNotFound
+		}
+		return fmt.Errorf("failed to delete maintenance record: %w", err)
+	}
+
+	return nil
+}
+
+// GetUpcomingMaintenanceは今日からdays日以内に整備予定日を迎えるアイテムを整備予定日の早い順で返す。
+// 整備予定日を過ぎているアイテムも含む
+func (u *maintenanceUsecase) GetUpcomingMaintenance(ctx context.Context, days int) ([]*UpcomingMaintenance, error) {
+	if days <= 0 || days > MaxUpcomingMaintenanceDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", domainErrors.ErrInvalidInput, MaxUpcomingMaintenanceDays)
+	}
+
+	items, err := u.itemRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	lastServiceDates, err := u.maintenanceRepo.FindLastServiceDates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve maintenance records: %w", err)
+	}
+
+	now := u.now()
+	today, _ := time.Parse("2006-01-02", now.Format("2006-01-02"))
+	until := today.AddDate(0, 0, days).Format("2006-01-02")
+
+	upcoming := []*UpcomingMaintenance{}
+	for _, item := range items {
+		lastServicedAt := lastServiceDates[item.ID]
+		due, ok := u.intervals.NextServiceDue(item, lastServicedAt)
+		if !ok || due > until {
+			continue
+		}
+
+		dueDate, _ := time.Parse("2006-01-02", due)
+		daysUntilDue := int(dueDate.Sub(today).Hours() / 24)
+
+		upcoming = append(upcoming, &UpcomingMaintenance{
+			ItemID:            item.ID,
+			Name:              item.Name,
+			Category:          item.Category,
+			Brand:             item.Brand,
+			LastServicedAt:    lastServicedAt,
+			NextServiceDue:    due,
+			DaysUntilDue:      daysUntilDue,
+			Overdue:           daysUntilDue < 0,
+			WarrantyExpiresAt: item.WarrantyExpiresAt,
+			WarrantyActive:    item.IsWarrantyActive(now),
+		})
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		if upcoming[i].NextServiceDue != upcoming[j].NextServiceDue {
+			return upcoming[i].NextServiceDue < upcoming[j].NextServiceDue
+		}
+		return upcoming[i].ItemID < upcoming[j].ItemID
+	})
+
+	return upcoming, nil
+}
+
+func (u *maintenanceUsecase) today() string {
+	return u.now().Format("2006-01-02")
+}
+
+// IncludeTotalCostOfOwnershipはアイテムに総保有コスト（購入価格 + 整備費用）を設定する。
+// 整備記録のリポジトリが設定されていない場合は購入価格だけを総保有コストとする
+func (u *itemUsecase) IncludeTotalCostOfOwnership(ctx context.Context, items []*entity.Item) error {
+	costs := map[int64]int64{}
+	if u.maintenanceRepo != nil && len(items) > 0 {
+		ids := make([]int64, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+
+		var err error
+		costs, err = u.maintenanceRepo.SumCostsByItem(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve maintenance costs: %w", err)
+		}
+	}
+
+	for _, item := range items {
+		item.SetMaintenanceCost(costs[item.ID])
+	}
+
+	return nil
+}
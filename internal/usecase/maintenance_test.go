@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockMaintenanceRepository はtestify/mockを使用した整備記録のモックリポジトリ
+type MockMaintenanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceRepository) FindByItem(ctx context.Context, itemID int64) ([]*entity.MaintenanceRecord, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.MaintenanceRecord), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) FindByID(ctx context.Context, id int64) (*entity.MaintenanceRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MaintenanceRecord), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) Create(ctx context.Context, record *entity.MaintenanceRecord) (*entity.MaintenanceRecord, error) {
+	args := m.Called(ctx, record)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MaintenanceRecord), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) FindLastServiceDates(ctx context.Context) (map[int64]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]string), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) SumCostsByItem(ctx context.Context, itemIDs []int64) (map[int64]int64, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]int64), args.Error(1)
+}
+
+// 2024-01-20 を現在日時としたユースケースを作成する
+func newTestMaintenanceUsecase(maintenanceRepo MaintenanceRepository, itemRepo ItemRepository) *maintenanceUsecase {
+	usecase := NewMaintenanceUsecase(maintenanceRepo, itemRepo, entity.MaintenanceIntervals{"時計": 36, "靴": 12}).(*maintenanceUsecase)
+	usecase.now = func() time.Time {
+		return time.Date(2024, 1, 20, 9, 0, 0, 0, time.Local)
+	}
+	return usecase
+}
+
+func TestMaintenanceUsecase_GetItemMaintenance(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{
+		ID: 1, Category: "時計", PurchasePrice: 1500000, PurchaseDate: "2020-01-15", Status: entity.ItemStatusOwned, WarrantyExpiresAt: "2025-01-15",
+	}, nil)
+	maintenanceRepo := new(MockMaintenanceRepository)
+	maintenanceRepo.On("FindByItem", mock.Anything, int64(1)).Return([]*entity.MaintenanceRecord{
+		{ID: 2, ItemID: 1, ServicedAt: "2023-06-01", Cost: 80000},
+		{ID: 1, ItemID: 1, ServicedAt: "2021-03-01", Cost: 20000},
+	}, nil)
+	usecase := newTestMaintenanceUsecase(maintenanceRepo, itemRepo)
+
+	maintenance, err := usecase.GetItemMaintenance(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.True(t, maintenance.WarrantyActive)
+	assert.Equal(t, 36, maintenance.IntervalMonths)
+	assert.Equal(t, "2023-06-01", maintenance.LastServicedAt)
+	assert.Equal(t, "2026-06-01", maintenance.NextServiceDue)
+	assert.Equal(t, int64(100000), maintenance.MaintenanceCost)
+	assert.Equal(t, int64(1600000), maintenance.TotalCostOfOwnership)
+	assert.Len(t, maintenance.Records, 2)
+}
+
+func TestMaintenanceUsecase_AddMaintenanceRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       MaintenanceRecordInput
+		setupMock   func(*MockMaintenanceRepository, *MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 整備記録を追加",
+			input: MaintenanceRecordInput{ServicedAt: "2024-01-10", Vendor: "日本ロレックス", Cost: 80000, Note: "オーバーホール"},
+			setupMock: func(maintenanceRepo *MockMaintenanceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, PurchaseDate: "2020-01-15"}, nil)
+				maintenanceRepo.On("Create", mock.Anything, mock.MatchedBy(func(record *entity.MaintenanceRecord) bool {
+					return record.ItemID == 1 && record.ServicedAt == "2024-01-10" && record.Cost == 80000
+				})).Return(&entity.MaintenanceRecord{ID: 1}, nil)
+			},
+		},
+		{
+			name:  "異常系: 整備日が購入日より前",
+			input: MaintenanceRecordInput{ServicedAt: "2019-12-31"},
+			setupMock: func(maintenanceRepo *MockMaintenanceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, PurchaseDate: "2020-01-15"}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 整備日が未来",
+			input: MaintenanceRecordInput{ServicedAt: "2024-01-21"},
+			setupMock: func(maintenanceRepo *MockMaintenanceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, PurchaseDate: "2020-01-15"}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			input: MaintenanceRecordInput{ServicedAt: "2024-01-10"},
+			setupMock: func(maintenanceRepo *MockMaintenanceRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintenanceRepo := new(MockMaintenanceRepository)
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(maintenanceRepo, itemRepo)
+			usecase := newTestMaintenanceUsecase(maintenanceRepo, itemRepo)
+
+			record, err := usecase.AddMaintenanceRecord(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, record)
+				maintenanceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), record.ID)
+			}
+
+			maintenanceRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMaintenanceUsecase_GetUpcomingMaintenance(t *testing.T) {
+	items := []*entity.Item{
+		// 購入から3年: 2024-02-01 が整備予定日
+		{ID: 1, Name: "ロレックス", Category: "時計", PurchaseDate: "2021-02-01", Status: entity.ItemStatusOwned},
+		// 2023-01-10 の整備から1年: 2024-01-10 で期限切れ
+		{ID: 2, Name: "ルブタン", Category: "靴", PurchaseDate: "2020-01-01", Status: entity.ItemStatusOwned},
+		// 2022-01-01 の整備から3年: 2025-01-01 で期間外
+		{ID: 3, Name: "オメガ", Category: "時計", PurchaseDate: "2018-01-01", Status: entity.ItemStatusLent},
+		// 整備間隔のないカテゴリー
+		{ID: 4, Name: "バーキン", Category: "バッグ", PurchaseDate: "2018-01-01", Status: entity.ItemStatusOwned},
+		// 売却済み
+		{ID: 5, Name: "カルティエ", Category: "時計", PurchaseDate: "2018-01-01", Status: entity.ItemStatusSold},
+	}
+
+	tests := []struct {
+		name        string
+		days        int
+		expectedIDs []int64
+		expectedErr error
+	}{
+		{name: "正常系: 30日以内と期限切れを予定日順で返す", days: 30, expectedIDs: []int64{2, 1}},
+		{name: "正常系: 7日以内は期限切れのみ", days: 7, expectedIDs: []int64{2}},
+		{name: "異常系: 日数が0", days: 0, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 日数が上限超過", days: MaxUpcomingMaintenanceDays + 1, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			maintenanceRepo := new(MockMaintenanceRepository)
+			if tt.expectedErr == nil {
+				itemRepo.On("FindAll", mock.Anything).Return(items, nil)
+				maintenanceRepo.On("FindLastServiceDates", mock.Anything).Return(map[int64]string{2: "2023-01-10", 3: "2022-01-01"}, nil)
+			}
+			usecase := newTestMaintenanceUsecase(maintenanceRepo, itemRepo)
+
+			upcoming, err := usecase.GetUpcomingMaintenance(context.Background(), tt.days)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]int64, len(upcoming))
+			for i, u := range upcoming {
+				ids[i] = u.ItemID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.True(t, upcoming[0].Overdue)
+			assert.Equal(t, -10, upcoming[0].DaysUntilDue)
+		})
+	}
+}
+
+func TestItemUsecase_IncludeTotalCostOfOwnership(t *testing.T) {
+	maintenanceRepo := new(MockMaintenanceRepository)
+	maintenanceRepo.On("SumCostsByItem", mock.Anything, []int64{1, 2}).Return(map[int64]int64{1: 100000}, nil)
+	usecase := NewItemUsecase(new(MockItemRepository), WithMaintenanceRepository(maintenanceRepo))
+	items := []*entity.Item{
+		{ID: 1, PurchasePrice: 1500000},
+		{ID: 2, PurchasePrice: 300000},
+	}
+
+	err := usecase.IncludeTotalCostOfOwnership(context.Background(), items)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1600000), *items[0].TotalCostOfOwnership)
+	assert.Equal(t, int64(300000), *items[1].TotalCostOfOwnership)
+	maintenanceRepo.AssertExpectations(t)
+}
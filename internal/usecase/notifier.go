@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// 通知の種類
+const (
+	NotificationLoanOverdue = "loan.overdue"
+)
+
+// Notification is a message delivered to the owner through a Notifier.
+type Notification struct {
+	Type      string      `json:"type"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Notifier delivers notifications. Implementations live in the infrastructure layer (log, webhook).
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
@@ -11,19 +12,323 @@ type ItemRepository interface {
 	// FindAll retrieves all items
 	FindAll(ctx context.Context) ([]*entity.Item, error)
 
+	// FindByFilter retrieves the items matching all conditions of the filter
+	FindByFilter(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
+
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
-	// Create creates a new item and returns it with the generated ID
+	// FindByIDForUpdate retrieves an item by ID and locks its row until the transaction ends.
+	// It must be called inside Transaction.
+	FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error)
+
+	// FindByIDs retrieves the items with the given IDs ordered by ID. Missing IDs are skipped.
+	FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error)
+
+	// FindBySerialNumber retrieves the items with the given serial number. An empty brand matches every brand.
+	FindBySerialNumber(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error)
+
+	// Create creates a new item and returns it with the generated ID.
+	// It returns ErrDuplicateEntry if another item already has the same brand and serial number.
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
+	// CreateBatch creates multiple items in a single transaction and returns them in input order.
+	// Rows are inserted one at a time so that every generated ID comes from the database.
+	// If an item cannot be created, the error is a BatchItemError holding that item's position.
+	CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error)
+
 	// Update updates an existing item. It returns the updated item or an error.
+	// It returns ErrDuplicateEntry if another item already has the same brand and serial number.
     Update(ctx context.Context, item *entity.Item) (*entity.Item, error) // 💡追記
 
 
 	// Delete deletes an item by ID
 	Delete(ctx context.Context, id int64) error
 
+	// DeleteBatch deletes all items with the given IDs. It returns ErrItemNotFound if any of them did not exist,
+	// wrapped in a BatchItemError holding the position of the first missing ID.
+	DeleteBatch(ctx context.Context, ids []int64) error
+
+	// MergeInto moves the tags and merge history of duplicate to the survivor, assigns the insurance policy
+	// of duplicate to the survivor if it has none, records the merge and deletes duplicate. It must be called in a transaction.
+	MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error
+
+	// FindMergeHistory retrieves the items merged into the given item, newest first
+	FindMergeHistory(ctx context.Context, survivorID int64) ([]*entity.ItemMerge, error)
+
 	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
 	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+
+	// GetSummaryByLocation returns the item count and purchase price total stored directly in each location.
+	// Every location is included, plus one entry with a nil LocationID for items without a location.
+	GetSummaryByLocation(ctx context.Context) ([]*LocationSummary, error)
+
+	// GetSummaryByStatus returns item counts grouped by status
+	GetSummaryByStatus(ctx context.Context) (map[string]int, error)
+
+	// GetSalesByCategory returns the count, purchase price total and sale price total of sold items grouped by category
+	GetSalesByCategory(ctx context.Context) (map[string]*SalesTotals, error)
+
+	// Transaction runs fn in a single transaction. Repository calls made with the ctx passed to fn take part in it.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ItemFilter holds the listing conditions. The zero value matches every item.
+type ItemFilter struct {
+	Category string
+	// Tags matches items that have all of the given tag names
+	Tags []string
+	// Attributes matches items whose custom attribute equals the value (attribute key -> value)
+	Attributes map[string]string
+	// LocationID matches items stored in the location or any of its sublocations
+	LocationID int64
+	// Status matches items in the given lifecycle status
+	Status entity.ItemStatus
+}
+
+// TagRepository defines the interface for tag data access
+type TagRepository interface {
+	// FindAll retrieves all tags with the number of items they are attached to
+	FindAll(ctx context.Context) ([]*entity.Tag, error)
+
+	// FindByID retrieves a tag by ID
+	FindByID(ctx context.Context, id int64) (*entity.Tag, error)
+
+	// FindByNames retrieves the tags with the given names. Missing names are skipped.
+	FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error)
+
+	// Create creates a new tag. It returns ErrDuplicateEntry if the name is already used.
+	Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+
+	// Update renames a tag. It returns ErrDuplicateEntry if the name is already used.
+	Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+
+	// Delete deletes a tag and detaches it from all items
+	Delete(ctx context.Context, id int64) error
+
+	// AttachToItem attaches the tags to an item. Already attached tags are ignored.
+	AttachToItem(ctx context.Context, itemID int64, tagIDs []int64) error
+
+	// DetachFromItem detaches a tag from an item. It returns ErrTagNotFound if the tag was not attached.
+	DetachFromItem(ctx context.Context, itemID, tagID int64) error
+
+	// Transaction runs fn in a single transaction
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// LocationRepository defines the interface for storage location and item movement data access
+type LocationRepository interface {
+	// FindAll retrieves all locations ordered by ID
+	FindAll(ctx context.Context) ([]*entity.Location, error)
+
+	// FindByID retrieves a location by ID
+	FindByID(ctx context.Context, id int64) (*entity.Location, error)
+
+	// Create creates a new location
+	Create(ctx context.Context, location *entity.Location) (*entity.Location, error)
+
+	// Update updates the name and parent of a location
+	Update(ctx context.Context, location *entity.Location) (*entity.Location, error)
+
+	// Delete deletes a location by ID
+	Delete(ctx context.Context, id int64) error
+
+	// CountUsage returns the number of child locations and items directly stored in the location
+	CountUsage(ctx context.Context, id int64) (children int, items int, err error)
+
+	// MoveItem sets the current location of the item and records the movement
+	MoveItem(ctx context.Context, movement *entity.ItemMovement) (*entity.ItemMovement, error)
+
+	// FindMovements retrieves the movements of an item, newest first
+	FindMovements(ctx context.Context, itemID int64) ([]*entity.ItemMovement, error)
+
+	// Transaction runs fn in a single transaction
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// LoanRepository defines the interface for borrower and loan data access
+type LoanRepository interface {
+	// FindBorrowers retrieves all borrowers ordered by name
+	FindBorrowers(ctx context.Context) ([]*entity.Borrower, error)
+
+	// FindBorrowerByID retrieves a borrower by ID
+	FindBorrowerByID(ctx context.Context, id int64) (*entity.Borrower, error)
+
+	// CreateBorrower creates a new borrower
+	CreateBorrower(ctx context.Context, borrower *entity.Borrower) (*entity.Borrower, error)
+
+	// FindLoans retrieves the loans matching the filter with their borrowers, newest first
+	FindLoans(ctx context.Context, filter LoanFilter) ([]*entity.Loan, error)
+
+	// FindLoanByID retrieves a loan by ID with its borrower
+	FindLoanByID(ctx context.Context, id int64) (*entity.Loan, error)
+
+	// FindActiveLoanByItem retrieves the loan of the item that has not been returned.
+	// It returns ErrLoanNotFound if the item is not on loan.
+	FindActiveLoanByItem(ctx context.Context, itemID int64) (*entity.Loan, error)
+
+	// CreateLoan creates a new loan
+	CreateLoan(ctx context.Context, loan *entity.Loan) (*entity.Loan, error)
+
+	// ReturnLoan saves the returned time of the loan
+	ReturnLoan(ctx context.Context, loan *entity.Loan) error
+
+	// MarkNotified records that an overdue notification was sent for the loans
+	MarkNotified(ctx context.Context, ids []int64, at time.Time) error
+
+	// Transaction runs fn in a single transaction
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// LoanFilter holds the loan listing conditions. The zero value matches every loan.
+type LoanFilter struct {
+	ItemID int64
+	// ActiveOnly matches loans that have not been returned
+	ActiveOnly bool
+	// DueBefore matches loans whose due date is before the date (YYYY-MM-DD)
+	DueBefore string
+}
+
+type MaintenanceRepository interface {
+	// FindByItem retrieves the maintenance records of an item, newest service first
+	FindByItem(ctx context.Context, itemID int64) ([]*entity.MaintenanceRecord, error)
+
+	// FindByID retrieves a maintenance record by ID
+	FindByID(ctx context.Context, id int64) (*entity.MaintenanceRecord, error)
+
+	// Create creates a new maintenance record
+	Create(ctx context.Context, record *entity.MaintenanceRecord) (*entity.MaintenanceRecord, error)
+
+	// Delete deletes a maintenance record
+	Delete(ctx context.Context, id int64) error
+
+	// FindLastServiceDates retrieves the latest service date (YYYY-MM-DD) of each item that has been serviced
+	FindLastServiceDates(ctx context.Context) (map[int64]string, error)
+
+	// SumCostsByItem retrieves the total maintenance cost of each item.
+	// Items without maintenance records are not included.
+	SumCostsByItem(ctx context.Context, itemIDs []int64) (map[int64]int64, error)
+}
+
+type InsuranceRepository interface {
+	// FindAll retrieves all insurance policies ordered by end date
+	FindAll(ctx context.Context) ([]*entity.InsurancePolicy, error)
+
+	// FindByID retrieves an insurance policy by ID
+	FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error)
+
+	// Create creates a new insurance policy
+	Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error)
+
+	// Update saves the contents of an insurance policy
+	Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error)
+
+	// Delete deletes an insurance policy
+	Delete(ctx context.Context, id int64) error
+
+	// CountItems returns the number of items assigned to the policy
+	CountItems(ctx context.Context, id int64) (int, error)
+
+	// AssignItem assigns an item to a policy. A nil policyID removes the item from its policy.
+	AssignItem(ctx context.Context, itemID int64, policyID *int64) error
+}
+
+type ValuationRepository interface {
+	// FindByItem retrieves the valuations of an item, newest first
+	FindByItem(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error)
+
+	// FindByItems retrieves the valuations of each item, newest first.
+	// Items without valuations are not included.
+	FindByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error)
+
+	// Create creates a new valuation
+	Create(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error)
+
+	// FindLatestByItems retrieves the latest valuation of each item.
+	// Items without valuations are not included.
+	FindLatestByItems(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemValuation, error)
+}
+
+type OutboxRepository interface {
+	// Append records item events and sets their IDs. Call it in the transaction of the item mutation so that
+	// the events are committed or rolled back together with the mutation.
+	Append(ctx context.Context, events []*entity.OutboxEvent) error
+
+	// FindAfter retrieves up to limit events whose ID is greater than afterID, oldest first.
+	// It serves as the change log for resuming event streams.
+	FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.OutboxEvent, error)
+
+	// ClaimPending locks and retrieves up to limit events that have not been dispatched, oldest first.
+	// Events locked by another worker are skipped. It must be called in a transaction.
+	ClaimPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error)
+
+	// MarkDispatched records that the events were fanned out to webhook deliveries
+	MarkDispatched(ctx context.Context, ids []int64, at time.Time) error
+
+	// Transaction runs fn in a single transaction
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type WebhookRepository interface {
+	// FindSubscriptions retrieves all webhook subscriptions ordered by ID
+	FindSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	// FindSubscriptionByID retrieves a webhook subscription by ID
+	FindSubscriptionByID(ctx context.Context, id int64) (*entity.WebhookSubscription, error)
+
+	// CreateSubscription creates a new webhook subscription
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+
+	// UpdateSubscription saves the contents of a webhook subscription
+	UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+
+	// DeleteSubscription deletes a webhook subscription together with its deliveries and attempts
+	DeleteSubscription(ctx context.Context, id int64) error
+
+	// CreateDeliveries creates deliveries with a single multi-row INSERT
+	CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+
+	// FindDeliveries retrieves the deliveries matching the filter, newest first
+	FindDeliveries(ctx context.Context, filter DeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// FindDeliveryByID retrieves a delivery by ID
+	FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+
+	// ClaimDueDeliveries retrieves up to limit pending deliveries due at now and postpones them to leaseUntil,
+	// so that another worker does not send them while they are in flight. Deliveries whose sender crashed
+	// are retried after leaseUntil.
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	// UpdateDelivery saves the status, attempt count and schedule of a delivery
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// CreateAttempt records a delivery attempt
+	CreateAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error
+
+	// FindAttempts retrieves the attempts of a delivery, oldest first
+	FindAttempts(ctx context.Context, deliveryID int64) ([]*entity.WebhookAttempt, error)
+
+	// Transaction runs fn in a single transaction
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DeliveryFilter holds the delivery listing conditions. The zero value matches every delivery.
+type DeliveryFilter struct {
+	SubscriptionID int64
+	Status         entity.DeliveryStatus
+	// Limit caps the number of deliveries returned. 0 means no limit.
+	Limit int
+}
+
+// TenantRepository defines the interface for tenant data access. Unlike the other repositories it is not
+// scoped to the tenant of the context.
+type TenantRepository interface {
+	// FindAll retrieves all tenants ordered by ID
+	FindAll(ctx context.Context) ([]*entity.Tenant, error)
+
+	// FindBySlug retrieves a tenant by slug. It returns ErrTenantNotFound if no tenant has the slug.
+	FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error)
+
+	// Create creates a new tenant. It returns ErrDuplicateEntry if the slug is already used.
+	Create(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error)
 }
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
@@ -11,45 +14,174 @@ import (
 
 type ItemUsecase interface {
 	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	ListItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
+	GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
 	DeleteItem(ctx context.Context, id int64) error
 	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
+	ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error)
+	LookupItemsBySerial(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error)
+	FindDuplicateCandidates(ctx context.Context, query DuplicateQuery) ([]*DuplicateCandidate, error)
+	MergeItems(ctx context.Context, survivorID int64, input MergeItemsInput) (*entity.Item, error)
+	GetMergeHistory(ctx context.Context, id int64) ([]*entity.ItemMerge, error)
+	ChangeItemStatus(ctx context.Context, id int64, status entity.ItemStatus) (*entity.Item, error)
+	SellItem(ctx context.Context, id int64, input SellItemInput) (*entity.Item, error)
+	IncludeTotalCostOfOwnership(ctx context.Context, items []*entity.Item) error
+	IncludeBookValue(items []*entity.Item)
+	GetBookValueReport(ctx context.Context, asOf string) (*BookValueReport, error)
 	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	ExecuteBatch(ctx context.Context, input BatchInput) (*BatchResult, error)
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category"`
+	Brand             string                 `json:"brand"`
+	PurchasePrice     int                    `json:"purchase_price"`
+	PurchaseDate      string                 `json:"purchase_date"`
+	SerialNumber      string                 `json:"serial_number"`
+	ModelNumber       string                 `json:"model_number"`
+	Condition         string                 `json:"condition"`
+	Authenticity      string                 `json:"authenticity"`
+	WarrantyExpiresAt string                 `json:"warranty_expires_at"`
+	Attributes        map[string]interface{} `json:"attributes"`
 }
 
 // UpdateItemInput is the input for updating an existing item.
 // Fields are pointers to allow for partial updates (PATCH requests).
 // If a field is nil, it means the client did not provide it, and it should not be updated.
+// Attributes are merged key by key; a nil value removes the attribute.
 type UpdateItemInput struct {
-    Name           *string `json:"name"`
-    Brand          *string `json:"brand"`
-    PurchasePrice  *int    `json:"purchase_price"`
+    Name              *string                `json:"name"`
+    Category          *string                `json:"category"`
+    Brand             *string                `json:"brand"`
+    PurchasePrice     *int                   `json:"purchase_price"`
+    PurchaseDate      *string                `json:"purchase_date"`
+    SerialNumber      *string                `json:"serial_number"`
+    ModelNumber       *string                `json:"model_number"`
+    Condition         *string                `json:"condition"`
+    Authenticity      *string                `json:"authenticity"`
+    WarrantyExpiresAt *string                `json:"warranty_expires_at"`
+    Attributes        map[string]interface{} `json:"attributes"`
+}
+
+// IsEmpty reports whether no field is set.
+func (in UpdateItemInput) IsEmpty() bool {
+	return in.Name == nil && in.Category == nil && in.Brand == nil && in.PurchasePrice == nil && in.PurchaseDate == nil &&
+		in.SerialNumber == nil && in.ModelNumber == nil && in.Condition == nil && in.Authenticity == nil && in.WarrantyExpiresAt == nil && in.Attributes == nil
+}
+
+func (in UpdateItemInput) toPatch() entity.ItemPatch {
+	return entity.ItemPatch{
+		Name:              in.Name,
+		Category:          in.Category,
+		Brand:             in.Brand,
+		PurchasePrice:     in.PurchasePrice,
+		PurchaseDate:      in.PurchaseDate,
+		SerialNumber:      in.SerialNumber,
+		ModelNumber:       in.ModelNumber,
+		Condition:         in.Condition,
+		Authenticity:      in.Authenticity,
+		WarrantyExpiresAt: in.WarrantyExpiresAt,
+		Attributes:        in.Attributes,
+	}
+}
+
+// ReplaceItemInput is the input for replacing all mutable fields of an existing item (PUT requests).
+type ReplaceItemInput struct {
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category"`
+	Brand             string                 `json:"brand"`
+	PurchasePrice     int                    `json:"purchase_price"`
+	PurchaseDate      string                 `json:"purchase_date"`
+	SerialNumber      string                 `json:"serial_number"`
+	ModelNumber       string                 `json:"model_number"`
+	Condition         string                 `json:"condition"`
+	Authenticity      string                 `json:"authenticity"`
+	WarrantyExpiresAt string                 `json:"warranty_expires_at"`
+	Attributes        map[string]interface{} `json:"attributes"`
 }
 
 type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+	Categories map[string]int     `json:"categories"`
+	Total      int                `json:"total"`
+	Locations  []*LocationSummary `json:"locations"`
+	Statuses   map[string]int     `json:"statuses"`
+	Sales      *SalesSummary      `json:"sales"`
 }
 
 type itemUsecase struct {
 	itemRepo ItemRepository
+	// 状態変更で貸出記録を終了するために使う（未設定の場合は貸出記録を扱わない）
+	loanRepo LoanRepository
+	// 総保有コストに整備費用を含めるために使う（未設定の場合は購入価格のみ）
+	maintenanceRepo MaintenanceRepository
+	// アイテムの変更イベントを記録するために使う（未設定の場合はイベントを記録しない）
+	outboxRepo OutboxRepository
+	// コミットしたイベントをSSEなどに発行するために使う（未設定の場合は発行しない）
+	eventBus EventBus
+	// 帳簿価額の計算方法（既定はDefaultDepreciationModels）
+	depreciationModels entity.DepreciationModels
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+// ItemUsecaseOption configures optional dependencies of the item usecase.
+type ItemUsecaseOption func(*itemUsecase)
+
+// WithLoanRepository closes the active loan of an item when it leaves the lent status.
+func WithLoanRepository(loanRepo LoanRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.loanRepo = loanRepo
+	}
+}
+
+// WithMaintenanceRepository rolls maintenance costs into the total cost of ownership of items.
+func WithMaintenanceRepository(maintenanceRepo MaintenanceRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.maintenanceRepo = maintenanceRepo
+	}
+}
+
+// WithOutbox records item.created, item.updated and item.deleted events in the outbox
+// in the same transaction as each item mutation.
+func WithOutbox(outboxRepo OutboxRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.outboxRepo = outboxRepo
+	}
+}
+
+// WithEventBus publishes item events to in-process subscribers after each item mutation commits.
+func WithEventBus(bus EventBus) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.eventBus = bus
+	}
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
-	return &itemUsecase{
-		itemRepo: itemRepo,
+// WithDepreciationModels sets the per-category models used to compute book values.
+func WithDepreciationModels(models entity.DepreciationModels) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.depreciationModels = models
 	}
 }
 
+func NewItemUsecase(itemRepo ItemRepository, opts ...ItemUsecaseOption) ItemUsecase {
+	return newItemUsecase(itemRepo, opts...)
+}
+
+func newItemUsecase(itemRepo ItemRepository, opts ...ItemUsecaseOption) *itemUsecase {
+	u := &itemUsecase{
+		itemRepo:           itemRepo,
+		depreciationModels: entity.DefaultDepreciationModels,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
 func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
 	items, err := u.itemRepo.FindAll(ctx)
 	if err != nil {
@@ -59,6 +191,84 @@ func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
 	return items, nil
 }
 
+// ListItemsは絞り込み条件に一致するアイテムを取得する
+func (u *itemUsecase) ListItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	normalized, err := normalizeItemFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindByFilter(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	return items, nil
+}
+
+// 絞り込み条件を検証し、属性の値を保存形式に揃える
+func normalizeItemFilter(filter ItemFilter) (ItemFilter, error) {
+	var errs []string
+
+	normalized := ItemFilter{
+		Category:   strings.TrimSpace(filter.Category),
+		LocationID: filter.LocationID,
+		Status:     entity.ItemStatus(strings.TrimSpace(string(filter.Status))),
+	}
+
+	if normalized.Status != "" && !slices.Contains(entity.ValidItemStatuses, normalized.Status) {
+		errs = append(errs, fmt.Sprintf("status must be one of: %s", joinStatuses(entity.ValidItemStatuses)))
+	}
+
+	if normalized.LocationID < 0 {
+		errs = append(errs, "location_id must be a positive integer")
+	}
+
+	if normalized.Category != "" && !slices.Contains(entity.GetValidCategories(), normalized.Category) {
+		errs = append(errs, "category must be one of: "+strings.Join(entity.GetValidCategories(), ", "))
+	}
+
+	for _, tag := range filter.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			normalized.Tags = append(normalized.Tags, tag)
+		}
+	}
+
+	if len(filter.Attributes) > 0 {
+		normalized.Attributes = make(map[string]string, len(filter.Attributes))
+	}
+	for key, raw := range filter.Attributes {
+		var def entity.AttributeDefinition
+		var ok bool
+		if normalized.Category != "" {
+			def, ok = entity.LookupAttribute(normalized.Category, key)
+		} else {
+			def, ok = entity.LookupAttributeAnyCategory(key)
+		}
+		if !ok {
+			errs = append(errs, fmt.Sprintf("attributes.%s is not a known attribute", key))
+			continue
+		}
+
+		value, err := def.Decode(strings.TrimSpace(raw))
+		if err == nil {
+			value, err = def.Normalize(value)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		normalized.Attributes[key] = def.Encode(value)
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return ItemFilter{}, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+	}
+
+	return normalized, nil
+}
+
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
@@ -75,8 +285,67 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 	return item, nil
 }
 
+// LookupItemsBySerialはシリアル番号（ブランドを指定した場合はブランドも）が一致するアイテムを取得する
+func (u *itemUsecase) LookupItemsBySerial(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" {
+		return nil, fmt.Errorf("%w: serial is required", domainErrors.ErrInvalidInput)
+	}
+
+	items, err := u.itemRepo.FindBySerialNumber(ctx, serialNumber, strings.TrimSpace(brand))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetItemsByIDsは複数のアイテムをまとめて取得する。存在しないIDは結果に含めない
+func (u *itemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: item ID must be a positive integer", domainErrors.ErrInvalidInput)
+		}
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	return items, nil
+}
+
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
 	// バリデーションして、新しいエンティティを作成
+	item, err := newItemFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.createItem(ctx, item)
+}
+
+// 検証済みのアイテムを1つのトランザクションで登録し、作成イベントを記録する
+func (u *itemUsecase) createItem(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	var createdItem *entity.Item
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		var err error
+		createdItem, err = u.itemRepo.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+		return u.recordItemEvents(ctx, entity.EventItemCreated, createdItem)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create item: %w", err)
+	}
+
+	return createdItem, nil
+}
+
+// 入力からエンティティを作成する。バリデーションエラーはErrInvalidInputでラップする
+func newItemFromInput(input CreateItemInput) (*entity.Item, error) {
 	item, err := entity.NewItem(
 		input.Name,
 		input.Category,
@@ -88,12 +357,23 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
 	}
 
-	createdItem, err := u.itemRepo.Create(ctx, item)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+	if err := item.Apply(entity.ItemPatch{
+		SerialNumber:      &input.SerialNumber,
+		ModelNumber:       &input.ModelNumber,
+		Condition:         &input.Condition,
+		Authenticity:      &input.Authenticity,
+		WarrantyExpiresAt: &input.WarrantyExpiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
 	}
 
-	return createdItem, nil
+	if input.Attributes != nil {
+		if err := item.SetAttributes(input.Attributes); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+	}
+
+	return item, nil
 }
 
 func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
@@ -109,7 +389,12 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 		return fmt.Errorf("failed to check item existence: %w", err)
 	}
 
-	err = u.itemRepo.Delete(ctx, id)
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		if err := u.itemRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return u.recordDeletedEvents(ctx, id)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
@@ -117,48 +402,110 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	return nil
 }
 
-
-// 💡 新規追加: UpdateItemメソッド
+// UpdateItemは指定されたフィールドだけをエンティティに適用し、ドメインのバリデーションを通過した変更のみを永続化する
 func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
     if id <= 0 {
         return nil, domainErrors.ErrInvalidInput
     }
 
-    // 1. データベースから既存のアイテムを取得
     existingItem, err := u.itemRepo.FindByID(ctx, id)
     if err != nil {
-        // FindByIDがNotFoundエラーを返す場合、そのまま伝播
         if domainErrors.IsNotFoundError(err) {
             return nil, domainErrors.ErrItemNotFound
         }
         return nil, fmt.Errorf("failed to retrieve existing item: %w", err)
     }
 
-    // 2. 更新対象のフィールドを上書き
-    // inputのポインタがnilでない場合のみ更新
-    if input.Name != nil {
-        existingItem.Name = *input.Name
+    if err := existingItem.Apply(input.toPatch()); err != nil {
+        return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
     }
-    if input.Brand != nil {
-        existingItem.Brand = *input.Brand
-    }
-    if input.PurchasePrice != nil {
-        existingItem.PurchasePrice = *input.PurchasePrice
+
+    // 値が変わらない場合は書き込まない
+    if !existingItem.HasChanges() {
+        return existingItem, nil
     }
-    
-    // 3. 更新日時を現在時刻に設定
-    existingItem.UpdatedAt = time.Now()
 
-    // 4. 更新されたアイテムをリポジトリに渡し、データベースを更新
-    updatedItem, err := u.itemRepo.Update(ctx, existingItem)
+    updatedItem, err := u.saveItem(ctx, existingItem)
     if err != nil {
-        // リポジトリからのエラーを適切にラップして返す
         return nil, fmt.Errorf("failed to update item: %w", err)
     }
 
     return updatedItem, nil
 }
 
+// ReplaceItemは既存アイテムの変更可能なフィールドをすべて置き換える（IDと作成日時は維持される）
+func (u *itemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	existingItem, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve existing item: %w", err)
+	}
+
+	// エンティティ側で全フィールド（属性を含む）を置き換えてバリデーション
+	if err := existingItem.Apply(entity.ItemPatch{
+		Name:              &input.Name,
+		Category:          &input.Category,
+		Brand:             &input.Brand,
+		PurchasePrice:     &input.PurchasePrice,
+		PurchaseDate:      &input.PurchaseDate,
+		SerialNumber:      &input.SerialNumber,
+		ModelNumber:       &input.ModelNumber,
+		Condition:         &input.Condition,
+		Authenticity:      &input.Authenticity,
+		WarrantyExpiresAt: &input.WarrantyExpiresAt,
+		Attributes:        replaceAttributes(existingItem.Attributes, input.Attributes),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if !existingItem.HasChanges() {
+		return existingItem, nil
+	}
+
+	updatedItem, err := u.saveItem(ctx, existingItem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace item: %w", err)
+	}
+
+	return updatedItem, nil
+}
+
+// 変更したアイテムを保存し、更新イベントを記録する
+func (u *itemUsecase) saveItem(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	var updated *entity.Item
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+		return u.recordItemEvents(ctx, entity.EventItemUpdated, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// 既存の属性をすべて置き換えるためのパッチを作る（入力にないキーは削除する）
+func replaceAttributes(existing, replacement map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{}, len(existing)+len(replacement))
+	for key := range existing {
+		patch[key] = nil
+	}
+	for key, value := range replacement {
+		patch[key] = value
+	}
+	return patch
+}
+
 func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
 	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
 	if err != nil {
@@ -180,8 +527,30 @@ func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary,
 		}
 	}
 
+	locations, err := u.itemRepo.GetSummaryByLocation(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location summary: %w", err)
+	}
+
+	statusCounts, err := u.itemRepo.GetSummaryByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status summary: %w", err)
+	}
+	statuses := make(map[string]int, len(entity.ValidItemStatuses))
+	for _, status := range entity.ValidItemStatuses {
+		statuses[string(status)] = statusCounts[string(status)]
+	}
+
+	sales, err := u.itemRepo.GetSalesByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales summary: %w", err)
+	}
+
 	return &CategorySummary{
 		Categories: summary,
 		Total:      total,
+		Locations:  rollUpLocationSummaries(locations),
+		Statuses:   statuses,
+		Sales:      newSalesSummary(sales),
 	}, nil
 }
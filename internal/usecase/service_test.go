@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +25,22 @@ func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindByFilter(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindBySerialNumber(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error) {
+	args := m.Called(ctx, serialNumber, brand)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -32,6 +49,14 @@ func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.It
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	args := m.Called(ctx, item)
 	if args.Get(0) == nil {
@@ -40,11 +65,50 @@ func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	args := m.Called(ctx, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockItemRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockItemRepository) MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error {
+	args := m.Called(ctx, survivorID, duplicate)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) FindMergeHistory(ctx context.Context, survivorID int64) ([]*entity.ItemMerge, error) {
+	args := m.Called(ctx, survivorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemMerge), args.Error(1)
+}
+
 func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -53,6 +117,30 @@ func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[stri
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *MockItemRepository) GetSummaryByLocation(ctx context.Context) ([]*LocationSummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*LocationSummary), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByStatus(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSalesByCategory(ctx context.Context) (map[string]*SalesTotals, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*SalesTotals), args.Error(1)
+}
+
 // 💡 新規追加: MockItemRepository に Update メソッドを実装
 func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
     args := m.Called(ctx, item)
@@ -202,6 +290,65 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_GetItemsByIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		ids           []int64
+		setupMock     func(*MockItemRepository)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "正常系: 存在するアイテムだけを返す",
+			ids:  []int64{1, 2, 999},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item1.ID = 1
+				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 2000000, "2023-01-01")
+				item2.ID = 2
+				mockRepo.On("FindByIDs", mock.Anything, []int64{1, 2, 999}).Return([]*entity.Item{item1, item2}, nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "異常系: 無効なID（0以下）",
+			ids:  []int64{1, 0},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindByIDsは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			ids:  []int64{1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDs", mock.Anything, []int64{1}).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			items, err := usecase.GetItemsByIDs(context.Background(), tt.ids)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, items)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, items, tt.expectedCount)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestItemUsecase_CreateItem(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -400,6 +547,9 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 					"バッグ": 1,
 				}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetSummaryByLocation", mock.Anything).Return([]*LocationSummary{}, nil)
+				mockRepo.On("GetSummaryByStatus", mock.Anything).Return(map[string]int{}, nil)
+				mockRepo.On("GetSalesByCategory", mock.Anything).Return(map[string]*SalesTotals{}, nil)
 			},
 			expectedTotal:      3,
 			expectedWatchCount: 2,
@@ -411,6 +561,9 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			setupMock: func(mockRepo *MockItemRepository) {
 				summary := map[string]int{}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetSummaryByLocation", mock.Anything).Return([]*LocationSummary{}, nil)
+				mockRepo.On("GetSummaryByStatus", mock.Anything).Return(map[string]int{}, nil)
+				mockRepo.On("GetSalesByCategory", mock.Anything).Return(map[string]*SalesTotals{}, nil)
 			},
 			expectedTotal:      0,
 			expectedWatchCount: 0,
@@ -596,6 +749,118 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
             },
             expectError: false,
         },
+        {
+            name: "正常系: categoryとpurchase_dateを更新",
+            id:   1,
+            input: UpdateItemInput{
+                Category:     strPtr("ジュエリー"),
+                PurchaseDate: strPtr("2022-10-10"),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+
+                updatedItem := *existingItem
+                updatedItem.Category = "ジュエリー"
+                updatedItem.PurchaseDate = "2022-10-10"
+                mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+                    return item.Category == "ジュエリー" && item.PurchaseDate == "2022-10-10"
+                })).Return(&updatedItem, nil).Once()
+            },
+            expectError: false,
+        },
+        {
+            name: "正常系: purchase_priceを0円に更新",
+            id:   1,
+            input: UpdateItemInput{
+                PurchasePrice: intPtr(0),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+
+                updatedItem := *existingItem
+                updatedItem.PurchasePrice = 0
+                // 変更されたフィールドとして purchase_price だけが記録されていること
+                mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+                    return item.PurchasePrice == 0 &&
+                        assert.ObjectsAreEqual([]entity.ItemField{entity.FieldPurchasePrice}, item.ChangedFields())
+                })).Return(&updatedItem, nil).Once()
+            },
+            expectError: false,
+        },
+        {
+            name: "正常系: 値が変わらない場合はUpdateを呼ばない",
+            id:   1,
+            input: UpdateItemInput{
+                Name:          strPtr("ロレックス"),
+                PurchasePrice: intPtr(1500000),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+                // Updateは呼ばれない
+            },
+            expectError: false,
+        },
+        {
+            name: "異常系: nameが100文字を超える",
+            id:   1,
+            input: UpdateItemInput{
+                Name: strPtr(strings.Repeat("a", 300)),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+                // バリデーションエラーのためUpdateは呼ばれない
+            },
+            expectError: true,
+            expectedErr: domainErrors.ErrInvalidInput,
+        },
+        {
+            name: "異常系: brandが100文字を超える",
+            id:   1,
+            input: UpdateItemInput{
+                Brand: strPtr(strings.Repeat("b", 101)),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+            },
+            expectError: true,
+            expectedErr: domainErrors.ErrInvalidInput,
+        },
+        {
+            name: "異常系: 無効なカテゴリー",
+            id:   1,
+            input: UpdateItemInput{
+                Category: strPtr("無効なカテゴリー"),
+            },
+            setupMock: func(mockRepo *MockItemRepository) {
+                existingItem := &entity.Item{
+                    ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+                    PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+                }
+                mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+            },
+            expectError: true,
+            expectedErr: domainErrors.ErrInvalidInput,
+        },
         {
             name: "異常系: 存在しないID",
             id:   999,
@@ -668,6 +933,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
                 if tt.input.PurchasePrice != nil {
                     assert.Equal(t, *tt.input.PurchasePrice, updatedItem.PurchasePrice)
                 }
+                if tt.input.Category != nil {
+                    assert.Equal(t, *tt.input.Category, updatedItem.Category)
+                }
+                if tt.input.PurchaseDate != nil {
+                    assert.Equal(t, *tt.input.PurchaseDate, updatedItem.PurchaseDate)
+                }
             }
 
             mockRepo.AssertExpectations(t)
@@ -675,6 +946,114 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
     }
 }
 
+func TestItemUsecase_ReplaceItem(t *testing.T) {
+	validInput := ReplaceItemInput{
+		Name:          "オメガ スピードマスター",
+		Category:      "時計",
+		Brand:         "OMEGA",
+		PurchasePrice: 800000,
+		PurchaseDate:  "2022-12-24",
+	}
+
+	tests := []struct {
+		name        string
+		id          int64
+		input       ReplaceItemInput
+		setupMock   func(*MockItemRepository)
+		expectError bool
+		expectedErr error
+	}{
+		{
+			name:  "正常系: カテゴリーと購入日を含む全フィールドを置き換え",
+			id:    1,
+			input: validInput,
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem := &entity.Item{
+					ID: 1, Name: "ロレックス", Category: "その他", Brand: "ROLEX", PurchasePrice: 1500000,
+					PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+				}
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.ID == 1 && item.Category == "時計" && item.PurchaseDate == "2022-12-24"
+				})).Return(&entity.Item{
+					ID: 1, Name: "オメガ スピードマスター", Category: "時計", Brand: "OMEGA", PurchasePrice: 800000,
+					PurchaseDate: "2022-12-24", CreatedAt: existingItem.CreatedAt, UpdatedAt: time.Now(),
+				}, nil).Once()
+			},
+			expectError: false,
+		},
+		{
+			name: "異常系: 無効なカテゴリー",
+			id:   1,
+			input: ReplaceItemInput{
+				Name:          "オメガ スピードマスター",
+				Category:      "無効なカテゴリー",
+				Brand:         "OMEGA",
+				PurchasePrice: 800000,
+				PurchaseDate:  "2022-12-24",
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem := &entity.Item{
+					ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000,
+					PurchaseDate: "2023-01-01", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+				}
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+				// Updateは呼ばれない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないID",
+			id:    999,
+			input: validInput,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound).Once()
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:  "異常系: 無効なID",
+			id:    0,
+			input: validInput,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// 何もモックしない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			ctx := context.Background()
+			item, err := usecase.ReplaceItem(ctx, tt.id, tt.input)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+				assert.Nil(t, item)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.id, item.ID)
+				assert.Equal(t, tt.input.Name, item.Name)
+				assert.Equal(t, tt.input.Category, item.Category)
+				assert.Equal(t, tt.input.Brand, item.Brand)
+				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice)
+				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
 
 // 💡 ユーティリティ関数: 文字列のポインタを生成
 func strPtr(s string) *string {
@@ -684,4 +1063,124 @@ func strPtr(s string) *string {
 // 💡 ユーティリティ関数: 整数のポインタを生成
 func intPtr(i int) *int {
     return &i
-}
\ No newline at end of file
+}
+func TestItemUsecase_ListItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      ItemFilter
+		expected    ItemFilter
+		expectedErr string
+	}{
+		{
+			name:     "正常系: 属性の値を保存形式に揃えて検索する",
+			filter:   ItemFilter{Category: "時計", Tags: []string{" 限定 ", ""}, Attributes: map[string]string{"case_size_mm": "40.0"}},
+			expected: ItemFilter{Category: "時計", Tags: []string{"限定"}, Attributes: map[string]string{"case_size_mm": "40"}},
+		},
+		{
+			name:        "異常系: 無効なカテゴリー",
+			filter:      ItemFilter{Category: "家具"},
+			expectedErr: "category must be one of",
+		},
+		{
+			name:        "異常系: 未定義の属性",
+			filter:      ItemFilter{Attributes: map[string]string{"weight": "10"}},
+			expectedErr: "attributes.weight is not a known attribute",
+		},
+		{
+			name:        "異常系: 属性の型が異なる",
+			filter:      ItemFilter{Category: "靴", Attributes: map[string]string{"size": "L"}},
+			expectedErr: "attributes.size must be a number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedErr == "" {
+				mockRepo.On("FindByFilter", mock.Anything, tt.expected).Return([]*entity.Item{}, nil)
+			}
+			usecase := NewItemUsecase(mockRepo)
+
+			items, err := usecase.ListItems(context.Background(), tt.filter)
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, items)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, items)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_LookupItemsBySerial(t *testing.T) {
+	tests := []struct {
+		name         string
+		serialNumber string
+		brand        string
+		setupMock    func(*MockItemRepository)
+		expectedErr  error
+	}{
+		{
+			name:         "正常系: シリアル番号とブランドで検索",
+			serialNumber: " Z123456 ",
+			brand:        "ROLEX",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindBySerialNumber", mock.Anything, "Z123456", "ROLEX").
+					Return([]*entity.Item{{ID: 1, SerialNumber: "Z123456", Brand: "ROLEX"}}, nil)
+			},
+		},
+		{
+			name:         "異常系: シリアル番号が空",
+			serialNumber: "  ",
+			setupMock:    func(mockRepo *MockItemRepository) {},
+			expectedErr:  domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			items, err := usecase.LookupItemsBySerial(context.Background(), tt.serialNumber, tt.brand)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, items)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, items, 1)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_DuplicateSerialNumber(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.SerialNumber == "Z123456" && item.Condition == "S"
+	})).Return(nil, domainErrors.ErrDuplicateEntry)
+	usecase := NewItemUsecase(mockRepo)
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		SerialNumber:  "Z123456",
+		Condition:     "S",
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	assert.Nil(t, item)
+	mockRepo.AssertExpectations(t)
+}
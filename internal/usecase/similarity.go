@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 重複判定用に文字列を正規化する。
+// 全角・半角の揺れをNFKCで吸収し、カナはローマ字に変換して英字表記と比較できるようにする
+func normalizeForMatch(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+
+	var b strings.Builder
+	for _, r := range transliterateKana(s) {
+		// 空白・記号・アクセントの違いは無視する
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(foldAccent(r))
+		}
+	}
+	return b.String()
+}
+
+// 主要ブランドのカナ表記。英字表記に揃えて比較する
+var brandAliases = map[string][]string{
+	"rolex":              {"ロレックス"},
+	"omega":              {"オメガ"},
+	"cartier":            {"カルティエ"},
+	"hermes":             {"エルメス"},
+	"chanel":             {"シャネル"},
+	"louisvuitton":       {"ルイヴィトン", "ヴィトン"},
+	"gucci":              {"グッチ"},
+	"prada":              {"プラダ"},
+	"tiffanyco":          {"ティファニー"},
+	"christianlouboutin": {"ルブタン", "クリスチャンルブタン", "louboutin"},
+	"apple":              {"アップル"},
+}
+
+// ブランド名を比較用の表記に揃える。既知のブランドはカナ表記でも英字表記に変換する
+func canonicalBrand(brand string) string {
+	normalized := normalizeForMatch(brand)
+	for canonical, aliases := range brandAliases {
+		if normalized == canonical {
+			return canonical
+		}
+		for _, alias := range aliases {
+			if normalized == normalizeForMatch(alias) {
+				return canonical
+			}
+		}
+	}
+	return normalized
+}
+
+// 名前からブランド名（英字・カナ表記）を取り除いた比較用の文字列を返す
+func normalizeItemName(name, brand string) string {
+	normalized := normalizeForMatch(name)
+
+	canonical := canonicalBrand(brand)
+	words := []string{canonical, normalizeForMatch(brand)}
+	for _, alias := range brandAliases[canonical] {
+		words = append(words, normalizeForMatch(alias))
+	}
+
+	stripped := normalized
+	for _, word := range words {
+		if word != "" {
+			stripped = strings.ReplaceAll(stripped, word, "")
+		}
+	}
+	// 名前がブランド名だけの場合はそのまま比較する
+	if stripped == "" {
+		return normalized
+	}
+	return stripped
+}
+
+// 正規化済みの2つの文字列の類似度を0〜1で返す（編集距離に基づく）
+func similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// アクセント付きの英字を基本の英字にする（HERMÈS → hermes）
+func foldAccent(r rune) rune {
+	decomposed := norm.NFD.String(string(r))
+	for _, d := range decomposed {
+		if !unicode.Is(unicode.Mn, d) {
+			return d
+		}
+	}
+	return r
+}
+
+// 拗音（キャ、シュなど）の変換表
+var kanaDigraphs = map[string]string{
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo", "シャ": "sha", "シュ": "shu", "ショ": "sho",
+	"チャ": "cha", "チュ": "chu", "チョ": "cho", "ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo", "ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo", "ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジョ": "jo", "ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo", "ティ": "ti", "ディ": "di", "トゥ": "tu",
+	"ドゥ": "du", "ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo", "ウィ": "wi",
+	"ウェ": "we", "ウォ": "wo", "ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+	"シェ": "she", "ジェ": "je", "チェ": "che",
+}
+
+var kanaTable = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヲ': "o", 'ン': "n",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'ヴ': "vu",
+	'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+	'ャ': "ya", 'ュ': "yu", 'ョ': "yo",
+}
+
+// ひらがな・カタカナをヘボン式に近いローマ字に変換する。カナ以外の文字はそのまま残す
+func transliterateKana(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		// ひらがなはカタカナに揃える
+		if r >= 'ぁ' && r <= 'ゖ' {
+			runes[i] = r + ('ァ' - 'ぁ')
+		}
+	}
+
+	var b strings.Builder
+	doubleNext := false
+	for i := 0; i < len(runes); i++ {
+		var roman string
+		if i+1 < len(runes) {
+			if digraph, ok := kanaDigraphs[string(runes[i:i+2])]; ok {
+				roman = digraph
+				i++
+			}
+		}
+		if roman == "" {
+			switch runes[i] {
+			case 'ッ':
+				doubleNext = true
+				continue
+			case 'ー':
+				// 長音は表記揺れが大きいため無視する
+				continue
+			}
+			var ok bool
+			if roman, ok = kanaTable[runes[i]]; !ok {
+				doubleNext = false
+				b.WriteRune(runes[i])
+				continue
+			}
+		}
+
+		if doubleNext {
+			b.WriteByte(roman[0])
+			doubleNext = false
+		}
+		b.WriteString(roman)
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeForMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "正常系: 全角英数字と空白", input: "ＲＯＬＥＸ　デイトナ", expected: "rolexdeitona"},
+		{name: "正常系: 半角カナ", input: "ﾃﾞｲﾄﾅ", expected: "deitona"},
+		{name: "正常系: 促音と拗音", input: "ショッピング", expected: "shoppingu"},
+		{name: "正常系: アクセント記号と記号", input: "HERMÈS Birkin-30", expected: "hermesbirkin30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeForMatch(tt.input))
+		})
+	}
+}
+
+func TestNormalizeItemName(t *testing.T) {
+	// ブランド名の表記（カナ・英字）を除いて比較する
+	assert.Equal(t, "deitona", normalizeItemName("ロレックス デイトナ", "ROLEX"))
+	assert.Equal(t, "daytona", normalizeItemName("ROLEX Daytona", "ロレックス"))
+	// 名前がブランド名だけの場合は取り除かない
+	assert.Equal(t, "rolex", normalizeItemName("ROLEX", "ROLEX"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, similarity("rolex", "rolex"))
+	assert.Equal(t, 0.0, similarity("", "rolex"))
+	assert.InDelta(t, 0.714, similarity("deitona", "daytona"), 0.001)
+}
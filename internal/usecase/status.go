@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// SellItemInput is the sale price and sale date of an item. SalePrice is required.
+type SellItemInput struct {
+	SalePrice *int   `json:"sale_price"`
+	SaleDate  string `json:"sale_date"`
+}
+
+// SalesTotals is the purchase price and sale price totals of sold items.
+// RealizedGain is negative when the items were sold at a loss.
+type SalesTotals struct {
+	SoldCount     int   `json:"sold_count"`
+	PurchaseTotal int64 `json:"purchase_total"`
+	SaleTotal     int64 `json:"sale_total"`
+	RealizedGain  int64 `json:"realized_gain"`
+}
+
+// SalesSummary is the realized gain or loss of sold items, overall and by category.
+type SalesSummary struct {
+	SalesTotals
+	Categories map[string]*SalesTotals `json:"categories"`
+}
+
+// ChangeItemStatusはアイテムを売却以外の状態に遷移させる。遷移できない場合はErrConflictを返す
+func (u *itemUsecase) ChangeItemStatus(ctx context.Context, id int64, status entity.ItemStatus) (*entity.Item, error) {
+	return u.transitionItem(ctx, id, func(item *entity.Item) error {
+		return item.ChangeStatus(status)
+	})
+}
+
+// SellItemは売却価格と売却日を記録してアイテムを売却済みにする
+func (u *itemUsecase) SellItem(ctx context.Context, id int64, input SellItemInput) (*entity.Item, error) {
+	if input.SalePrice == nil {
+		return nil, fmt.Errorf("%w: sale_price is required", domainErrors.ErrInvalidInput)
+	}
+
+	return u.transitionItem(ctx, id, func(item *entity.Item) error {
+		return item.Sell(*input.SalePrice, input.SaleDate)
+	})
+}
+
+// 同時に状態を変更されないよう、アイテムの行をロックして取得し、更新までを1つのトランザクションで行う
+func (u *itemUsecase) transitionItem(ctx context.Context, id int64, change func(item *entity.Item) error) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var updated *entity.Item
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		previous := item.Status
+		if err := change(item); err != nil {
+			return statusChangeError(err)
+		}
+
+		updated, err = u.saveItem(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		// 貸出中から戻した・紛失した場合は貸出記録も終了する
+		if previous == entity.ItemStatusLent && u.loanRepo != nil {
+			return u.closeActiveLoan(ctx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsValidationError(err) || domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change item status: %w", err)
+	}
+
+	return updated, nil
+}
+
+// 貸出中の貸出記録があれば返却済みにする
+func (u *itemUsecase) closeActiveLoan(ctx context.Context, itemID int64) error {
+	loan, err := u.loanRepo.FindActiveLoanByItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrLoanNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := loan.Return(u.now()); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrConflict, err.Error())
+	}
+	return u.loanRepo.ReturnLoan(ctx, loan)
+}
+
+// 状態遷移のエラーをユースケースのエラーに変換する
+func statusChangeError(err error) error {
+	if errors.Is(err, entity.ErrInvalidStatusTransition) {
+		return fmt.Errorf("%w: %s", domainErrors.ErrConflict, err.Error())
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+}
+
+// カテゴリーごとの売却額から実現損益を計算する。売却済みのないカテゴリーも0件として含める
+func newSalesSummary(byCategory map[string]*SalesTotals) *SalesSummary {
+	summary := &SalesSummary{
+		Categories: make(map[string]*SalesTotals, len(entity.GetValidCategories())),
+	}
+
+	for _, category := range entity.GetValidCategories() {
+		totals := &SalesTotals{}
+		if sales, exists := byCategory[category]; exists {
+			totals.SoldCount = sales.SoldCount
+			totals.PurchaseTotal = sales.PurchaseTotal
+			totals.SaleTotal = sales.SaleTotal
+		}
+		totals.RealizedGain = totals.SaleTotal - totals.PurchaseTotal
+		summary.Categories[category] = totals
+
+		summary.SoldCount += totals.SoldCount
+		summary.PurchaseTotal += totals.PurchaseTotal
+		summary.SaleTotal += totals.SaleTotal
+	}
+	summary.RealizedGain = summary.SaleTotal - summary.PurchaseTotal
+
+	return summary
+}
+
+func joinStatuses(statuses []entity.ItemStatus) string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_ChangeItemStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     entity.ItemStatus
+		next        entity.ItemStatus
+		expectedErr error
+	}{
+		{name: "正常系: 所有中から修理中", current: entity.ItemStatusOwned, next: entity.ItemStatusInRepair},
+		{name: "正常系: 貸出中から所有中に戻す", current: entity.ItemStatusLent, next: entity.ItemStatusOwned},
+		{name: "異常系: 許可されていない遷移", current: entity.ItemStatusLent, next: entity.ItemStatusConsigned, expectedErr: domainErrors.ErrConflict},
+		{name: "異常系: 不正な状態", current: entity.ItemStatusOwned, next: "stolen", expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: tt.current}
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(item, nil)
+			if tt.expectedErr == nil {
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == tt.next
+				})).Return(item, nil)
+			}
+			usecase := NewItemUsecase(mockRepo)
+
+			updated, err := usecase.ChangeItemStatus(context.Background(), 1, tt.next)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, updated)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.next, updated.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_SellItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       SellItemInput
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 売却価格と売却日を記録",
+			input: SellItemInput{SalePrice: intPtr(1800000), SaleDate: "2024-01-10"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+					ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-01", Status: entity.ItemStatusOwned,
+				}, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == entity.ItemStatusSold && *item.SalePrice == 1800000 && item.SaleDate == "2024-01-10"
+				})).Return(&entity.Item{ID: 1, Status: entity.ItemStatusSold}, nil)
+			},
+		},
+		{
+			name:        "異常系: 売却価格がない",
+			input:       SellItemInput{SaleDate: "2024-01-10"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 売却済みのアイテム",
+			input: SellItemInput{SalePrice: intPtr(1800000), SaleDate: "2024-01-10"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(&entity.Item{
+					ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01",
+					Status: entity.ItemStatusSold, SalePrice: intPtr(100), SaleDate: "2023-02-01",
+				}, nil)
+			},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			input: SellItemInput{SalePrice: intPtr(1800000), SaleDate: "2024-01-10"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			item, err := usecase.SellItem(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.ItemStatusSold, item.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// rowLockingItemRepository は1件のアイテムを保持し、FindByIDForUpdateでトランザクションの終了まで行をロックする。
+// 最初のUpdateはreleaseが閉じられるまで待つ
+type rowLockingItemRepository struct {
+	*MockItemRepository
+	row     sync.Mutex
+	mu      sync.Mutex
+	item    entity.Item
+	locking chan struct{}
+	release chan struct{}
+	updates int
+}
+
+type rowLockKey struct{}
+
+func (r *rowLockingItemRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, nested := ctx.Value(rowLockKey{}).(*bool); nested {
+		return fn(ctx)
+	}
+	locked := new(bool)
+	defer func() {
+		if *locked {
+			r.row.Unlock()
+		}
+	}()
+	return fn(context.WithValue(ctx, rowLockKey{}, locked))
+}
+
+func (r *rowLockingItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	// ロックを取得したとき、またはロックを待ち始めるときにlockingに通知する
+	if r.row.TryLock() {
+		r.locking <- struct{}{}
+	} else {
+		r.locking <- struct{}{}
+		r.row.Lock()
+	}
+	*ctx.Value(rowLockKey{}).(*bool) = true
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	item := r.item
+	return &item, nil
+}
+
+func (r *rowLockingItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	r.updates++
+	first := r.updates == 1
+	r.mu.Unlock()
+	if first {
+		<-r.release
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.item = *item
+	return item, nil
+}
+
+func TestItemUsecase_SellItem_Concurrent(t *testing.T) {
+	repo := &rowLockingItemRepository{
+		MockItemRepository: new(MockItemRepository),
+		item:               entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusOwned},
+		locking:            make(chan struct{}, 2),
+		release:            make(chan struct{}),
+	}
+	usecase := NewItemUsecase(repo)
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	sell := func(i int, price int) {
+		defer wg.Done()
+		_, errs[i] = usecase.SellItem(context.Background(), 1, SellItemInput{SalePrice: intPtr(price), SaleDate: "2024-01-10"})
+	}
+	wg.Add(2)
+	go sell(0, 1800000)
+	<-repo.locking
+	// 1件目が行をロックして更新する前に、2件目が同じアイテムを取得しようとする
+	go sell(1, 1900000)
+	<-repo.locking
+	close(repo.release)
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], domainErrors.ErrConflict)
+	assert.Equal(t, 1, repo.updates)
+	assert.Equal(t, 1800000, *repo.item.SalePrice)
+}
+
+func TestItemUsecase_GetCategorySummary_Sales(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2, "バッグ": 1}, nil)
+	mockRepo.On("GetSummaryByLocation", mock.Anything).Return([]*LocationSummary{}, nil)
+	mockRepo.On("GetSummaryByStatus", mock.Anything).Return(map[string]int{"owned": 1, "sold": 2}, nil)
+	mockRepo.On("GetSalesByCategory", mock.Anything).Return(map[string]*SalesTotals{
+		"時計":  {SoldCount: 1, PurchaseTotal: 1500000, SaleTotal: 1800000},
+		"バッグ": {SoldCount: 1, PurchaseTotal: 2000000, SaleTotal: 1500000},
+	}, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	summary, err := usecase.GetCategorySummary(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Statuses["sold"])
+	assert.Equal(t, 0, summary.Statuses["lost"])
+	assert.Equal(t, 2, summary.Sales.SoldCount)
+	assert.Equal(t, int64(-200000), summary.Sales.RealizedGain)
+	assert.Equal(t, int64(300000), summary.Sales.Categories["時計"].RealizedGain)
+	assert.Equal(t, int64(-500000), summary.Sales.Categories["バッグ"].RealizedGain)
+	assert.Equal(t, 0, summary.Sales.Categories["靴"].SoldCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_ChangeItemStatus_ClosesActiveLoan(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", Status: entity.ItemStatusLent}
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(item, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(item, nil)
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("FindActiveLoanByItem", mock.Anything, int64(1)).Return(&entity.Loan{ID: 10, ItemID: 1}, nil)
+	loanRepo.On("ReturnLoan", mock.Anything, mock.MatchedBy(func(loan *entity.Loan) bool {
+		return loan.ID == 10 && loan.ReturnedAt != nil
+	})).Return(nil)
+	usecase := NewItemUsecase(mockRepo, WithLoanRepository(loanRepo))
+
+	_, err := usecase.ChangeItemStatus(context.Background(), 1, entity.ItemStatusLost)
+
+	require.NoError(t, err)
+	loanRepo.AssertExpectations(t)
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 1回のリクエストでアイテムに付与できるタグ数の上限
+const MaxTagsPerRequest = 20
+
+type TagUsecase interface {
+	GetAllTags(ctx context.Context) ([]*entity.Tag, error)
+	GetTagByID(ctx context.Context, id int64) (*entity.Tag, error)
+	CreateTag(ctx context.Context, input TagInput) (*entity.Tag, error)
+	UpdateTag(ctx context.Context, id int64, input TagInput) (*entity.Tag, error)
+	DeleteTag(ctx context.Context, id int64) error
+	AddItemTags(ctx context.Context, itemID int64, input AddItemTagsInput) (*entity.Item, error)
+	RemoveItemTag(ctx context.Context, itemID, tagID int64) (*entity.Item, error)
+}
+
+type TagInput struct {
+	Name string `json:"name"`
+}
+
+// AddItemTagsInput はアイテムに付与するタグ名の一覧。存在しないタグは作成される
+type AddItemTagsInput struct {
+	Tags []string `json:"tags"`
+}
+
+type tagUsecase struct {
+	tagRepo  TagRepository
+	itemRepo ItemRepository
+}
+
+func NewTagUsecase(tagRepo TagRepository, itemRepo ItemRepository) TagUsecase {
+	return &tagUsecase{
+		tagRepo:  tagRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (u *tagUsecase) GetAllTags(ctx context.Context) ([]*entity.Tag, error) {
+	tags, err := u.tagRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (u *tagUsecase) GetTagByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	tag, err := u.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (u *tagUsecase) CreateTag(ctx context.Context, input TagInput) (*entity.Tag, error) {
+	tag, err := entity.NewTag(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.tagRepo.Create(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *tagUsecase) UpdateTag(ctx context.Context, id int64, input TagInput) (*entity.Tag, error) {
+	tag, err := u.GetTagByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tag.Rename(input.Name); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	updated, err := u.tagRepo.Update(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (u *tagUsecase) DeleteTag(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.tagRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrTagNotFound
+		}
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+// AddItemTagsはタグ名でアイテムにタグを付与する。未登録のタグは作成し、付与済みのタグは無視する
+func (u *tagUsecase) AddItemTags(ctx context.Context, itemID int64, input AddItemTagsInput) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	names, err := normalizeTagNames(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	err = u.tagRepo.Transaction(ctx, func(ctx context.Context) error {
+		tags, err := u.tagRepo.FindByNames(ctx, names)
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[string]int64, len(tags))
+		for _, tag := range tags {
+			existing[strings.ToLower(tag.Name)] = tag.ID
+		}
+
+		tagIDs := make([]int64, 0, len(names))
+		for _, name := range names {
+			if id, ok := existing[strings.ToLower(name)]; ok {
+				tagIDs = append(tagIDs, id)
+				continue
+			}
+			tag, err := entity.NewTag(name)
+			if err != nil {
+				return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+			}
+			created, err := u.tagRepo.Create(ctx, tag)
+			if err != nil {
+				return err
+			}
+			existing[strings.ToLower(created.Name)] = created.ID
+			tagIDs = append(tagIDs, created.ID)
+		}
+
+		return u.tagRepo.AttachToItem(ctx, itemID, tagIDs)
+	})
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to add tags: %w", err)
+	}
+
+	return u.itemRepo.FindByID(ctx, itemID)
+}
+
+func (u *tagUsecase) RemoveItemTag(ctx context.Context, itemID, tagID int64) (*entity.Item, error) {
+	if itemID <= 0 || tagID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	if err := u.tagRepo.DetachFromItem(ctx, itemID, tagID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	return u.itemRepo.FindByID(ctx, itemID)
+}
+
+// タグ名の前後の空白を除去し、大文字小文字を区別せずに重複を取り除く
+func normalizeTagNames(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: tags is required", domainErrors.ErrInvalidInput)
+	}
+	if len(names) > MaxTagsPerRequest {
+		return nil, fmt.Errorf("%w: tags must be %d or fewer", domainErrors.ErrInvalidInput, MaxTagsPerRequest)
+	}
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := entity.NewTag(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		key := strings.ToLower(tag.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag.Name)
+	}
+
+	return normalized, nil
+}
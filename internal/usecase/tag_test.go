@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockTagRepository はtestify/mockを使用したタグのモックリポジトリ
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context) ([]*entity.Tag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) AttachToItem(ctx context.Context, itemID int64, tagIDs []int64) error {
+	args := m.Called(ctx, itemID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) DetachFromItem(ctx context.Context, itemID, tagID int64) error {
+	args := m.Called(ctx, itemID, tagID)
+	return args.Error(0)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockTagRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestTagUsecase_CreateTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       TagInput
+		setupMock   func(*MockTagRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: タグを作成",
+			input: TagInput{Name: " ヴィンテージ "},
+			setupMock: func(tagRepo *MockTagRepository) {
+				tagRepo.On("Create", mock.Anything, mock.MatchedBy(func(tag *entity.Tag) bool {
+					return tag.Name == "ヴィンテージ"
+				})).Return(&entity.Tag{ID: 1, Name: "ヴィンテージ"}, nil)
+			},
+		},
+		{
+			name:        "異常系: タグ名が空",
+			input:       TagInput{Name: "  "},
+			setupMock:   func(tagRepo *MockTagRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 同名のタグが存在する",
+			input: TagInput{Name: "ヴィンテージ"},
+			setupMock: func(tagRepo *MockTagRepository) {
+				tagRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedErr: domainErrors.ErrDuplicateEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := new(MockTagRepository)
+			tt.setupMock(tagRepo)
+			usecase := NewTagUsecase(tagRepo, new(MockItemRepository))
+
+			tag, err := usecase.CreateTag(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tag)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "ヴィンテージ", tag.Name)
+			}
+
+			tagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagUsecase_AddItemTags(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"}
+
+	tests := []struct {
+		name        string
+		input       AddItemTagsInput
+		setupMock   func(*MockTagRepository, *MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 既存タグは再利用し、未登録のタグは作成する",
+			input: AddItemTagsInput{Tags: []string{"ヴィンテージ", "限定", "ヴィンテージ"}},
+			setupMock: func(tagRepo *MockTagRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				tagRepo.On("FindByNames", mock.Anything, []string{"ヴィンテージ", "限定"}).
+					Return([]*entity.Tag{{ID: 1, Name: "ヴィンテージ"}}, nil)
+				tagRepo.On("Create", mock.Anything, mock.MatchedBy(func(tag *entity.Tag) bool {
+					return tag.Name == "限定"
+				})).Return(&entity.Tag{ID: 2, Name: "限定"}, nil)
+				tagRepo.On("AttachToItem", mock.Anything, int64(1), []int64{1, 2}).Return(nil)
+			},
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			input: AddItemTagsInput{Tags: []string{"限定"}},
+			setupMock: func(tagRepo *MockTagRepository, itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:        "異常系: タグが指定されていない",
+			input:       AddItemTagsInput{},
+			setupMock:   func(tagRepo *MockTagRepository, itemRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := new(MockTagRepository)
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(tagRepo, itemRepo)
+			usecase := NewTagUsecase(tagRepo, itemRepo)
+
+			result, err := usecase.AddItemTags(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), result.ID)
+			}
+
+			tagRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// テナントのIDをctxに保持するためのキー
+type tenantKey struct{}
+
+// WithTenant returns a context scoped to the tenant. Repositories read and write only the data of the tenant
+// of the context and fail with ErrTenantRequired when the context has no tenant.
+func WithTenant(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the tenant set by WithTenant
+func TenantIDFromContext(ctx context.Context) (int64, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(int64)
+	return tenantID, ok
+}
+
+type TenantUsecase interface {
+	GetTenants(ctx context.Context) ([]*entity.Tenant, error)
+	GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error)
+	CreateTenant(ctx context.Context, input TenantInput) (*entity.Tenant, error)
+	// ForEachTenant runs fn once for every tenant with a context scoped to the tenant.
+	// Background jobs use it to process the data of all tenants. An error of one tenant does not stop the others.
+	ForEachTenant(ctx context.Context, fn func(ctx context.Context, tenant *entity.Tenant) error) error
+}
+
+type TenantInput struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type tenantUsecase struct {
+	tenantRepo TenantRepository
+}
+
+func NewTenantUsecase(tenantRepo TenantRepository) TenantUsecase {
+	return &tenantUsecase{
+		tenantRepo: tenantRepo,
+	}
+}
+
+func (u *tenantUsecase) GetTenants(ctx context.Context) ([]*entity.Tenant, error) {
+	tenants, err := u.tenantRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+func (u *tenantUsecase) GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	tenant, err := u.tenantRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+func (u *tenantUsecase) CreateTenant(ctx context.Context, input TenantInput) (*entity.Tenant, error) {
+	tenant, err := entity.NewTenant(input.Slug, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.tenantRepo.Create(ctx, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *tenantUsecase) ForEachTenant(ctx context.Context, fn func(ctx context.Context, tenant *entity.Tenant) error) error {
+	tenants, err := u.GetTenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, tenant := range tenants {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(WithTenant(ctx, tenant.ID), tenant); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant.Slug, err))
+		}
+	}
+	return errors.Join(errs...)
+}
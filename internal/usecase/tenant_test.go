@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockTenantRepository はtestify/mockを使用したテナントのモックリポジトリ
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) FindAll(ctx context.Context) ([]*entity.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) Create(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error) {
+	args := m.Called(ctx, tenant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tenant), args.Error(1)
+}
+
+func TestTenantUsecase_CreateTenant(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       TenantInput
+		setupMock   func(*MockTenantRepository)
+		expectedErr error
+		wantErr     bool
+	}{
+		{
+			name:  "正常系: スラッグを小文字にして作成する",
+			input: TenantInput{Slug: " Acme ", Name: "Acme株式会社"},
+			setupMock: func(tenantRepo *MockTenantRepository) {
+				tenantRepo.On("Create", mock.Anything, mock.MatchedBy(func(tenant *entity.Tenant) bool {
+					return tenant.Slug == "acme" && tenant.Name == "Acme株式会社"
+				})).Return(&entity.Tenant{ID: 2, Slug: "acme", Name: "Acme株式会社"}, nil)
+			},
+		},
+		{
+			name:        "異常系: サブドメインに使えないスラッグ",
+			input:       TenantInput{Slug: "-acme", Name: "Acme株式会社"},
+			setupMock:   func(tenantRepo *MockTenantRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+			wantErr:     true,
+		},
+		{
+			name:        "異常系: 名前がない",
+			input:       TenantInput{Slug: "acme"},
+			setupMock:   func(tenantRepo *MockTenantRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+			wantErr:     true,
+		},
+		{
+			name:  "異常系: スラッグが重複している",
+			input: TenantInput{Slug: "default", Name: "Default"},
+			setupMock: func(tenantRepo *MockTenantRepository) {
+				tenantRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedErr: domainErrors.ErrDuplicateEntry,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo := new(MockTenantRepository)
+			tt.setupMock(tenantRepo)
+			usecase := NewTenantUsecase(tenantRepo)
+
+			tenant, err := usecase.CreateTenant(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tenant)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "acme", tenant.Slug)
+			}
+			tenantRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantUsecase_GetTenantBySlug(t *testing.T) {
+	tests := []struct {
+		name        string
+		slug        string
+		setupMock   func(*MockTenantRepository)
+		expectedErr error
+		wantErr     bool
+	}{
+		{
+			name: "正常系: 大文字のスラッグでも取得できる",
+			slug: "ACME",
+			setupMock: func(tenantRepo *MockTenantRepository) {
+				tenantRepo.On("FindBySlug", mock.Anything, "acme").Return(&entity.Tenant{ID: 2, Slug: "acme"}, nil)
+			},
+		},
+		{
+			name: "異常系: 存在しないテナント",
+			slug: "unknown",
+			setupMock: func(tenantRepo *MockTenantRepository) {
+				tenantRepo.On("FindBySlug", mock.Anything, "unknown").Return(nil, domainErrors.ErrTenantNotFound)
+			},
+			expectedErr: domainErrors.ErrTenantNotFound,
+			wantErr:     true,
+		},
+		{
+			name:        "異常系: スラッグが空",
+			slug:        " ",
+			setupMock:   func(tenantRepo *MockTenantRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo := new(MockTenantRepository)
+			tt.setupMock(tenantRepo)
+			usecase := NewTenantUsecase(tenantRepo)
+
+			tenant, err := usecase.GetTenantBySlug(context.Background(), tt.slug)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tenant)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), tenant.ID)
+			}
+			tenantRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantUsecase_ForEachTenant(t *testing.T) {
+	tenants := []*entity.Tenant{{ID: 1, Slug: "default"}, {ID: 2, Slug: "acme"}}
+
+	t.Run("正常系: テナントごとにそのテナントのctxで実行する", func(t *testing.T) {
+		tenantRepo := new(MockTenantRepository)
+		tenantRepo.On("FindAll", mock.Anything).Return(tenants, nil)
+		usecase := NewTenantUsecase(tenantRepo)
+
+		var visited []int64
+		err := usecase.ForEachTenant(context.Background(), func(ctx context.Context, tenant *entity.Tenant) error {
+			tenantID, ok := TenantIDFromContext(ctx)
+			require.True(t, ok)
+			assert.Equal(t, tenant.ID, tenantID)
+			visited = append(visited, tenantID)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, visited)
+	})
+
+	t.Run("異常系: 失敗したテナントがあっても残りのテナントを実行する", func(t *testing.T) {
+		tenantRepo := new(MockTenantRepository)
+		tenantRepo.On("FindAll", mock.Anything).Return(tenants, nil)
+		usecase := NewTenantUsecase(tenantRepo)
+
+		failure := errors.New("notification failed")
+		var visited []string
+		err := usecase.ForEachTenant(context.Background(), func(ctx context.Context, tenant *entity.Tenant) error {
+			visited = append(visited, tenant.Slug)
+			if tenant.Slug == "default" {
+				return failure
+			}
+			return nil
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Contains(t, err.Error(), "tenant default")
+		assert.Equal(t, []string{"default", "acme"}, visited)
+	})
+}
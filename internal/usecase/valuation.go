@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ValuationUsecase interface {
+	GetItemValuations(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error)
+	GetValuationsByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error)
+	AddValuation(ctx context.Context, itemID int64, input ValuationInput) (*entity.ItemValuation, error)
+}
+
+type ValuationInput struct {
+	ValuedAt string `json:"valued_at"`
+	Amount   int    `json:"amount"`
+	Source   string `json:"source"`
+}
+
+type valuationUsecase struct {
+	valuationRepo ValuationRepository
+	itemRepo      ItemRepository
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewValuationUsecase(valuationRepo ValuationRepository, itemRepo ItemRepository) ValuationUsecase {
+	return &valuationUsecase{
+		valuationRepo: valuationRepo,
+		itemRepo:      itemRepo,
+		now:           time.Now,
+	}
+}
+
+func (u *valuationUsecase) GetItemValuations(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error) {
+	if _, err := findItemByID(ctx, u.itemRepo, itemID); err != nil {
+		return nil, err
+	}
+
+	valuations, err := u.valuationRepo.FindByItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	return valuations, nil
+}
+
+// GetValuationsByItemsは複数のアイテムの評価額をまとめて取得する。評価額のないアイテムは結果に含めない
+func (u *valuationUsecase) GetValuationsByItems(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemValuation, error) {
+	valuations, err := u.valuationRepo.FindByItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	return valuations, nil
+}
+
+// AddValuationは評価額を記録する。評価日は購入日から今日までの日付
+func (u *valuationUsecase) AddValuation(ctx context.Context, itemID int64, input ValuationInput) (*entity.ItemValuation, error) {
+	item, err := findItemByID(ctx, u.itemRepo, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation, err := entity.NewItemValuation(item.ID, input.ValuedAt, input.Amount, input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if valuation.ValuedAt < item.PurchaseDate {
+		return nil, fmt.Errorf("%w: valued_at must be on or after purchase_date", domainErrors.ErrInvalidInput)
+	}
+	if valuation.ValuedAt > u.now().Format("2006-01-02") {
+		return nil, fmt.Errorf("%w: valued_at must not be in the future", domainErrors.ErrInvalidInput)
+	}
+
+	created, err := u.valuationRepo.Create(ctx, valuation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create valuation: %w", err)
+	}
+
+	return created, nil
+}
+
+// IDでアイテムを取得する。存在しない場合はErrItemNotFoundを返す
+func findItemByID(ctx context.Context, itemRepo ItemRepository, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestValuationUsecase_AddValuation(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       ValuationInput
+		setupMock   func(*MockValuationRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 評価額を記録",
+			input: ValuationInput{ValuedAt: "2024-05-01", Amount: 2200000, Source: "買取店査定"},
+			setupMock: func(valuationRepo *MockValuationRepository) {
+				valuationRepo.On("Create", mock.Anything, mock.MatchedBy(func(valuation *entity.ItemValuation) bool {
+					return valuation.ItemID == 1 && valuation.Amount == 2200000 && valuation.Source == "買取店査定"
+				})).Return(&entity.ItemValuation{ID: 1, ItemID: 1, Amount: 2200000}, nil)
+			},
+		},
+		{
+			name:        "異常系: 評価日が購入日より前",
+			input:       ValuationInput{ValuedAt: "2022-12-31", Amount: 2200000},
+			setupMock:   func(valuationRepo *MockValuationRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 評価日が未来",
+			input:       ValuationInput{ValuedAt: "2024-06-16", Amount: 2200000},
+			setupMock:   func(valuationRepo *MockValuationRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 金額が負の値",
+			input:       ValuationInput{ValuedAt: "2024-05-01", Amount: -1},
+			setupMock:   func(valuationRepo *MockValuationRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}, nil)
+			valuationRepo := new(MockValuationRepository)
+			tt.setupMock(valuationRepo)
+			usecase := NewValuationUsecase(valuationRepo, itemRepo).(*valuationUsecase)
+			usecase.now = func() time.Time {
+				return time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+			}
+
+			valuation, err := usecase.AddValuation(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, valuation)
+				valuationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 2200000, valuation.Amount)
+			}
+
+			valuationRepo.AssertExpectations(t)
+		})
+	}
+}
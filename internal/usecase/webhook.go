@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type WebhookUsecase interface {
+	GetSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*entity.WebhookSubscription, error)
+	CreateSubscription(ctx context.Context, input WebhookInput) (*entity.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id int64, input WebhookInput) (*entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, subscriptionID int64, status string) ([]*entity.WebhookDelivery, error)
+	GetDeadLetters(ctx context.Context) ([]*entity.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id int64) (*DeliveryLog, error)
+	RetryDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	ProcessWebhooks(ctx context.Context) (*WebhookRunResult, error)
+}
+
+// WebhookInput is the input for creating or replacing a webhook subscription.
+// On update an empty Secret keeps the current secret and a nil Active keeps the current state.
+type WebhookInput struct {
+	URL    string             `json:"url"`
+	Events []entity.EventType `json:"events"`
+	Secret string             `json:"secret"`
+	Active *bool              `json:"active"`
+}
+
+// DeliveryLog is a delivery with the log of its attempts.
+type DeliveryLog struct {
+	*entity.WebhookDelivery
+	AttemptLogs []*entity.WebhookAttempt `json:"attempt_logs"`
+}
+
+// WebhookRunResult is the outcome of one run of the webhook worker.
+type WebhookRunResult struct {
+	Dispatched int `json:"dispatched"` // outbox events fanned out to deliveries
+	Succeeded  int `json:"succeeded"`
+	Failed     int `json:"failed"` // deliveries scheduled for retry or moved to the dead letters
+}
+
+// WebhookSender posts a signed delivery to the subscription URL.
+// Implementations live in the infrastructure layer.
+type WebhookSender interface {
+	// Send returns the response status code. err is non-nil when no response was received.
+	Send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error)
+}
+
+// 1回の実行で振り分けるイベント・送信する配信の上限
+const webhookBatchSize = 100
+
+// 送信中の配信を他のワーカーが取得しないよう先送りする時間（送信のタイムアウトより長くする）
+const webhookDeliveryLease = 5 * time.Minute
+
+// 配信履歴の一覧で返す件数の上限
+const maxDeliveriesListed = 100
+
+type webhookUsecase struct {
+	webhookRepo WebhookRepository
+	outboxRepo  OutboxRepository
+	sender      WebhookSender
+	// テストで日付を固定するための現在時刻
+	now func() time.Time
+}
+
+func NewWebhookUsecase(webhookRepo WebhookRepository, outboxRepo OutboxRepository, sender WebhookSender) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+		outboxRepo:  outboxRepo,
+		sender:      sender,
+		now:         time.Now,
+	}
+}
+
+func (u *webhookUsecase) GetSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	subscriptions, err := u.webhookRepo.FindSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (u *webhookUsecase) GetSubscription(ctx context.Context, id int64) (*entity.WebhookSubscription, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	subscription, err := u.webhookRepo.FindSubscriptionByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (u *webhookUsecase) CreateSubscription(ctx context.Context, input WebhookInput) (*entity.WebhookSubscription, error) {
+	subscription, err := entity.NewWebhookSubscription(input.URL, input.Events, input.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if input.Active != nil {
+		subscription.Active = *input.Active
+	}
+
+	created, err := u.webhookRepo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *webhookUsecase) UpdateSubscription(ctx context.Context, id int64, input WebhookInput) (*entity.WebhookSubscription, error) {
+	subscription, err := u.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active := subscription.Active
+	if input.Active != nil {
+		active = *input.Active
+	}
+	if err := subscription.Update(input.URL, input.Events, input.Secret, active); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	updated, err := u.webhookRepo.UpdateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSubscriptionは購読を削除する。未配信・デッドレターの配信も削除される
+func (u *webhookUsecase) DeleteSubscription(ctx context.Context, id int64) error {
+	if _, err := u.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.webhookRepo.DeleteSubscription(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveriesは購読の配信を新しい順で返す。statusを指定した場合はその状態の配信だけを返す
+func (u *webhookUsecase) GetDeliveries(ctx context.Context, subscriptionID int64, status string) ([]*entity.WebhookDelivery, error) {
+	deliveryStatus := entity.DeliveryStatus(strings.TrimSpace(status))
+	if deliveryStatus != "" && !slices.Contains(entity.ValidDeliveryStatuses, deliveryStatus) {
+		return nil, fmt.Errorf("%w: status must be one of: pending, succeeded, dead", domainErrors.ErrInvalidInput)
+	}
+
+	if _, err := u.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.webhookRepo.FindDeliveries(ctx, DeliveryFilter{
+		SubscriptionID: subscriptionID,
+		Status:         deliveryStatus,
+		Limit:          maxDeliveriesListed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeadLettersは再試行の上限に達したすべての購読の配信を新しい順で返す
+func (u *webhookUsecase) GetDeadLetters(ctx context.Context) ([]*entity.WebhookDelivery, error) {
+	deliveries, err := u.webhookRepo.FindDeliveries(ctx, DeliveryFilter{Status: entity.DeliveryDead})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve dead letters: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveryは配信と試行ごとのログを返す
+func (u *webhookUsecase) GetDelivery(ctx context.Context, id int64) (*DeliveryLog, error) {
+	delivery, err := u.findDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := u.webhookRepo.FindAttempts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook attempts: %w", err)
+	}
+
+	return &DeliveryLog{WebhookDelivery: delivery, AttemptLogs: attempts}, nil
+}
+
+// RetryDeliveryはデッドレターの配信を配信待ちに戻す。次回のワーカーの実行で送信される
+func (u *webhookUsecase) RetryDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	delivery, err := u.findDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := delivery.Requeue(u.now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrConflict, err.Error())
+	}
+
+	if err := u.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (u *webhookUsecase) findDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	delivery, err := u.webhookRepo.FindDeliveryByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ProcessWebhooksはアウトボックスのイベントを購読ごとの配信に振り分け、送信時刻を迎えた配信を送信する。
+// 送信に失敗した配信は間隔を空けて再試行し、上限に達した場合はデッドレターにする
+func (u *webhookUsecase) ProcessWebhooks(ctx context.Context) (*WebhookRunResult, error) {
+	result := &WebhookRunResult{}
+
+	for {
+		dispatched, err := u.dispatchEvents(ctx)
+		result.Dispatched += dispatched
+		if err != nil {
+			return result, fmt.Errorf("failed to dispatch webhook events: %w", err)
+		}
+		if dispatched < webhookBatchSize {
+			break
+		}
+	}
+
+	if err := u.deliverDue(ctx, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// 未配信のイベントを購読している有効な購読ごとの配信にし、振り分けたイベントの件数を返す
+func (u *webhookUsecase) dispatchEvents(ctx context.Context) (int, error) {
+	dispatched := 0
+	err := u.outboxRepo.Transaction(ctx, func(ctx context.Context) error {
+		events, err := u.outboxRepo.ClaimPending(ctx, webhookBatchSize)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		subscriptions, err := u.webhookRepo.FindSubscriptions(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := u.now()
+		var deliveries []*entity.WebhookDelivery
+		ids := make([]int64, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+			for _, subscription := range subscriptions {
+				if subscription.Subscribes(event.EventType) {
+					deliveries = append(deliveries, entity.NewWebhookDelivery(subscription, event, now))
+				}
+			}
+		}
+
+		if len(deliveries) > 0 {
+			if err := u.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+				return err
+			}
+		}
+		if err := u.outboxRepo.MarkDispatched(ctx, ids, now); err != nil {
+			return err
+		}
+
+		dispatched = len(events)
+		return nil
+	})
+
+	return dispatched, err
+}
+
+// 送信時刻を迎えた配信を送信し、結果と試行ログを記録する
+func (u *webhookUsecase) deliverDue(ctx context.Context, result *WebhookRunResult) error {
+	now := u.now()
+	deliveries, err := u.webhookRepo.ClaimDueDeliveries(ctx, now, now.Add(webhookDeliveryLease), webhookBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	subscriptions, err := u.webhookRepo.FindSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+	subscriptionsByID := make(map[int64]*entity.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionsByID[subscription.ID] = subscription
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptionsByID[delivery.SubscriptionID]
+		if !ok {
+			// 取得後に購読が削除された
+			continue
+		}
+
+		attempt := u.send(ctx, subscription, delivery)
+		if delivery.Status == entity.DeliverySucceeded {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+
+		if err := u.saveAttempt(ctx, delivery, attempt); err != nil {
+			errs = append(errs, fmt.Errorf("failed to record webhook delivery %d: %w", delivery.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// 配信を1回送信し、結果を配信に反映して試行ログを返す。無効な購読の配信は送信せずにデッドレターにする
+func (u *webhookUsecase) send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) *entity.WebhookAttempt {
+	if !subscription.Active {
+		delivery.Abandon("webhook is inactive")
+		return nil
+	}
+
+	startedAt := u.now()
+	status, err := u.sender.Send(ctx, subscription, delivery)
+	finishedAt := u.now()
+
+	attempt := &entity.WebhookAttempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: startedAt,
+		DurationMs:  finishedAt.Sub(startedAt).Milliseconds(),
+	}
+	if status > 0 {
+		attempt.ResponseStatus = &status
+	}
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case status < 200 || status >= 300:
+		// 2xx以外は配信失敗として扱う
+		attempt.Error = fmt.Sprintf("webhook responded with status %d", status)
+	}
+
+	if attempt.Error == "" {
+		delivery.RecordSuccess(finishedAt)
+	} else {
+		delivery.RecordFailure(finishedAt, attempt.Error)
+		attempt.Error = delivery.LastError
+	}
+
+	return attempt
+}
+
+// 配信の状態と試行ログを1つのトランザクションで保存する
+func (u *webhookUsecase) saveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, attempt *entity.WebhookAttempt) error {
+	return u.webhookRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		if attempt == nil {
+			return nil
+		}
+		return u.webhookRepo.CreateAttempt(ctx, attempt)
+	})
+}
@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockOutboxRepository はtestify/mockを使用したアウトボックスのモックリポジトリ
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Append(ctx context.Context, events []*entity.OutboxEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.OutboxEvent, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkDispatched(ctx context.Context, ids []int64, at time.Time) error {
+	args := m.Called(ctx, ids, at)
+	return args.Error(0)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockOutboxRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// MockWebhookRepository はtestify/mockを使用したWebhookのモックリポジトリ
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) FindSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindSubscriptionByID(ctx context.Context, id int64) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, subscription)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, subscription)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, filter DeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindAttempts(ctx context.Context, deliveryID int64) ([]*entity.WebhookAttempt, error) {
+	args := m.Called(ctx, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookAttempt), args.Error(1)
+}
+
+// Transaction はトランザクションを張らずにfnをそのまま実行する
+func (m *MockWebhookRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeWebhookSender は送信した配信を記録し、配信IDごとに決めた応答を返す（未設定の配信は200）
+type fakeWebhookSender struct {
+	sent     []int64
+	statuses map[int64]int
+	failures map[int64]error
+}
+
+func (s *fakeWebhookSender) Send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error) {
+	s.sent = append(s.sent, delivery.ID)
+	if err, ok := s.failures[delivery.ID]; ok {
+		return 0, err
+	}
+	if status, ok := s.statuses[delivery.ID]; ok {
+		return status, nil
+	}
+	return 200, nil
+}
+
+// 2024-06-15 09:00 を現在日時としたユースケースを作成する
+func newTestWebhookUsecase(webhookRepo WebhookRepository, outboxRepo OutboxRepository, sender WebhookSender) *webhookUsecase {
+	usecase := NewWebhookUsecase(webhookRepo, outboxRepo, sender).(*webhookUsecase)
+	usecase.now = func() time.Time {
+		return time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+	}
+	return usecase
+}
+
+func TestWebhookUsecase_CreateSubscription(t *testing.T) {
+	inactive := false
+
+	tests := []struct {
+		name        string
+		input       WebhookInput
+		setupMock   func(*MockWebhookRepository)
+		wantActive  bool
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 購読を作成",
+			input: WebhookInput{URL: "https://example.com/hooks", Events: []entity.EventType{entity.EventItemCreated}, Secret: "0123456789abcdef"},
+			setupMock: func(webhookRepo *MockWebhookRepository) {
+				webhookRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("*entity.WebhookSubscription")).
+					Return(&entity.WebhookSubscription{ID: 1, Active: true}, nil)
+			},
+			wantActive: true,
+		},
+		{
+			name:  "正常系: 無効な状態で作成",
+			input: WebhookInput{URL: "https://example.com/hooks", Events: []entity.EventType{entity.EventItemCreated}, Secret: "0123456789abcdef", Active: &inactive},
+			setupMock: func(webhookRepo *MockWebhookRepository) {
+				webhookRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(subscription *entity.WebhookSubscription) bool {
+					return !subscription.Active
+				})).Return(&entity.WebhookSubscription{ID: 1}, nil)
+			},
+		},
+		{
+			name:        "異常系: シークレットが短い",
+			input:       WebhookInput{URL: "https://example.com/hooks", Events: []entity.EventType{entity.EventItemCreated}, Secret: "short"},
+			setupMock:   func(webhookRepo *MockWebhookRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookRepo := new(MockWebhookRepository)
+			tt.setupMock(webhookRepo)
+			usecase := newTestWebhookUsecase(webhookRepo, new(MockOutboxRepository), &fakeWebhookSender{})
+
+			subscription, err := usecase.CreateSubscription(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, subscription)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantActive, subscription.Active)
+			}
+			webhookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookUsecase_RetryDelivery(t *testing.T) {
+	tests := []struct {
+		name        string
+		delivery    *entity.WebhookDelivery
+		expectedErr error
+	}{
+		{
+			name:     "正常系: デッドレターを配信待ちに戻す",
+			delivery: &entity.WebhookDelivery{ID: 1, Status: entity.DeliveryDead, Attempts: entity.MaxWebhookAttempts},
+		},
+		{
+			name:        "異常系: 配信待ちの配信は再送できない",
+			delivery:    &entity.WebhookDelivery{ID: 1, Status: entity.DeliveryPending, Attempts: 2},
+			expectedErr: domainErrors.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookRepo := new(MockWebhookRepository)
+			webhookRepo.On("FindDeliveryByID", mock.Anything, int64(1)).Return(tt.delivery, nil)
+			if tt.expectedErr == nil {
+				webhookRepo.On("UpdateDelivery", mock.Anything, tt.delivery).Return(nil)
+			}
+			usecase := newTestWebhookUsecase(webhookRepo, new(MockOutboxRepository), &fakeWebhookSender{})
+
+			delivery, err := usecase.RetryDelivery(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.DeliveryPending, delivery.Status)
+				assert.Equal(t, 0, delivery.Attempts)
+			}
+			webhookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookUsecase_GetDeliveries_InvalidStatus(t *testing.T) {
+	usecase := newTestWebhookUsecase(new(MockWebhookRepository), new(MockOutboxRepository), &fakeWebhookSender{})
+
+	_, err := usecase.GetDeliveries(context.Background(), 1, "failed")
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
+
+func TestWebhookUsecase_ProcessWebhooks(t *testing.T) {
+	webhookRepo := new(MockWebhookRepository)
+	outboxRepo := new(MockOutboxRepository)
+	sender := &fakeWebhookSender{
+		statuses: map[int64]int{2: 500},
+		failures: map[int64]error{3: errors.New("connection refused")},
+	}
+	usecase := newTestWebhookUsecase(webhookRepo, outboxRepo, sender)
+	now := usecase.now()
+
+	subscriptions := []*entity.WebhookSubscription{
+		{ID: 1, Events: []entity.EventType{entity.EventItemCreated}, Active: true},
+		{ID: 2, Events: []entity.EventType{entity.EventItemCreated, entity.EventItemDeleted}, Active: true},
+		// 無効な購読には振り分けず、配信待ちの配信は送信せずにデッドレターにする
+		{ID: 3, Events: []entity.EventType{entity.EventItemCreated}, Active: false},
+	}
+	events := []*entity.OutboxEvent{
+		{ID: 10, EventType: entity.EventItemCreated, ItemID: 1},
+		{ID: 11, EventType: entity.EventItemDeleted, ItemID: 2},
+	}
+	due := []*entity.WebhookDelivery{
+		{ID: 1, SubscriptionID: 1, EventID: 10, Status: entity.DeliveryPending},
+		{ID: 2, SubscriptionID: 2, EventID: 10, Status: entity.DeliveryPending},
+		{ID: 3, SubscriptionID: 2, EventID: 11, Status: entity.DeliveryPending, Attempts: entity.MaxWebhookAttempts - 1},
+		{ID: 4, SubscriptionID: 3, EventID: 9, Status: entity.DeliveryPending},
+	}
+
+	outboxRepo.On("ClaimPending", mock.Anything, webhookBatchSize).Return(events, nil)
+	webhookRepo.On("FindSubscriptions", mock.Anything).Return(subscriptions, nil)
+	webhookRepo.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []*entity.WebhookDelivery) bool {
+		// item.created は購読1・2に、item.deleted は購読2に振り分ける
+		if len(deliveries) != 3 {
+			return false
+		}
+		return deliveries[0].SubscriptionID == 1 && deliveries[0].EventID == 10 &&
+			deliveries[1].SubscriptionID == 2 && deliveries[1].EventID == 10 &&
+			deliveries[2].SubscriptionID == 2 && deliveries[2].EventID == 11
+	})).Return(nil)
+	outboxRepo.On("MarkDispatched", mock.Anything, []int64{10, 11}, now).Return(nil)
+	webhookRepo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(webhookDeliveryLease), webhookBatchSize).Return(due, nil)
+	webhookRepo.On("UpdateDelivery", mock.Anything, mock.AnythingOfType("*entity.WebhookDelivery")).Return(nil)
+	webhookRepo.On("CreateAttempt", mock.Anything, mock.AnythingOfType("*entity.WebhookAttempt")).Return(nil)
+
+	result, err := usecase.ProcessWebhooks(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &WebhookRunResult{Dispatched: 2, Succeeded: 1, Failed: 3}, result)
+	assert.Equal(t, []int64{1, 2, 3}, sender.sent)
+
+	assert.Equal(t, entity.DeliverySucceeded, due[0].Status)
+	// 500は再試行を予定する
+	assert.Equal(t, entity.DeliveryPending, due[1].Status)
+	assert.Equal(t, "webhook responded with status 500", due[1].LastError)
+	assert.Equal(t, now.Add(30*time.Second), *due[1].NextAttemptAt)
+	// 上限に達した配信はデッドレターにする
+	assert.Equal(t, entity.DeliveryDead, due[2].Status)
+	assert.Equal(t, "connection refused", due[2].LastError)
+	assert.Equal(t, entity.DeliveryDead, due[3].Status)
+	assert.Equal(t, 0, due[3].Attempts)
+
+	webhookRepo.AssertNumberOfCalls(t, "UpdateDelivery", 4)
+	webhookRepo.AssertNumberOfCalls(t, "CreateAttempt", 3)
+	outboxRepo.AssertExpectations(t)
+	webhookRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_RecordsItemEvents(t *testing.T) {
+	t.Run("正常系: 作成したアイテムのイベントを記録", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		outboxRepo := new(MockOutboxRepository)
+		usecase := NewItemUsecase(itemRepo, WithOutbox(outboxRepo))
+
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Return(&entity.Item{ID: 5, Name: "ロレックス", Category: "時計"}, nil)
+		outboxRepo.On("Append", mock.Anything, mock.MatchedBy(func(events []*entity.OutboxEvent) bool {
+			return len(events) == 1 && events[0].EventType == entity.EventItemCreated && events[0].ItemID == 5
+		})).Return(nil)
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01",
+		})
+
+		require.NoError(t, err)
+		itemRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除したアイテムのイベントを記録", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		outboxRepo := new(MockOutboxRepository)
+		usecase := NewItemUsecase(itemRepo, WithOutbox(outboxRepo))
+
+		itemRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Item{ID: 5}, nil)
+		itemRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		outboxRepo.On("Append", mock.Anything, mock.MatchedBy(func(events []*entity.OutboxEvent) bool {
+			return len(events) == 1 && events[0].EventType == entity.EventItemDeleted && string(events[0].Payload) == `{"id":5}`
+		})).Return(nil)
+
+		err := usecase.DeleteItem(context.Background(), 5)
+
+		require.NoError(t, err)
+		itemRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: イベントの記録に失敗した場合はエラーを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		outboxRepo := new(MockOutboxRepository)
+		usecase := NewItemUsecase(itemRepo, WithOutbox(outboxRepo))
+
+		itemRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Item{ID: 5}, nil)
+		itemRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		outboxRepo.On("Append", mock.Anything, mock.Anything).Return(domainErrors.ErrDatabaseError)
+
+		err := usecase.DeleteItem(context.Background(), 5)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
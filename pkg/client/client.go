@@ -0,0 +1,214 @@
+// Package client is a typed Go client for the items API.
+//
+// A Client is safe for concurrent use. Requests that fail with 429 Too Many Requests are retried
+// with exponential backoff (honoring Retry-After); 5xx responses and network errors are retried
+// as well except for POST requests, which may already have created a resource.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults used by New.
+const (
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = 200 * time.Millisecond
+	DefaultTimeout    = 30 * time.Second
+)
+
+// 再試行の間隔の上限（Retry-Afterで指定された場合を除く）
+const maxRetryDelay = 10 * time.Second
+
+// Client calls the items API.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	tenant     string
+	maxRetries int
+	retryDelay time.Duration
+	// テストで待ち時間を固定するための乱数
+	jitter func(time.Duration) time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey sends the key in the X-API-Key header so that the client is rate limited by key instead of by IP address.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithTenant sends the tenant slug in the X-Tenant header. The server only serves the tenant of the API key
+// (or its default tenant when no key is sent) and rejects requests whose X-Tenant names another tenant.
+func WithTenant(slug string) Option {
+	return func(c *Client) {
+		c.tenant = slug
+	}
+}
+
+// WithRetry sets how many times a failed request is retried and the delay before the first retry.
+// The delay doubles on every retry. A maxRetries of 0 disables retries.
+func WithRetry(maxRetries int, delay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryDelay = delay
+	}
+}
+
+// New creates a client for the API at baseURL (for example http://localhost:8080).
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http or https URL", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		maxRetries: DefaultMaxRetries,
+		retryDelay: DefaultRetryDelay,
+		jitter: func(d time.Duration) time.Duration {
+			// 同時に失敗したクライアントが一斉に再試行しないよう、半分から全体の間でばらつかせる
+			return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// do はリクエストを送り、成功した場合はレスポンスのJSONをoutに読み込む
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, query, payload, out)
+		if err == nil {
+			return nil
+		}
+
+		delay, retryable := c.retryDelayFor(method, err, attempt)
+		if !retryable || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, out interface{}) error {
+	endpoint := c.baseURL.JoinPath(path)
+	endpoint.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.tenant != "" {
+		req.Header.Set("X-Tenant", c.tenant)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newAPIError(res)
+	}
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// 再試行するかと、再試行までの待ち時間を返す
+func (c *Client) retryDelayFor(method string, err error, attempt int) (time.Duration, bool) {
+	if attempt >= c.maxRetries {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			// 制限されたリクエストは処理されていないので、POSTも再試行できる
+		case apiErr.StatusCode >= http.StatusInternalServerError && method != http.MethodPost:
+		default:
+			return 0, false
+		}
+	} else {
+		// 接続できない・応答がないなどの通信エラーだけを再試行する
+		var urlErr *url.Error
+		if !errors.As(err, &urlErr) || method == http.MethodPost || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+	}
+
+	if apiErr != nil && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	delay := c.retryDelay << attempt
+	if delay < 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return c.jitter(delay), true
+}
+
+// Retry-Afterヘッダーの秒数
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
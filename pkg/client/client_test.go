@@ -0,0 +1,456 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// memoryItemRepository はアイテムをメモリに保持するリポジトリ
+type memoryItemRepository struct {
+	mu     sync.Mutex
+	items  map[int64]*entity.Item
+	nextID int64
+}
+
+func newMemoryItemRepository() *memoryItemRepository {
+	return &memoryItemRepository{items: make(map[int64]*entity.Item), nextID: 1}
+}
+
+func (r *memoryItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
+	return r.FindByFilter(ctx, usecase.ItemFilter{})
+}
+
+func (r *memoryItemRepository) FindByFilter(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := []*entity.Item{}
+	for _, item := range r.items {
+		if filter.Category != "" && item.Category != filter.Category {
+			continue
+		}
+		if filter.Status != "" && item.Status != filter.Status {
+			continue
+		}
+		copied := *item
+		items = append(items, &copied)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+func (r *memoryItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	copied := *item
+	return &copied, nil
+}
+
+// FindByIDForUpdate はロックせずにFindByIDと同じ結果を返す
+func (r *memoryItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *memoryItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	var items []*entity.Item
+	for _, id := range ids {
+		if item, err := r.FindByID(ctx, id); err == nil {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *memoryItemRepository) FindBySerialNumber(ctx context.Context, serialNumber, brand string) ([]*entity.Item, error) {
+	return nil, nil
+}
+
+func (r *memoryItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := *item
+	created.ID = r.nextID
+	r.nextID++
+	r.items[created.ID] = &created
+	result := created
+	return &result, nil
+}
+
+func (r *memoryItemRepository) CreateBatch(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	created := make([]*entity.Item, 0, len(items))
+	for i, item := range items {
+		c, err := r.Create(ctx, item)
+		if err != nil {
+			return nil, &domainErrors.BatchItemError{Index: i, Err: err}
+		}
+		created = append(created, c)
+	}
+	return created, nil
+}
+
+func (r *memoryItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[item.ID]; !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	updated := *item
+	r.items[item.ID] = &updated
+	result := updated
+	return &result, nil
+}
+
+func (r *memoryItemRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; !ok {
+		return domainErrors.ErrItemNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *memoryItemRepository) DeleteBatch(ctx context.Context, ids []int64) error {
+	for i, id := range ids {
+		if err := r.Delete(ctx, id); err != nil {
+			return &domainErrors.BatchItemError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+func (r *memoryItemRepository) MergeInto(ctx context.Context, survivorID int64, duplicate *entity.Item) error {
+	return r.Delete(ctx, duplicate.ID)
+}
+
+func (r *memoryItemRepository) FindMergeHistory(ctx context.Context, survivorID int64) ([]*entity.ItemMerge, error) {
+	return []*entity.ItemMerge{}, nil
+}
+
+func (r *memoryItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, item := range r.items {
+		counts[item.Category]++
+	}
+	return counts, nil
+}
+
+func (r *memoryItemRepository) GetSummaryByLocation(ctx context.Context) ([]*usecase.LocationSummary, error) {
+	return []*usecase.LocationSummary{}, nil
+}
+
+func (r *memoryItemRepository) GetSummaryByStatus(ctx context.Context) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, item := range r.items {
+		counts[string(item.Status)]++
+	}
+	return counts, nil
+}
+
+func (r *memoryItemRepository) GetSalesByCategory(ctx context.Context) (map[string]*usecase.SalesTotals, error) {
+	return map[string]*usecase.SalesTotals{}, nil
+}
+
+func (r *memoryItemRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// newTestClient は実際のハンドラーとOpenAPIドキュメントによる検証を組み込んだサーバーのクライアントを作成する
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	handler := itemController.NewItemHandler(usecase.NewItemUsecase(newMemoryItemRepository()))
+	doc, err := openapi.New()
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(doc.Validator(openapi.ValidatorConfig{ValidateResponses: true}))
+	e.GET("/items", handler.GetItems)
+	e.POST("/items", handler.CreateItem)
+	e.GET("/items/summary", handler.GetSummary)
+	e.GET("/items/:id", handler.GetItem)
+	e.PATCH("/items/:id", handler.UpdateItem)
+	e.DELETE("/items/:id", handler.DeleteItem)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, WithRetry(0, 0))
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_Items(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	created, err := c.CreateItem(ctx, CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		Attributes:    map[string]interface{}{"movement": "自動巻き"},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, "owned", created.Status)
+
+	_, err = c.CreateItem(ctx, CreateItemInput{
+		Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2500000, PurchaseDate: "2023-02-20",
+	})
+	require.NoError(t, err)
+
+	t.Run("正常系: アイテムの取得", func(t *testing.T) {
+		item, err := c.GetItem(ctx, created.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+		assert.Equal(t, "自動巻き", item.Attributes["movement"])
+		assert.NotNil(t, item.BookValue)
+	})
+
+	t.Run("正常系: カテゴリーで絞り込む", func(t *testing.T) {
+		items, err := c.ListItems(ctx, ListItemsOptions{Category: "バッグ", Status: "owned"})
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "エルメス バーキン", items[0].Name)
+	})
+
+	t.Run("正常系: 部分更新", func(t *testing.T) {
+		item, err := c.UpdateItem(ctx, created.ID, UpdateItemInput{Name: String("ロレックス サブマリーナ"), PurchasePrice: Int(1200000)})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス サブマリーナ", item.Name)
+		assert.Equal(t, 1200000, item.PurchasePrice)
+		assert.Equal(t, "時計", item.Category)
+	})
+
+	t.Run("正常系: 集計", func(t *testing.T) {
+		summary, err := c.GetSummary(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+		assert.Equal(t, 1, summary.Categories["時計"])
+		assert.Equal(t, 1, summary.Categories["バッグ"])
+		assert.Equal(t, 0, summary.Categories["靴"])
+	})
+
+	t.Run("正常系: 削除したアイテムは取得できない", func(t *testing.T) {
+		require.NoError(t, c.DeleteItem(ctx, created.ID))
+
+		_, err := c.GetItem(ctx, created.ID)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("異常系: 入力が不正な場合はエラーの詳細を返す", func(t *testing.T) {
+		_, err := c.CreateItem(ctx, CreateItemInput{Name: "ロレックス", Brand: "ROLEX", PurchaseDate: "2023-01-15"})
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, "validation failed", apiErr.Message)
+		assert.NotEmpty(t, apiErr.Details)
+	})
+
+	t.Run("異常系: 存在しないアイテムの削除", func(t *testing.T) {
+		err := c.DeleteItem(ctx, 999)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "404 item not found")
+	})
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		statuses         []int
+		retryAfter       string
+		expectedAttempts int32
+		expectedErr      error
+	}{
+		{
+			name:             "正常系: 5xxの後に成功する",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "正常系: 429はPOSTでも再試行する",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter:       "0",
+			expectedAttempts: 2,
+		},
+		{
+			name:             "異常系: POSTは5xxで再試行しない",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusInternalServerError, http.StatusCreated},
+			expectedAttempts: 1,
+			expectedErr:      ErrServer,
+		},
+		{
+			name:             "異常系: 4xxは再試行しない",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusNotFound, http.StatusOK},
+			expectedAttempts: 1,
+			expectedErr:      ErrNotFound,
+		},
+		{
+			name:             "異常系: 再試行の上限に達する",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 4,
+			expectedErr:      ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[atomic.AddInt32(&attempts, 1)-1]
+				w.Header().Set("Content-Type", "application/json")
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				if status >= http.StatusBadRequest {
+					w.Write([]byte(`{"error":"failed"}`))
+					return
+				}
+				w.Write([]byte(`{"id":1,"name":"ロレックス"}`))
+			}))
+			defer server.Close()
+
+			c, err := New(server.URL, WithRetry(3, time.Millisecond))
+			require.NoError(t, err)
+
+			if tt.method == http.MethodPost {
+				_, err = c.CreateItem(context.Background(), CreateItemInput{Name: "ロレックス"})
+			} else {
+				_, err = c.GetItem(context.Background(), 1)
+			}
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+
+	t.Run("異常系: 待機中にキャンセルされた場合は再試行しない", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		c, err := New(server.URL, WithRetry(3, time.Minute))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = c.GetItem(ctx, 1)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+}
+
+func TestClient_Headers(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		expectedAPIKey string
+		expectedTenant string
+	}{
+		{name: "正常系: APIキーとテナントを送る", opts: []Option{WithAPIKey("key1"), WithTenant("acme")}, expectedAPIKey: "key1", expectedTenant: "acme"},
+		{name: "正常系: 指定しない場合は送らない"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := New(server.URL, tt.opts...)
+			require.NoError(t, err)
+			_, err = c.ListItems(context.Background(), ListItemsOptions{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAPIKey, header.Get("X-API-Key"))
+			assert.Equal(t, tt.expectedTenant, header.Get("X-Tenant"))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{name: "正常系: http", baseURL: "http://localhost:8080"},
+		{name: "正常系: 末尾のスラッシュ", baseURL: "https://api.example.com/"},
+		{name: "異常系: スキームがない", baseURL: "localhost:8080", wantErr: true},
+		{name: "異常系: 空文字", baseURL: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(tt.baseURL)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, c)
+			}
+		})
+	}
+}
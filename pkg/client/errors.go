@@ -0,0 +1,82 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Errors matched by APIError with errors.Is according to the response status code.
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrRateLimited = errors.New("rate limited")
+	ErrServer      = errors.New("server error")
+)
+
+// APIError is an error response of the API.
+type APIError struct {
+	StatusCode int
+	// Message is the "error" field of the response, such as "validation failed"
+	Message string
+	// Details lists the individual problems, such as "name is required"
+	Details []string
+	// RetryAfter is the Retry-After header of 429 and 503 responses
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if len(e.Details) == 0 {
+		return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Message, strings.Join(e.Details, ", "))
+}
+
+// Is reports whether the status code of the error corresponds to target (ErrNotFound for 404 and so on).
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// レスポンスのボディ（{"error": ..., "details": [...]}）からエラーを作成する
+func newAPIError(res *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+	}
+
+	var body struct {
+		Error   string   `json:"error"`
+		Details []string `json:"details"`
+		// ルートがない場合などのEchoのエラーの形式
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err := json.Unmarshal(data, &body); err == nil {
+		apiErr.Message = body.Error
+		apiErr.Details = body.Details
+		if apiErr.Message == "" {
+			apiErr.Message = body.Message
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.ToLower(http.StatusText(res.StatusCode))
+	}
+	return apiErr
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Item is an owned item.
+type Item struct {
+	ID                int64                  `json:"id"`
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category"`
+	Brand             string                 `json:"brand"`
+	PurchasePrice     int                    `json:"purchase_price"`
+	PurchaseDate      string                 `json:"purchase_date"` // YYYY-MM-DD
+	SerialNumber      string                 `json:"serial_number"`
+	ModelNumber       string                 `json:"model_number"`
+	Condition         string                 `json:"condition"`
+	Authenticity      string                 `json:"authenticity"`
+	LocationID        *int64                 `json:"location_id"`
+	Status            string                 `json:"status"`
+	SalePrice         *int                   `json:"sale_price"`
+	SaleDate          string                 `json:"sale_date"`
+	WarrantyExpiresAt string                 `json:"warranty_expires_at"`
+	InsurancePolicyID *int64                 `json:"insurance_policy_id"`
+	Tags              []string               `json:"tags"`
+	Attributes        map[string]interface{} `json:"attributes"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+
+	// TotalCostOfOwnership is set when listed with IncludeTotalCostOfOwnership
+	TotalCostOfOwnership *int64 `json:"total_cost_of_ownership,omitempty"`
+	// BookValue is not set for sold or lost items
+	BookValue *int64 `json:"book_value,omitempty"`
+}
+
+// CreateItemInput is the input for creating an item.
+type CreateItemInput struct {
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category"`
+	Brand             string                 `json:"brand"`
+	PurchasePrice     int                    `json:"purchase_price"`
+	PurchaseDate      string                 `json:"purchase_date"`
+	SerialNumber      string                 `json:"serial_number,omitempty"`
+	ModelNumber       string                 `json:"model_number,omitempty"`
+	Condition         string                 `json:"condition,omitempty"`
+	Authenticity      string                 `json:"authenticity,omitempty"`
+	WarrantyExpiresAt string                 `json:"warranty_expires_at,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// UpdateItemInput is the input for partially updating an item. Nil fields are left unchanged.
+// Attributes are merged key by key; a nil value removes the attribute.
+type UpdateItemInput struct {
+	Name              *string                `json:"name,omitempty"`
+	Category          *string                `json:"category,omitempty"`
+	Brand             *string                `json:"brand,omitempty"`
+	PurchasePrice     *int                   `json:"purchase_price,omitempty"`
+	PurchaseDate      *string                `json:"purchase_date,omitempty"`
+	SerialNumber      *string                `json:"serial_number,omitempty"`
+	ModelNumber       *string                `json:"model_number,omitempty"`
+	Condition         *string                `json:"condition,omitempty"`
+	Authenticity      *string                `json:"authenticity,omitempty"`
+	WarrantyExpiresAt *string                `json:"warranty_expires_at,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ListItemsOptions are the listing conditions. The zero value lists every item.
+type ListItemsOptions struct {
+	Category string
+	// Tags matches items that have all of the given tags
+	Tags []string
+	// Status matches items in the given status (owned, lent, in_repair, consigned, sold or lost)
+	Status string
+	// LocationID matches items stored in the location or any of its sublocations
+	LocationID int64
+	// Attributes matches items whose custom attribute equals the value
+	Attributes map[string]string
+	// IncludeTotalCostOfOwnership sets TotalCostOfOwnership of the items
+	IncludeTotalCostOfOwnership bool
+}
+
+func (o ListItemsOptions) query() url.Values {
+	query := url.Values{}
+	if o.Category != "" {
+		query.Set("category", o.Category)
+	}
+	for _, tag := range o.Tags {
+		query.Add("tag", tag)
+	}
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.LocationID != 0 {
+		query.Set("location_id", strconv.FormatInt(o.LocationID, 10))
+	}
+	for key, value := range o.Attributes {
+		query.Set("attr."+key, value)
+	}
+	if o.IncludeTotalCostOfOwnership {
+		query.Set("include", "tco")
+	}
+	return query
+}
+
+// CategorySummary is the number of items by category, location and status, and the realized gain of sold items.
+type CategorySummary struct {
+	Categories map[string]int     `json:"categories"`
+	Total      int                `json:"total"`
+	Locations  []*LocationSummary `json:"locations"`
+	Statuses   map[string]int     `json:"statuses"`
+	Sales      *SalesSummary      `json:"sales"`
+}
+
+// LocationSummary is the number and purchase price total of the items stored directly in a location.
+// LocationID is nil for items without a location.
+type LocationSummary struct {
+	LocationID *int64 `json:"location_id"`
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	ParentID   *int64 `json:"parent_id"`
+	ItemCount  int    `json:"item_count"`
+	TotalValue int64  `json:"total_value"`
+}
+
+// SalesTotals is the realized gain or loss of sold items.
+type SalesTotals struct {
+	SoldCount     int   `json:"sold_count"`
+	PurchaseTotal int64 `json:"purchase_total"`
+	SaleTotal     int64 `json:"sale_total"`
+	RealizedGain  int64 `json:"realized_gain"`
+}
+
+// SalesSummary is the realized gain or loss of sold items, overall and by category.
+type SalesSummary struct {
+	SalesTotals
+	Categories map[string]*SalesTotals `json:"categories"`
+}
+
+// ListItems returns the items matching opts.
+func (c *Client) ListItems(ctx context.Context, opts ListItemsOptions) ([]*Item, error) {
+	var items []*Item
+	if err := c.do(ctx, http.MethodGet, "/items", opts.query(), nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetItem returns the item. The error matches ErrNotFound if it does not exist.
+func (c *Client) GetItem(ctx context.Context, id int64) (*Item, error) {
+	var item Item
+	if err := c.do(ctx, http.MethodGet, itemPath(id), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateItem creates an item. The error matches ErrBadRequest if the input is invalid
+// and ErrConflict if another item of the brand has the same serial number.
+func (c *Client) CreateItem(ctx context.Context, input CreateItemInput) (*Item, error) {
+	var item Item
+	if err := c.do(ctx, http.MethodPost, "/items", nil, input, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateItem updates the non-nil fields of input and returns the updated item.
+func (c *Client) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*Item, error) {
+	var item Item
+	if err := c.do(ctx, http.MethodPatch, itemPath(id), nil, input, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteItem deletes the item. The error matches ErrNotFound if it does not exist.
+func (c *Client) DeleteItem(ctx context.Context, id int64) error {
+	return c.do(ctx, http.MethodDelete, itemPath(id), nil, nil, nil)
+}
+
+// GetSummary returns the number of items by category, location and status.
+func (c *Client) GetSummary(ctx context.Context) (*CategorySummary, error) {
+	var summary CategorySummary
+	if err := c.do(ctx, http.MethodGet, "/items/summary", nil, nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func itemPath(id int64) string {
+	return "/items/" + strconv.FormatInt(id, 10)
+}
+
+// String returns a pointer to v for the fields of UpdateItemInput.
+func String(v string) *string {
+	return &v
+}
+
+// Int returns a pointer to v for the fields of UpdateItemInput.
+func Int(v int) *int {
+	return &v
+}
@@ -2,23 +2,297 @@
 SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
 SET CHARACTER SET utf8mb4;
 
+-- Create tenants table for the organizations whose inventories are managed in this deployment
+CREATE TABLE IF NOT EXISTS tenants (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    slug VARCHAR(63) NOT NULL COMMENT 'Identifier used in subdomains and the X-Tenant header',
+    name VARCHAR(100) NOT NULL COMMENT 'Organization name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uk_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tenants (organizations) owning the collections';
+
+-- Create the default tenant (id 1) owning existing rows and the sample data; every other table has tenant_id
+-- (databases created before tenants are upgraded by `go run ./cmd migrate`, which adds tenant_id defaulting to this tenant and the per-tenant unique keys)
+INSERT IGNORE INTO tenants (id, slug, name) VALUES (1, 'default', 'Default');
+
+-- Create locations table for the storage hierarchy (site > room > container)
+CREATE TABLE IF NOT EXISTS locations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    name VARCHAR(100) NOT NULL COMMENT 'Location name',
+    type VARCHAR(20) NOT NULL COMMENT 'Location type: site, room, container',
+    parent_id BIGINT NULL COMMENT 'Parent location (NULL for sites)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_parent_id (parent_id),
+    FOREIGN KEY (parent_id) REFERENCES locations(id) ON DELETE RESTRICT,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Storage locations of items';
+
+-- Create insurance_policies table for policies covering items
+CREATE TABLE IF NOT EXISTS insurance_policies (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    insurer VARCHAR(100) NOT NULL COMMENT 'Insurance company',
+    policy_number VARCHAR(100) NOT NULL COMMENT 'Policy number',
+    coverage_limit BIGINT NOT NULL DEFAULT 0 COMMENT 'Coverage limit in yen',
+    starts_on DATE NOT NULL COMMENT 'First day of the policy period',
+    ends_on DATE NOT NULL COMMENT 'Last day of the policy period',
+    note VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Policy notes',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_ends_on (ends_on),
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Insurance policies covering items';
+
 -- Create items table for managing valuable items and collections
 CREATE TABLE IF NOT EXISTS items (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
     name VARCHAR(100) NOT NULL COMMENT 'Item name',
     category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他',
     brand VARCHAR(100) NOT NULL COMMENT 'Brand name',
     purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen',
     purchase_date DATE NOT NULL COMMENT 'Purchase date in YYYY-MM-DD format',
+    serial_number VARCHAR(100) NULL COMMENT 'Serial number, unique per tenant and brand (NULL when unknown)',
+    model_number VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'Model or reference number',
+    condition_grade VARCHAR(1) NOT NULL DEFAULT '' COMMENT 'Condition grade: S, A, B, C, D',
+    authenticity VARCHAR(20) NOT NULL DEFAULT 'unverified' COMMENT 'Authenticity status: unverified, authentic, counterfeit',
+    location_id BIGINT NULL COMMENT 'Current storage location (NULL when unassigned)',
+    status VARCHAR(20) NOT NULL DEFAULT 'owned' COMMENT 'Lifecycle status: owned, lent, in_repair, consigned, sold, lost',
+    sale_price INT NULL COMMENT 'Sale price in yen (sold items only)',
+    sale_date DATE NULL COMMENT 'Sale date (sold items only)',
+    warranty_expires_at DATE NULL COMMENT 'Manufacturer warranty expiry date',
+    insurance_policy_id BIGINT NULL COMMENT 'Insurance policy covering the item (NULL when uninsured)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
     
     INDEX idx_category (category),
     INDEX idx_brand (brand),
     INDEX idx_purchase_date (purchase_date),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    INDEX idx_serial_number (serial_number),
+    UNIQUE KEY uk_tenant_id_brand_serial_number (tenant_id, brand, serial_number),
+    INDEX idx_location_id (location_id),
+    INDEX idx_status (status),
+    INDEX idx_insurance_policy_id (insurance_policy_id),
+    FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT,
+    FOREIGN KEY (insurance_policy_id) REFERENCES insurance_policies(id) ON DELETE RESTRICT,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
 
+-- Create tags table (tag names are unique per tenant, case-insensitive by collation)
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    name VARCHAR(50) NOT NULL COMMENT 'Tag name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uk_tenant_id_name (tenant_id, name),
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Free-form labels attached to items';
+
+-- Create item_tags table linking items and tags
+CREATE TABLE IF NOT EXISTS item_tags (
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Many-to-many relation between items and tags';
+
+-- Create item_attributes table for category-specific custom attributes
+CREATE TABLE IF NOT EXISTS item_attributes (
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    attr_key VARCHAR(50) NOT NULL COMMENT 'Attribute key defined by the category schema',
+    attr_value VARCHAR(255) NOT NULL COMMENT 'Attribute value encoded as string',
+
+    PRIMARY KEY (item_id, attr_key),
+    INDEX idx_key_value (attr_key, attr_value),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Custom attributes of items';
+
+-- Create item_merges table keeping the history of merged duplicate items
+CREATE TABLE IF NOT EXISTS item_merges (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    survivor_id BIGINT NOT NULL COMMENT 'Item the duplicate was merged into',
+    merged_item_id BIGINT NOT NULL COMMENT 'ID of the deleted duplicate item',
+    merged_item JSON NOT NULL COMMENT 'Snapshot of the duplicate item at merge time',
+    merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Merge timestamp',
+
+    INDEX idx_survivor_id (survivor_id),
+    FOREIGN KEY (survivor_id) REFERENCES items(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='History of merged duplicate items';
+
+-- Create item_movements table recording location changes of items
+CREATE TABLE IF NOT EXISTS item_movements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    from_location_id BIGINT NULL COMMENT 'Location before the move (NULL when unassigned)',
+    to_location_id BIGINT NULL COMMENT 'Location after the move (NULL when unassigned)',
+    note VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Reason or memo for the move',
+    moved_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Move timestamp',
+
+    INDEX idx_item_id_moved_at (item_id, moved_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_location_id) REFERENCES locations(id) ON DELETE SET NULL,
+    FOREIGN KEY (to_location_id) REFERENCES locations(id) ON DELETE SET NULL,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='History of item location changes';
+
+-- Create borrowers table for people and studios items are lent to
+CREATE TABLE IF NOT EXISTS borrowers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    name VARCHAR(100) NOT NULL COMMENT 'Borrower name',
+    contact VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Email address, phone number, etc.',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Borrowers of items';
+
+-- Create loans table recording who has which item until when
+CREATE TABLE IF NOT EXISTS loans (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    borrower_id BIGINT NOT NULL,
+    lent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'Lending timestamp',
+    due_date DATE NOT NULL COMMENT 'Due date for return',
+    returned_at TIMESTAMP NULL COMMENT 'Return timestamp (NULL while on loan)',
+    note VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Purpose of the loan',
+    last_notified_at TIMESTAMP NULL COMMENT 'Last time an overdue notification was sent',
+
+    INDEX idx_item_id (item_id),
+    INDEX idx_returned_at_due_date (returned_at, due_date),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (borrower_id) REFERENCES borrowers(id) ON DELETE RESTRICT,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Loans of items to borrowers';
+
+-- Create maintenance_records table for overhauls, cleanings and repairs
+CREATE TABLE IF NOT EXISTS maintenance_records (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    serviced_at DATE NOT NULL COMMENT 'Service date',
+    vendor VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'Service vendor',
+    cost INT NOT NULL DEFAULT 0 COMMENT 'Service cost',
+    note VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Service details',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id_serviced_at (item_id, serviced_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Maintenance history of items';
+
+-- Create item_valuations table for appraisals and market values of items
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    item_id BIGINT NOT NULL,
+    valued_at DATE NOT NULL COMMENT 'Valuation date',
+    amount INT NOT NULL COMMENT 'Valued amount in yen',
+    source VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'Appraiser or market source',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id_valued_at (item_id, valued_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Valuation history of items';
+
+-- Create item_events table used as a transactional outbox for webhook delivery
+CREATE TABLE IF NOT EXISTS item_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    event_type VARCHAR(50) NOT NULL COMMENT 'item.created, item.updated or item.deleted',
+    item_id BIGINT NOT NULL COMMENT 'Item the event is about (kept after the item is deleted)',
+    payload JSON NOT NULL COMMENT 'Event data sent to webhooks',
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'Event timestamp',
+    dispatched_at TIMESTAMP NULL COMMENT 'Time the event was fanned out to deliveries (NULL while pending)',
+
+    INDEX idx_dispatched_at_id (dispatched_at, id),
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Outbox of item events';
+
+-- Create webhook_subscriptions table for endpoints notified of item events
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    url VARCHAR(500) NOT NULL COMMENT 'Endpoint the events are posted to',
+    events VARCHAR(255) NOT NULL COMMENT 'Comma-separated event types',
+    secret VARCHAR(255) NOT NULL COMMENT 'HMAC-SHA256 signing secret',
+    active BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Inactive subscriptions receive no deliveries',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record last update timestamp',
+
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook subscriptions';
+
+-- Create webhook_deliveries table tracking each event sent to each subscription
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    subscription_id BIGINT NOT NULL,
+    event_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT 'pending, succeeded or dead',
+    attempts INT NOT NULL DEFAULT 0 COMMENT 'Number of attempts made',
+    next_attempt_at TIMESTAMP NULL COMMENT 'Time of the next attempt (NULL unless pending)',
+    last_error VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Error of the last failed attempt',
+    delivered_at TIMESTAMP NULL COMMENT 'Time a 2xx response was received',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uk_subscription_id_event_id (subscription_id, event_id),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES item_events(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook deliveries and dead letters';
+
+-- Create webhook_attempts table logging every delivery attempt
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owning tenant',
+    delivery_id BIGINT NOT NULL,
+    attempted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'Attempt timestamp',
+    response_status INT NULL COMMENT 'HTTP status code (NULL when no response was received)',
+    error VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Failure reason',
+    duration_ms INT NOT NULL DEFAULT 0 COMMENT 'Time taken by the request',
+
+    INDEX idx_delivery_id (delivery_id),
+    FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    INDEX idx_tenant_id (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Log of webhook delivery attempts';
+
 -- Insert sample data for testing
 INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES
 ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),